@@ -0,0 +1,2309 @@
+// Code generated by server --gen-client from docs/swagger.json. DO NOT EDIT.
+
+// Package goclient is a typed client for the Elastic Search Skill-Test API (version 1.0)
+package goclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// basePath is prefixed to every operation path
+const basePath = ""
+
+// Client calls the API over HTTP
+type Client struct {
+	// BaseURL is the scheme and host of the service, e.g. http://localhost:8080
+	BaseURL string
+	// AdminKey is sent as a Bearer token on admin operations
+	AdminKey string
+	// HTTPClient sends the requests, http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// New creates a client for the service at baseURL
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// APIError is returned for every non-2xx response
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+// Ptr returns a pointer to v, for optional query parameters
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// do sends a request and decodes a 2xx JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, admin bool, out any) error {
+	endpoint := c.BaseURL + basePath + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if admin && c.AdminKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AdminKey)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		data, _ := io.ReadAll(res.Body)
+		return &APIError{StatusCode: res.StatusCode, Body: data}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// BaseResponseArrayProduct is generated from the API spec
+type BaseResponseArrayProduct struct {
+	Data      []Product `json:"data,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	IsSuccess bool      `json:"is_success,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// BaseResponseArraySuggestion is generated from the API spec
+type BaseResponseArraySuggestion struct {
+	Data      []Suggestion `json:"data,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	IsSuccess bool         `json:"is_success,omitempty"`
+	Message   string       `json:"message,omitempty"`
+}
+
+// BaseResponseBatchGetResult is generated from the API spec
+type BaseResponseBatchGetResult struct {
+	Data      BatchGetResult `json:"data,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	IsSuccess bool           `json:"is_success,omitempty"`
+	Message   string         `json:"message,omitempty"`
+}
+
+// BaseResponseBulkResult is generated from the API spec
+type BaseResponseBulkResult struct {
+	Data      BulkResult `json:"data,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	IsSuccess bool       `json:"is_success,omitempty"`
+	Message   string     `json:"message,omitempty"`
+}
+
+// BaseResponseCatalogStats is generated from the API spec
+type BaseResponseCatalogStats struct {
+	Data      CatalogStats `json:"data,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	IsSuccess bool         `json:"is_success,omitempty"`
+	Message   string       `json:"message,omitempty"`
+}
+
+// BaseResponseCreationHistogram is generated from the API spec
+type BaseResponseCreationHistogram struct {
+	Data      CreationHistogram `json:"data,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	IsSuccess bool              `json:"is_success,omitempty"`
+	Message   string            `json:"message,omitempty"`
+}
+
+// BaseResponseCuration is generated from the API spec
+type BaseResponseCuration struct {
+	Data      Curation `json:"data,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	IsSuccess bool     `json:"is_success,omitempty"`
+	Message   string   `json:"message,omitempty"`
+}
+
+// BaseResponseDistinctValues is generated from the API spec
+type BaseResponseDistinctValues struct {
+	Data      DistinctValues `json:"data,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	IsSuccess bool           `json:"is_success,omitempty"`
+	Message   string         `json:"message,omitempty"`
+}
+
+// BaseResponseExclusionRule is generated from the API spec
+type BaseResponseExclusionRule struct {
+	Data      ExclusionRule `json:"data,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	IsSuccess bool          `json:"is_success,omitempty"`
+	Message   string        `json:"message,omitempty"`
+}
+
+// BaseResponseImportDiff is generated from the API spec
+type BaseResponseImportDiff struct {
+	Data      ImportDiff `json:"data,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	IsSuccess bool       `json:"is_success,omitempty"`
+	Message   string     `json:"message,omitempty"`
+}
+
+// BaseResponseImportJob is generated from the API spec
+type BaseResponseImportJob struct {
+	Data      ImportJob `json:"data,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	IsSuccess bool      `json:"is_success,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// BaseResponseImportProgress is generated from the API spec
+type BaseResponseImportProgress struct {
+	Data      ImportProgress `json:"data,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	IsSuccess bool           `json:"is_success,omitempty"`
+	Message   string         `json:"message,omitempty"`
+}
+
+// BaseResponseIndexStats is generated from the API spec
+type BaseResponseIndexStats struct {
+	Data      IndexStats `json:"data,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	IsSuccess bool       `json:"is_success,omitempty"`
+	Message   string     `json:"message,omitempty"`
+}
+
+// BaseResponseProduct is generated from the API spec
+type BaseResponseProduct struct {
+	Data      Product `json:"data,omitempty"`
+	Error     string  `json:"error,omitempty"`
+	IsSuccess bool    `json:"is_success,omitempty"`
+	Message   string  `json:"message,omitempty"`
+}
+
+// BaseResponseProductCount is generated from the API spec
+type BaseResponseProductCount struct {
+	Data      ProductCount `json:"data,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	IsSuccess bool         `json:"is_success,omitempty"`
+	Message   string       `json:"message,omitempty"`
+}
+
+// BaseResponseProductFacets is generated from the API spec
+type BaseResponseProductFacets struct {
+	Data      ProductFacets `json:"data,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	IsSuccess bool          `json:"is_success,omitempty"`
+	Message   string        `json:"message,omitempty"`
+}
+
+// BaseResponseProductGroups is generated from the API spec
+type BaseResponseProductGroups struct {
+	Data      ProductGroups `json:"data,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	IsSuccess bool          `json:"is_success,omitempty"`
+	Message   string        `json:"message,omitempty"`
+}
+
+// BaseResponseSearchSnapshot is generated from the API spec
+type BaseResponseSearchSnapshot struct {
+	Data      SearchSnapshot `json:"data,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	IsSuccess bool           `json:"is_success,omitempty"`
+	Message   string         `json:"message,omitempty"`
+}
+
+// BaseResponseSignificantTerms is generated from the API spec
+type BaseResponseSignificantTerms struct {
+	Data      SignificantTerms `json:"data,omitempty"`
+	Error     string           `json:"error,omitempty"`
+	IsSuccess bool             `json:"is_success,omitempty"`
+	Message   string           `json:"message,omitempty"`
+}
+
+// BaseResponseSynonymRule is generated from the API spec
+type BaseResponseSynonymRule struct {
+	Data      SynonymRule `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	IsSuccess bool        `json:"is_success,omitempty"`
+	Message   string      `json:"message,omitempty"`
+}
+
+// BaseResponseTypeaheadGroups is generated from the API spec
+type BaseResponseTypeaheadGroups struct {
+	Data      TypeaheadGroups `json:"data,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	IsSuccess bool            `json:"is_success,omitempty"`
+	Message   string          `json:"message,omitempty"`
+}
+
+// BaseResponseStatus is generated from the API spec
+type BaseResponseStatus struct {
+	Data      Status `json:"data,omitempty"`
+	Error     string `json:"error,omitempty"`
+	IsSuccess bool   `json:"is_success,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// BaseResponseString is generated from the API spec
+type BaseResponseString struct {
+	Data      string `json:"data,omitempty"`
+	Error     string `json:"error,omitempty"`
+	IsSuccess bool   `json:"is_success,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// CommonFacetBucket is generated from the API spec
+type CommonFacetBucket struct {
+	Count int64  `json:"count,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// PagedResponseArrayCuration is generated from the API spec
+type PagedResponseArrayCuration struct {
+	Data  []Curation `json:"data,omitempty"`
+	Error string     `json:"error,omitempty"`
+	// Facets holds the value counts of the requested facets, keyed by field
+	Facets    map[string][]CommonFacetBucket `json:"facets,omitempty"`
+	IsSuccess bool                           `json:"is_success,omitempty"`
+	Message   string                         `json:"message,omitempty"`
+	// NextCursor fetches the next page of a cursor-paginated list; empty on the last page
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Pagination PaginationInfo `json:"pagination,omitempty"`
+	// PIT is the token pinning the following pages of a point-in-time session
+	Pit string `json:"pit,omitempty"`
+	// Search reports how Elasticsearch executed a search; only set on search responses
+	Search SearchMeta `json:"search,omitempty"`
+	// Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean")
+	Suggestions []string `json:"suggestions,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// PagedResponseArrayExclusionRule is generated from the API spec
+type PagedResponseArrayExclusionRule struct {
+	Data  []ExclusionRule `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+	// Facets holds the value counts of the requested facets, keyed by field
+	Facets    map[string][]CommonFacetBucket `json:"facets,omitempty"`
+	IsSuccess bool                           `json:"is_success,omitempty"`
+	Message   string                         `json:"message,omitempty"`
+	// NextCursor fetches the next page of a cursor-paginated list; empty on the last page
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Pagination PaginationInfo `json:"pagination,omitempty"`
+	// PIT is the token pinning the following pages of a point-in-time session
+	Pit string `json:"pit,omitempty"`
+	// Search reports how Elasticsearch executed a search; only set on search responses
+	Search SearchMeta `json:"search,omitempty"`
+	// Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean")
+	Suggestions []string `json:"suggestions,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// PagedResponseArrayImportRun is generated from the API spec
+type PagedResponseArrayImportRun struct {
+	Data  []ImportRun `json:"data,omitempty"`
+	Error string      `json:"error,omitempty"`
+	// Facets holds the value counts of the requested facets, keyed by field
+	Facets    map[string][]CommonFacetBucket `json:"facets,omitempty"`
+	IsSuccess bool                           `json:"is_success,omitempty"`
+	Message   string                         `json:"message,omitempty"`
+	// NextCursor fetches the next page of a cursor-paginated list; empty on the last page
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Pagination PaginationInfo `json:"pagination,omitempty"`
+	// PIT is the token pinning the following pages of a point-in-time session
+	Pit string `json:"pit,omitempty"`
+	// Search reports how Elasticsearch executed a search; only set on search responses
+	Search SearchMeta `json:"search,omitempty"`
+	// Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean")
+	Suggestions []string `json:"suggestions,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// PagedResponseArrayProduct is generated from the API spec
+type PagedResponseArrayProduct struct {
+	Data  []Product `json:"data,omitempty"`
+	Error string    `json:"error,omitempty"`
+	// Facets holds the value counts of the requested facets, keyed by field
+	Facets    map[string][]CommonFacetBucket `json:"facets,omitempty"`
+	IsSuccess bool                           `json:"is_success,omitempty"`
+	Message   string                         `json:"message,omitempty"`
+	// NextCursor fetches the next page of a cursor-paginated list; empty on the last page
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Pagination PaginationInfo `json:"pagination,omitempty"`
+	// PIT is the token pinning the following pages of a point-in-time session
+	Pit string `json:"pit,omitempty"`
+	// Search reports how Elasticsearch executed a search; only set on search responses
+	Search SearchMeta `json:"search,omitempty"`
+	// Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean")
+	Suggestions []string `json:"suggestions,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// PagedResponseArraySearchDailySummary is generated from the API spec
+type PagedResponseArraySearchDailySummary struct {
+	Data  []SearchDailySummary `json:"data,omitempty"`
+	Error string               `json:"error,omitempty"`
+	// Facets holds the value counts of the requested facets, keyed by field
+	Facets    map[string][]CommonFacetBucket `json:"facets,omitempty"`
+	IsSuccess bool                           `json:"is_success,omitempty"`
+	Message   string                         `json:"message,omitempty"`
+	// NextCursor fetches the next page of a cursor-paginated list; empty on the last page
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Pagination PaginationInfo `json:"pagination,omitempty"`
+	// PIT is the token pinning the following pages of a point-in-time session
+	Pit string `json:"pit,omitempty"`
+	// Search reports how Elasticsearch executed a search; only set on search responses
+	Search SearchMeta `json:"search,omitempty"`
+	// Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean")
+	Suggestions []string `json:"suggestions,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// PagedResponseArraySynonymRule is generated from the API spec
+type PagedResponseArraySynonymRule struct {
+	Data  []SynonymRule `json:"data,omitempty"`
+	Error string        `json:"error,omitempty"`
+	// Facets holds the value counts of the requested facets, keyed by field
+	Facets    map[string][]CommonFacetBucket `json:"facets,omitempty"`
+	IsSuccess bool                           `json:"is_success,omitempty"`
+	Message   string                         `json:"message,omitempty"`
+	// NextCursor fetches the next page of a cursor-paginated list; empty on the last page
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Pagination PaginationInfo `json:"pagination,omitempty"`
+	// PIT is the token pinning the following pages of a point-in-time session
+	Pit string `json:"pit,omitempty"`
+	// Search reports how Elasticsearch executed a search; only set on search responses
+	Search SearchMeta `json:"search,omitempty"`
+	// Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean")
+	Suggestions []string `json:"suggestions,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// PaginationInfo is generated from the API spec
+type PaginationInfo struct {
+	CurrentPage int64 `json:"current_page,omitempty"`
+	Limit       int64 `json:"limit,omitempty"`
+	Offset      int64 `json:"offset,omitempty"`
+	Total       int64 `json:"total,omitempty"`
+	TotalPages  int64 `json:"total_pages,omitempty"`
+	// TotalRelation is "gte" when Total is a lower bound because hit counting was capped
+	TotalRelation string `json:"total_relation,omitempty"`
+}
+
+// SearchMeta is generated from the API spec
+type SearchMeta struct {
+	// MaxScore is the highest relevance score of any hit; null when hits aren't scored or nothing matched
+	MaxScore float64 `json:"max_score,omitempty"`
+	TimedOut bool    `json:"timed_out,omitempty"`
+	// TookMs is the time Elasticsearch spent executing the search
+	TookMs int64 `json:"took_ms,omitempty"`
+}
+
+// Fragment is generated from the API spec
+type Fragment struct {
+	Matches []Span `json:"matches,omitempty"`
+	Text    string `json:"text,omitempty"`
+}
+
+// Span is generated from the API spec
+type Span struct {
+	End   int64 `json:"end,omitempty"`
+	Start int64 `json:"start,omitempty"`
+}
+
+// BatchGetRequest product IDs to fetch in one request
+type BatchGetRequest struct {
+	IDs []string `json:"ids,omitempty"`
+}
+
+// BatchGetResult products found for a batch get, in request order, and the IDs that don't exist
+type BatchGetResult struct {
+	Missing  []string  `json:"missing,omitempty"`
+	Products []Product `json:"products,omitempty"`
+}
+
+// BulkItemResult outcome of a single item in a bulk write
+type BulkItemResult struct {
+	Error    string `json:"error,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Position int64  `json:"position,omitempty"`
+	// RolledBack is set on items written and then removed again because another item of an all-or-nothing
+	// write failed
+	RolledBack bool  `json:"rolled_back,omitempty"`
+	Status     int64 `json:"status,omitempty"`
+}
+
+// BulkResult per-item outcome of a bulk write
+type BulkResult struct {
+	Failed int64            `json:"failed,omitempty"`
+	Items  []BulkItemResult `json:"items,omitempty"`
+	// Rollback reports the reverted writes of an all-or-nothing write with failed items
+	Rollback  BulkRollback `json:"rollback,omitempty"`
+	Succeeded int64        `json:"succeeded,omitempty"`
+}
+
+// BulkRollback reconciliation report of the writes reverted after an all-or-nothing bulk write failed
+type BulkRollback struct {
+	// Failed is the number of written products that couldn't be removed and are still stored
+	Failed int64 `json:"failed,omitempty"`
+	// Items holds the outcome of reverting every written product, positions referring to the request array
+	Items []BulkItemResult `json:"items,omitempty"`
+	// Reverted is the number of written products removed again
+	Reverted int64 `json:"reverted,omitempty"`
+}
+
+// CatalogStats aggregated overview of the product catalog
+type CatalogStats struct {
+	AddedLast30Days   int64  `json:"added_last_30_days,omitempty"`
+	DistinctCompanies int64  `json:"distinct_companies,omitempty"`
+	DistinctGenerics  int64  `json:"distinct_generics,omitempty"`
+	NewestUpdatedAt   string `json:"newest_updated_at,omitempty"`
+	OldestUpdatedAt   string `json:"oldest_updated_at,omitempty"`
+	TotalProducts     int64  `json:"total_products,omitempty"`
+}
+
+// CreateProductRequest payload for creating a product; when the ID is omitted one is assigned by the configured ID strategy
+type CreateProductRequest struct {
+	Company     string `json:"company,omitempty"`
+	DrugGeneric string `json:"drug_generic,omitempty"`
+	ID          string `json:"id,omitempty"`
+	ProductName string `json:"product_name,omitempty"`
+}
+
+// CreationHistogram number of products created per calendar interval, oldest interval first
+type CreationHistogram struct {
+	Buckets  []DateBucket `json:"buckets,omitempty"`
+	Interval string       `json:"interval,omitempty"`
+}
+
+// Curation products pinned to the top of the results for a keyword
+type Curation struct {
+	Keyword   string   `json:"keyword,omitempty"`
+	PinnedIDs []string `json:"pinned_ids,omitempty"`
+	UpdatedAt string   `json:"updated_at,omitempty"`
+}
+
+// CurationRequest products to pin for a keyword, in display order
+type CurationRequest struct {
+	PinnedIDs []string `json:"pinned_ids,omitempty"`
+}
+
+// DateBucket number of products created in the interval starting at Date
+type DateBucket struct {
+	Count int64  `json:"count,omitempty"`
+	Date  string `json:"date,omitempty"`
+}
+
+// DistinctValues distinct values of a field over the products matching a search
+type DistinctValues struct {
+	// Count is the approximate number of distinct values; Values lists the first of them alphabetically
+	Count  int64    `json:"count,omitempty"`
+	Field  string   `json:"field,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// ExclusionRule products or companies hidden from the results of a keyword, or of every search when the keyword is empty
+type ExclusionRule struct {
+	Companies  []string `json:"companies,omitempty"`
+	ID         string   `json:"id,omitempty"`
+	Keyword    string   `json:"keyword,omitempty"`
+	ProductIDs []string `json:"product_ids,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+	UpdatedAt  string   `json:"updated_at,omitempty"`
+}
+
+// ExclusionRuleRequest exclusion rule to create or replace; leave keyword empty for a global rule
+type ExclusionRuleRequest struct {
+	Companies  []string `json:"companies,omitempty"`
+	Keyword    string   `json:"keyword,omitempty"`
+	ProductIDs []string `json:"product_ids,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+}
+
+// ModelsFacetBucket number of matching products with one value of a faceted field
+type ModelsFacetBucket struct {
+	Count int64  `json:"count,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// ImportCheck outcome of a verification or data-quality check run on a staged import
+type ImportCheck struct {
+	// Advisory checks are reported but don't fail the import
+	Advisory bool   `json:"advisory,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Passed   bool   `json:"passed,omitempty"`
+}
+
+// ImportDiff differences between the catalogs loaded by two import runs
+type ImportDiff struct {
+	Added         int64    `json:"added,omitempty"`
+	Changed       int64    `json:"changed,omitempty"`
+	From          string   `json:"from,omitempty"`
+	Removed       int64    `json:"removed,omitempty"`
+	SampleAdded   []string `json:"sample_added,omitempty"`
+	SampleChanged []string `json:"sample_changed,omitempty"`
+	SampleRemoved []string `json:"sample_removed,omitempty"`
+	To            string   `json:"to,omitempty"`
+	Unchanged     int64    `json:"unchanged,omitempty"`
+}
+
+// ImportDuplicate rows of an import sharing a product ID, or a product name and company under different IDs
+type ImportDuplicate struct {
+	// Kind is "id" for rows with the same ID or "name_company" for near duplicates
+	Kind string         `json:"kind,omitempty"`
+	Rows []ImportRowRef `json:"rows,omitempty"`
+	// Value is the shared ID, or the product name and company of the first repeated row
+	Value string `json:"value,omitempty"`
+}
+
+// ImportJob import started in the background
+type ImportJob struct {
+	CreatedAt  string   `json:"created_at,omitempty"`
+	Errors     []string `json:"errors,omitempty"`
+	Failed     int64    `json:"failed,omitempty"`
+	FinishedAt string   `json:"finished_at,omitempty"`
+	// ID identifies the import in its progress and, once it finished, in the import history
+	ID      string `json:"id,omitempty"`
+	Indexed int64  `json:"indexed,omitempty"`
+	// The counts of a running job are those of its last progress report
+	RowsRead    int64 `json:"rows_read,omitempty"`
+	RowsSkipped int64 `json:"rows_skipped,omitempty"`
+	// Source is the imported URL or file; uploads are imported from a copy saved by the server
+	Source string `json:"source,omitempty"`
+	// Status is queued, running, succeeded, failed or cancelled
+	Status      string `json:"status,omitempty"`
+	TriggeredBy string `json:"triggered_by,omitempty"`
+}
+
+// ImportProgress progress of the import running in the background, or outcome of the last one
+type ImportProgress struct {
+	EtaSeconds int64 `json:"eta_seconds,omitempty"`
+	Failed     int64 `json:"failed,omitempty"`
+	// ID is the job ID of an import started over HTTP, also the ID of its run in the import history
+	ID      string `json:"id,omitempty"`
+	Index   string `json:"index,omitempty"`
+	Indexed int64  `json:"indexed,omitempty"`
+	// PercentDone and ETASeconds are estimated from the share of the source read so far; they are left out
+	// for sources of unknown size
+	PercentDone float64 `json:"percent_done,omitempty"`
+	RowsPerSec  float64 `json:"rows_per_sec,omitempty"`
+	RowsRead    int64   `json:"rows_read,omitempty"`
+	RowsSkipped int64   `json:"rows_skipped,omitempty"`
+	Source      string  `json:"source,omitempty"`
+	StartedAt   string  `json:"started_at,omitempty"`
+	Status      string  `json:"status,omitempty"`
+}
+
+// ImportRowRef row of an import source; rows are numbered like spreadsheet rows, the header being row 1
+type ImportRowRef struct {
+	Row    int64  `json:"row,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+// ImportRun summary of a single import run
+type ImportRun struct {
+	Checks []ImportCheck `json:"checks,omitempty"`
+	// DuplicateIDs and NearDuplicates count the IDs, and the product names and companies under different IDs,
+	// found on several rows; Duplicates lists the first of them
+	DuplicateIDs   int64             `json:"duplicate_ids,omitempty"`
+	Duplicates     []ImportDuplicate `json:"duplicates,omitempty"`
+	DurationMs     int64             `json:"duration_ms,omitempty"`
+	Errors         []string          `json:"errors,omitempty"`
+	Failed         int64             `json:"failed,omitempty"`
+	FinishedAt     string            `json:"finished_at,omitempty"`
+	ID             string            `json:"id,omitempty"`
+	Index          string            `json:"index,omitempty"`
+	Indexed        int64             `json:"indexed,omitempty"`
+	NearDuplicates int64             `json:"near_duplicates,omitempty"`
+	Promoted       bool              `json:"promoted,omitempty"`
+	RowsRead       int64             `json:"rows_read,omitempty"`
+	RowsSkipped    int64             `json:"rows_skipped,omitempty"`
+	// Sheets breaks imports of every worksheet of a workbook down per worksheet
+	Sheets []ImportSheet `json:"sheets,omitempty"`
+	Source string        `json:"source,omitempty"`
+	// Staged is set for imports loaded into a staging index instead of the live alias
+	Staged      bool   `json:"staged,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	Status      string `json:"status,omitempty"`
+	TriggeredBy string `json:"triggered_by,omitempty"`
+}
+
+// ImportSheet outcome of importing one worksheet of a workbook
+type ImportSheet struct {
+	// Error is set for worksheets left out of the import, such as those without the required columns
+	Error   string `json:"error,omitempty"`
+	Failed  int64  `json:"failed,omitempty"`
+	Indexed int64  `json:"indexed,omitempty"`
+	// Name identifies the worksheet as workbook#sheet
+	Name        string `json:"name,omitempty"`
+	RowsRead    int64  `json:"rows_read,omitempty"`
+	RowsSkipped int64  `json:"rows_skipped,omitempty"`
+}
+
+// IndexStats operational overview of the product index
+type IndexStats struct {
+	// Companies and DrugGenerics hold the document counts of the most frequent values
+	Companies       []ModelsFacetBucket `json:"companies,omitempty"`
+	DrugGenerics    []ModelsFacetBucket `json:"drug_generics,omitempty"`
+	Index           string              `json:"index,omitempty"`
+	NewestCreatedAt string              `json:"newest_created_at,omitempty"`
+	OldestCreatedAt string              `json:"oldest_created_at,omitempty"`
+	// PrimarySizeBytes is the disk size of the primary shards, SizeBytes includes the replicas
+	PrimarySizeBytes int64 `json:"primary_size_bytes,omitempty"`
+	SizeBytes        int64 `json:"size_bytes,omitempty"`
+	TotalDocuments   int64 `json:"total_documents,omitempty"`
+}
+
+// Product represents a product object
+type Product struct {
+	// Attributes holds the source columns an import keeps besides the product fields, keyed by attribute name
+	Attributes  json.RawMessage `json:"attributes,omitempty"`
+	Company     string          `json:"company,omitempty"`
+	CreatedAt   string          `json:"created_at,omitempty"`
+	DeletedAt   string          `json:"deleted_at,omitempty"`
+	DrugGeneric string          `json:"drug_generic,omitempty"`
+	// HighlightOffsets holds the plain-text fragments and match offsets per field with the offsets format
+	HighlightOffsets map[string][]Fragment `json:"highlight_offsets,omitempty"`
+	// Highlights holds the highlighted fragments per field when requested with the html format
+	Highlights  map[string][]string `json:"highlights,omitempty"`
+	ID          string              `json:"id,omitempty"`
+	ProductName string              `json:"product_name,omitempty"`
+	Score       float64             `json:"score,omitempty"`
+	UpdatedAt   string              `json:"updated_at,omitempty"`
+}
+
+// ProductCount number of products matching a search
+type ProductCount struct {
+	Count int64 `json:"count,omitempty"`
+}
+
+// ProductFacets value counts of the faceted fields over the products matching a search
+type ProductFacets struct {
+	Facets map[string][]ModelsFacetBucket `json:"facets,omitempty"`
+	// Total is the number of matching products, the selections on faceted fields included
+	Total int64 `json:"total,omitempty"`
+}
+
+// ProductGroup best matching products of one company
+type ProductGroup struct {
+	Company string `json:"company,omitempty"`
+	// Count is the number of matching products of the company, of which Products are the best
+	Count    int64     `json:"count,omitempty"`
+	Products []Product `json:"products,omitempty"`
+}
+
+// ProductGroups best matching products of the companies with the most matches, most matches first
+type ProductGroups struct {
+	Groups []ProductGroup `json:"groups,omitempty"`
+	// Total is the number of matching products of every company
+	Total int64 `json:"total,omitempty"`
+}
+
+// QueryCount number of searches for a keyword
+type QueryCount struct {
+	Count int64  `json:"count,omitempty"`
+	Query string `json:"query,omitempty"`
+}
+
+// SearchDailySummary search activity rolled up for a single UTC day
+type SearchDailySummary struct {
+	AvgLatencyMs float64 `json:"avg_latency_ms,omitempty"`
+	// Date is the UTC day in YYYY-MM-DD form
+	Date               string       `json:"date,omitempty"`
+	RolledUpAt         string       `json:"rolled_up_at,omitempty"`
+	Searches           int64        `json:"searches,omitempty"`
+	TopQueries         []QueryCount `json:"top_queries,omitempty"`
+	ZeroResultRate     float64      `json:"zero_result_rate,omitempty"`
+	ZeroResultSearches int64        `json:"zero_result_searches,omitempty"`
+}
+
+// SearchSnapshot search captured with its effective Elasticsearch query and results, for reproducing reported results later
+type SearchSnapshot struct {
+	CreatedAt string    `json:"created_at,omitempty"`
+	ID        string    `json:"id,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	Products  []Product `json:"products,omitempty"`
+	// Query is the search request body sent to Elasticsearch
+	Query json.RawMessage `json:"query,omitempty"`
+	// Request is the query string of the captured search, as sent to GET /product
+	Request    string   `json:"request,omitempty"`
+	TookMs     int64    `json:"took_ms,omitempty"`
+	TotalCount int64    `json:"total_count,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
+}
+
+// SignificantTerm value of a field that is overrepresented in the products matching a search
+type SignificantTerm struct {
+	BackgroundCount int64 `json:"background_count,omitempty"`
+	// Count is the number of matching products with the value, BackgroundCount the number in the whole catalog
+	Count int64   `json:"count,omitempty"`
+	Score float64 `json:"score,omitempty"`
+	Value string  `json:"value,omitempty"`
+}
+
+// SignificantTerms values of a field that are overrepresented in the products matching a search, most significant first
+type SignificantTerms struct {
+	// Count is the number of matching products the values were compared against the whole catalog with
+	Count int64             `json:"count,omitempty"`
+	Field string            `json:"field,omitempty"`
+	Terms []SignificantTerm `json:"terms,omitempty"`
+}
+
+// SnapshotRequest optional context stored with a search snapshot, such as a support ticket reference
+type SnapshotRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// StartImportRequest request to start an import in the background
+type StartImportRequest struct {
+	// Source is a Google Sheets URL or an s3:// or gs:// object; local paths and sql: queries are refused
+	Source string `json:"source,omitempty"`
+	// TriggeredBy identifies who started the import; defaults to "api:admin"
+	TriggeredBy string `json:"triggered_by,omitempty"`
+}
+
+// Suggestion product name or popular search completing a typed prefix
+type Suggestion struct {
+	Company string `json:"company,omitempty"`
+	// ID and Company identify the product a completion was taken from
+	ID string `json:"id,omitempty"`
+	// Score ranks completions; Searches counts the searches of a popular suggestion
+	Score    float64 `json:"score,omitempty"`
+	Searches int64   `json:"searches,omitempty"`
+	// Source is completion for product names and popular_suggestions for popular searches
+	Source string `json:"source,omitempty"`
+	Text   string `json:"text,omitempty"`
+}
+
+// SynonymRule terms matched interchangeably when searching products, e.g. acetaminophen and paracetamol
+type SynonymRule struct {
+	ID       string   `json:"id,omitempty"`
+	Synonyms []string `json:"synonyms,omitempty"`
+}
+
+// SynonymRuleRequest synonym rule to create or replace
+type SynonymRuleRequest struct {
+	// Synonyms lists at least two equivalent terms or phrases
+	Synonyms []string `json:"synonyms,omitempty"`
+}
+
+// TypeaheadGroups typeahead matches of a partially typed keyword grouped by entity type, for sectioned autocomplete
+type TypeaheadGroups struct {
+	Companies []ModelsFacetBucket `json:"companies,omitempty"`
+	// Generics and Companies are the matching values with the most matching products first
+	Generics []ModelsFacetBucket `json:"generics,omitempty"`
+	// Products are the best products whose name matches
+	Products []Product `json:"products,omitempty"`
+}
+
+// UpdateProductRequest partial product update; only the provided fields are changed
+type UpdateProductRequest struct {
+	Company     string `json:"company,omitempty"`
+	DrugGeneric string `json:"drug_generic,omitempty"`
+	ProductName string `json:"product_name,omitempty"`
+}
+
+// Status is generated from the API spec
+type Status struct {
+	Enabled  bool   `json:"enabled,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Recorded int64  `json:"recorded,omitempty"`
+}
+
+// ListDailySearchSummariesParams holds the query parameters of ListDailySearchSummaries
+type ListDailySearchSummariesParams struct {
+	// Limit number of results
+	Limit *int64
+	// Offset for pagination
+	Offset *int64
+}
+
+// ListDailySearchSummaries lists the nightly search rollups (top queries, zero-result rate, average latency), newest day first (GET /admin/analytics/daily)
+func (c *Client) ListDailySearchSummaries(ctx context.Context, params ListDailySearchSummariesParams) (*PagedResponseArraySearchDailySummary, error) {
+	query := url.Values{}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	if params.Offset != nil {
+		query.Set("offset", fmt.Sprint(*params.Offset))
+	}
+	var out PagedResponseArraySearchDailySummary
+	if err := c.do(ctx, "GET", "/admin/analytics/daily", query, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListCurationsParams holds the query parameters of ListCurations
+type ListCurationsParams struct {
+	// Limit number of results
+	Limit *int64
+	// Offset for pagination
+	Offset *int64
+}
+
+// ListCurations lists keywords with pinned products, ordered by keyword (GET /admin/curations)
+func (c *Client) ListCurations(ctx context.Context, params ListCurationsParams) (*PagedResponseArrayCuration, error) {
+	query := url.Values{}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	if params.Offset != nil {
+		query.Set("offset", fmt.Sprint(*params.Offset))
+	}
+	var out PagedResponseArrayCuration
+	if err := c.do(ctx, "GET", "/admin/curations", query, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCuration returns the products pinned for a keyword (GET /admin/curations/{keyword})
+func (c *Client) GetCuration(ctx context.Context, keyword string) (*BaseResponseCuration, error) {
+	var out BaseResponseCuration
+	if err := c.do(ctx, "GET", "/admin/curations/"+url.PathEscape(keyword), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetCuration pins products, in order, to the top of the results of a keyword search, replacing earlier pins (PUT /admin/curations/{keyword})
+func (c *Client) SetCuration(ctx context.Context, keyword string, body CurationRequest) (*BaseResponseCuration, error) {
+	var out BaseResponseCuration
+	if err := c.do(ctx, "PUT", "/admin/curations/"+url.PathEscape(keyword), nil, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteCuration removes the pinned products of a keyword (DELETE /admin/curations/{keyword})
+func (c *Client) DeleteCuration(ctx context.Context, keyword string) (*BaseResponseString, error) {
+	var out BaseResponseString
+	if err := c.do(ctx, "DELETE", "/admin/curations/"+url.PathEscape(keyword), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateSearchSnapshotParams holds the query parameters of CreateSearchSnapshot
+type CreateSearchSnapshotParams struct {
+	// Search keyword
+	Keyword string
+	// Only products of these companies (exact match, repeatable)
+	Company []string
+	// Limit number of results
+	Limit *int64
+	// Offset for pagination
+	Offset *int64
+	// Comma-separated field:direction list, e.g. product_name:asc,created_at:desc
+	Sort string
+}
+
+// CreateSearchSnapshot runs a product search with the GET /product query parameters given in the query string (all of them are accepted, cursor and pit excepted) and stores the request, the effective Elasticsearch query and the results (POST /admin/debug/snapshot)
+func (c *Client) CreateSearchSnapshot(ctx context.Context, body SnapshotRequest, params CreateSearchSnapshotParams) (*BaseResponseSearchSnapshot, error) {
+	query := url.Values{}
+	if params.Keyword != "" {
+		query.Set("keyword", params.Keyword)
+	}
+	for _, v := range params.Company {
+		query.Add("company", v)
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	if params.Offset != nil {
+		query.Set("offset", fmt.Sprint(*params.Offset))
+	}
+	if params.Sort != "" {
+		query.Set("sort", params.Sort)
+	}
+	var out BaseResponseSearchSnapshot
+	if err := c.do(ctx, "POST", "/admin/debug/snapshot", query, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSearchSnapshot returns a captured search with its request, effective Elasticsearch query and results (GET /admin/debug/snapshot/{id})
+func (c *Client) GetSearchSnapshot(ctx context.Context, id string) (*BaseResponseSearchSnapshot, error) {
+	var out BaseResponseSearchSnapshot
+	if err := c.do(ctx, "GET", "/admin/debug/snapshot/"+url.PathEscape(id), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListExclusionRulesParams holds the query parameters of ListExclusionRules
+type ListExclusionRulesParams struct {
+	// Limit number of results
+	Limit *int64
+	// Offset for pagination
+	Offset *int64
+}
+
+// ListExclusionRules lists the rules hiding products or companies from search results, global rules first (GET /admin/exclusions)
+func (c *Client) ListExclusionRules(ctx context.Context, params ListExclusionRulesParams) (*PagedResponseArrayExclusionRule, error) {
+	query := url.Values{}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	if params.Offset != nil {
+		query.Set("offset", fmt.Sprint(*params.Offset))
+	}
+	var out PagedResponseArrayExclusionRule
+	if err := c.do(ctx, "GET", "/admin/exclusions", query, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateExclusionRule hides products or companies from the results of a keyword, or of every search when the keyword is empty (POST /admin/exclusions)
+func (c *Client) CreateExclusionRule(ctx context.Context, body ExclusionRuleRequest) (*BaseResponseExclusionRule, error) {
+	var out BaseResponseExclusionRule
+	if err := c.do(ctx, "POST", "/admin/exclusions", nil, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExclusionRule returns an exclusion rule by ID (GET /admin/exclusions/{id})
+func (c *Client) GetExclusionRule(ctx context.Context, id string) (*BaseResponseExclusionRule, error) {
+	var out BaseResponseExclusionRule
+	if err := c.do(ctx, "GET", "/admin/exclusions/"+url.PathEscape(id), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReplaceExclusionRule replaces the keyword, products and companies of an exclusion rule (PUT /admin/exclusions/{id})
+func (c *Client) ReplaceExclusionRule(ctx context.Context, id string, body ExclusionRuleRequest) (*BaseResponseExclusionRule, error) {
+	var out BaseResponseExclusionRule
+	if err := c.do(ctx, "PUT", "/admin/exclusions/"+url.PathEscape(id), nil, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteExclusionRule removes an exclusion rule (DELETE /admin/exclusions/{id})
+func (c *Client) DeleteExclusionRule(ctx context.Context, id string) (*BaseResponseString, error) {
+	var out BaseResponseString
+	if err := c.do(ctx, "DELETE", "/admin/exclusions/"+url.PathEscape(id), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StartImport starts importing a Google Sheets URL or an s3:// or gs:// object (local paths and sql: queries are refused, they only run from the command line), or a .csv, .ndjson, .jsonl, .parquet or .xlsx file uploaded as the "file" field of a multipart/form-data request (with an optional "triggered_by" field), and returns the job ID of the import; its progress and outcome are reported under that ID by the import progress and history (POST /admin/import)
+func (c *Client) StartImport(ctx context.Context, body StartImportRequest) (*BaseResponseImportJob, error) {
+	var out BaseResponseImportJob
+	if err := c.do(ctx, "POST", "/admin/import", nil, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportHistoryParams holds the query parameters of ImportHistory
+type ImportHistoryParams struct {
+	// Limit number of results
+	Limit *int64
+	// Offset for pagination
+	Offset *int64
+	// Filter by (partial) source
+	Source string
+	// Filter by status (succeeded, failed, cancelled)
+	Status string
+	// Filter by who triggered the import
+	TriggeredBy string
+	// Only runs started at or after this RFC3339 time
+	Since string
+	// Only runs started at or before this RFC3339 time
+	Until string
+}
+
+// ImportHistory lists import run summaries, newest first, with optional filters; the same listing as GET /admin/imports (GET /admin/import/history)
+func (c *Client) ImportHistory(ctx context.Context, params ImportHistoryParams) (*PagedResponseArrayImportRun, error) {
+	query := url.Values{}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	if params.Offset != nil {
+		query.Set("offset", fmt.Sprint(*params.Offset))
+	}
+	if params.Source != "" {
+		query.Set("source", params.Source)
+	}
+	if params.Status != "" {
+		query.Set("status", params.Status)
+	}
+	if params.TriggeredBy != "" {
+		query.Set("triggered_by", params.TriggeredBy)
+	}
+	if params.Since != "" {
+		query.Set("since", params.Since)
+	}
+	if params.Until != "" {
+		query.Set("until", params.Until)
+	}
+	var out PagedResponseArrayImportRun
+	if err := c.do(ctx, "GET", "/admin/import/history", query, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetImportJob returns the status (queued, running, succeeded, failed or cancelled), row counts and errors of an import started over HTTP since the server started (GET /admin/import/jobs/{id})
+func (c *Client) GetImportJob(ctx context.Context, id string) (*BaseResponseImportJob, error) {
+	var out BaseResponseImportJob
+	if err := c.do(ctx, "GET", "/admin/import/jobs/"+url.PathEscape(id), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelImportJob cancels a queued or running import started over HTTP. The import stops at its next row, keeping the rows already indexed, and is recorded in the import history as cancelled; poll the job for its final status (DELETE /admin/import/jobs/{id})
+func (c *Client) CancelImportJob(ctx context.Context, id string) (*BaseResponseImportJob, error) {
+	var out BaseResponseImportJob
+	if err := c.do(ctx, "DELETE", "/admin/import/jobs/"+url.PathEscape(id), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetImportProgress returns the rows read, indexed and failed, the throughput and the estimated time left of the import running in the background, or the outcome of the last import (GET /admin/import/progress)
+func (c *Client) GetImportProgress(ctx context.Context) (*BaseResponseImportProgress, error) {
+	var out BaseResponseImportProgress
+	if err := c.do(ctx, "GET", "/admin/import/progress", nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListImportRunsParams holds the query parameters of ListImportRuns
+type ListImportRunsParams struct {
+	// Limit number of results
+	Limit *int64
+	// Offset for pagination
+	Offset *int64
+	// Filter by (partial) source
+	Source string
+	// Filter by status (succeeded, failed, cancelled)
+	Status string
+	// Filter by who triggered the import
+	TriggeredBy string
+	// Only runs started at or after this RFC3339 time
+	Since string
+	// Only runs started at or before this RFC3339 time
+	Until string
+}
+
+// ListImportRuns lists import run summaries, newest first, with optional filters (GET /admin/imports)
+func (c *Client) ListImportRuns(ctx context.Context, params ListImportRunsParams) (*PagedResponseArrayImportRun, error) {
+	query := url.Values{}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	if params.Offset != nil {
+		query.Set("offset", fmt.Sprint(*params.Offset))
+	}
+	if params.Source != "" {
+		query.Set("source", params.Source)
+	}
+	if params.Status != "" {
+		query.Set("status", params.Status)
+	}
+	if params.TriggeredBy != "" {
+		query.Set("triggered_by", params.TriggeredBy)
+	}
+	if params.Since != "" {
+		query.Set("since", params.Since)
+	}
+	if params.Until != "" {
+		query.Set("until", params.Until)
+	}
+	var out PagedResponseArrayImportRun
+	if err := c.do(ctx, "GET", "/admin/imports", query, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DiffImportRuns returns added, removed and changed product counts with sample IDs between import runs a and b (GET /admin/imports/{a}/diff/{b})
+func (c *Client) DiffImportRuns(ctx context.Context, a string, b string) (*BaseResponseImportDiff, error) {
+	var out BaseResponseImportDiff
+	if err := c.do(ctx, "GET", "/admin/imports/"+url.PathEscape(a)+"/diff/"+url.PathEscape(b), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchRecorderStatus returns whether searches are being recorded, the output file and the number of recorded searches (GET /admin/recorder)
+func (c *Client) SearchRecorderStatus(ctx context.Context) (*BaseResponseStatus, error) {
+	var out BaseResponseStatus
+	if err := c.do(ctx, "GET", "/admin/recorder", nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StartSearchRecorder starts appending anonymized searches and their Elasticsearch responses to the recorder file (POST /admin/recorder/start)
+func (c *Client) StartSearchRecorder(ctx context.Context) (*BaseResponseStatus, error) {
+	var out BaseResponseStatus
+	if err := c.do(ctx, "POST", "/admin/recorder/start", nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StopSearchRecorder stops recording searches and closes the recorder file (POST /admin/recorder/stop)
+func (c *Client) StopSearchRecorder(ctx context.Context) (*BaseResponseStatus, error) {
+	var out BaseResponseStatus
+	if err := c.do(ctx, "POST", "/admin/recorder/stop", nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetIndexStatsParams holds the query parameters of GetIndexStats
+type GetIndexStatsParams struct {
+	// Number of companies and drug generics listed, 1-1000 (default 10)
+	Size *int64
+}
+
+// GetIndexStats returns the total documents, the document counts of the most frequent companies and drug generics, the range of creation times and the disk size of the product index (GET /admin/stats)
+func (c *Client) GetIndexStats(ctx context.Context, params GetIndexStatsParams) (*BaseResponseIndexStats, error) {
+	query := url.Values{}
+	if params.Size != nil {
+		query.Set("size", fmt.Sprint(*params.Size))
+	}
+	var out BaseResponseIndexStats
+	if err := c.do(ctx, "GET", "/admin/stats", query, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListSynonymRulesParams holds the query parameters of ListSynonymRules
+type ListSynonymRulesParams struct {
+	// Limit number of results
+	Limit *int64
+	// Offset for pagination
+	Offset *int64
+}
+
+// ListSynonymRules lists the synonym rules expanding product searches and suggestions, in ID order (GET /admin/synonyms)
+func (c *Client) ListSynonymRules(ctx context.Context, params ListSynonymRulesParams) (*PagedResponseArraySynonymRule, error) {
+	query := url.Values{}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	if params.Offset != nil {
+		query.Set("offset", fmt.Sprint(*params.Offset))
+	}
+	var out PagedResponseArraySynonymRule
+	if err := c.do(ctx, "GET", "/admin/synonyms", query, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateSynonymRule makes searches for any of the terms match the others; applied to live indices without reindexing (POST /admin/synonyms)
+func (c *Client) CreateSynonymRule(ctx context.Context, body SynonymRuleRequest) (*BaseResponseSynonymRule, error) {
+	var out BaseResponseSynonymRule
+	if err := c.do(ctx, "POST", "/admin/synonyms", nil, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSynonymRule returns a synonym rule by ID (GET /admin/synonyms/{id})
+func (c *Client) GetSynonymRule(ctx context.Context, id string) (*BaseResponseSynonymRule, error) {
+	var out BaseResponseSynonymRule
+	if err := c.do(ctx, "GET", "/admin/synonyms/"+url.PathEscape(id), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReplaceSynonymRule replaces the terms of a synonym rule (PUT /admin/synonyms/{id})
+func (c *Client) ReplaceSynonymRule(ctx context.Context, id string, body SynonymRuleRequest) (*BaseResponseSynonymRule, error) {
+	var out BaseResponseSynonymRule
+	if err := c.do(ctx, "PUT", "/admin/synonyms/"+url.PathEscape(id), nil, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteSynonymRule removes a synonym rule (DELETE /admin/synonyms/{id})
+func (c *Client) DeleteSynonymRule(ctx context.Context, id string) (*BaseResponseString, error) {
+	var out BaseResponseString
+	if err := c.do(ctx, "DELETE", "/admin/synonyms/"+url.PathEscape(id), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// HealthCheck checks the health of the service and returns a status message (GET /health)
+func (c *Client) HealthCheck(ctx context.Context) (map[string]string, error) {
+	var out map[string]string
+	if err := c.do(ctx, "GET", "/health", nil, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetProductsParams holds the query parameters of GetProducts
+type GetProductsParams struct {
+	// Limit number of results
+	Limit *int64
+	// Offset for pagination
+	Offset *int64
+	// start to begin cursor pagination, then the next_cursor of the previous page (offset must be 0)
+	Cursor string
+	// start to pin offset pages to a point in time, then the pit of the previous page
+	Pit string
+	// Search keyword
+	Keyword string
+	// Only products of these companies (exact match, repeatable)
+	Company []string
+	// Only products with these generic names (exact match, repeatable)
+	DrugGeneric []string
+	// Only products with these IDs (repeatable)
+	ID []string
+	// Only products created at or after this RFC3339 time
+	CreatedAfter string
+	// Only products created before this RFC3339 time
+	CreatedBefore string
+	// Only products updated at or after this RFC3339 time
+	UpdatedAfter string
+	// Only products updated before this RFC3339 time
+	UpdatedBefore string
+	// Comma-separated field:direction list, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at)
+	Sort string
+	// Comma-separated fields to return, e.g. id,product_name (fields: id, product_name, drug_generic, company, score, created_at, updated_at, deleted_at, attributes)
+	Fields string
+	// Return highlighted fragments of the matched fields (format set by SEARCH_HIGHLIGHT_FORMAT)
+	Highlight *bool
+	// Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)
+	Fuzziness string
+	// Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)
+	SearchFields string
+	// Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)
+	Mode string
+	// true to count every hit or a number to cap the count, e.g. 10000 (default SEARCH_TRACK_TOTAL_HITS)
+	TrackTotalHits string
+	// Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)
+	MinScore *float64
+	// Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)
+	Syntax *bool
+	// Merge hits with the same normalized product name and company
+	Dedupe *bool
+	// Comma-separated fields to return value counts of with the hits (fields: company, drug_generic); a facet ignores its own filter
+	Facets string
+	// At most this many products of one company in the top SEARCH_DIVERSITY_TOP_K results, 0 disables it (default SEARCH_DIVERSITY_MAX_PER_COMPANY; not with cursor)
+	MaxPerCompany *int64
+	// Query a non-default index or alias (admin only)
+	Index string
+	// Include soft-deleted products (admin only)
+	IncludeDeleted *bool
+	// Set to false to return the bare product array with X-Total-Count and Link headers
+	Envelope *bool
+	// Omit zero-value fields such as score 0 and unset timestamps
+	OmitEmpty *bool
+}
+
+// GetProducts retrieves a list of products with pagination and search keywords (GET /product)
+func (c *Client) GetProducts(ctx context.Context, params GetProductsParams) (*PagedResponseArrayProduct, error) {
+	query := url.Values{}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	if params.Offset != nil {
+		query.Set("offset", fmt.Sprint(*params.Offset))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	if params.Pit != "" {
+		query.Set("pit", params.Pit)
+	}
+	if params.Keyword != "" {
+		query.Set("keyword", params.Keyword)
+	}
+	for _, v := range params.Company {
+		query.Add("company", v)
+	}
+	for _, v := range params.DrugGeneric {
+		query.Add("drug_generic", v)
+	}
+	for _, v := range params.ID {
+		query.Add("id", v)
+	}
+	if params.CreatedAfter != "" {
+		query.Set("created_after", params.CreatedAfter)
+	}
+	if params.CreatedBefore != "" {
+		query.Set("created_before", params.CreatedBefore)
+	}
+	if params.UpdatedAfter != "" {
+		query.Set("updated_after", params.UpdatedAfter)
+	}
+	if params.UpdatedBefore != "" {
+		query.Set("updated_before", params.UpdatedBefore)
+	}
+	if params.Sort != "" {
+		query.Set("sort", params.Sort)
+	}
+	if params.Fields != "" {
+		query.Set("fields", params.Fields)
+	}
+	if params.Highlight != nil {
+		query.Set("highlight", fmt.Sprint(*params.Highlight))
+	}
+	if params.Fuzziness != "" {
+		query.Set("fuzziness", params.Fuzziness)
+	}
+	if params.SearchFields != "" {
+		query.Set("search_fields", params.SearchFields)
+	}
+	if params.Mode != "" {
+		query.Set("mode", params.Mode)
+	}
+	if params.TrackTotalHits != "" {
+		query.Set("track_total_hits", params.TrackTotalHits)
+	}
+	if params.MinScore != nil {
+		query.Set("min_score", fmt.Sprint(*params.MinScore))
+	}
+	if params.Syntax != nil {
+		query.Set("syntax", fmt.Sprint(*params.Syntax))
+	}
+	if params.Dedupe != nil {
+		query.Set("dedupe", fmt.Sprint(*params.Dedupe))
+	}
+	if params.Facets != "" {
+		query.Set("facets", params.Facets)
+	}
+	if params.MaxPerCompany != nil {
+		query.Set("max_per_company", fmt.Sprint(*params.MaxPerCompany))
+	}
+	if params.Index != "" {
+		query.Set("index", params.Index)
+	}
+	if params.IncludeDeleted != nil {
+		query.Set("include_deleted", fmt.Sprint(*params.IncludeDeleted))
+	}
+	if params.Envelope != nil {
+		query.Set("envelope", fmt.Sprint(*params.Envelope))
+	}
+	if params.OmitEmpty != nil {
+		query.Set("omit_empty", fmt.Sprint(*params.OmitEmpty))
+	}
+	var out PagedResponseArrayProduct
+	if err := c.do(ctx, "GET", "/product", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateProductParams holds the query parameters of CreateProduct
+type CreateProductParams struct {
+	// Omit zero-value fields such as score 0 and unset timestamps
+	OmitEmpty *bool
+}
+
+// CreateProduct indexes a new product; when the ID is omitted one is assigned by the configured ID strategy (Elasticsearch by default) (POST /product)
+func (c *Client) CreateProduct(ctx context.Context, body CreateProductRequest, params CreateProductParams) (*BaseResponseProduct, error) {
+	query := url.Values{}
+	if params.OmitEmpty != nil {
+		query.Set("omit_empty", fmt.Sprint(*params.OmitEmpty))
+	}
+	var out BaseResponseProduct
+	if err := c.do(ctx, "POST", "/product", query, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BulkCreateProductsParams holds the query parameters of BulkCreateProducts
+type BulkCreateProductsParams struct {
+	// Revert the written products when any item fails
+	AllOrNothing *bool
+}
+
+// BulkCreateProducts indexes an array of products with the bulk API and returns the outcome of every item; with all_or_nothing=true nothing is written when an item is invalid, and products written before another item failed are deleted again (best effort, reported in rollback) (POST /product/bulk)
+func (c *Client) BulkCreateProducts(ctx context.Context, body []CreateProductRequest, params BulkCreateProductsParams) (*BaseResponseBulkResult, error) {
+	query := url.Values{}
+	if params.AllOrNothing != nil {
+		query.Set("all_or_nothing", fmt.Sprint(*params.AllOrNothing))
+	}
+	var out BaseResponseBulkResult
+	if err := c.do(ctx, "POST", "/product/bulk", query, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CountProductsParams holds the query parameters of CountProducts
+type CountProductsParams struct {
+	// Search keyword
+	Keyword string
+	// Only products of these companies (exact match, repeatable)
+	Company []string
+	// Only products with these generic names (exact match, repeatable)
+	DrugGeneric []string
+	// Only products with these IDs (repeatable)
+	ID []string
+	// Only products created at or after this RFC3339 time
+	CreatedAfter string
+	// Only products created before this RFC3339 time
+	CreatedBefore string
+	// Only products updated at or after this RFC3339 time
+	UpdatedAfter string
+	// Only products updated before this RFC3339 time
+	UpdatedBefore string
+	// Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)
+	Fuzziness string
+	// Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)
+	SearchFields string
+	// Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)
+	Mode string
+	// Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)
+	MinScore *float64
+	// Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)
+	Syntax *bool
+	// Count in a non-default index or alias (admin only)
+	Index string
+	// Include soft-deleted products (admin only)
+	IncludeDeleted *bool
+}
+
+// CountProducts counts the products matching a keyword and filters with the Count API, without fetching them (GET /product/count)
+func (c *Client) CountProducts(ctx context.Context, params CountProductsParams) (*BaseResponseProductCount, error) {
+	query := url.Values{}
+	if params.Keyword != "" {
+		query.Set("keyword", params.Keyword)
+	}
+	for _, v := range params.Company {
+		query.Add("company", v)
+	}
+	for _, v := range params.DrugGeneric {
+		query.Add("drug_generic", v)
+	}
+	for _, v := range params.ID {
+		query.Add("id", v)
+	}
+	if params.CreatedAfter != "" {
+		query.Set("created_after", params.CreatedAfter)
+	}
+	if params.CreatedBefore != "" {
+		query.Set("created_before", params.CreatedBefore)
+	}
+	if params.UpdatedAfter != "" {
+		query.Set("updated_after", params.UpdatedAfter)
+	}
+	if params.UpdatedBefore != "" {
+		query.Set("updated_before", params.UpdatedBefore)
+	}
+	if params.Fuzziness != "" {
+		query.Set("fuzziness", params.Fuzziness)
+	}
+	if params.SearchFields != "" {
+		query.Set("search_fields", params.SearchFields)
+	}
+	if params.Mode != "" {
+		query.Set("mode", params.Mode)
+	}
+	if params.MinScore != nil {
+		query.Set("min_score", fmt.Sprint(*params.MinScore))
+	}
+	if params.Syntax != nil {
+		query.Set("syntax", fmt.Sprint(*params.Syntax))
+	}
+	if params.Index != "" {
+		query.Set("index", params.Index)
+	}
+	if params.IncludeDeleted != nil {
+		query.Set("include_deleted", fmt.Sprint(*params.IncludeDeleted))
+	}
+	var out BaseResponseProductCount
+	if err := c.do(ctx, "GET", "/product/count", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DistinctValuesParams holds the query parameters of DistinctValues
+type DistinctValuesParams struct {
+	// Field to list the values of (fields: company, drug_generic)
+	Field string
+	// Number of values listed, 1-1000 (default 100)
+	Size *int64
+	// Search keyword
+	Keyword string
+	// Only products of these companies (exact match, repeatable)
+	Company []string
+	// Only products with these generic names (exact match, repeatable)
+	DrugGeneric []string
+	// Only products with these IDs (repeatable)
+	ID []string
+	// Only products created at or after this RFC3339 time
+	CreatedAfter string
+	// Only products created before this RFC3339 time
+	CreatedBefore string
+	// Only products updated at or after this RFC3339 time
+	UpdatedAfter string
+	// Only products updated before this RFC3339 time
+	UpdatedBefore string
+	// Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)
+	Fuzziness string
+	// Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)
+	SearchFields string
+	// Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)
+	Mode string
+	// Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)
+	MinScore *float64
+	// Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)
+	Syntax *bool
+	// Search a non-default index or alias (admin only)
+	Index string
+	// Include soft-deleted products (admin only)
+	IncludeDeleted *bool
+}
+
+// DistinctValues counts the distinct companies or drug generics of the products matching a keyword and filters and lists the first of them alphabetically, for populating dropdowns; the field ignores its own filter and the count is approximate above 3000 values (GET /product/distinct)
+func (c *Client) DistinctValues(ctx context.Context, params DistinctValuesParams) (*BaseResponseDistinctValues, error) {
+	query := url.Values{}
+	if params.Field != "" {
+		query.Set("field", params.Field)
+	}
+	if params.Size != nil {
+		query.Set("size", fmt.Sprint(*params.Size))
+	}
+	if params.Keyword != "" {
+		query.Set("keyword", params.Keyword)
+	}
+	for _, v := range params.Company {
+		query.Add("company", v)
+	}
+	for _, v := range params.DrugGeneric {
+		query.Add("drug_generic", v)
+	}
+	for _, v := range params.ID {
+		query.Add("id", v)
+	}
+	if params.CreatedAfter != "" {
+		query.Set("created_after", params.CreatedAfter)
+	}
+	if params.CreatedBefore != "" {
+		query.Set("created_before", params.CreatedBefore)
+	}
+	if params.UpdatedAfter != "" {
+		query.Set("updated_after", params.UpdatedAfter)
+	}
+	if params.UpdatedBefore != "" {
+		query.Set("updated_before", params.UpdatedBefore)
+	}
+	if params.Fuzziness != "" {
+		query.Set("fuzziness", params.Fuzziness)
+	}
+	if params.SearchFields != "" {
+		query.Set("search_fields", params.SearchFields)
+	}
+	if params.Mode != "" {
+		query.Set("mode", params.Mode)
+	}
+	if params.MinScore != nil {
+		query.Set("min_score", fmt.Sprint(*params.MinScore))
+	}
+	if params.Syntax != nil {
+		query.Set("syntax", fmt.Sprint(*params.Syntax))
+	}
+	if params.Index != "" {
+		query.Set("index", params.Index)
+	}
+	if params.IncludeDeleted != nil {
+		query.Set("include_deleted", fmt.Sprint(*params.IncludeDeleted))
+	}
+	var out BaseResponseDistinctValues
+	if err := c.do(ctx, "GET", "/product/distinct", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ProductFacetsParams holds the query parameters of ProductFacets
+type ProductFacetsParams struct {
+	// Comma-separated fields to count values of (fields: company, drug_generic; default all)
+	Facets string
+	// Number of values counted per facet, 1-1000 (default SEARCH_FACET_SIZE)
+	Size *int64
+	// Search keyword
+	Keyword string
+	// Only products of these companies (exact match, repeatable)
+	Company []string
+	// Only products with these generic names (exact match, repeatable)
+	DrugGeneric []string
+	// Only products with these IDs (repeatable)
+	ID []string
+	// Only products created at or after this RFC3339 time
+	CreatedAfter string
+	// Only products created before this RFC3339 time
+	CreatedBefore string
+	// Only products updated at or after this RFC3339 time
+	UpdatedAfter string
+	// Only products updated before this RFC3339 time
+	UpdatedBefore string
+	// Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)
+	Fuzziness string
+	// Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)
+	SearchFields string
+	// Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)
+	Mode string
+	// Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)
+	MinScore *float64
+	// Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)
+	Syntax *bool
+	// Count in a non-default index or alias (admin only)
+	Index string
+	// Include soft-deleted products (admin only)
+	IncludeDeleted *bool
+}
+
+// ProductFacets counts the products per company and drug generic matching a keyword and filters, without fetching them, for building filter UIs; a facet ignores its own filter and total counts the products matching every filter (GET /product/facets)
+func (c *Client) ProductFacets(ctx context.Context, params ProductFacetsParams) (*BaseResponseProductFacets, error) {
+	query := url.Values{}
+	if params.Facets != "" {
+		query.Set("facets", params.Facets)
+	}
+	if params.Size != nil {
+		query.Set("size", fmt.Sprint(*params.Size))
+	}
+	if params.Keyword != "" {
+		query.Set("keyword", params.Keyword)
+	}
+	for _, v := range params.Company {
+		query.Add("company", v)
+	}
+	for _, v := range params.DrugGeneric {
+		query.Add("drug_generic", v)
+	}
+	for _, v := range params.ID {
+		query.Add("id", v)
+	}
+	if params.CreatedAfter != "" {
+		query.Set("created_after", params.CreatedAfter)
+	}
+	if params.CreatedBefore != "" {
+		query.Set("created_before", params.CreatedBefore)
+	}
+	if params.UpdatedAfter != "" {
+		query.Set("updated_after", params.UpdatedAfter)
+	}
+	if params.UpdatedBefore != "" {
+		query.Set("updated_before", params.UpdatedBefore)
+	}
+	if params.Fuzziness != "" {
+		query.Set("fuzziness", params.Fuzziness)
+	}
+	if params.SearchFields != "" {
+		query.Set("search_fields", params.SearchFields)
+	}
+	if params.Mode != "" {
+		query.Set("mode", params.Mode)
+	}
+	if params.MinScore != nil {
+		query.Set("min_score", fmt.Sprint(*params.MinScore))
+	}
+	if params.Syntax != nil {
+		query.Set("syntax", fmt.Sprint(*params.Syntax))
+	}
+	if params.Index != "" {
+		query.Set("index", params.Index)
+	}
+	if params.IncludeDeleted != nil {
+		query.Set("include_deleted", fmt.Sprint(*params.IncludeDeleted))
+	}
+	var out BaseResponseProductFacets
+	if err := c.do(ctx, "GET", "/product/facets", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ProductsGroupedByCompanyParams holds the query parameters of ProductsGroupedByCompany
+type ProductsGroupedByCompanyParams struct {
+	// Number of companies, 1-100 (default 10)
+	Size *int64
+	// Number of products per company, 1-20 (default 3)
+	PerCompany *int64
+	// Search keyword
+	Keyword string
+	// Only products of these companies (exact match, repeatable)
+	Company []string
+	// Only products with these generic names (exact match, repeatable)
+	DrugGeneric []string
+	// Only products with these IDs (repeatable)
+	ID []string
+	// Only products created at or after this RFC3339 time
+	CreatedAfter string
+	// Only products created before this RFC3339 time
+	CreatedBefore string
+	// Only products updated at or after this RFC3339 time
+	UpdatedAfter string
+	// Only products updated before this RFC3339 time
+	UpdatedBefore string
+	// Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)
+	Fuzziness string
+	// Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)
+	SearchFields string
+	// Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)
+	Mode string
+	// Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)
+	MinScore *float64
+	// Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)
+	Syntax *bool
+	// Comma-separated sort of the products within a company, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at)
+	Sort string
+	// Search a non-default index or alias (admin only)
+	Index string
+	// Include soft-deleted products (admin only)
+	IncludeDeleted *bool
+}
+
+// ProductsGroupedByCompany returns the best products of each of the companies with the most products matching a keyword and filters, most matches first, for comparison views; products within a company are ordered like search results (GET /product/grouped)
+func (c *Client) ProductsGroupedByCompany(ctx context.Context, params ProductsGroupedByCompanyParams) (*BaseResponseProductGroups, error) {
+	query := url.Values{}
+	if params.Size != nil {
+		query.Set("size", fmt.Sprint(*params.Size))
+	}
+	if params.PerCompany != nil {
+		query.Set("per_company", fmt.Sprint(*params.PerCompany))
+	}
+	if params.Keyword != "" {
+		query.Set("keyword", params.Keyword)
+	}
+	for _, v := range params.Company {
+		query.Add("company", v)
+	}
+	for _, v := range params.DrugGeneric {
+		query.Add("drug_generic", v)
+	}
+	for _, v := range params.ID {
+		query.Add("id", v)
+	}
+	if params.CreatedAfter != "" {
+		query.Set("created_after", params.CreatedAfter)
+	}
+	if params.CreatedBefore != "" {
+		query.Set("created_before", params.CreatedBefore)
+	}
+	if params.UpdatedAfter != "" {
+		query.Set("updated_after", params.UpdatedAfter)
+	}
+	if params.UpdatedBefore != "" {
+		query.Set("updated_before", params.UpdatedBefore)
+	}
+	if params.Fuzziness != "" {
+		query.Set("fuzziness", params.Fuzziness)
+	}
+	if params.SearchFields != "" {
+		query.Set("search_fields", params.SearchFields)
+	}
+	if params.Mode != "" {
+		query.Set("mode", params.Mode)
+	}
+	if params.MinScore != nil {
+		query.Set("min_score", fmt.Sprint(*params.MinScore))
+	}
+	if params.Syntax != nil {
+		query.Set("syntax", fmt.Sprint(*params.Syntax))
+	}
+	if params.Sort != "" {
+		query.Set("sort", params.Sort)
+	}
+	if params.Index != "" {
+		query.Set("index", params.Index)
+	}
+	if params.IncludeDeleted != nil {
+		query.Set("include_deleted", fmt.Sprint(*params.IncludeDeleted))
+	}
+	var out BaseResponseProductGroups
+	if err := c.do(ctx, "GET", "/product/grouped", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BatchGetProductsParams holds the query parameters of BatchGetProducts
+type BatchGetProductsParams struct {
+	// Omit zero-value fields such as score 0 and unset timestamps
+	OmitEmpty *bool
+}
+
+// BatchGetProducts retrieves up to 100 products by document ID in request order; unknown IDs are listed as missing (POST /product/mget)
+func (c *Client) BatchGetProducts(ctx context.Context, body BatchGetRequest, params BatchGetProductsParams) (*BaseResponseBatchGetResult, error) {
+	query := url.Values{}
+	if params.OmitEmpty != nil {
+		query.Set("omit_empty", fmt.Sprint(*params.OmitEmpty))
+	}
+	var out BaseResponseBatchGetResult
+	if err := c.do(ctx, "POST", "/product/mget", query, body, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ClosePITParams holds the query parameters of ClosePIT
+type ClosePITParams struct {
+	// pit token of the session
+	Pit string
+}
+
+// ClosePIT releases the point in time of a pit session before it expires (DELETE /product/pit)
+func (c *Client) ClosePIT(ctx context.Context, params ClosePITParams) (*BaseResponseString, error) {
+	query := url.Values{}
+	if params.Pit != "" {
+		query.Set("pit", params.Pit)
+	}
+	var out BaseResponseString
+	if err := c.do(ctx, "DELETE", "/product/pit", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RelatedGenericsParams holds the query parameters of RelatedGenerics
+type RelatedGenericsParams struct {
+	// Number of generics, 1-100 (default 10)
+	Size *int64
+	// Search keyword
+	Keyword string
+	// Only products of these companies (exact match, repeatable)
+	Company []string
+	// Only products with these generic names (exact match, repeatable)
+	DrugGeneric []string
+	// Only products with these IDs (repeatable)
+	ID []string
+	// Only products created at or after this RFC3339 time
+	CreatedAfter string
+	// Only products created before this RFC3339 time
+	CreatedBefore string
+	// Only products updated at or after this RFC3339 time
+	UpdatedAfter string
+	// Only products updated before this RFC3339 time
+	UpdatedBefore string
+	// Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)
+	Fuzziness string
+	// Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)
+	SearchFields string
+	// Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)
+	Mode string
+	// Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)
+	MinScore *float64
+	// Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)
+	Syntax *bool
+	// Search a non-default index or alias (admin only)
+	Index string
+	// Include soft-deleted products (admin only)
+	IncludeDeleted *bool
+}
+
+// RelatedGenerics returns the drug generics that are statistically overrepresented in the products matching a keyword or company compared to the whole catalog, most significant first, for exploring the catalog; a keyword or company is required (GET /product/related-generics)
+func (c *Client) RelatedGenerics(ctx context.Context, params RelatedGenericsParams) (*BaseResponseSignificantTerms, error) {
+	query := url.Values{}
+	if params.Size != nil {
+		query.Set("size", fmt.Sprint(*params.Size))
+	}
+	if params.Keyword != "" {
+		query.Set("keyword", params.Keyword)
+	}
+	for _, v := range params.Company {
+		query.Add("company", v)
+	}
+	for _, v := range params.DrugGeneric {
+		query.Add("drug_generic", v)
+	}
+	for _, v := range params.ID {
+		query.Add("id", v)
+	}
+	if params.CreatedAfter != "" {
+		query.Set("created_after", params.CreatedAfter)
+	}
+	if params.CreatedBefore != "" {
+		query.Set("created_before", params.CreatedBefore)
+	}
+	if params.UpdatedAfter != "" {
+		query.Set("updated_after", params.UpdatedAfter)
+	}
+	if params.UpdatedBefore != "" {
+		query.Set("updated_before", params.UpdatedBefore)
+	}
+	if params.Fuzziness != "" {
+		query.Set("fuzziness", params.Fuzziness)
+	}
+	if params.SearchFields != "" {
+		query.Set("search_fields", params.SearchFields)
+	}
+	if params.Mode != "" {
+		query.Set("mode", params.Mode)
+	}
+	if params.MinScore != nil {
+		query.Set("min_score", fmt.Sprint(*params.MinScore))
+	}
+	if params.Syntax != nil {
+		query.Set("syntax", fmt.Sprint(*params.Syntax))
+	}
+	if params.Index != "" {
+		query.Set("index", params.Index)
+	}
+	if params.IncludeDeleted != nil {
+		query.Set("include_deleted", fmt.Sprint(*params.IncludeDeleted))
+	}
+	var out BaseResponseSignificantTerms
+	if err := c.do(ctx, "GET", "/product/related-generics", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SampleProductsParams holds the query parameters of SampleProducts
+type SampleProductsParams struct {
+	// Number of products, 1-100 (default 10)
+	Size *int64
+	// Seed making the sample reproducible
+	Seed *int64
+	// Search keyword
+	Keyword string
+	// Only products of these companies (exact match, repeatable)
+	Company []string
+	// Only products with these generic names (exact match, repeatable)
+	DrugGeneric []string
+	// Only products with these IDs (repeatable)
+	ID []string
+	// Only products created at or after this RFC3339 time
+	CreatedAfter string
+	// Only products created before this RFC3339 time
+	CreatedBefore string
+	// Only products updated at or after this RFC3339 time
+	UpdatedAfter string
+	// Only products updated before this RFC3339 time
+	UpdatedBefore string
+	// Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)
+	Fuzziness string
+	// Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)
+	SearchFields string
+	// Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)
+	Mode string
+	// Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)
+	Syntax *bool
+	// Sample a non-default index or alias (admin only)
+	Index string
+	// Include soft-deleted products (admin only)
+	IncludeDeleted *bool
+	// Omit zero-value fields such as score 0 and unset timestamps
+	OmitEmpty *bool
+}
+
+// SampleProducts returns random products matching an optional keyword and filters, for QA spot checks and demo pages; pass a seed to get the same sample again while the index is unchanged (GET /product/sample)
+func (c *Client) SampleProducts(ctx context.Context, params SampleProductsParams) (*BaseResponseArrayProduct, error) {
+	query := url.Values{}
+	if params.Size != nil {
+		query.Set("size", fmt.Sprint(*params.Size))
+	}
+	if params.Seed != nil {
+		query.Set("seed", fmt.Sprint(*params.Seed))
+	}
+	if params.Keyword != "" {
+		query.Set("keyword", params.Keyword)
+	}
+	for _, v := range params.Company {
+		query.Add("company", v)
+	}
+	for _, v := range params.DrugGeneric {
+		query.Add("drug_generic", v)
+	}
+	for _, v := range params.ID {
+		query.Add("id", v)
+	}
+	if params.CreatedAfter != "" {
+		query.Set("created_after", params.CreatedAfter)
+	}
+	if params.CreatedBefore != "" {
+		query.Set("created_before", params.CreatedBefore)
+	}
+	if params.UpdatedAfter != "" {
+		query.Set("updated_after", params.UpdatedAfter)
+	}
+	if params.UpdatedBefore != "" {
+		query.Set("updated_before", params.UpdatedBefore)
+	}
+	if params.Fuzziness != "" {
+		query.Set("fuzziness", params.Fuzziness)
+	}
+	if params.SearchFields != "" {
+		query.Set("search_fields", params.SearchFields)
+	}
+	if params.Mode != "" {
+		query.Set("mode", params.Mode)
+	}
+	if params.Syntax != nil {
+		query.Set("syntax", fmt.Sprint(*params.Syntax))
+	}
+	if params.Index != "" {
+		query.Set("index", params.Index)
+	}
+	if params.IncludeDeleted != nil {
+		query.Set("include_deleted", fmt.Sprint(*params.IncludeDeleted))
+	}
+	if params.OmitEmpty != nil {
+		query.Set("omit_empty", fmt.Sprint(*params.OmitEmpty))
+	}
+	var out BaseResponseArrayProduct
+	if err := c.do(ctx, "GET", "/product/sample", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SuggestProductsParams holds the query parameters of SuggestProducts
+type SuggestProductsParams struct {
+	// Typed prefix of a product name
+	Q string
+	// Number of suggestions, 1-20 (default 5)
+	Size *int64
+	// Only suggest names of this company's products (exact match)
+	Company string
+}
+
+// SuggestProducts completes a typed prefix to distinct product names with the completion suggester, for autocomplete as the user types; soft-deleted and excluded products aren't suggested. With analytics enabled, prefixes shorter than 3 characters or completing no product name are completed with popular searches first (source popular_suggestions) unless a company is given (GET /product/suggest)
+func (c *Client) SuggestProducts(ctx context.Context, params SuggestProductsParams) (*BaseResponseArraySuggestion, error) {
+	query := url.Values{}
+	if params.Q != "" {
+		query.Set("q", params.Q)
+	}
+	if params.Size != nil {
+		query.Set("size", fmt.Sprint(*params.Size))
+	}
+	if params.Company != "" {
+		query.Set("company", params.Company)
+	}
+	var out BaseResponseArraySuggestion
+	if err := c.do(ctx, "GET", "/product/suggest", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TypeaheadProductsParams holds the query parameters of TypeaheadProducts
+type TypeaheadProductsParams struct {
+	// Partially typed product name
+	Q string
+	// Number of products, 1-20 (default 5)
+	Size *int64
+	// Omit zero-value fields such as score 0 and unset timestamps
+	OmitEmpty *bool
+}
+
+// TypeaheadProducts returns the best products whose name matches what the user has typed so far: every complete word must match and the last one may be a prefix, matched on a search_as_you_type field instead of wildcards; soft-deleted and excluded products aren't returned (GET /product/typeahead)
+func (c *Client) TypeaheadProducts(ctx context.Context, params TypeaheadProductsParams) (*BaseResponseArrayProduct, error) {
+	query := url.Values{}
+	if params.Q != "" {
+		query.Set("q", params.Q)
+	}
+	if params.Size != nil {
+		query.Set("size", fmt.Sprint(*params.Size))
+	}
+	if params.OmitEmpty != nil {
+		query.Set("omit_empty", fmt.Sprint(*params.OmitEmpty))
+	}
+	var out BaseResponseArrayProduct
+	if err := c.do(ctx, "GET", "/product/typeahead", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GroupedTypeaheadParams holds the query parameters of GroupedTypeahead
+type GroupedTypeaheadParams struct {
+	// Partially typed keyword
+	Q string
+	// Number of entries per section, 1-20 (default 5)
+	Size *int64
+}
+
+// GroupedTypeahead returns what the user has typed so far grouped into sections: the best products whose name matches, and the generics and companies matching it with the most products. Every complete word must match and the last one may be a prefix; soft-deleted and excluded products aren't counted (GET /product/typeahead/grouped)
+func (c *Client) GroupedTypeahead(ctx context.Context, params GroupedTypeaheadParams) (*BaseResponseTypeaheadGroups, error) {
+	query := url.Values{}
+	if params.Q != "" {
+		query.Set("q", params.Q)
+	}
+	if params.Size != nil {
+		query.Set("size", fmt.Sprint(*params.Size))
+	}
+	var out BaseResponseTypeaheadGroups
+	if err := c.do(ctx, "GET", "/product/typeahead/grouped", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProductByIDParams holds the query parameters of GetProductByID
+type GetProductByIDParams struct {
+	// Omit zero-value fields such as score 0 and unset timestamps
+	OmitEmpty *bool
+}
+
+// GetProductByID retrieves a single product by its document ID (GET /product/{id})
+func (c *Client) GetProductByID(ctx context.Context, id string, params GetProductByIDParams) (*BaseResponseProduct, error) {
+	query := url.Values{}
+	if params.OmitEmpty != nil {
+		query.Set("omit_empty", fmt.Sprint(*params.OmitEmpty))
+	}
+	var out BaseResponseProduct
+	if err := c.do(ctx, "GET", "/product/"+url.PathEscape(id), query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReplaceProductParams holds the query parameters of ReplaceProduct
+type ReplaceProductParams struct {
+	// Create the product when it doesn't exist
+	Upsert *bool
+	// Omit zero-value fields such as score 0 and unset timestamps
+	OmitEmpty *bool
+}
+
+// ReplaceProduct sets all catalog fields of a product; with upsert=true the product is created when missing (PUT /product/{id})
+func (c *Client) ReplaceProduct(ctx context.Context, id string, body CreateProductRequest, params ReplaceProductParams) (*BaseResponseProduct, error) {
+	query := url.Values{}
+	if params.Upsert != nil {
+		query.Set("upsert", fmt.Sprint(*params.Upsert))
+	}
+	if params.OmitEmpty != nil {
+		query.Set("omit_empty", fmt.Sprint(*params.OmitEmpty))
+	}
+	var out BaseResponseProduct
+	if err := c.do(ctx, "PUT", "/product/"+url.PathEscape(id), query, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateProductParams holds the query parameters of UpdateProduct
+type UpdateProductParams struct {
+	// Omit zero-value fields such as score 0 and unset timestamps
+	OmitEmpty *bool
+}
+
+// UpdateProduct updates only the provided fields of a product using doc merge semantics (PATCH /product/{id})
+func (c *Client) UpdateProduct(ctx context.Context, id string, body UpdateProductRequest, params UpdateProductParams) (*BaseResponseProduct, error) {
+	query := url.Values{}
+	if params.OmitEmpty != nil {
+		query.Set("omit_empty", fmt.Sprint(*params.OmitEmpty))
+	}
+	var out BaseResponseProduct
+	if err := c.do(ctx, "PATCH", "/product/"+url.PathEscape(id), query, body, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteProduct removes a product document by ID (DELETE /product/{id})
+func (c *Client) DeleteProduct(ctx context.Context, id string) (*BaseResponseString, error) {
+	var out BaseResponseString
+	if err := c.do(ctx, "DELETE", "/product/"+url.PathEscape(id), nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SoftDeleteProductParams holds the query parameters of SoftDeleteProduct
+type SoftDeleteProductParams struct {
+	// Omit zero-value fields such as score 0 and unset timestamps
+	OmitEmpty *bool
+}
+
+// SoftDeleteProduct sets deleted_at on a product so it no longer appears in searches (POST /product/{id}/soft-delete)
+func (c *Client) SoftDeleteProduct(ctx context.Context, id string, params SoftDeleteProductParams) (*BaseResponseProduct, error) {
+	query := url.Values{}
+	if params.OmitEmpty != nil {
+		query.Set("omit_empty", fmt.Sprint(*params.OmitEmpty))
+	}
+	var out BaseResponseProduct
+	if err := c.do(ctx, "POST", "/product/"+url.PathEscape(id)+"/soft-delete", query, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCatalogStats returns total products, distinct companies and generics, update range and recent additions (GET /stats/catalog)
+func (c *Client) GetCatalogStats(ctx context.Context) (*BaseResponseCatalogStats, error) {
+	var out BaseResponseCatalogStats
+	if err := c.do(ctx, "GET", "/stats/catalog", nil, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCreationHistogramParams holds the query parameters of GetCreationHistogram
+type GetCreationHistogramParams struct {
+	// Calendar interval: day, week, month, quarter or year (default month)
+	Interval string
+	// Only products created at or after this RFC3339 time
+	CreatedAfter string
+	// Only products created before this RFC3339 time
+	CreatedBefore string
+}
+
+// GetCreationHistogram returns the number of products created per calendar interval, oldest interval first; intervals without products are included with a zero count (GET /stats/created)
+func (c *Client) GetCreationHistogram(ctx context.Context, params GetCreationHistogramParams) (*BaseResponseCreationHistogram, error) {
+	query := url.Values{}
+	if params.Interval != "" {
+		query.Set("interval", params.Interval)
+	}
+	if params.CreatedAfter != "" {
+		query.Set("created_after", params.CreatedAfter)
+	}
+	if params.CreatedBefore != "" {
+		query.Set("created_before", params.CreatedBefore)
+	}
+	var out BaseResponseCreationHistogram
+	if err := c.do(ctx, "GET", "/stats/created", query, nil, false, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
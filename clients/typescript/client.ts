@@ -0,0 +1,1484 @@
+// Code generated by server --gen-client from docs/swagger.json. DO NOT EDIT.
+// Typed client for the Elastic Search Skill-Test API (version 1.0)
+
+const BASE_PATH = "";
+
+export interface BaseResponseArrayProduct {
+  data?: Product[];
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseArraySuggestion {
+  data?: Suggestion[];
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseBatchGetResult {
+  data?: BatchGetResult;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseBulkResult {
+  data?: BulkResult;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseCatalogStats {
+  data?: CatalogStats;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseCreationHistogram {
+  data?: CreationHistogram;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseCuration {
+  data?: Curation;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseDistinctValues {
+  data?: DistinctValues;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseExclusionRule {
+  data?: ExclusionRule;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseImportDiff {
+  data?: ImportDiff;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseImportJob {
+  data?: ImportJob;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseImportProgress {
+  data?: ImportProgress;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseIndexStats {
+  data?: IndexStats;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseProduct {
+  data?: Product;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseProductCount {
+  data?: ProductCount;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseProductFacets {
+  data?: ProductFacets;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseProductGroups {
+  data?: ProductGroups;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseSearchSnapshot {
+  data?: SearchSnapshot;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseSignificantTerms {
+  data?: SignificantTerms;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseSynonymRule {
+  data?: SynonymRule;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseTypeaheadGroups {
+  data?: TypeaheadGroups;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseStatus {
+  data?: Status;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface BaseResponseString {
+  data?: string;
+  error?: string;
+  is_success?: boolean;
+  message?: string;
+}
+
+export interface CommonFacetBucket {
+  count?: number;
+  value?: string;
+}
+
+export interface PagedResponseArrayCuration {
+  data?: Curation[];
+  error?: string;
+  /** Facets holds the value counts of the requested facets, keyed by field */
+  facets?: Record<string, CommonFacetBucket[]>;
+  is_success?: boolean;
+  message?: string;
+  /** NextCursor fetches the next page of a cursor-paginated list; empty on the last page */
+  next_cursor?: string;
+  pagination?: PaginationInfo;
+  /** PIT is the token pinning the following pages of a point-in-time session */
+  pit?: string;
+  /** Search reports how Elasticsearch executed a search; only set on search responses */
+  search?: SearchMeta;
+  /** Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean") */
+  suggestions?: string[];
+  warnings?: string[];
+}
+
+export interface PagedResponseArrayExclusionRule {
+  data?: ExclusionRule[];
+  error?: string;
+  /** Facets holds the value counts of the requested facets, keyed by field */
+  facets?: Record<string, CommonFacetBucket[]>;
+  is_success?: boolean;
+  message?: string;
+  /** NextCursor fetches the next page of a cursor-paginated list; empty on the last page */
+  next_cursor?: string;
+  pagination?: PaginationInfo;
+  /** PIT is the token pinning the following pages of a point-in-time session */
+  pit?: string;
+  /** Search reports how Elasticsearch executed a search; only set on search responses */
+  search?: SearchMeta;
+  /** Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean") */
+  suggestions?: string[];
+  warnings?: string[];
+}
+
+export interface PagedResponseArrayImportRun {
+  data?: ImportRun[];
+  error?: string;
+  /** Facets holds the value counts of the requested facets, keyed by field */
+  facets?: Record<string, CommonFacetBucket[]>;
+  is_success?: boolean;
+  message?: string;
+  /** NextCursor fetches the next page of a cursor-paginated list; empty on the last page */
+  next_cursor?: string;
+  pagination?: PaginationInfo;
+  /** PIT is the token pinning the following pages of a point-in-time session */
+  pit?: string;
+  /** Search reports how Elasticsearch executed a search; only set on search responses */
+  search?: SearchMeta;
+  /** Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean") */
+  suggestions?: string[];
+  warnings?: string[];
+}
+
+export interface PagedResponseArrayProduct {
+  data?: Product[];
+  error?: string;
+  /** Facets holds the value counts of the requested facets, keyed by field */
+  facets?: Record<string, CommonFacetBucket[]>;
+  is_success?: boolean;
+  message?: string;
+  /** NextCursor fetches the next page of a cursor-paginated list; empty on the last page */
+  next_cursor?: string;
+  pagination?: PaginationInfo;
+  /** PIT is the token pinning the following pages of a point-in-time session */
+  pit?: string;
+  /** Search reports how Elasticsearch executed a search; only set on search responses */
+  search?: SearchMeta;
+  /** Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean") */
+  suggestions?: string[];
+  warnings?: string[];
+}
+
+export interface PagedResponseArraySearchDailySummary {
+  data?: SearchDailySummary[];
+  error?: string;
+  /** Facets holds the value counts of the requested facets, keyed by field */
+  facets?: Record<string, CommonFacetBucket[]>;
+  is_success?: boolean;
+  message?: string;
+  /** NextCursor fetches the next page of a cursor-paginated list; empty on the last page */
+  next_cursor?: string;
+  pagination?: PaginationInfo;
+  /** PIT is the token pinning the following pages of a point-in-time session */
+  pit?: string;
+  /** Search reports how Elasticsearch executed a search; only set on search responses */
+  search?: SearchMeta;
+  /** Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean") */
+  suggestions?: string[];
+  warnings?: string[];
+}
+
+export interface PagedResponseArraySynonymRule {
+  data?: SynonymRule[];
+  error?: string;
+  /** Facets holds the value counts of the requested facets, keyed by field */
+  facets?: Record<string, CommonFacetBucket[]>;
+  is_success?: boolean;
+  message?: string;
+  /** NextCursor fetches the next page of a cursor-paginated list; empty on the last page */
+  next_cursor?: string;
+  pagination?: PaginationInfo;
+  /** PIT is the token pinning the following pages of a point-in-time session */
+  pit?: string;
+  /** Search reports how Elasticsearch executed a search; only set on search responses */
+  search?: SearchMeta;
+  /** Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean") */
+  suggestions?: string[];
+  warnings?: string[];
+}
+
+export interface PaginationInfo {
+  current_page?: number;
+  limit?: number;
+  offset?: number;
+  total?: number;
+  total_pages?: number;
+  /** TotalRelation is "gte" when Total is a lower bound because hit counting was capped */
+  total_relation?: string;
+}
+
+export interface SearchMeta {
+  /** MaxScore is the highest relevance score of any hit; null when hits aren't scored or nothing matched */
+  max_score?: number;
+  timed_out?: boolean;
+  /** TookMs is the time Elasticsearch spent executing the search */
+  took_ms?: number;
+}
+
+export interface Fragment {
+  matches?: Span[];
+  text?: string;
+}
+
+export interface Span {
+  end?: number;
+  start?: number;
+}
+
+/** Product IDs to fetch in one request */
+export interface BatchGetRequest {
+  ids?: string[];
+}
+
+/** Products found for a batch get, in request order, and the IDs that don't exist */
+export interface BatchGetResult {
+  missing?: string[];
+  products?: Product[];
+}
+
+/** Outcome of a single item in a bulk write */
+export interface BulkItemResult {
+  error?: string;
+  id?: string;
+  position?: number;
+  /**
+   * RolledBack is set on items written and then removed again because another item of an all-or-nothing
+   * write failed
+   */
+  rolled_back?: boolean;
+  status?: number;
+}
+
+/** Per-item outcome of a bulk write */
+export interface BulkResult {
+  failed?: number;
+  items?: BulkItemResult[];
+  /** Rollback reports the reverted writes of an all-or-nothing write with failed items */
+  rollback?: BulkRollback;
+  succeeded?: number;
+}
+
+/** Reconciliation report of the writes reverted after an all-or-nothing bulk write failed */
+export interface BulkRollback {
+  /** Failed is the number of written products that couldn't be removed and are still stored */
+  failed?: number;
+  /** Items holds the outcome of reverting every written product, positions referring to the request array */
+  items?: BulkItemResult[];
+  /** Reverted is the number of written products removed again */
+  reverted?: number;
+}
+
+/** Aggregated overview of the product catalog */
+export interface CatalogStats {
+  added_last_30_days?: number;
+  distinct_companies?: number;
+  distinct_generics?: number;
+  newest_updated_at?: string;
+  oldest_updated_at?: string;
+  total_products?: number;
+}
+
+/** Payload for creating a product; when the ID is omitted one is assigned by the configured ID strategy */
+export interface CreateProductRequest {
+  company?: string;
+  drug_generic?: string;
+  id?: string;
+  product_name?: string;
+}
+
+/** Number of products created per calendar interval, oldest interval first */
+export interface CreationHistogram {
+  buckets?: DateBucket[];
+  interval?: string;
+}
+
+/** Products pinned to the top of the results for a keyword */
+export interface Curation {
+  keyword?: string;
+  pinned_ids?: string[];
+  updated_at?: string;
+}
+
+/** Products to pin for a keyword, in display order */
+export interface CurationRequest {
+  pinned_ids?: string[];
+}
+
+/** Number of products created in the interval starting at Date */
+export interface DateBucket {
+  count?: number;
+  date?: string;
+}
+
+/** Distinct values of a field over the products matching a search */
+export interface DistinctValues {
+  /** Count is the approximate number of distinct values; Values lists the first of them alphabetically */
+  count?: number;
+  field?: string;
+  values?: string[];
+}
+
+/** Products or companies hidden from the results of a keyword, or of every search when the keyword is empty */
+export interface ExclusionRule {
+  companies?: string[];
+  id?: string;
+  keyword?: string;
+  product_ids?: string[];
+  reason?: string;
+  updated_at?: string;
+}
+
+/** Exclusion rule to create or replace; leave keyword empty for a global rule */
+export interface ExclusionRuleRequest {
+  companies?: string[];
+  keyword?: string;
+  product_ids?: string[];
+  reason?: string;
+}
+
+/** Number of matching products with one value of a faceted field */
+export interface ModelsFacetBucket {
+  count?: number;
+  value?: string;
+}
+
+/** Outcome of a verification or data-quality check run on a staged import */
+export interface ImportCheck {
+  /** Advisory checks are reported but don't fail the import */
+  advisory?: boolean;
+  detail?: string;
+  name?: string;
+  passed?: boolean;
+}
+
+/** Differences between the catalogs loaded by two import runs */
+export interface ImportDiff {
+  added?: number;
+  changed?: number;
+  from?: string;
+  removed?: number;
+  sample_added?: string[];
+  sample_changed?: string[];
+  sample_removed?: string[];
+  to?: string;
+  unchanged?: number;
+}
+
+/** Rows of an import sharing a product ID, or a product name and company under different IDs */
+export interface ImportDuplicate {
+  /** Kind is "id" for rows with the same ID or "name_company" for near duplicates */
+  kind?: string;
+  rows?: ImportRowRef[];
+  /** Value is the shared ID, or the product name and company of the first repeated row */
+  value?: string;
+}
+
+/** Import started in the background */
+export interface ImportJob {
+  created_at?: string;
+  errors?: string[];
+  failed?: number;
+  finished_at?: string;
+  /** ID identifies the import in its progress and, once it finished, in the import history */
+  id?: string;
+  indexed?: number;
+  /** The counts of a running job are those of its last progress report */
+  rows_read?: number;
+  rows_skipped?: number;
+  /** Source is the imported URL or file; uploads are imported from a copy saved by the server */
+  source?: string;
+  /** Status is queued, running, succeeded, failed or cancelled */
+  status?: string;
+  triggered_by?: string;
+}
+
+/** Progress of the import running in the background, or outcome of the last one */
+export interface ImportProgress {
+  eta_seconds?: number;
+  failed?: number;
+  /** ID is the job ID of an import started over HTTP, also the ID of its run in the import history */
+  id?: string;
+  index?: string;
+  indexed?: number;
+  /**
+   * PercentDone and ETASeconds are estimated from the share of the source read so far; they are left out
+   * for sources of unknown size
+   */
+  percent_done?: number;
+  rows_per_sec?: number;
+  rows_read?: number;
+  rows_skipped?: number;
+  source?: string;
+  started_at?: string;
+  status?: string;
+}
+
+/** Row of an import source; rows are numbered like spreadsheet rows, the header being row 1 */
+export interface ImportRowRef {
+  row?: number;
+  source?: string;
+}
+
+/** Summary of a single import run */
+export interface ImportRun {
+  checks?: ImportCheck[];
+  /**
+   * DuplicateIDs and NearDuplicates count the IDs, and the product names and companies under different IDs,
+   * found on several rows; Duplicates lists the first of them
+   */
+  duplicate_ids?: number;
+  duplicates?: ImportDuplicate[];
+  duration_ms?: number;
+  errors?: string[];
+  failed?: number;
+  finished_at?: string;
+  id?: string;
+  index?: string;
+  indexed?: number;
+  near_duplicates?: number;
+  promoted?: boolean;
+  rows_read?: number;
+  rows_skipped?: number;
+  /** Sheets breaks imports of every worksheet of a workbook down per worksheet */
+  sheets?: ImportSheet[];
+  source?: string;
+  /** Staged is set for imports loaded into a staging index instead of the live alias */
+  staged?: boolean;
+  started_at?: string;
+  status?: string;
+  triggered_by?: string;
+}
+
+/** Outcome of importing one worksheet of a workbook */
+export interface ImportSheet {
+  /** Error is set for worksheets left out of the import, such as those without the required columns */
+  error?: string;
+  failed?: number;
+  indexed?: number;
+  /** Name identifies the worksheet as workbook#sheet */
+  name?: string;
+  rows_read?: number;
+  rows_skipped?: number;
+}
+
+/** Operational overview of the product index */
+export interface IndexStats {
+  /** Companies and DrugGenerics hold the document counts of the most frequent values */
+  companies?: ModelsFacetBucket[];
+  drug_generics?: ModelsFacetBucket[];
+  index?: string;
+  newest_created_at?: string;
+  oldest_created_at?: string;
+  /** PrimarySizeBytes is the disk size of the primary shards, SizeBytes includes the replicas */
+  primary_size_bytes?: number;
+  size_bytes?: number;
+  total_documents?: number;
+}
+
+/** Represents a product object */
+export interface Product {
+  /** Attributes holds the source columns an import keeps besides the product fields, keyed by attribute name */
+  attributes?: unknown;
+  company?: string;
+  created_at?: string;
+  deleted_at?: string;
+  drug_generic?: string;
+  /** HighlightOffsets holds the plain-text fragments and match offsets per field with the offsets format */
+  highlight_offsets?: Record<string, Fragment[]>;
+  /** Highlights holds the highlighted fragments per field when requested with the html format */
+  highlights?: Record<string, string[]>;
+  id?: string;
+  product_name?: string;
+  score?: number;
+  updated_at?: string;
+}
+
+/** Number of products matching a search */
+export interface ProductCount {
+  count?: number;
+}
+
+/** Value counts of the faceted fields over the products matching a search */
+export interface ProductFacets {
+  facets?: Record<string, ModelsFacetBucket[]>;
+  /** Total is the number of matching products, the selections on faceted fields included */
+  total?: number;
+}
+
+/** Best matching products of one company */
+export interface ProductGroup {
+  company?: string;
+  /** Count is the number of matching products of the company, of which Products are the best */
+  count?: number;
+  products?: Product[];
+}
+
+/** Best matching products of the companies with the most matches, most matches first */
+export interface ProductGroups {
+  groups?: ProductGroup[];
+  /** Total is the number of matching products of every company */
+  total?: number;
+}
+
+/** Number of searches for a keyword */
+export interface QueryCount {
+  count?: number;
+  query?: string;
+}
+
+/** Search activity rolled up for a single UTC day */
+export interface SearchDailySummary {
+  avg_latency_ms?: number;
+  /** Date is the UTC day in YYYY-MM-DD form */
+  date?: string;
+  rolled_up_at?: string;
+  searches?: number;
+  top_queries?: QueryCount[];
+  zero_result_rate?: number;
+  zero_result_searches?: number;
+}
+
+/** Search captured with its effective Elasticsearch query and results, for reproducing reported results later */
+export interface SearchSnapshot {
+  created_at?: string;
+  id?: string;
+  note?: string;
+  products?: Product[];
+  /** Query is the search request body sent to Elasticsearch */
+  query?: unknown;
+  /** Request is the query string of the captured search, as sent to GET /product */
+  request?: string;
+  took_ms?: number;
+  total_count?: number;
+  warnings?: string[];
+}
+
+/** Value of a field that is overrepresented in the products matching a search */
+export interface SignificantTerm {
+  background_count?: number;
+  /** Count is the number of matching products with the value, BackgroundCount the number in the whole catalog */
+  count?: number;
+  score?: number;
+  value?: string;
+}
+
+/** Values of a field that are overrepresented in the products matching a search, most significant first */
+export interface SignificantTerms {
+  /** Count is the number of matching products the values were compared against the whole catalog with */
+  count?: number;
+  field?: string;
+  terms?: SignificantTerm[];
+}
+
+/** Optional context stored with a search snapshot, such as a support ticket reference */
+export interface SnapshotRequest {
+  note?: string;
+}
+
+/** Request to start an import in the background */
+export interface StartImportRequest {
+  /** Source is a Google Sheets URL or an s3:// or gs:// object; local paths and sql: queries are refused */
+  source?: string;
+  /** TriggeredBy identifies who started the import; defaults to "api:admin" */
+  triggered_by?: string;
+}
+
+/** Product name or popular search completing a typed prefix */
+export interface Suggestion {
+  company?: string;
+  /** ID and Company identify the product a completion was taken from */
+  id?: string;
+  /** Score ranks completions; Searches counts the searches of a popular suggestion */
+  score?: number;
+  searches?: number;
+  /** Source is completion for product names and popular_suggestions for popular searches */
+  source?: string;
+  text?: string;
+}
+
+/** Terms matched interchangeably when searching products, e.g. acetaminophen and paracetamol */
+export interface SynonymRule {
+  id?: string;
+  synonyms?: string[];
+}
+
+/** Synonym rule to create or replace */
+export interface SynonymRuleRequest {
+  /** Synonyms lists at least two equivalent terms or phrases */
+  synonyms?: string[];
+}
+
+/** Typeahead matches of a partially typed keyword grouped by entity type, for sectioned autocomplete */
+export interface TypeaheadGroups {
+  companies?: ModelsFacetBucket[];
+  /** Generics and Companies are the matching values with the most matching products first */
+  generics?: ModelsFacetBucket[];
+  /** Products are the best products whose name matches */
+  products?: Product[];
+}
+
+/** Partial product update; only the provided fields are changed */
+export interface UpdateProductRequest {
+  company?: string;
+  drug_generic?: string;
+  product_name?: string;
+}
+
+export interface Status {
+  enabled?: boolean;
+  path?: string;
+  recorded?: number;
+}
+
+/** Query parameters of listDailySearchSummaries */
+export interface ListDailySearchSummariesParams {
+  /** Limit number of results */
+  limit?: number;
+  /** Offset for pagination */
+  offset?: number;
+}
+
+/** Query parameters of listCurations */
+export interface ListCurationsParams {
+  /** Limit number of results */
+  limit?: number;
+  /** Offset for pagination */
+  offset?: number;
+}
+
+/** Query parameters of createSearchSnapshot */
+export interface CreateSearchSnapshotParams {
+  /** Search keyword */
+  keyword?: string;
+  /** Only products of these companies (exact match, repeatable) */
+  company?: string[];
+  /** Limit number of results */
+  limit?: number;
+  /** Offset for pagination */
+  offset?: number;
+  /** Comma-separated field:direction list, e.g. product_name:asc,created_at:desc */
+  sort?: string;
+}
+
+/** Query parameters of listExclusionRules */
+export interface ListExclusionRulesParams {
+  /** Limit number of results */
+  limit?: number;
+  /** Offset for pagination */
+  offset?: number;
+}
+
+/** Query parameters of importHistory */
+export interface ImportHistoryParams {
+  /** Limit number of results */
+  limit?: number;
+  /** Offset for pagination */
+  offset?: number;
+  /** Filter by (partial) source */
+  source?: string;
+  /** Filter by status (succeeded, failed, cancelled) */
+  status?: string;
+  /** Filter by who triggered the import */
+  triggered_by?: string;
+  /** Only runs started at or after this RFC3339 time */
+  since?: string;
+  /** Only runs started at or before this RFC3339 time */
+  until?: string;
+}
+
+/** Query parameters of listImportRuns */
+export interface ListImportRunsParams {
+  /** Limit number of results */
+  limit?: number;
+  /** Offset for pagination */
+  offset?: number;
+  /** Filter by (partial) source */
+  source?: string;
+  /** Filter by status (succeeded, failed, cancelled) */
+  status?: string;
+  /** Filter by who triggered the import */
+  triggered_by?: string;
+  /** Only runs started at or after this RFC3339 time */
+  since?: string;
+  /** Only runs started at or before this RFC3339 time */
+  until?: string;
+}
+
+/** Query parameters of getIndexStats */
+export interface GetIndexStatsParams {
+  /** Number of companies and drug generics listed, 1-1000 (default 10) */
+  size?: number;
+}
+
+/** Query parameters of listSynonymRules */
+export interface ListSynonymRulesParams {
+  /** Limit number of results */
+  limit?: number;
+  /** Offset for pagination */
+  offset?: number;
+}
+
+/** Query parameters of getProducts */
+export interface GetProductsParams {
+  /** Limit number of results */
+  limit?: number;
+  /** Offset for pagination */
+  offset?: number;
+  /** start to begin cursor pagination, then the next_cursor of the previous page (offset must be 0) */
+  cursor?: string;
+  /** start to pin offset pages to a point in time, then the pit of the previous page */
+  pit?: string;
+  /** Search keyword */
+  keyword?: string;
+  /** Only products of these companies (exact match, repeatable) */
+  company?: string[];
+  /** Only products with these generic names (exact match, repeatable) */
+  drug_generic?: string[];
+  /** Only products with these IDs (repeatable) */
+  id?: string[];
+  /** Only products created at or after this RFC3339 time */
+  created_after?: string;
+  /** Only products created before this RFC3339 time */
+  created_before?: string;
+  /** Only products updated at or after this RFC3339 time */
+  updated_after?: string;
+  /** Only products updated before this RFC3339 time */
+  updated_before?: string;
+  /** Comma-separated field:direction list, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at) */
+  sort?: string;
+  /** Comma-separated fields to return, e.g. id,product_name (fields: id, product_name, drug_generic, company, score, created_at, updated_at, deleted_at, attributes) */
+  fields?: string;
+  /** Return highlighted fragments of the matched fields (format set by SEARCH_HIGHLIGHT_FORMAT) */
+  highlight?: boolean;
+  /** Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS) */
+  fuzziness?: string;
+  /** Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all) */
+  search_fields?: string;
+  /** Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive) */
+  mode?: string;
+  /** true to count every hit or a number to cap the count, e.g. 10000 (default SEARCH_TRACK_TOTAL_HITS) */
+  track_total_hits?: string;
+  /** Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE) */
+  min_score?: number;
+  /** Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness) */
+  syntax?: boolean;
+  /** Merge hits with the same normalized product name and company */
+  dedupe?: boolean;
+  /** Comma-separated fields to return value counts of with the hits (fields: company, drug_generic); a facet ignores its own filter */
+  facets?: string;
+  /** At most this many products of one company in the top SEARCH_DIVERSITY_TOP_K results, 0 disables it (default SEARCH_DIVERSITY_MAX_PER_COMPANY; not with cursor) */
+  max_per_company?: number;
+  /** Query a non-default index or alias (admin only) */
+  index?: string;
+  /** Include soft-deleted products (admin only) */
+  include_deleted?: boolean;
+  /** Set to false to return the bare product array with X-Total-Count and Link headers */
+  envelope?: boolean;
+  /** Omit zero-value fields such as score 0 and unset timestamps */
+  omit_empty?: boolean;
+}
+
+/** Query parameters of createProduct */
+export interface CreateProductParams {
+  /** Omit zero-value fields such as score 0 and unset timestamps */
+  omit_empty?: boolean;
+}
+
+/** Query parameters of bulkCreateProducts */
+export interface BulkCreateProductsParams {
+  /** Revert the written products when any item fails */
+  all_or_nothing?: boolean;
+}
+
+/** Query parameters of countProducts */
+export interface CountProductsParams {
+  /** Search keyword */
+  keyword?: string;
+  /** Only products of these companies (exact match, repeatable) */
+  company?: string[];
+  /** Only products with these generic names (exact match, repeatable) */
+  drug_generic?: string[];
+  /** Only products with these IDs (repeatable) */
+  id?: string[];
+  /** Only products created at or after this RFC3339 time */
+  created_after?: string;
+  /** Only products created before this RFC3339 time */
+  created_before?: string;
+  /** Only products updated at or after this RFC3339 time */
+  updated_after?: string;
+  /** Only products updated before this RFC3339 time */
+  updated_before?: string;
+  /** Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS) */
+  fuzziness?: string;
+  /** Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all) */
+  search_fields?: string;
+  /** Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive) */
+  mode?: string;
+  /** Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE) */
+  min_score?: number;
+  /** Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness) */
+  syntax?: boolean;
+  /** Count in a non-default index or alias (admin only) */
+  index?: string;
+  /** Include soft-deleted products (admin only) */
+  include_deleted?: boolean;
+}
+
+/** Query parameters of distinctValues */
+export interface DistinctValuesParams {
+  /** Field to list the values of (fields: company, drug_generic) */
+  field: string;
+  /** Number of values listed, 1-1000 (default 100) */
+  size?: number;
+  /** Search keyword */
+  keyword?: string;
+  /** Only products of these companies (exact match, repeatable) */
+  company?: string[];
+  /** Only products with these generic names (exact match, repeatable) */
+  drug_generic?: string[];
+  /** Only products with these IDs (repeatable) */
+  id?: string[];
+  /** Only products created at or after this RFC3339 time */
+  created_after?: string;
+  /** Only products created before this RFC3339 time */
+  created_before?: string;
+  /** Only products updated at or after this RFC3339 time */
+  updated_after?: string;
+  /** Only products updated before this RFC3339 time */
+  updated_before?: string;
+  /** Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS) */
+  fuzziness?: string;
+  /** Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all) */
+  search_fields?: string;
+  /** Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive) */
+  mode?: string;
+  /** Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE) */
+  min_score?: number;
+  /** Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness) */
+  syntax?: boolean;
+  /** Search a non-default index or alias (admin only) */
+  index?: string;
+  /** Include soft-deleted products (admin only) */
+  include_deleted?: boolean;
+}
+
+/** Query parameters of productFacets */
+export interface ProductFacetsParams {
+  /** Comma-separated fields to count values of (fields: company, drug_generic; default all) */
+  facets?: string;
+  /** Number of values counted per facet, 1-1000 (default SEARCH_FACET_SIZE) */
+  size?: number;
+  /** Search keyword */
+  keyword?: string;
+  /** Only products of these companies (exact match, repeatable) */
+  company?: string[];
+  /** Only products with these generic names (exact match, repeatable) */
+  drug_generic?: string[];
+  /** Only products with these IDs (repeatable) */
+  id?: string[];
+  /** Only products created at or after this RFC3339 time */
+  created_after?: string;
+  /** Only products created before this RFC3339 time */
+  created_before?: string;
+  /** Only products updated at or after this RFC3339 time */
+  updated_after?: string;
+  /** Only products updated before this RFC3339 time */
+  updated_before?: string;
+  /** Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS) */
+  fuzziness?: string;
+  /** Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all) */
+  search_fields?: string;
+  /** Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive) */
+  mode?: string;
+  /** Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE) */
+  min_score?: number;
+  /** Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness) */
+  syntax?: boolean;
+  /** Count in a non-default index or alias (admin only) */
+  index?: string;
+  /** Include soft-deleted products (admin only) */
+  include_deleted?: boolean;
+}
+
+/** Query parameters of productsGroupedByCompany */
+export interface ProductsGroupedByCompanyParams {
+  /** Number of companies, 1-100 (default 10) */
+  size?: number;
+  /** Number of products per company, 1-20 (default 3) */
+  per_company?: number;
+  /** Search keyword */
+  keyword?: string;
+  /** Only products of these companies (exact match, repeatable) */
+  company?: string[];
+  /** Only products with these generic names (exact match, repeatable) */
+  drug_generic?: string[];
+  /** Only products with these IDs (repeatable) */
+  id?: string[];
+  /** Only products created at or after this RFC3339 time */
+  created_after?: string;
+  /** Only products created before this RFC3339 time */
+  created_before?: string;
+  /** Only products updated at or after this RFC3339 time */
+  updated_after?: string;
+  /** Only products updated before this RFC3339 time */
+  updated_before?: string;
+  /** Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS) */
+  fuzziness?: string;
+  /** Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all) */
+  search_fields?: string;
+  /** Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive) */
+  mode?: string;
+  /** Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE) */
+  min_score?: number;
+  /** Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness) */
+  syntax?: boolean;
+  /** Comma-separated sort of the products within a company, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at) */
+  sort?: string;
+  /** Search a non-default index or alias (admin only) */
+  index?: string;
+  /** Include soft-deleted products (admin only) */
+  include_deleted?: boolean;
+}
+
+/** Query parameters of batchGetProducts */
+export interface BatchGetProductsParams {
+  /** Omit zero-value fields such as score 0 and unset timestamps */
+  omit_empty?: boolean;
+}
+
+/** Query parameters of closePIT */
+export interface ClosePITParams {
+  /** pit token of the session */
+  pit: string;
+}
+
+/** Query parameters of relatedGenerics */
+export interface RelatedGenericsParams {
+  /** Number of generics, 1-100 (default 10) */
+  size?: number;
+  /** Search keyword */
+  keyword?: string;
+  /** Only products of these companies (exact match, repeatable) */
+  company?: string[];
+  /** Only products with these generic names (exact match, repeatable) */
+  drug_generic?: string[];
+  /** Only products with these IDs (repeatable) */
+  id?: string[];
+  /** Only products created at or after this RFC3339 time */
+  created_after?: string;
+  /** Only products created before this RFC3339 time */
+  created_before?: string;
+  /** Only products updated at or after this RFC3339 time */
+  updated_after?: string;
+  /** Only products updated before this RFC3339 time */
+  updated_before?: string;
+  /** Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS) */
+  fuzziness?: string;
+  /** Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all) */
+  search_fields?: string;
+  /** Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive) */
+  mode?: string;
+  /** Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE) */
+  min_score?: number;
+  /** Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness) */
+  syntax?: boolean;
+  /** Search a non-default index or alias (admin only) */
+  index?: string;
+  /** Include soft-deleted products (admin only) */
+  include_deleted?: boolean;
+}
+
+/** Query parameters of sampleProducts */
+export interface SampleProductsParams {
+  /** Number of products, 1-100 (default 10) */
+  size?: number;
+  /** Seed making the sample reproducible */
+  seed?: number;
+  /** Search keyword */
+  keyword?: string;
+  /** Only products of these companies (exact match, repeatable) */
+  company?: string[];
+  /** Only products with these generic names (exact match, repeatable) */
+  drug_generic?: string[];
+  /** Only products with these IDs (repeatable) */
+  id?: string[];
+  /** Only products created at or after this RFC3339 time */
+  created_after?: string;
+  /** Only products created before this RFC3339 time */
+  created_before?: string;
+  /** Only products updated at or after this RFC3339 time */
+  updated_after?: string;
+  /** Only products updated before this RFC3339 time */
+  updated_before?: string;
+  /** Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS) */
+  fuzziness?: string;
+  /** Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all) */
+  search_fields?: string;
+  /** Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive) */
+  mode?: string;
+  /** Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness) */
+  syntax?: boolean;
+  /** Sample a non-default index or alias (admin only) */
+  index?: string;
+  /** Include soft-deleted products (admin only) */
+  include_deleted?: boolean;
+  /** Omit zero-value fields such as score 0 and unset timestamps */
+  omit_empty?: boolean;
+}
+
+/** Query parameters of suggestProducts */
+export interface SuggestProductsParams {
+  /** Typed prefix of a product name */
+  q: string;
+  /** Number of suggestions, 1-20 (default 5) */
+  size?: number;
+  /** Only suggest names of this company's products (exact match) */
+  company?: string;
+}
+
+/** Query parameters of typeaheadProducts */
+export interface TypeaheadProductsParams {
+  /** Partially typed product name */
+  q: string;
+  /** Number of products, 1-20 (default 5) */
+  size?: number;
+  /** Omit zero-value fields such as score 0 and unset timestamps */
+  omit_empty?: boolean;
+}
+
+/** Query parameters of groupedTypeahead */
+export interface GroupedTypeaheadParams {
+  /** Partially typed keyword */
+  q: string;
+  /** Number of entries per section, 1-20 (default 5) */
+  size?: number;
+}
+
+/** Query parameters of getProductByID */
+export interface GetProductByIDParams {
+  /** Omit zero-value fields such as score 0 and unset timestamps */
+  omit_empty?: boolean;
+}
+
+/** Query parameters of replaceProduct */
+export interface ReplaceProductParams {
+  /** Create the product when it doesn't exist */
+  upsert?: boolean;
+  /** Omit zero-value fields such as score 0 and unset timestamps */
+  omit_empty?: boolean;
+}
+
+/** Query parameters of updateProduct */
+export interface UpdateProductParams {
+  /** Omit zero-value fields such as score 0 and unset timestamps */
+  omit_empty?: boolean;
+}
+
+/** Query parameters of softDeleteProduct */
+export interface SoftDeleteProductParams {
+  /** Omit zero-value fields such as score 0 and unset timestamps */
+  omit_empty?: boolean;
+}
+
+/** Query parameters of getCreationHistogram */
+export interface GetCreationHistogramParams {
+  /** Calendar interval: day, week, month, quarter or year (default month) */
+  interval?: string;
+  /** Only products created at or after this RFC3339 time */
+  created_after?: string;
+  /** Only products created before this RFC3339 time */
+  created_before?: string;
+}
+
+/** Thrown for every non-2xx response */
+export class ApiError extends Error {
+  readonly status: number;
+  readonly body: string;
+
+  constructor(status: number, body: string) {
+    super(`api error ${status}: ${body}`);
+    this.name = "ApiError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+export interface ClientOptions {
+  /** Sent as a Bearer token on admin operations */
+  adminKey?: string;
+  /** Defaults to the global fetch */
+  fetch?: typeof fetch;
+}
+
+type Query = Record<string, string | number | boolean | Array<string | number | boolean> | undefined>;
+
+/** Typed client for the API */
+export class Client {
+  private readonly baseUrl: string;
+  private readonly adminKey?: string;
+  private readonly fetchFn: typeof fetch;
+
+  constructor(baseUrl: string, options: ClientOptions = {}) {
+    this.baseUrl = baseUrl.replace(/\/+$/, "");
+    this.adminKey = options.adminKey;
+    this.fetchFn = options.fetch ?? fetch;
+  }
+
+  private async request<T>(method: string, path: string, query: Query | undefined, body: unknown, admin: boolean): Promise<T> {
+    const search = new URLSearchParams();
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value === undefined) continue;
+      for (const item of Array.isArray(value) ? value : [value]) search.append(key, String(item));
+    }
+    const qs = search.toString();
+
+    const headers: Record<string, string> = { Accept: "application/json" };
+    if (body !== undefined) headers["Content-Type"] = "application/json";
+    if (admin && this.adminKey) headers["Authorization"] = `Bearer ${this.adminKey}`;
+
+    const res = await this.fetchFn(this.baseUrl + BASE_PATH + path + (qs ? "?" + qs : ""), {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    const text = await res.text();
+    if (!res.ok) throw new ApiError(res.status, text);
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+
+  /** Lists the nightly search rollups (top queries, zero-result rate, average latency), newest day first (GET /admin/analytics/daily) */
+  listDailySearchSummaries(params: ListDailySearchSummariesParams = {}): Promise<PagedResponseArraySearchDailySummary> {
+    return this.request<PagedResponseArraySearchDailySummary>("GET", "/admin/analytics/daily", params as Query, undefined, true);
+  }
+
+  /** Lists keywords with pinned products, ordered by keyword (GET /admin/curations) */
+  listCurations(params: ListCurationsParams = {}): Promise<PagedResponseArrayCuration> {
+    return this.request<PagedResponseArrayCuration>("GET", "/admin/curations", params as Query, undefined, true);
+  }
+
+  /** Returns the products pinned for a keyword (GET /admin/curations/{keyword}) */
+  getCuration(keyword: string): Promise<BaseResponseCuration> {
+    return this.request<BaseResponseCuration>("GET", `/admin/curations/${encodeURIComponent(String(keyword))}`, undefined, undefined, true);
+  }
+
+  /** Pins products, in order, to the top of the results of a keyword search, replacing earlier pins (PUT /admin/curations/{keyword}) */
+  setCuration(keyword: string, body: CurationRequest): Promise<BaseResponseCuration> {
+    return this.request<BaseResponseCuration>("PUT", `/admin/curations/${encodeURIComponent(String(keyword))}`, undefined, body, true);
+  }
+
+  /** Removes the pinned products of a keyword (DELETE /admin/curations/{keyword}) */
+  deleteCuration(keyword: string): Promise<BaseResponseString> {
+    return this.request<BaseResponseString>("DELETE", `/admin/curations/${encodeURIComponent(String(keyword))}`, undefined, undefined, true);
+  }
+
+  /** Runs a product search with the GET /product query parameters given in the query string (all of them are accepted, cursor and pit excepted) and stores the request, the effective Elasticsearch query and the results (POST /admin/debug/snapshot) */
+  createSearchSnapshot(body: SnapshotRequest, params: CreateSearchSnapshotParams = {}): Promise<BaseResponseSearchSnapshot> {
+    return this.request<BaseResponseSearchSnapshot>("POST", "/admin/debug/snapshot", params as Query, body, true);
+  }
+
+  /** Returns a captured search with its request, effective Elasticsearch query and results (GET /admin/debug/snapshot/{id}) */
+  getSearchSnapshot(id: string): Promise<BaseResponseSearchSnapshot> {
+    return this.request<BaseResponseSearchSnapshot>("GET", `/admin/debug/snapshot/${encodeURIComponent(String(id))}`, undefined, undefined, true);
+  }
+
+  /** Lists the rules hiding products or companies from search results, global rules first (GET /admin/exclusions) */
+  listExclusionRules(params: ListExclusionRulesParams = {}): Promise<PagedResponseArrayExclusionRule> {
+    return this.request<PagedResponseArrayExclusionRule>("GET", "/admin/exclusions", params as Query, undefined, true);
+  }
+
+  /** Hides products or companies from the results of a keyword, or of every search when the keyword is empty (POST /admin/exclusions) */
+  createExclusionRule(body: ExclusionRuleRequest): Promise<BaseResponseExclusionRule> {
+    return this.request<BaseResponseExclusionRule>("POST", "/admin/exclusions", undefined, body, true);
+  }
+
+  /** Returns an exclusion rule by ID (GET /admin/exclusions/{id}) */
+  getExclusionRule(id: string): Promise<BaseResponseExclusionRule> {
+    return this.request<BaseResponseExclusionRule>("GET", `/admin/exclusions/${encodeURIComponent(String(id))}`, undefined, undefined, true);
+  }
+
+  /** Replaces the keyword, products and companies of an exclusion rule (PUT /admin/exclusions/{id}) */
+  replaceExclusionRule(id: string, body: ExclusionRuleRequest): Promise<BaseResponseExclusionRule> {
+    return this.request<BaseResponseExclusionRule>("PUT", `/admin/exclusions/${encodeURIComponent(String(id))}`, undefined, body, true);
+  }
+
+  /** Removes an exclusion rule (DELETE /admin/exclusions/{id}) */
+  deleteExclusionRule(id: string): Promise<BaseResponseString> {
+    return this.request<BaseResponseString>("DELETE", `/admin/exclusions/${encodeURIComponent(String(id))}`, undefined, undefined, true);
+  }
+
+  /** Starts importing a Google Sheets URL or an s3:// or gs:// object (local paths and sql: queries are refused, they only run from the command line), or a .csv, .ndjson, .jsonl, .parquet or .xlsx file uploaded as the "file" field of a multipart/form-data request (with an optional "triggered_by" field), and returns the job ID of the import; its progress and outcome are reported under that ID by the import progress and history (POST /admin/import) */
+  startImport(body: StartImportRequest): Promise<BaseResponseImportJob> {
+    return this.request<BaseResponseImportJob>("POST", "/admin/import", undefined, body, true);
+  }
+
+  /** Lists import run summaries, newest first, with optional filters; the same listing as GET /admin/imports (GET /admin/import/history) */
+  importHistory(params: ImportHistoryParams = {}): Promise<PagedResponseArrayImportRun> {
+    return this.request<PagedResponseArrayImportRun>("GET", "/admin/import/history", params as Query, undefined, true);
+  }
+
+  /** Returns the status (queued, running, succeeded, failed or cancelled), row counts and errors of an import started over HTTP since the server started (GET /admin/import/jobs/{id}) */
+  getImportJob(id: string): Promise<BaseResponseImportJob> {
+    return this.request<BaseResponseImportJob>("GET", `/admin/import/jobs/${encodeURIComponent(String(id))}`, undefined, undefined, true);
+  }
+
+  /** Cancels a queued or running import started over HTTP. The import stops at its next row, keeping the rows already indexed, and is recorded in the import history as cancelled; poll the job for its final status (DELETE /admin/import/jobs/{id}) */
+  cancelImportJob(id: string): Promise<BaseResponseImportJob> {
+    return this.request<BaseResponseImportJob>("DELETE", `/admin/import/jobs/${encodeURIComponent(String(id))}`, undefined, undefined, true);
+  }
+
+  /** Returns the rows read, indexed and failed, the throughput and the estimated time left of the import running in the background, or the outcome of the last import (GET /admin/import/progress) */
+  getImportProgress(): Promise<BaseResponseImportProgress> {
+    return this.request<BaseResponseImportProgress>("GET", "/admin/import/progress", undefined, undefined, true);
+  }
+
+  /** Lists import run summaries, newest first, with optional filters (GET /admin/imports) */
+  listImportRuns(params: ListImportRunsParams = {}): Promise<PagedResponseArrayImportRun> {
+    return this.request<PagedResponseArrayImportRun>("GET", "/admin/imports", params as Query, undefined, true);
+  }
+
+  /** Returns added, removed and changed product counts with sample IDs between import runs a and b (GET /admin/imports/{a}/diff/{b}) */
+  diffImportRuns(a: string, b: string): Promise<BaseResponseImportDiff> {
+    return this.request<BaseResponseImportDiff>("GET", `/admin/imports/${encodeURIComponent(String(a))}/diff/${encodeURIComponent(String(b))}`, undefined, undefined, true);
+  }
+
+  /** Returns whether searches are being recorded, the output file and the number of recorded searches (GET /admin/recorder) */
+  searchRecorderStatus(): Promise<BaseResponseStatus> {
+    return this.request<BaseResponseStatus>("GET", "/admin/recorder", undefined, undefined, true);
+  }
+
+  /** Starts appending anonymized searches and their Elasticsearch responses to the recorder file (POST /admin/recorder/start) */
+  startSearchRecorder(): Promise<BaseResponseStatus> {
+    return this.request<BaseResponseStatus>("POST", "/admin/recorder/start", undefined, undefined, true);
+  }
+
+  /** Stops recording searches and closes the recorder file (POST /admin/recorder/stop) */
+  stopSearchRecorder(): Promise<BaseResponseStatus> {
+    return this.request<BaseResponseStatus>("POST", "/admin/recorder/stop", undefined, undefined, true);
+  }
+
+  /** Returns the total documents, the document counts of the most frequent companies and drug generics, the range of creation times and the disk size of the product index (GET /admin/stats) */
+  getIndexStats(params: GetIndexStatsParams = {}): Promise<BaseResponseIndexStats> {
+    return this.request<BaseResponseIndexStats>("GET", "/admin/stats", params as Query, undefined, true);
+  }
+
+  /** Lists the synonym rules expanding product searches and suggestions, in ID order (GET /admin/synonyms) */
+  listSynonymRules(params: ListSynonymRulesParams = {}): Promise<PagedResponseArraySynonymRule> {
+    return this.request<PagedResponseArraySynonymRule>("GET", "/admin/synonyms", params as Query, undefined, true);
+  }
+
+  /** Makes searches for any of the terms match the others; applied to live indices without reindexing (POST /admin/synonyms) */
+  createSynonymRule(body: SynonymRuleRequest): Promise<BaseResponseSynonymRule> {
+    return this.request<BaseResponseSynonymRule>("POST", "/admin/synonyms", undefined, body, true);
+  }
+
+  /** Returns a synonym rule by ID (GET /admin/synonyms/{id}) */
+  getSynonymRule(id: string): Promise<BaseResponseSynonymRule> {
+    return this.request<BaseResponseSynonymRule>("GET", `/admin/synonyms/${encodeURIComponent(String(id))}`, undefined, undefined, true);
+  }
+
+  /** Replaces the terms of a synonym rule (PUT /admin/synonyms/{id}) */
+  replaceSynonymRule(id: string, body: SynonymRuleRequest): Promise<BaseResponseSynonymRule> {
+    return this.request<BaseResponseSynonymRule>("PUT", `/admin/synonyms/${encodeURIComponent(String(id))}`, undefined, body, true);
+  }
+
+  /** Removes a synonym rule (DELETE /admin/synonyms/{id}) */
+  deleteSynonymRule(id: string): Promise<BaseResponseString> {
+    return this.request<BaseResponseString>("DELETE", `/admin/synonyms/${encodeURIComponent(String(id))}`, undefined, undefined, true);
+  }
+
+  /** Checks the health of the service and returns a status message (GET /health) */
+  healthCheck(): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("GET", "/health", undefined, undefined, false);
+  }
+
+  /** Retrieves a list of products with pagination and search keywords (GET /product) */
+  getProducts(params: GetProductsParams = {}): Promise<PagedResponseArrayProduct> {
+    return this.request<PagedResponseArrayProduct>("GET", "/product", params as Query, undefined, false);
+  }
+
+  /** Indexes a new product; when the ID is omitted one is assigned by the configured ID strategy (Elasticsearch by default) (POST /product) */
+  createProduct(body: CreateProductRequest, params: CreateProductParams = {}): Promise<BaseResponseProduct> {
+    return this.request<BaseResponseProduct>("POST", "/product", params as Query, body, true);
+  }
+
+  /** Indexes an array of products with the bulk API and returns the outcome of every item; with all_or_nothing=true nothing is written when an item is invalid, and products written before another item failed are deleted again (best effort, reported in rollback) (POST /product/bulk) */
+  bulkCreateProducts(body: CreateProductRequest[], params: BulkCreateProductsParams = {}): Promise<BaseResponseBulkResult> {
+    return this.request<BaseResponseBulkResult>("POST", "/product/bulk", params as Query, body, true);
+  }
+
+  /** Counts the products matching a keyword and filters with the Count API, without fetching them (GET /product/count) */
+  countProducts(params: CountProductsParams = {}): Promise<BaseResponseProductCount> {
+    return this.request<BaseResponseProductCount>("GET", "/product/count", params as Query, undefined, false);
+  }
+
+  /** Counts the distinct companies or drug generics of the products matching a keyword and filters and lists the first of them alphabetically, for populating dropdowns; the field ignores its own filter and the count is approximate above 3000 values (GET /product/distinct) */
+  distinctValues(params: DistinctValuesParams = {}): Promise<BaseResponseDistinctValues> {
+    return this.request<BaseResponseDistinctValues>("GET", "/product/distinct", params as Query, undefined, false);
+  }
+
+  /** Counts the products per company and drug generic matching a keyword and filters, without fetching them, for building filter UIs; a facet ignores its own filter and total counts the products matching every filter (GET /product/facets) */
+  productFacets(params: ProductFacetsParams = {}): Promise<BaseResponseProductFacets> {
+    return this.request<BaseResponseProductFacets>("GET", "/product/facets", params as Query, undefined, false);
+  }
+
+  /** Returns the best products of each of the companies with the most products matching a keyword and filters, most matches first, for comparison views; products within a company are ordered like search results (GET /product/grouped) */
+  productsGroupedByCompany(params: ProductsGroupedByCompanyParams = {}): Promise<BaseResponseProductGroups> {
+    return this.request<BaseResponseProductGroups>("GET", "/product/grouped", params as Query, undefined, false);
+  }
+
+  /** Retrieves up to 100 products by document ID in request order; unknown IDs are listed as missing (POST /product/mget) */
+  batchGetProducts(body: BatchGetRequest, params: BatchGetProductsParams = {}): Promise<BaseResponseBatchGetResult> {
+    return this.request<BaseResponseBatchGetResult>("POST", "/product/mget", params as Query, body, false);
+  }
+
+  /** Releases the point in time of a pit session before it expires (DELETE /product/pit) */
+  closePIT(params: ClosePITParams = {}): Promise<BaseResponseString> {
+    return this.request<BaseResponseString>("DELETE", "/product/pit", params as Query, undefined, false);
+  }
+
+  /** Returns the drug generics that are statistically overrepresented in the products matching a keyword or company compared to the whole catalog, most significant first, for exploring the catalog; a keyword or company is required (GET /product/related-generics) */
+  relatedGenerics(params: RelatedGenericsParams = {}): Promise<BaseResponseSignificantTerms> {
+    return this.request<BaseResponseSignificantTerms>("GET", "/product/related-generics", params as Query, undefined, false);
+  }
+
+  /** Returns random products matching an optional keyword and filters, for QA spot checks and demo pages; pass a seed to get the same sample again while the index is unchanged (GET /product/sample) */
+  sampleProducts(params: SampleProductsParams = {}): Promise<BaseResponseArrayProduct> {
+    return this.request<BaseResponseArrayProduct>("GET", "/product/sample", params as Query, undefined, false);
+  }
+
+  /** Completes a typed prefix to distinct product names with the completion suggester, for autocomplete as the user types; soft-deleted and excluded products aren't suggested. With analytics enabled, prefixes shorter than 3 characters or completing no product name are completed with popular searches first (source popular_suggestions) unless a company is given (GET /product/suggest) */
+  suggestProducts(params: SuggestProductsParams = {}): Promise<BaseResponseArraySuggestion> {
+    return this.request<BaseResponseArraySuggestion>("GET", "/product/suggest", params as Query, undefined, false);
+  }
+
+  /** Returns the best products whose name matches what the user has typed so far: every complete word must match and the last one may be a prefix, matched on a search_as_you_type field instead of wildcards; soft-deleted and excluded products aren't returned (GET /product/typeahead) */
+  typeaheadProducts(params: TypeaheadProductsParams = {}): Promise<BaseResponseArrayProduct> {
+    return this.request<BaseResponseArrayProduct>("GET", "/product/typeahead", params as Query, undefined, false);
+  }
+
+  /** Returns what the user has typed so far grouped into sections: the best products whose name matches, and the generics and companies matching it with the most products. Every complete word must match and the last one may be a prefix; soft-deleted and excluded products aren't counted (GET /product/typeahead/grouped) */
+  groupedTypeahead(params: GroupedTypeaheadParams = {}): Promise<BaseResponseTypeaheadGroups> {
+    return this.request<BaseResponseTypeaheadGroups>("GET", "/product/typeahead/grouped", params as Query, undefined, false);
+  }
+
+  /** Retrieves a single product by its document ID (GET /product/{id}) */
+  getProductByID(id: string, params: GetProductByIDParams = {}): Promise<BaseResponseProduct> {
+    return this.request<BaseResponseProduct>("GET", `/product/${encodeURIComponent(String(id))}`, params as Query, undefined, false);
+  }
+
+  /** Sets all catalog fields of a product; with upsert=true the product is created when missing (PUT /product/{id}) */
+  replaceProduct(id: string, body: CreateProductRequest, params: ReplaceProductParams = {}): Promise<BaseResponseProduct> {
+    return this.request<BaseResponseProduct>("PUT", `/product/${encodeURIComponent(String(id))}`, params as Query, body, true);
+  }
+
+  /** Updates only the provided fields of a product using doc merge semantics (PATCH /product/{id}) */
+  updateProduct(id: string, body: UpdateProductRequest, params: UpdateProductParams = {}): Promise<BaseResponseProduct> {
+    return this.request<BaseResponseProduct>("PATCH", `/product/${encodeURIComponent(String(id))}`, params as Query, body, true);
+  }
+
+  /** Removes a product document by ID (DELETE /product/{id}) */
+  deleteProduct(id: string): Promise<BaseResponseString> {
+    return this.request<BaseResponseString>("DELETE", `/product/${encodeURIComponent(String(id))}`, undefined, undefined, true);
+  }
+
+  /** Sets deleted_at on a product so it no longer appears in searches (POST /product/{id}/soft-delete) */
+  softDeleteProduct(id: string, params: SoftDeleteProductParams = {}): Promise<BaseResponseProduct> {
+    return this.request<BaseResponseProduct>("POST", `/product/${encodeURIComponent(String(id))}/soft-delete`, params as Query, undefined, true);
+  }
+
+  /** Returns total products, distinct companies and generics, update range and recent additions (GET /stats/catalog) */
+  getCatalogStats(): Promise<BaseResponseCatalogStats> {
+    return this.request<BaseResponseCatalogStats>("GET", "/stats/catalog", undefined, undefined, false);
+  }
+
+  /** Returns the number of products created per calendar interval, oldest interval first; intervals without products are included with a zero count (GET /stats/created) */
+  getCreationHistogram(params: GetCreationHistogramParams = {}): Promise<BaseResponseCreationHistogram> {
+    return this.request<BaseResponseCreationHistogram>("GET", "/stats/created", params as Query, undefined, false);
+  }
+}
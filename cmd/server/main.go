@@ -20,24 +20,77 @@ import (
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 // @host localhost:8080
 // @BasePath /
+// @securityDefinitions.apikey AdminKey
+// @in header
+// @name Authorization
+// @description Admin API key as "Bearer <ADMIN_API_KEY>"
 func main() {
 	// Parse command-line flags
 	flags := parseFlags()
 
+	// Handle client generation before loading configuration, it only needs the spec
+	if flags.genClient || flags.genClientCheck {
+		if err := executeGenClient(flags); err != nil {
+			fiberlog.Fatalf("❌ Client generation failed: %v", err)
+		}
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		fiberlog.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// --import-csv reads the source as CSV whatever its extension
+	if flags.importCSV != "" {
+		if flags.importPath != "" {
+			fiberlog.Fatalf("--import-excel and --import-csv are mutually exclusive")
+		}
+		flags.importPath = flags.importCSV
+	}
+
+	// Handle inspection of an import source if specified
+	if flags.importPath != "" && flags.inspect {
+		if err := executeInspect(cfg, flags); err != nil {
+			fiberlog.Fatalf("❌ Inspection failed: %v", err)
+		}
+		return
+	}
+
+	// Handle a dry run of an import if specified
+	if flags.importPath != "" && flags.dryRun {
+		if err := executeDryRun(cfg, flags); err != nil {
+			fiberlog.Fatalf("❌ Dry run failed: %v", err)
+		}
+		return
+	}
+
 	// Handle import mode if specified
 	if flags.importPath != "" {
-		if err := executeImport(cfg, flags.importPath); err != nil {
+		if err := executeImport(cfg, flags); err != nil {
 			fiberlog.Fatalf("❌ Import failed: %v", err)
 		}
 		return
 	}
 
+	// Handle reindexing if specified
+	if flags.reindex {
+		fiberlog.Infof("Reindexing %s", cfg.Elasticsearch.Index)
+		if err := app.Reindex(cfg); err != nil {
+			fiberlog.Fatalf("❌ Reindex failed: %v", err)
+		}
+		return
+	}
+
+	// Handle replay mode if specified
+	if flags.replayPath != "" {
+		if err := executeReplay(cfg, flags); err != nil {
+			fiberlog.Fatalf("❌ Replay failed: %v", err)
+		}
+		return
+	}
+
 	// Run the application in server mode
 	if err := startServer(cfg); err != nil {
 		fiberlog.Fatalf("Application error: %v", err)
@@ -46,9 +99,85 @@ func main() {
 }
 
 // executeImport handles importing data from Excel
-func executeImport(cfg *config.Config, path string) error {
-	fiberlog.Infof("Starting import from: %s", path)
-	return app.ImportExcel(cfg, path)
+func executeImport(cfg *config.Config, flags CommandFlags) error {
+	fiberlog.Infof("Starting import from: %s", flags.importPath)
+	return app.ImportExcel(cfg, flags.importPath, flags.triggeredBy, app.ImportOptions{
+		TargetIndex:     flags.targetIndex,
+		Promote:         flags.promote,
+		MappingFile:     flags.mappingFile,
+		Mode:            flags.importMode,
+		DuplicatePolicy: flags.duplicatePolicy,
+		IDPolicy:        flags.idPolicy,
+		RejectReport:    flags.rejectReport,
+		BatchSize:       flags.batchSize,
+		FailOnError:     flags.failOnError,
+		Source:          sourceFlags(flags),
+	})
+}
+
+// executeDryRun validates an import source and reports what importing it would do
+func executeDryRun(cfg *config.Config, flags CommandFlags) error {
+	fiberlog.Infof("Dry run of import from: %s", flags.importPath)
+	return app.DryRunImport(cfg, flags.importPath, app.ImportOptions{
+		TargetIndex:     flags.targetIndex,
+		Promote:         flags.promote,
+		MappingFile:     flags.mappingFile,
+		DuplicatePolicy: flags.duplicatePolicy,
+		IDPolicy:        flags.idPolicy,
+		RejectReport:    flags.rejectReport,
+		Source:          sourceFlags(flags),
+	})
+}
+
+// sourceFlags collects the flags selecting how the import source is read
+func sourceFlags(flags CommandFlags) app.SourceFlags {
+	source := app.SourceFlags{
+		CSVDelimiter: flags.csvDelimiter,
+		CSVQuote:     flags.csvQuote,
+		CSVEncoding:  flags.csvEncoding,
+		Sheet:        flags.sheet,
+		AllSheets:    flags.allSheets,
+	}
+	if flags.importCSV != "" {
+		source.Format = "csv"
+	}
+	return source
+}
+
+// executeInspect reports the columns of an import source and writes a best-guess column mapping
+func executeInspect(cfg *config.Config, flags CommandFlags) error {
+	mappingFile := flags.mappingFile
+	if mappingFile == "" {
+		mappingFile = "import-mapping.json"
+	}
+
+	fiberlog.Infof("Inspecting import source: %s", flags.importPath)
+	return app.InspectImport(cfg, flags.importPath, app.InspectOptions{
+		SampleRows:  flags.inspectRows,
+		MappingFile: mappingFile,
+		Attributes:  flags.inspectAttrs,
+		Source:      sourceFlags(flags),
+	})
+}
+
+// executeReplay replays recorded searches against a target cluster
+func executeReplay(cfg *config.Config, flags CommandFlags) error {
+	fiberlog.Infof("Replaying searches from: %s", flags.replayPath)
+	return app.ReplaySearches(cfg, app.ReplayOptions{
+		Path:    flags.replayPath,
+		Targets: flags.replayTargets,
+		Index:   flags.replayIndex,
+		TopK:    flags.replayTopK,
+	})
+}
+
+// executeGenClient regenerates or checks the typed API clients
+func executeGenClient(flags CommandFlags) error {
+	return app.GenerateClients(app.GenerateClientsOptions{
+		SpecPath: flags.genClientSpec,
+		OutDir:   flags.genClientOut,
+		Check:    flags.genClientCheck,
+	})
 }
 
 // startServer initializes and starts the application server
@@ -66,15 +195,81 @@ func startServer(cfg *config.Config) error {
 
 // CommandFlags holds all command-line flags
 type CommandFlags struct {
-	importPath string
+	importPath      string
+	importCSV       string
+	csvDelimiter    string
+	csvQuote        string
+	csvEncoding     string
+	sheet           string
+	allSheets       bool
+	triggeredBy     string
+	targetIndex     string
+	promote         bool
+	mappingFile     string
+	importMode      string
+	duplicatePolicy string
+	idPolicy        string
+	rejectReport    string
+	batchSize       int
+	failOnError     int
+	inspect         bool
+	dryRun          bool
+	inspectRows     int
+	inspectAttrs    bool
+	reindex         bool
+	replayPath      string
+	replayTargets   string
+	replayIndex     string
+	replayTopK      int
+	genClient       bool
+	genClientSpec   string
+	genClientOut    string
+	genClientCheck  bool
 }
 
 // parseFlags parses command-line arguments and returns structured flags
 func parseFlags() CommandFlags {
 	var flags CommandFlags
 
-	flag.StringVar(&flags.importPath, "import-excel", "", "Path to Excel file to import")
+	flag.StringVar(&flags.importPath, "import-excel", "", "Import source to load (Google Sheets URL, .csv, .ndjson/.jsonl, .xlsx or .parquet path, or s3:// or gs:// object URI)")
+	flag.StringVar(&flags.importCSV, "import-csv", "", "CSV file or s3:// or gs:// object to load, whatever its extension (e.g. a semicolon delimited ERP export)")
+	flag.StringVar(&flags.csvDelimiter, "csv-delimiter", "", "Field delimiter of CSV sources: a single character such as , or ; or \"tab\" (overrides IMPORT_CSV_DELIMITER)")
+	flag.StringVar(&flags.csvQuote, "csv-quote", "", "Quote character of CSV sources (overrides IMPORT_CSV_QUOTE)")
+	flag.StringVar(&flags.csvEncoding, "csv-encoding", "", "Character encoding of CSV sources, such as windows-1252, windows-1254 or utf-16le, or auto to detect it (overrides IMPORT_CSV_ENCODING)")
+	flag.StringVar(&flags.sheet, "sheet", "", "Worksheet of an .xlsx source or of a Google Sheet read with IMPORT_GOOGLE_CREDENTIALS_FILE to load, by name or 1-based position (default the first one)")
+	flag.BoolVar(&flags.allSheets, "all-sheets", false, "Load every worksheet of an .xlsx source that has the required columns, reporting results per worksheet")
+	flag.StringVar(&flags.triggeredBy, "triggered-by", defaultTriggeredBy(), "Who triggered the import, recorded in the import history")
+	flag.StringVar(&flags.targetIndex, "target-index", "", "Load the import into this staging index and run the import checks instead of updating the live index")
+	flag.BoolVar(&flags.promote, "promote", false, "Swap the staging index behind the live alias once every import check passed (requires --target-index)")
+	flag.StringVar(&flags.mappingFile, "import-mapping", "", "Column mapping file (.json or .yaml) of the import (overrides IMPORT_MAPPING_FILE); with --inspect, where the guessed mapping is written (default import-mapping.json)")
+	flag.StringVar(&flags.importMode, "import-mode", "", "How products are written: index to replace them or upsert to only overwrite the imported fields, keeping created_at (overrides IMPORT_MODE)")
+	flag.StringVar(&flags.duplicatePolicy, "duplicate-policy", "", "What to do with rows repeating the id, or the product name and company, of an earlier row: last-wins, skip or fail (overrides IMPORT_DUPLICATE_POLICY)")
+	flag.StringVar(&flags.idPolicy, "id-policy", "", "Where product ids come from: column reads them from the id column; for rows without one, hash generates them from the product name and company and elasticsearch lets Elasticsearch assign them (overrides IMPORT_ID_POLICY)")
+	flag.StringVar(&flags.rejectReport, "reject-report", "", "File the rows skipped or failed to index are written to with the reason, as CSV or, for .ndjson and .jsonl paths, NDJSON (overrides IMPORT_REJECT_REPORT)")
+	flag.IntVar(&flags.batchSize, "batch-size", 0, "Products sent per bulk request (overrides IMPORT_BATCH_SIZE)")
+	flag.IntVar(&flags.failOnError, "fail-on-error", 0, "Abort the import once this many rows failed to index (overrides IMPORT_FAIL_ON_ERROR)")
+	flag.BoolVar(&flags.inspect, "inspect", false, "Inspect the columns of the --import-excel or --import-csv source and write a best-guess column mapping instead of importing")
+	flag.BoolVar(&flags.dryRun, "dry-run", false, "Read and validate the whole --import-excel or --import-csv source and report row counts, rejected rows, duplicate ids and the index mapping without touching Elasticsearch")
+	flag.IntVar(&flags.inspectRows, "inspect-rows", 100, "Number of rows sampled by --inspect")
+	flag.BoolVar(&flags.inspectAttrs, "inspect-attributes", false, "With --inspect, add the columns no product field is read from to the mapping as typed product attributes, and print the Elasticsearch mapping imports will put for them")
+	flag.BoolVar(&flags.reindex, "reindex", false, "Copy the live catalog into a new index created with the current mapping and swap the alias to it (requires ELASTICSEARCH_INDEX_TEMPLATE)")
+	flag.StringVar(&flags.replayPath, "replay", "", "Path to a search recording (NDJSON) to replay")
+	flag.StringVar(&flags.replayTargets, "replay-target", "", "Comma separated Elasticsearch addresses to replay against (defaults to ELASTICSEARCH_ADDRESSES)")
+	flag.StringVar(&flags.replayIndex, "replay-index", "", "Index or alias to replay against (defaults to the recorded index)")
+	flag.IntVar(&flags.replayTopK, "replay-top-k", 10, "Number of leading hits compared per search")
+	flag.BoolVar(&flags.genClient, "gen-client", false, "Regenerate the typed Go and TypeScript API clients from the OpenAPI spec")
+	flag.StringVar(&flags.genClientSpec, "gen-client-spec", "docs/swagger.json", "OpenAPI (Swagger 2.0) spec the clients are generated from")
+	flag.StringVar(&flags.genClientOut, "gen-client-out", "clients", "Directory the generated clients are written to")
+	flag.BoolVar(&flags.genClientCheck, "gen-client-check", false, "Fail if the generated clients are out of date with the spec instead of writing them")
 	flag.Parse()
 
 	return flags
 }
+
+// defaultTriggeredBy identifies the local user running a CLI import
+func defaultTriggeredBy() string {
+	if user := os.Getenv("USER"); user != "" {
+		return "cli:" + user
+	}
+	return "cli"
+}
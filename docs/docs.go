@@ -23,35 +23,130 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
+        "/admin/analytics/daily": {
             "get": {
-                "description": "Checks the health of the service and returns a status message",
-                "consumes": [
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Lists the nightly search rollups (top queries, zero-result rate, average latency), newest day first",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Daily Search Summaries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.PagedResponse-array_models_SearchDailySummary"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/curations": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Lists keywords with pinned products, ordered by keyword",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "Admin"
+                ],
+                "summary": "List Curations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Health Check",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/common.PagedResponse-array_models_Curation"
                         }
                     }
                 }
             }
         },
-        "/product": {
+        "/admin/curations/{keyword}": {
             "get": {
-                "description": "Retrieves a list of products with optional pagination and search keywords",
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Returns the products pinned for a keyword",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Curation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_Curation"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Pins products, in order, to the top of the results of a keyword search, replacing earlier pins",
                 "consumes": [
                     "application/json"
                 ],
@@ -59,10 +154,116 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Admin"
                 ],
-                "summary": "Get Products",
+                "summary": "Set Curation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Products to pin",
+                        "name": "curation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CurationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_Curation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Removes the pinned products of a keyword",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Curation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/debug/snapshot": {
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Runs a product search with the GET /product query parameters given in the query string (all of them are accepted, cursor and pit excepted) and stores the request, the effective Elasticsearch query and the results",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Search Snapshot",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products of these companies (exact match, repeatable)",
+                        "name": "company",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Limit number of results",
@@ -77,52 +278,4860 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search keyword",
-                        "name": "keyword",
+                        "description": "Comma-separated field:direction list, e.g. product_name:asc,created_at:desc",
+                        "name": "sort",
                         "in": "query"
+                    },
+                    {
+                        "description": "Note stored with the snapshot",
+                        "name": "snapshot",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.SnapshotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_SearchSnapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/debug/snapshot/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Returns a captured search with its request, effective Elasticsearch query and results",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Search Snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Product"
-                            }
+                            "$ref": "#/definitions/common.BaseResponse-models_SearchSnapshot"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Product": {
-            "description": "Represents a product object",
-            "type": "object",
-            "properties": {
-                "company": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "drug_generic": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "product_name": {
-                    "type": "string"
-                },
-                "score": {
-                    "type": "number"
-                },
-                "updated_at": {
-                    "type": "string"
+        },
+        "/admin/exclusions": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Lists the rules hiding products or companies from search results, global rules first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Exclusion Rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.PagedResponse-array_models_ExclusionRule"
+                        }
+                    }
                 }
-            }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Hides products or companies from the results of a keyword, or of every search when the keyword is empty",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Exclusion Rule",
+                "parameters": [
+                    {
+                        "description": "Rule to create",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ExclusionRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ExclusionRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exclusions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Returns an exclusion rule by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Exclusion Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ExclusionRule"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Replaces the keyword, products and companies of an exclusion rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Replace Exclusion Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule contents",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ExclusionRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ExclusionRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Removes an exclusion rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Exclusion Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Starts importing a Google Sheets URL or an s3:// or gs:// object (local paths and sql: queries are refused, they only run from the command line), or a .csv, .ndjson, .jsonl, .parquet or .xlsx file uploaded as the \"file\" field of a multipart/form-data request (with an optional \"triggered_by\" field), and returns the job ID of the import; its progress and outcome are reported under that ID by the import progress and history",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Start Import",
+                "parameters": [
+                    {
+                        "description": "Import to start",
+                        "name": "import",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StartImportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/import/history": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Lists import run summaries, newest first, with optional filters; the same listing as GET /admin/imports",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Import History",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by (partial) source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (succeeded, failed, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by who triggered the import",
+                        "name": "triggered_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only runs started at or after this RFC3339 time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only runs started at or before this RFC3339 time",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.PagedResponse-array_models_ImportRun"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/import/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Returns the status (queued, running, succeeded, failed or cancelled), row counts and errors of an import started over HTTP since the server started",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Import Job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ImportJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Cancels a queued or running import started over HTTP. The import stops at its next row, keeping the rows already indexed, and is recorded in the import history as cancelled; poll the job for its final status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Cancel Import Job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ImportJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/import/progress": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Returns the rows read, indexed and failed, the throughput and the estimated time left of the import running in the background, or the outcome of the last import",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Import Progress",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ImportProgress"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/imports": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Lists import run summaries, newest first, with optional filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Import Runs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by (partial) source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (succeeded, failed, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by who triggered the import",
+                        "name": "triggered_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only runs started at or after this RFC3339 time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only runs started at or before this RFC3339 time",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.PagedResponse-array_models_ImportRun"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/imports/{a}/diff/{b}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Returns added, removed and changed product counts with sample IDs between import runs a and b",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Diff Import Runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Earlier import run ID",
+                        "name": "a",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Later import run ID",
+                        "name": "b",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ImportDiff"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/recorder": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Returns whether searches are being recorded, the output file and the number of recorded searches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Search Recorder Status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-recorder_Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/recorder/start": {
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Starts appending anonymized searches and their Elasticsearch responses to the recorder file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Start Search Recorder",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-recorder_Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/recorder/stop": {
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Stops recording searches and closes the recorder file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Stop Search Recorder",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-recorder_Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stats": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Returns the total documents, the document counts of the most frequent companies and drug generics, the range of creation times and the disk size of the product index",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Index Stats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of companies and drug generics listed, 1-1000 (default 10)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_IndexStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/synonyms": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Lists the synonym rules expanding product searches and suggestions, in ID order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Synonym Rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.PagedResponse-array_models_SynonymRule"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Makes searches for any of the terms match the others; applied to live indices without reindexing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Synonym Rule",
+                "parameters": [
+                    {
+                        "description": "Rule to create",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SynonymRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_SynonymRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/synonyms/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Returns a synonym rule by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Synonym Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_SynonymRule"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Replaces the terms of a synonym rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Replace Synonym Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule contents",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SynonymRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_SynonymRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Removes a synonym rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Synonym Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Checks the health of the service and returns a status message",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Health Check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/product": {
+            "get": {
+                "description": "Retrieves a list of products with pagination and search keywords",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get Products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start to begin cursor pagination, then the next_cursor of the previous page (offset must be 0)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start to pin offset pages to a point in time, then the pit of the previous page",
+                        "name": "pit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products of these companies (exact match, repeatable)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these generic names (exact match, repeatable)",
+                        "name": "drug_generic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these IDs (repeatable)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated field:direction list, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,product_name (fields: id, product_name, drug_generic, company, score, created_at, updated_at, deleted_at, attributes)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return highlighted fragments of the matched fields (format set by SEARCH_HIGHLIGHT_FORMAT)",
+                        "name": "highlight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)",
+                        "name": "fuzziness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)",
+                        "name": "search_fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "true to count every hit or a number to cap the count, e.g. 10000 (default SEARCH_TRACK_TOTAL_HITS)",
+                        "name": "track_total_hits",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)",
+                        "name": "syntax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Merge hits with the same normalized product name and company",
+                        "name": "dedupe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return value counts of with the hits (fields: company, drug_generic); a facet ignores its own filter",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "At most this many products of one company in the top SEARCH_DIVERSITY_TOP_K results, 0 disables it (default SEARCH_DIVERSITY_MAX_PER_COMPANY; not with cursor)",
+                        "name": "max_per_company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Query a non-default index or alias (admin only)",
+                        "name": "index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return the bare product array with X-Total-Count and Link headers",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Omit zero-value fields such as score 0 and unset timestamps",
+                        "name": "omit_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.PagedResponse-array_models_Product"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Indexes a new product; when the ID is omitted one is assigned by the configured ID strategy (Elasticsearch by default)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create Product",
+                "parameters": [
+                    {
+                        "description": "Product to create",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProductRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Omit zero-value fields such as score 0 and unset timestamps",
+                        "name": "omit_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Indexes an array of products with the bulk API and returns the outcome of every item; with all_or_nothing=true nothing is written when an item is invalid, and products written before another item failed are deleted again (best effort, reported in rollback)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Bulk Create Products",
+                "parameters": [
+                    {
+                        "description": "Products to create",
+                        "name": "products",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CreateProductRequest"
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Revert the written products when any item fails",
+                        "name": "all_or_nothing",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_BulkResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_BulkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/count": {
+            "get": {
+                "description": "Counts the products matching a keyword and filters with the Count API, without fetching them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Count Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products of these companies (exact match, repeatable)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these generic names (exact match, repeatable)",
+                        "name": "drug_generic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these IDs (repeatable)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)",
+                        "name": "fuzziness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)",
+                        "name": "search_fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)",
+                        "name": "syntax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Count in a non-default index or alias (admin only)",
+                        "name": "index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ProductCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/distinct": {
+            "get": {
+                "description": "Counts the distinct companies or drug generics of the products matching a keyword and filters and lists the first of them alphabetically, for populating dropdowns; the field ignores its own filter and the count is approximate above 3000 values",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Distinct Values",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field to list the values of (fields: company, drug_generic)",
+                        "name": "field",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of values listed, 1-1000 (default 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products of these companies (exact match, repeatable)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these generic names (exact match, repeatable)",
+                        "name": "drug_generic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these IDs (repeatable)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)",
+                        "name": "fuzziness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)",
+                        "name": "search_fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)",
+                        "name": "syntax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search a non-default index or alias (admin only)",
+                        "name": "index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_DistinctValues"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/export.csv": {
+            "get": {
+                "description": "Streams every product matching a keyword and filters as a CSV file with a header row naming the fields, read in batches from a point in time so the export is consistent and isn't bounded by the result window; the default columns can be imported again as is. An export failing after the first batch ends with a broken response rather than a truncated file.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Export Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields exported as columns, in order (fields: id, product_name, drug_generic, company, score, created_at, updated_at, deleted_at, attributes; default id,product_name,drug_generic,company); attributes adds a column per attribute mapped in the index, named after it",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated field:direction list, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products of these companies (exact match, repeatable)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these generic names (exact match, repeatable)",
+                        "name": "drug_generic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these IDs (repeatable)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)",
+                        "name": "fuzziness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)",
+                        "name": "search_fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)",
+                        "name": "syntax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export a non-default index or alias (admin only)",
+                        "name": "index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/facets": {
+            "get": {
+                "description": "Counts the products per company and drug generic matching a keyword and filters, without fetching them, for building filter UIs; a facet ignores its own filter and total counts the products matching every filter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Product Facets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to count values of (fields: company, drug_generic; default all)",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of values counted per facet, 1-1000 (default SEARCH_FACET_SIZE)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products of these companies (exact match, repeatable)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these generic names (exact match, repeatable)",
+                        "name": "drug_generic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these IDs (repeatable)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)",
+                        "name": "fuzziness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)",
+                        "name": "search_fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)",
+                        "name": "syntax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Count in a non-default index or alias (admin only)",
+                        "name": "index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ProductFacets"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/grouped": {
+            "get": {
+                "description": "Returns the best products of each of the companies with the most products matching a keyword and filters, most matches first, for comparison views; products within a company are ordered like search results",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Products Grouped by Company",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of companies, 1-100 (default 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of products per company, 1-20 (default 3)",
+                        "name": "per_company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products of these companies (exact match, repeatable)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these generic names (exact match, repeatable)",
+                        "name": "drug_generic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these IDs (repeatable)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)",
+                        "name": "fuzziness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)",
+                        "name": "search_fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)",
+                        "name": "syntax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort of the products within a company, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search a non-default index or alias (admin only)",
+                        "name": "index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_ProductGroups"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/mget": {
+            "post": {
+                "description": "Retrieves up to 100 products by document ID in request order; unknown IDs are listed as missing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Batch Get Products",
+                "parameters": [
+                    {
+                        "description": "Product IDs to fetch",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BatchGetRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Omit zero-value fields such as score 0 and unset timestamps",
+                        "name": "omit_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_BatchGetResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/pit": {
+            "delete": {
+                "description": "Releases the point in time of a pit session before it expires",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Close PIT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pit token of the session",
+                        "name": "pit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/related-generics": {
+            "get": {
+                "description": "Returns the drug generics that are statistically overrepresented in the products matching a keyword or company compared to the whole catalog, most significant first, for exploring the catalog; a keyword or company is required",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Related Generics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of generics, 1-100 (default 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products of these companies (exact match, repeatable)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these generic names (exact match, repeatable)",
+                        "name": "drug_generic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these IDs (repeatable)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)",
+                        "name": "fuzziness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)",
+                        "name": "search_fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)",
+                        "name": "syntax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search a non-default index or alias (admin only)",
+                        "name": "index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_SignificantTerms"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/sample": {
+            "get": {
+                "description": "Returns random products matching an optional keyword and filters, for QA spot checks and demo pages; pass a seed to get the same sample again while the index is unchanged",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Sample Products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of products, 1-100 (default 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seed making the sample reproducible",
+                        "name": "seed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products of these companies (exact match, repeatable)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these generic names (exact match, repeatable)",
+                        "name": "drug_generic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only products with these IDs (repeatable)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)",
+                        "name": "fuzziness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)",
+                        "name": "search_fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)",
+                        "name": "syntax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sample a non-default index or alias (admin only)",
+                        "name": "index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Omit zero-value fields such as score 0 and unset timestamps",
+                        "name": "omit_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-array_models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/suggest": {
+            "get": {
+                "description": "Completes a typed prefix to distinct product names with the completion suggester, for autocomplete as the user types; soft-deleted and excluded products aren't suggested. With analytics enabled, prefixes shorter than 3 characters or completing no product name are completed with popular searches first (source popular_suggestions) unless a company is given",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Suggest Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Typed prefix of a product name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of suggestions, 1-20 (default 5)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only suggest names of this company's products (exact match)",
+                        "name": "company",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-array_models_Suggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/typeahead": {
+            "get": {
+                "description": "Returns the best products whose name matches what the user has typed so far: every complete word must match and the last one may be a prefix, matched on a search_as_you_type field instead of wildcards; soft-deleted and excluded products aren't returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Typeahead Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partially typed product name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of products, 1-20 (default 5)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Omit zero-value fields such as score 0 and unset timestamps",
+                        "name": "omit_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-array_models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/typeahead/grouped": {
+            "get": {
+                "description": "Returns what the user has typed so far grouped into sections: the best products whose name matches, and the generics and companies matching it with the most products. Every complete word must match and the last one may be a prefix; soft-deleted and excluded products aren't counted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Grouped Typeahead",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partially typed keyword",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries per section, 1-20 (default 5)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_TypeaheadGroups"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}": {
+            "get": {
+                "description": "Retrieves a single product by its document ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get Product By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Omit zero-value fields such as score 0 and unset timestamps",
+                        "name": "omit_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Sets all catalog fields of a product; with upsert=true the product is created when missing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Replace Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create the product when it doesn't exist",
+                        "name": "upsert",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Product fields",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProductRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Omit zero-value fields such as score 0 and unset timestamps",
+                        "name": "omit_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_Product"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Removes a product document by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Updates only the provided fields of a product using doc merge semantics",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateProductRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Omit zero-value fields such as score 0 and unset timestamps",
+                        "name": "omit_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/soft-delete": {
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Sets deleted_at on a product so it no longer appears in searches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Soft Delete Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Omit zero-value fields such as score 0 and unset timestamps",
+                        "name": "omit_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/catalog": {
+            "get": {
+                "description": "Returns total products, distinct companies and generics, update range and recent additions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Get Catalog Stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_CatalogStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/created": {
+            "get": {
+                "description": "Returns the number of products created per calendar interval, oldest interval first; intervals without products are included with a zero count",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Get Creation Histogram",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Calendar interval: day, week, month, quarter or year (default month)",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-models_CreationHistogram"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.BaseResponse-string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "common.BaseResponse-array_models_Product": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-array_models_Suggestion": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Suggestion"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_BatchGetResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.BatchGetResult"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_BulkResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.BulkResult"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_CatalogStats": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.CatalogStats"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_CreationHistogram": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.CreationHistogram"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_Curation": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Curation"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_DistinctValues": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.DistinctValues"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_ExclusionRule": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ExclusionRule"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_ImportDiff": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ImportDiff"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_ImportJob": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ImportJob"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_ImportProgress": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ImportProgress"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_IndexStats": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.IndexStats"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_Product": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_ProductCount": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ProductCount"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_ProductFacets": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_ProductGroups": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ProductGroups"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_SearchSnapshot": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.SearchSnapshot"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_SignificantTerms": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.SignificantTerms"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_SynonymRule": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.SynonymRule"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-models_TypeaheadGroups": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.TypeaheadGroups"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-recorder_Status": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/recorder.Status"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.BaseResponse-string": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.FacetBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.PagedResponse-array_models_Curation": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Curation"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "description": "Facets holds the value counts of the requested facets, keyed by field",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/common.FacetBucket"
+                        }
+                    }
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the next page of a cursor-paginated list; empty on the last page",
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/common.PaginationInfo"
+                },
+                "pit": {
+                    "description": "PIT is the token pinning the following pages of a point-in-time session",
+                    "type": "string"
+                },
+                "search": {
+                    "description": "Search reports how Elasticsearch executed a search; only set on search responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/common.SearchMeta"
+                        }
+                    ]
+                },
+                "suggestions": {
+                    "description": "Suggestions are corrected spellings of a search keyword that matched nothing (\"did you mean\")",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.PagedResponse-array_models_ExclusionRule": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExclusionRule"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "description": "Facets holds the value counts of the requested facets, keyed by field",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/common.FacetBucket"
+                        }
+                    }
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the next page of a cursor-paginated list; empty on the last page",
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/common.PaginationInfo"
+                },
+                "pit": {
+                    "description": "PIT is the token pinning the following pages of a point-in-time session",
+                    "type": "string"
+                },
+                "search": {
+                    "description": "Search reports how Elasticsearch executed a search; only set on search responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/common.SearchMeta"
+                        }
+                    ]
+                },
+                "suggestions": {
+                    "description": "Suggestions are corrected spellings of a search keyword that matched nothing (\"did you mean\")",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.PagedResponse-array_models_ImportRun": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRun"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "description": "Facets holds the value counts of the requested facets, keyed by field",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/common.FacetBucket"
+                        }
+                    }
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the next page of a cursor-paginated list; empty on the last page",
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/common.PaginationInfo"
+                },
+                "pit": {
+                    "description": "PIT is the token pinning the following pages of a point-in-time session",
+                    "type": "string"
+                },
+                "search": {
+                    "description": "Search reports how Elasticsearch executed a search; only set on search responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/common.SearchMeta"
+                        }
+                    ]
+                },
+                "suggestions": {
+                    "description": "Suggestions are corrected spellings of a search keyword that matched nothing (\"did you mean\")",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.PagedResponse-array_models_Product": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "description": "Facets holds the value counts of the requested facets, keyed by field",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/common.FacetBucket"
+                        }
+                    }
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the next page of a cursor-paginated list; empty on the last page",
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/common.PaginationInfo"
+                },
+                "pit": {
+                    "description": "PIT is the token pinning the following pages of a point-in-time session",
+                    "type": "string"
+                },
+                "search": {
+                    "description": "Search reports how Elasticsearch executed a search; only set on search responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/common.SearchMeta"
+                        }
+                    ]
+                },
+                "suggestions": {
+                    "description": "Suggestions are corrected spellings of a search keyword that matched nothing (\"did you mean\")",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.PagedResponse-array_models_SearchDailySummary": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchDailySummary"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "description": "Facets holds the value counts of the requested facets, keyed by field",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/common.FacetBucket"
+                        }
+                    }
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the next page of a cursor-paginated list; empty on the last page",
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/common.PaginationInfo"
+                },
+                "pit": {
+                    "description": "PIT is the token pinning the following pages of a point-in-time session",
+                    "type": "string"
+                },
+                "search": {
+                    "description": "Search reports how Elasticsearch executed a search; only set on search responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/common.SearchMeta"
+                        }
+                    ]
+                },
+                "suggestions": {
+                    "description": "Suggestions are corrected spellings of a search keyword that matched nothing (\"did you mean\")",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.PagedResponse-array_models_SynonymRule": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SynonymRule"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "description": "Facets holds the value counts of the requested facets, keyed by field",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/common.FacetBucket"
+                        }
+                    }
+                },
+                "is_success": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the next page of a cursor-paginated list; empty on the last page",
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/common.PaginationInfo"
+                },
+                "pit": {
+                    "description": "PIT is the token pinning the following pages of a point-in-time session",
+                    "type": "string"
+                },
+                "search": {
+                    "description": "Search reports how Elasticsearch executed a search; only set on search responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/common.SearchMeta"
+                        }
+                    ]
+                },
+                "suggestions": {
+                    "description": "Suggestions are corrected spellings of a search keyword that matched nothing (\"did you mean\")",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.PaginationInfo": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "total_relation": {
+                    "description": "TotalRelation is \"gte\" when Total is a lower bound because hit counting was capped",
+                    "type": "string"
+                }
+            }
+        },
+        "common.SearchMeta": {
+            "type": "object",
+            "properties": {
+                "max_score": {
+                    "description": "MaxScore is the highest relevance score of any hit; null when hits aren't scored or nothing matched",
+                    "type": "number"
+                },
+                "timed_out": {
+                    "type": "boolean"
+                },
+                "took_ms": {
+                    "description": "TookMs is the time Elasticsearch spent executing the search",
+                    "type": "integer"
+                }
+            }
+        },
+        "highlight.Fragment": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/highlight.Span"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "highlight.Span": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BatchGetRequest": {
+            "description": "Product IDs to fetch in one request",
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.BatchGetResult": {
+            "description": "Products found for a batch get, in request order, and the IDs that don't exist",
+            "type": "object",
+            "properties": {
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                }
+            }
+        },
+        "models.BulkItemResult": {
+            "description": "Outcome of a single item in a bulk write",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "rolled_back": {
+                    "description": "RolledBack is set on items written and then removed again because another item of an all-or-nothing\nwrite failed",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BulkResult": {
+            "description": "Per-item outcome of a bulk write",
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkItemResult"
+                    }
+                },
+                "rollback": {
+                    "description": "Rollback reports the reverted writes of an all-or-nothing write with failed items",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.BulkRollback"
+                        }
+                    ]
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BulkRollback": {
+            "description": "Reconciliation report of the writes reverted after an all-or-nothing bulk write failed",
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "description": "Failed is the number of written products that couldn't be removed and are still stored",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "Items holds the outcome of reverting every written product, positions referring to the request array",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkItemResult"
+                    }
+                },
+                "reverted": {
+                    "description": "Reverted is the number of written products removed again",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CatalogStats": {
+            "description": "Aggregated overview of the product catalog",
+            "type": "object",
+            "properties": {
+                "added_last_30_days": {
+                    "type": "integer"
+                },
+                "distinct_companies": {
+                    "type": "integer"
+                },
+                "distinct_generics": {
+                    "type": "integer"
+                },
+                "newest_updated_at": {
+                    "type": "string"
+                },
+                "oldest_updated_at": {
+                    "type": "string"
+                },
+                "total_products": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateProductRequest": {
+            "description": "Payload for creating a product; when the ID is omitted one is assigned by the configured ID strategy",
+            "type": "object",
+            "properties": {
+                "company": {
+                    "type": "string"
+                },
+                "drug_generic": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreationHistogram": {
+            "description": "Number of products created per calendar interval, oldest interval first",
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DateBucket"
+                    }
+                },
+                "interval": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Curation": {
+            "description": "Products pinned to the top of the results for a keyword",
+            "type": "object",
+            "properties": {
+                "keyword": {
+                    "type": "string"
+                },
+                "pinned_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CurationRequest": {
+            "description": "Products to pin for a keyword, in display order",
+            "type": "object",
+            "properties": {
+                "pinned_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.DateBucket": {
+            "description": "Number of products created in the interval starting at Date",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DistinctValues": {
+            "description": "Distinct values of a field over the products matching a search",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the approximate number of distinct values; Values lists the first of them alphabetically",
+                    "type": "integer"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ExclusionRule": {
+            "description": "Products or companies hidden from the results of a keyword, or of every search when the keyword is empty",
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExclusionRuleRequest": {
+            "description": "Exclusion rule to create or replace; leave keyword empty for a global rule",
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FacetBucket": {
+            "description": "Number of matching products with one value of a faceted field",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImportCheck": {
+            "description": "Outcome of a verification or data-quality check run on a staged import",
+            "type": "object",
+            "properties": {
+                "advisory": {
+                    "description": "Advisory checks are reported but don't fail the import",
+                    "type": "boolean"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "passed": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ImportDiff": {
+            "description": "Differences between the catalogs loaded by two import runs",
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "changed": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "removed": {
+                    "type": "integer"
+                },
+                "sample_added": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sample_changed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sample_removed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportDuplicate": {
+            "description": "Rows of an import sharing a product ID, or a product name and company under different IDs",
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "description": "Kind is \"id\" for rows with the same ID or \"name_company\" for near duplicates",
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowRef"
+                    }
+                },
+                "value": {
+                    "description": "Value is the shared ID, or the product name and company of the first repeated row",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImportJob": {
+            "description": "Import started in the background",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID identifies the import in its progress and, once it finished, in the import history",
+                    "type": "string"
+                },
+                "indexed": {
+                    "type": "integer"
+                },
+                "rows_read": {
+                    "description": "The counts of a running job are those of its last progress report",
+                    "type": "integer"
+                },
+                "rows_skipped": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Source is the imported URL or file; uploads are imported from a copy saved by the server",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is queued, running, succeeded, failed or cancelled",
+                    "type": "string"
+                },
+                "triggered_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImportProgress": {
+            "description": "Progress of the import running in the background, or outcome of the last one",
+            "type": "object",
+            "properties": {
+                "eta_seconds": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "ID is the job ID of an import started over HTTP, also the ID of its run in the import history",
+                    "type": "string"
+                },
+                "index": {
+                    "type": "string"
+                },
+                "indexed": {
+                    "type": "integer"
+                },
+                "percent_done": {
+                    "description": "PercentDone and ETASeconds are estimated from the share of the source read so far; they are left out\nfor sources of unknown size",
+                    "type": "number"
+                },
+                "rows_per_sec": {
+                    "type": "number"
+                },
+                "rows_read": {
+                    "type": "integer"
+                },
+                "rows_skipped": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImportRowRef": {
+            "description": "Row of an import source; rows are numbered like spreadsheet rows, the header being row 1",
+            "type": "object",
+            "properties": {
+                "row": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImportRun": {
+            "description": "Summary of a single import run",
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportCheck"
+                    }
+                },
+                "duplicate_ids": {
+                    "description": "DuplicateIDs and NearDuplicates count the IDs, and the product names and companies under different IDs,\nfound on several rows; Duplicates lists the first of them",
+                    "type": "integer"
+                },
+                "duplicates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportDuplicate"
+                    }
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "string"
+                },
+                "indexed": {
+                    "type": "integer"
+                },
+                "near_duplicates": {
+                    "type": "integer"
+                },
+                "promoted": {
+                    "type": "boolean"
+                },
+                "rows_read": {
+                    "type": "integer"
+                },
+                "rows_skipped": {
+                    "type": "integer"
+                },
+                "sheets": {
+                    "description": "Sheets breaks imports of every worksheet of a workbook down per worksheet",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportSheet"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                },
+                "staged": {
+                    "description": "Staged is set for imports loaded into a staging index instead of the live alias",
+                    "type": "boolean"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "triggered_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImportSheet": {
+            "description": "Outcome of importing one worksheet of a workbook",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error is set for worksheets left out of the import, such as those without the required columns",
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "indexed": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name identifies the worksheet as workbook#sheet",
+                    "type": "string"
+                },
+                "rows_read": {
+                    "type": "integer"
+                },
+                "rows_skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.IndexStats": {
+            "description": "Operational overview of the product index",
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "description": "Companies and DrugGenerics hold the document counts of the most frequent values",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetBucket"
+                    }
+                },
+                "drug_generics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetBucket"
+                    }
+                },
+                "index": {
+                    "type": "string"
+                },
+                "newest_created_at": {
+                    "type": "string"
+                },
+                "oldest_created_at": {
+                    "type": "string"
+                },
+                "primary_size_bytes": {
+                    "description": "PrimarySizeBytes is the disk size of the primary shards, SizeBytes includes the replicas",
+                    "type": "integer"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "total_documents": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Product": {
+            "description": "Represents a product object",
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "Attributes holds the source columns an import keeps besides the product fields, keyed by attribute name",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "company": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "drug_generic": {
+                    "type": "string"
+                },
+                "highlight_offsets": {
+                    "description": "HighlightOffsets holds the plain-text fragments and match offsets per field with the offsets format",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/highlight.Fragment"
+                        }
+                    }
+                },
+                "highlights": {
+                    "description": "Highlights holds the highlighted fragments per field when requested with the html format",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductCount": {
+            "description": "Number of products matching a search",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProductFacets": {
+            "description": "Value counts of the faceted fields over the products matching a search",
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.FacetBucket"
+                        }
+                    }
+                },
+                "total": {
+                    "description": "Total is the number of matching products, the selections on faceted fields included",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProductGroup": {
+            "description": "Best matching products of one company",
+            "type": "object",
+            "properties": {
+                "company": {
+                    "type": "string"
+                },
+                "count": {
+                    "description": "Count is the number of matching products of the company, of which Products are the best",
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                }
+            }
+        },
+        "models.ProductGroups": {
+            "description": "Best matching products of the companies with the most matches, most matches first",
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductGroup"
+                    }
+                },
+                "total": {
+                    "description": "Total is the number of matching products of every company",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.QueryCount": {
+            "description": "Number of searches for a keyword",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SearchDailySummary": {
+            "description": "Search activity rolled up for a single UTC day",
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "type": "number"
+                },
+                "date": {
+                    "description": "Date is the UTC day in YYYY-MM-DD form",
+                    "type": "string"
+                },
+                "rolled_up_at": {
+                    "type": "string"
+                },
+                "searches": {
+                    "type": "integer"
+                },
+                "top_queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QueryCount"
+                    }
+                },
+                "zero_result_rate": {
+                    "type": "number"
+                },
+                "zero_result_searches": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SearchSnapshot": {
+            "description": "Search captured with its effective Elasticsearch query and results, for reproducing reported results later",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "query": {
+                    "description": "Query is the search request body sent to Elasticsearch",
+                    "type": "object"
+                },
+                "request": {
+                    "description": "Request is the query string of the captured search, as sent to GET /product",
+                    "type": "string"
+                },
+                "took_ms": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.SignificantTerm": {
+            "description": "Value of a field that is overrepresented in the products matching a search",
+            "type": "object",
+            "properties": {
+                "background_count": {
+                    "type": "integer"
+                },
+                "count": {
+                    "description": "Count is the number of matching products with the value, BackgroundCount the number in the whole catalog",
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SignificantTerms": {
+            "description": "Values of a field that are overrepresented in the products matching a search, most significant first",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of matching products the values were compared against the whole catalog with",
+                    "type": "integer"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SignificantTerm"
+                    }
+                }
+            }
+        },
+        "models.SnapshotRequest": {
+            "description": "Optional context stored with a search snapshot, such as a support ticket reference",
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StartImportRequest": {
+            "description": "Request to start an import in the background",
+            "type": "object",
+            "properties": {
+                "source": {
+                    "description": "Source is a Google Sheets URL or an s3:// or gs:// object; local paths and sql: queries are refused",
+                    "type": "string"
+                },
+                "triggered_by": {
+                    "description": "TriggeredBy identifies who started the import; defaults to \"api:admin\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Suggestion": {
+            "description": "Product name or popular search completing a typed prefix",
+            "type": "object",
+            "properties": {
+                "company": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID and Company identify the product a completion was taken from",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Score ranks completions; Searches counts the searches of a popular suggestion",
+                    "type": "number"
+                },
+                "searches": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Source is completion for product names and popular_suggestions for popular searches",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SynonymRule": {
+            "description": "Terms matched interchangeably when searching products, e.g. acetaminophen and paracetamol",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "synonyms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.SynonymRuleRequest": {
+            "description": "Synonym rule to create or replace",
+            "type": "object",
+            "properties": {
+                "synonyms": {
+                    "description": "Synonyms lists at least two equivalent terms or phrases",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.TypeaheadGroups": {
+            "description": "Typeahead matches of a partially typed keyword grouped by entity type, for sectioned autocomplete",
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetBucket"
+                    }
+                },
+                "generics": {
+                    "description": "Generics and Companies are the matching values with the most matching products first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetBucket"
+                    }
+                },
+                "products": {
+                    "description": "Products are the best products whose name matches",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                }
+            }
+        },
+        "models.UpdateProductRequest": {
+            "description": "Partial product update; only the provided fields are changed",
+            "type": "object",
+            "properties": {
+                "company": {
+                    "type": "string"
+                },
+                "drug_generic": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "recorder.Status": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "recorded": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminKey": {
+            "description": "Admin API key as \"Bearer \u003cADMIN_API_KEY\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,20 @@
+// Package adminui embeds the static admin web UI served under /admin/ui
+package adminui
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed static
+var static embed.FS
+
+// Assets returns the UI files rooted at the static directory
+func Assets() fs.FS {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		// The embedded directory is fixed at build time
+		panic(err)
+	}
+	return assets
+}
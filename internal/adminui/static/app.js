@@ -0,0 +1,191 @@
+// Minimal admin UI talking to the JSON API. The admin API key is kept in
+// sessionStorage and sent as a bearer token with every request.
+(function () {
+  "use strict";
+
+  const keyStorage = "adminApiKey";
+
+  function apiKey() {
+    return sessionStorage.getItem(keyStorage) || "";
+  }
+
+  async function api(method, path, body) {
+    const headers = { Accept: "application/json" };
+    if (apiKey()) {
+      headers.Authorization = "Bearer " + apiKey();
+    }
+    if (body !== undefined) {
+      headers["Content-Type"] = "application/json";
+    }
+
+    const res = await fetch(path, {
+      method: method,
+      headers: headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    const payload = await res.json().catch(() => ({}));
+    if (!res.ok) {
+      throw new Error(payload.error || payload.message || res.status + " " + res.statusText);
+    }
+    return payload;
+  }
+
+  function cell(text) {
+    const td = document.createElement("td");
+    td.textContent = text === undefined || text === null ? "" : String(text);
+    return td;
+  }
+
+  function row(values) {
+    const tr = document.createElement("tr");
+    values.forEach((value) => tr.appendChild(value instanceof Node ? value : cell(value)));
+    return tr;
+  }
+
+  function fillList(dl, entries) {
+    dl.replaceChildren();
+    Object.keys(entries).forEach((key) => {
+      const dt = document.createElement("dt");
+      dt.textContent = key.replace(/_/g, " ");
+      const dd = document.createElement("dd");
+      dd.textContent = entries[key] === null ? "—" : String(entries[key]);
+      dl.append(dt, dd);
+    });
+  }
+
+  function showError(el, err) {
+    el.textContent = err.message;
+    el.classList.add("error");
+  }
+
+  function showMessage(el, message) {
+    el.textContent = message;
+    el.classList.remove("error");
+  }
+
+  // Tabs
+  document.querySelectorAll("nav button").forEach((button) => {
+    button.addEventListener("click", () => {
+      document.querySelectorAll("nav button, .tab").forEach((el) => el.classList.remove("active"));
+      button.classList.add("active");
+      document.getElementById(button.dataset.tab).classList.add("active");
+      const loader = loaders[button.dataset.tab];
+      if (loader) {
+        loader();
+      }
+    });
+  });
+
+  // API key
+  const keyInput = document.getElementById("api-key");
+  keyInput.value = apiKey();
+  document.getElementById("key-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    sessionStorage.setItem(keyStorage, keyInput.value.trim());
+  });
+
+  // Search
+  const searchSummary = document.getElementById("search-summary");
+  document.getElementById("search-form").addEventListener("submit", async (event) => {
+    event.preventDefault();
+    const form = new FormData(event.target);
+    const query = new URLSearchParams();
+    ["keyword", "company", "drug_generic", "limit"].forEach((name) => {
+      if (form.get(name)) {
+        query.set(name, form.get(name));
+      }
+    });
+    if (form.get("include_deleted")) {
+      query.set("include_deleted", "true");
+    }
+
+    const tbody = document.getElementById("search-results");
+    tbody.replaceChildren();
+    try {
+      const result = await api("GET", "/product?" + query.toString());
+      showMessage(searchSummary, result.pagination.total + " products found" +
+        (result.warnings ? " — " + result.warnings.join("; ") : ""));
+      (result.data || []).forEach((p) => {
+        tbody.appendChild(row([p.id, p.product_name, p.drug_generic, p.company, p.score, p.deleted_at || ""]));
+      });
+    } catch (err) {
+      showError(searchSummary, err);
+    }
+  });
+
+  // Imports
+  const importMessage = document.getElementById("import-message");
+  document.getElementById("import-form").addEventListener("submit", async (event) => {
+    event.preventDefault();
+    const source = new FormData(event.target).get("source");
+    try {
+      await api("POST", "/admin/import", { source: source, triggered_by: "ui:admin" });
+      showMessage(importMessage, "Import started; refresh the runs below to follow its outcome.");
+    } catch (err) {
+      showError(importMessage, err);
+    }
+  });
+
+  async function loadImports() {
+    const tbody = document.getElementById("import-runs");
+    tbody.replaceChildren();
+    try {
+      const result = await api("GET", "/admin/imports?limit=25");
+      (result.data || []).forEach((run) => {
+        const status = cell(run.status);
+        status.className = "status-" + run.status;
+        const errors = cell((run.errors || []).length);
+        errors.title = (run.errors || []).join("\n");
+        tbody.appendChild(row([run.id, status, run.source, run.index, run.triggered_by,
+          new Date(run.started_at).toLocaleString(), run.rows_read, run.indexed, run.rows_skipped, run.failed, errors]));
+      });
+    } catch (err) {
+      showError(importMessage, err);
+    }
+  }
+  document.getElementById("imports-refresh").addEventListener("click", loadImports);
+
+  const diffResult = document.getElementById("diff-result");
+  document.getElementById("diff-form").addEventListener("submit", async (event) => {
+    event.preventDefault();
+    const form = new FormData(event.target);
+    try {
+      const result = await api("GET", "/admin/imports/" + encodeURIComponent(form.get("from")) +
+        "/diff/" + encodeURIComponent(form.get("to")));
+      diffResult.textContent = JSON.stringify(result.data, null, 2);
+    } catch (err) {
+      diffResult.textContent = err.message;
+    }
+  });
+
+  // Catalog
+  async function loadCatalog() {
+    const dl = document.getElementById("catalog-stats");
+    try {
+      const result = await api("GET", "/stats/catalog");
+      fillList(dl, result.data);
+    } catch (err) {
+      fillList(dl, { error: err.message });
+    }
+  }
+  document.getElementById("catalog-refresh").addEventListener("click", loadCatalog);
+
+  // Recorder
+  async function loadRecorder(method, path) {
+    const dl = document.getElementById("recorder-status");
+    try {
+      const result = await api(method || "GET", path || "/admin/recorder");
+      fillList(dl, result.data);
+    } catch (err) {
+      fillList(dl, { error: err.message });
+    }
+  }
+  document.getElementById("recorder-start").addEventListener("click", () => loadRecorder("POST", "/admin/recorder/start"));
+  document.getElementById("recorder-stop").addEventListener("click", () => loadRecorder("POST", "/admin/recorder/stop"));
+
+  const loaders = {
+    imports: loadImports,
+    catalog: loadCatalog,
+    recorder: loadRecorder,
+  };
+})();
@@ -0,0 +1,89 @@
+// Package analytics logs product searches and rolls the raw logs up into daily summaries
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"elasticsearch/internal/metrics"
+	"elasticsearch/internal/models"
+	storageEs "elasticsearch/internal/storage/elasticsearch"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+const (
+	// logBufferSize is the number of entries queued before new searches stop being logged
+	logBufferSize = 1000
+	// logBatchSize is the number of entries written per bulk request
+	logBatchSize = 200
+	// logFlushInterval bounds how long an entry waits in the queue
+	logFlushInterval = 5 * time.Second
+)
+
+// Logger queues search log entries and writes them in batches off the search path
+type Logger struct {
+	repo    storageEs.AnalyticsRepository
+	entries chan models.SearchLogEntry
+}
+
+// NewLogger creates a Logger writing to the given repository; call Run to start writing
+func NewLogger(repo storageEs.AnalyticsRepository) *Logger {
+	return &Logger{
+		repo:    repo,
+		entries: make(chan models.SearchLogEntry, logBufferSize),
+	}
+}
+
+// LogSearch queues an entry without blocking. Entries are dropped while the queue is full
+// so a slow cluster can't hold up searches.
+func (l *Logger) LogSearch(entry models.SearchLogEntry) {
+	select {
+	case l.entries <- entry:
+	default:
+		metrics.SearchLogsDropped.Add(1)
+	}
+}
+
+// Run writes queued entries until ctx is cancelled, flushing what is left before returning
+func (l *Logger) Run(ctx context.Context) {
+	ticker := time.NewTicker(logFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.SearchLogEntry, 0, logBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		// Use a fresh context so the final flush still runs during shutdown
+		flushCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := l.repo.AppendSearchLogs(flushCtx, batch); err != nil {
+			fiberlog.Warnf("Failed to write %d search log entries: %v", len(batch), err)
+			metrics.SearchLogsDropped.Add(int64(len(batch)))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case entry := <-l.entries:
+			batch = append(batch, entry)
+			if len(batch) >= logBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+			for {
+				select {
+				case entry := <-l.entries:
+					batch = append(batch, entry)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
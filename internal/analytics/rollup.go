@@ -0,0 +1,88 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"elasticsearch/internal/models"
+	storageEs "elasticsearch/internal/storage/elasticsearch"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// RollupJob aggregates a day of raw search logs into a summary and prunes logs past retention
+type RollupJob struct {
+	repo      storageEs.AnalyticsRepository
+	hour      int
+	retention time.Duration
+}
+
+// NewRollupJob creates a RollupJob running daily at the given UTC hour and keeping raw logs for retentionDays
+func NewRollupJob(repo storageEs.AnalyticsRepository, hour int, retentionDays int) *RollupJob {
+	return &RollupJob{
+		repo:      repo,
+		hour:      hour,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// Run rolls up the previous day once a day until ctx is cancelled
+func (j *RollupJob) Run(ctx context.Context) {
+	fiberlog.Infof("Search analytics rollup scheduled daily at %02d:00 UTC", j.hour)
+
+	for {
+		timer := time.NewTimer(time.Until(j.nextRun(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			fiberlog.Info("Search analytics rollup stopped")
+			return
+		case now := <-timer.C:
+			if _, err := j.RunOnce(ctx, now); err != nil {
+				fiberlog.Errorf("Search analytics rollup failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce rolls up the UTC day before now, then prunes raw logs older than the retention period.
+// Rolling up the same day again replaces its summary, so reruns are safe.
+func (j *RollupJob) RunOnce(ctx context.Context, now time.Time) (models.SearchDailySummary, error) {
+	day := now.UTC().Truncate(24 * time.Hour).Add(-24 * time.Hour)
+
+	summary, err := j.repo.SummarizeDay(ctx, day)
+	if err != nil {
+		return models.SearchDailySummary{}, err
+	}
+	summary.RolledUpAt = now.UTC()
+
+	if err := j.repo.SaveDailySummary(ctx, summary); err != nil {
+		return models.SearchDailySummary{}, err
+	}
+	fiberlog.Infof("Rolled up %d search(es) for %s", summary.Searches, summary.Date)
+
+	// Never prune the day that was just rolled up, whatever the configured retention
+	cutoff := now.UTC().Truncate(24 * time.Hour).Add(-j.retention)
+	if cutoff.After(day) {
+		cutoff = day
+	}
+	deleted, err := j.repo.PruneSearchLogs(ctx, cutoff)
+	if err != nil {
+		return summary, err
+	}
+	if deleted > 0 {
+		fiberlog.Infof("Pruned %d search log entries older than %s", deleted, cutoff.Format(time.DateOnly))
+	}
+
+	return summary, nil
+}
+
+// nextRun returns the next occurrence of the configured UTC hour after now
+func (j *RollupJob) nextRun(now time.Time) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), j.hour, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
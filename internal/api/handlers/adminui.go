@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"elasticsearch/internal/adminui"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/static"
+)
+
+// RegisterAdminUIRoutes serves the embedded admin UI under /admin/ui. The static assets hold no data
+// and are served without authentication; the UI sends the admin API key with its API calls.
+// Must be registered before the authenticated /admin group.
+func RegisterAdminUIRoutes(app fiber.Router) {
+	app.Get("/admin/ui*", static.New("", static.Config{FS: adminui.Assets()}))
+}
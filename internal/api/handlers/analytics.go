@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"elasticsearch/internal/api/transport"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// AnalyticsHandler handles search analytics HTTP requests
+type AnalyticsHandler struct {
+	analyticsService services.AnalyticsService
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler
+func NewAnalyticsHandler(analyticsService services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetDailySummaries handles GET requests listing daily search summaries
+// @Summary     List Daily Search Summaries
+// @Description Lists the nightly search rollups (top queries, zero-result rate, average latency), newest day first
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       limit  query int false "Limit number of results"
+// @Param       offset query int false "Offset for pagination"
+// @Success     200 {object} common.PagedResponse[[]models.SearchDailySummary]
+// @Router      /admin/analytics/daily [get]
+func (h *AnalyticsHandler) GetDailySummaries(c fiber.Ctx) error {
+	limit, err := transport.Query(c, "limit", 30, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	offset, err := transport.Query(c, "offset", 0, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	params := models.SearchDailySummaryParams{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	result, err := h.analyticsService.GetDailySummaries(c.UserContext(), params)
+	if err != nil {
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to retrieve daily search summaries", err)
+	}
+
+	pagination := common.PaginationInfo{
+		Total:       result.TotalCount,
+		Limit:       result.Limit,
+		Offset:      result.Offset,
+		CurrentPage: result.CurrentPage,
+		TotalPages:  result.TotalPages,
+	}
+
+	return transport.RespondPaged(c, common.NewPagedSuccess(result.Summaries, "Daily search summaries retrieved successfully", pagination))
+}
+
+// RegisterAnalyticsRoutes registers routes for the AnalyticsHandler
+func RegisterAnalyticsRoutes(admin fiber.Router, analyticsService services.AnalyticsService) {
+	handler := NewAnalyticsHandler(analyticsService)
+	admin.Get("/analytics/daily", handler.GetDailySummaries)
+}
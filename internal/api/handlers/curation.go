@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"elasticsearch/internal/api/transport"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// CurationHandler handles admin requests managing pinned search results
+type CurationHandler struct {
+	curationService services.CurationService
+}
+
+// NewCurationHandler creates a new CurationHandler
+func NewCurationHandler(curationService services.CurationService) *CurationHandler {
+	return &CurationHandler{
+		curationService: curationService,
+	}
+}
+
+// GetCurations handles GET requests listing curated keywords
+// @Summary     List Curations
+// @Description Lists keywords with pinned products, ordered by keyword
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       limit  query int false "Limit number of results"
+// @Param       offset query int false "Offset for pagination"
+// @Success     200 {object} common.PagedResponse[[]models.Curation]
+// @Router      /admin/curations [get]
+func (h *CurationHandler) GetCurations(c fiber.Ctx) error {
+	limit, err := transport.Query(c, "limit", 10, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	offset, err := transport.Query(c, "offset", 0, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.curationService.GetCurations(c.UserContext(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	pagination := common.PaginationInfo{
+		Total:       result.TotalCount,
+		Limit:       result.Limit,
+		Offset:      result.Offset,
+		CurrentPage: result.CurrentPage,
+		TotalPages:  result.TotalPages,
+	}
+
+	return transport.RespondPaged(c, common.NewPagedSuccess(result.Curations, "Curations retrieved successfully", pagination))
+}
+
+// GetCuration handles GET requests for the pinned products of a keyword
+// @Summary     Get Curation
+// @Description Returns the products pinned for a keyword
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       keyword path string true "Search keyword"
+// @Success     200 {object} common.BaseResponse[models.Curation]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/curations/{keyword} [get]
+func (h *CurationHandler) GetCuration(c fiber.Ctx) error {
+	// Domain errors are translated into status codes by the Fiber error handler
+	curation, err := h.curationService.GetCuration(c.UserContext(), c.Params("keyword"))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, curation, "Curation retrieved successfully")
+}
+
+// SetCuration handles PUT requests pinning products for a keyword
+// @Summary     Set Curation
+// @Description Pins products, in order, to the top of the results of a keyword search, replacing earlier pins
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       keyword  path string                 true "Search keyword"
+// @Param       curation body models.CurationRequest true "Products to pin"
+// @Success     200 {object} common.BaseResponse[models.Curation]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Router      /admin/curations/{keyword} [put]
+func (h *CurationHandler) SetCuration(c fiber.Ctx) error {
+	req, err := transport.Body[models.CurationRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	curation, err := h.curationService.SetCuration(c.UserContext(), c.Params("keyword"), req)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, curation, "Curation saved successfully")
+}
+
+// DeleteCuration handles DELETE requests removing the pins of a keyword
+// @Summary     Delete Curation
+// @Description Removes the pinned products of a keyword
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       keyword path string true "Search keyword"
+// @Success     200 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/curations/{keyword} [delete]
+func (h *CurationHandler) DeleteCuration(c fiber.Ctx) error {
+	keyword := c.Params("keyword")
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	if err := h.curationService.DeleteCuration(c.UserContext(), keyword); err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, keyword, "Curation deleted successfully")
+}
+
+// RegisterCurationRoutes registers routes for the CurationHandler
+func RegisterCurationRoutes(admin fiber.Router, curationService services.CurationService) {
+	handler := NewCurationHandler(curationService)
+	admin.Get("/curations", handler.GetCurations)
+	admin.Get("/curations/:keyword", handler.GetCuration)
+	admin.Put("/curations/:keyword", handler.SetCuration)
+	admin.Delete("/curations/:keyword", handler.DeleteCuration)
+}
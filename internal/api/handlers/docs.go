@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"os"
+
+	"elasticsearch/internal/apidocs"
+
+	"github.com/gofiber/fiber/v3"
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// DocsHandler serves role-scoped views of the generated swagger document
+type DocsHandler struct {
+	specPath string
+}
+
+// NewDocsHandler creates a new DocsHandler reading the swagger document at specPath
+func NewDocsHandler(specPath string) *DocsHandler {
+	return &DocsHandler{
+		specPath: specPath,
+	}
+}
+
+// PublicSpec serves the swagger document of the public search surface
+func (h *DocsHandler) PublicSpec(c fiber.Ctx) error {
+	return h.serve(c, apidocs.ScopePublic)
+}
+
+// AdminSpec serves the swagger document of the admin endpoints
+func (h *DocsHandler) AdminSpec(c fiber.Ctx) error {
+	return h.serve(c, apidocs.ScopeAdmin)
+}
+
+// serve reads the swagger document and writes the part matching the scope
+func (h *DocsHandler) serve(c fiber.Ctx, scope apidocs.Scope) error {
+	file, err := os.ReadFile(h.specPath)
+	if err != nil {
+		fiberlog.Error("Failed to read swagger.json:", err)
+		return c.Status(fiber.StatusInternalServerError).SendString("Failed to load swagger.json")
+	}
+
+	spec, err := apidocs.Filter(file, scope)
+	if err != nil {
+		fiberlog.Error("Failed to filter swagger.json:", err)
+		return c.Status(fiber.StatusInternalServerError).SendString("Failed to load swagger.json")
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(spec)
+}
+
+// RegisterPublicDocsRoutes registers the public swagger document
+func RegisterPublicDocsRoutes(app fiber.Router, specPath string) {
+	handler := NewDocsHandler(specPath)
+	app.Get("/docs/swagger.json", handler.PublicSpec)
+}
+
+// RegisterAdminDocsRoutes registers the admin swagger document
+func RegisterAdminDocsRoutes(app fiber.Router, specPath string) {
+	handler := NewDocsHandler(specPath)
+	app.Get("/docs/swagger.json", handler.AdminSpec)
+}
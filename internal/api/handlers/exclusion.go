@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"elasticsearch/internal/api/transport"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// ExclusionHandler handles admin requests managing search exclusion rules
+type ExclusionHandler struct {
+	exclusionService services.ExclusionService
+}
+
+// NewExclusionHandler creates a new ExclusionHandler
+func NewExclusionHandler(exclusionService services.ExclusionService) *ExclusionHandler {
+	return &ExclusionHandler{
+		exclusionService: exclusionService,
+	}
+}
+
+// GetRules handles GET requests listing exclusion rules
+// @Summary     List Exclusion Rules
+// @Description Lists the rules hiding products or companies from search results, global rules first
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       limit  query int false "Limit number of results"
+// @Param       offset query int false "Offset for pagination"
+// @Success     200 {object} common.PagedResponse[[]models.ExclusionRule]
+// @Router      /admin/exclusions [get]
+func (h *ExclusionHandler) GetRules(c fiber.Ctx) error {
+	limit, err := transport.Query(c, "limit", 10, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	offset, err := transport.Query(c, "offset", 0, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.exclusionService.GetRules(c.UserContext(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	pagination := common.PaginationInfo{
+		Total:       result.TotalCount,
+		Limit:       result.Limit,
+		Offset:      result.Offset,
+		CurrentPage: result.CurrentPage,
+		TotalPages:  result.TotalPages,
+	}
+
+	return transport.RespondPaged(c, common.NewPagedSuccess(result.Rules, "Exclusion rules retrieved successfully", pagination))
+}
+
+// GetRule handles GET requests for a single exclusion rule
+// @Summary     Get Exclusion Rule
+// @Description Returns an exclusion rule by ID
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       id path string true "Rule ID"
+// @Success     200 {object} common.BaseResponse[models.ExclusionRule]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/exclusions/{id} [get]
+func (h *ExclusionHandler) GetRule(c fiber.Ctx) error {
+	// Domain errors are translated into status codes by the Fiber error handler
+	rule, err := h.exclusionService.GetRule(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, rule, "Exclusion rule retrieved successfully")
+}
+
+// CreateRule handles POST requests creating an exclusion rule
+// @Summary     Create Exclusion Rule
+// @Description Hides products or companies from the results of a keyword, or of every search when the keyword is empty
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       rule body models.ExclusionRuleRequest true "Rule to create"
+// @Success     201 {object} common.BaseResponse[models.ExclusionRule]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Router      /admin/exclusions [post]
+func (h *ExclusionHandler) CreateRule(c fiber.Ctx) error {
+	req, err := transport.Body[models.ExclusionRuleRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	rule, err := h.exclusionService.CreateRule(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusCreated, rule, "Exclusion rule created successfully")
+}
+
+// ReplaceRule handles PUT requests replacing an exclusion rule
+// @Summary     Replace Exclusion Rule
+// @Description Replaces the keyword, products and companies of an exclusion rule
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       id   path string                      true "Rule ID"
+// @Param       rule body models.ExclusionRuleRequest true "Rule contents"
+// @Success     200 {object} common.BaseResponse[models.ExclusionRule]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/exclusions/{id} [put]
+func (h *ExclusionHandler) ReplaceRule(c fiber.Ctx) error {
+	req, err := transport.Body[models.ExclusionRuleRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	rule, err := h.exclusionService.ReplaceRule(c.UserContext(), c.Params("id"), req)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, rule, "Exclusion rule updated successfully")
+}
+
+// DeleteRule handles DELETE requests removing an exclusion rule
+// @Summary     Delete Exclusion Rule
+// @Description Removes an exclusion rule
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       id path string true "Rule ID"
+// @Success     200 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/exclusions/{id} [delete]
+func (h *ExclusionHandler) DeleteRule(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	if err := h.exclusionService.DeleteRule(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, id, "Exclusion rule deleted successfully")
+}
+
+// RegisterExclusionRoutes registers routes for the ExclusionHandler
+func RegisterExclusionRoutes(admin fiber.Router, exclusionService services.ExclusionService) {
+	handler := NewExclusionHandler(exclusionService)
+	admin.Get("/exclusions", handler.GetRules)
+	admin.Post("/exclusions", handler.CreateRule)
+	admin.Get("/exclusions/:id", handler.GetRule)
+	admin.Put("/exclusions/:id", handler.ReplaceRule)
+	admin.Delete("/exclusions/:id", handler.DeleteRule)
+}
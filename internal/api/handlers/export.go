@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"elasticsearch/internal/api/transport"
+	"elasticsearch/internal/models"
+
+	"github.com/gofiber/fiber/v3"
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// exportFields are the columns of a CSV export without fields=, the columns imports read products from
+var exportFields = []string{"id", "product_name", "drug_generic", "company"}
+
+// exportFilenameLayout timestamps the file name of a CSV export
+const exportFilenameLayout = "20060102-150405"
+
+// exportColumn is a column of a CSV export, holding a product field or, when attribute is set, a product attribute
+type exportColumn struct {
+	name      string
+	field     string
+	attribute string
+}
+
+// ExportProducts handles GET requests downloading the products of a search as CSV
+// @Summary     Export Products
+// @Description Streams every product matching a keyword and filters as a CSV file with a header row naming the fields, read in batches from a point in time so the export is consistent and isn't bounded by the result window; the default columns can be imported again as is. An export failing after the first batch ends with a broken response rather than a truncated file.
+// @Tags        Products
+// @Produce     text/csv
+// @Param       fields  query string false "Comma-separated fields exported as columns, in order (fields: id, product_name, drug_generic, company, score, created_at, updated_at, deleted_at, attributes; default id,product_name,drug_generic,company); attributes adds a column per attribute mapped in the index, named after it"
+// @Param       sort    query string false "Comma-separated field:direction list, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at)"
+// @Param       keyword query string false "Search keyword"
+// @Param       company query []string false "Only products of these companies (exact match, repeatable)" collectionFormat(multi)
+// @Param       drug_generic query []string false "Only products with these generic names (exact match, repeatable)" collectionFormat(multi)
+// @Param       id      query []string false "Only products with these IDs (repeatable)" collectionFormat(multi)
+// @Param       created_after  query string false "Only products created at or after this RFC3339 time"
+// @Param       created_before query string false "Only products created before this RFC3339 time"
+// @Param       updated_after  query string false "Only products updated at or after this RFC3339 time"
+// @Param       updated_before query string false "Only products updated before this RFC3339 time"
+// @Param       fuzziness query string false "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)"
+// @Param       search_fields query string false "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)"
+// @Param       mode    query string false "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)"
+// @Param       min_score query number false "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)"
+// @Param       syntax  query bool false "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)"
+// @Param       index   query string false "Export a non-default index or alias (admin only)"
+// @Param       include_deleted query bool false "Include soft-deleted products (admin only)"
+// @Success     200 {file} file
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     403 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/export.csv [get]
+func (h *ProductHandler) ExportProducts(c fiber.Ctx) error {
+	params, err := filterParams(c, h.cfg)
+	if err != nil {
+		return err
+	}
+	if params.Sort, err = models.ParseSort(c.Query("sort")); err != nil {
+		return transport.InvalidParam("sort", err)
+	}
+	if params.Fields, err = models.ParseFields(c.Query("fields")); err != nil {
+		return transport.InvalidParam("fields", err)
+	}
+	if len(params.Fields) == 0 {
+		params.Fields = exportFields
+	}
+
+	// Attribute columns come from the index mapping, as the header is written before any product is read
+	var attributes []string
+	if slices.Contains(params.Fields, "attributes") {
+		if attributes, err = h.productService.AttributeNames(c.UserContext(), params); err != nil {
+			return err
+		}
+	}
+	columns := exportColumns(params.Fields, attributes)
+
+	// The export keeps streaming after the handler returns, until its last batch, the client going away or the
+	// deadline of the export route group
+	deadline, ok := c.UserContext().Deadline()
+	if !ok {
+		deadline = time.Now().Add(time.Duration(h.cfg.Server.ExportTimeoutSec) * time.Second)
+	}
+	ctx, cancel := context.WithDeadline(context.WithoutCancel(c.UserContext()), deadline)
+	body, started := h.streamExport(ctx, cancel, params, columns)
+
+	// Domain errors before the first batch are translated into status codes by the Fiber error handler
+	select {
+	case err := <-started:
+		if err != nil {
+			body.Close()
+			return err
+		}
+	case <-c.UserContext().Done():
+		cancel()
+		body.Close()
+		return c.UserContext().Err()
+	}
+
+	c.Attachment(fmt.Sprintf("products-%s.csv", time.Now().UTC().Format(exportFilenameLayout)))
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return c.SendStream(body)
+}
+
+// streamExport runs an export in the background, writing it as CSV to the returned reader. started receives nil
+// when the first batch, or the header of an empty export, is written, or the error the export failed with before.
+// The export ends with cancel, and stops once the reader is closed.
+func (h *ProductHandler) streamExport(ctx context.Context, cancel context.CancelFunc, params models.ProductSearchParams, columns []exportColumn) (io.ReadCloser, <-chan error) {
+	reader, writer := io.Pipe()
+	started := make(chan error, 1)
+
+	go func() {
+		defer cancel()
+
+		out := csv.NewWriter(writer)
+		begun := false
+		begin := func() error {
+			begun = true
+			started <- nil
+			header := make([]string, len(columns))
+			for i, column := range columns {
+				header[i] = column.name
+			}
+			return out.Write(header)
+		}
+
+		err := h.productService.ExportProducts(ctx, params, func(products []models.Product) error {
+			if !begun {
+				if err := begin(); err != nil {
+					return err
+				}
+			}
+			for _, product := range products {
+				if err := out.Write(exportRecord(product, columns)); err != nil {
+					return err
+				}
+			}
+			// Hand every batch to the client rather than only full buffers
+			out.Flush()
+			return out.Error()
+		})
+		if !begun {
+			if err != nil {
+				started <- err
+				writer.CloseWithError(err)
+				return
+			}
+			err = begin()
+		}
+		if err == nil {
+			out.Flush()
+			err = out.Error()
+		}
+
+		// The status is sent already, so a failed export breaks off the response
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			fiberlog.Errorf("Failed to export products: %v", err)
+		}
+		writer.CloseWithError(err)
+	}()
+
+	return reader, started
+}
+
+// exportColumns returns the columns exporting the selected fields, the attributes field expanding into a column per
+// attribute. Attribute columns are named after their attribute, or "attributes.<name>" for an attribute named like a
+// product field, so they don't clash with the column of the field.
+func exportColumns(fields, attributes []string) []exportColumn {
+	columns := make([]exportColumn, 0, len(fields)+len(attributes))
+	for _, field := range fields {
+		if field != "attributes" {
+			columns = append(columns, exportColumn{name: field, field: field})
+			continue
+		}
+		for _, attribute := range attributes {
+			name := attribute
+			if slices.Contains(models.SelectableFields, name) {
+				name = "attributes." + attribute
+			}
+			columns = append(columns, exportColumn{name: name, attribute: attribute})
+		}
+	}
+	return columns
+}
+
+// exportRecord returns the CSV record of a product, one value per column
+func exportRecord(product models.Product, columns []exportColumn) []string {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		if column.attribute != "" {
+			record[i] = exportAttribute(product.Attributes[column.attribute])
+		} else {
+			record[i] = exportValue(product, column.field)
+		}
+	}
+	return record
+}
+
+// exportAttribute formats an attribute value for a CSV export; products without the attribute leave it empty
+func exportAttribute(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	// Attributes written outside imports may hold objects or arrays, exported as JSON
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// exportValue formats a product field for a CSV export; unset timestamps are left empty
+func exportValue(product models.Product, field string) string {
+	switch field {
+	case "id":
+		return product.ID.String()
+	case "product_name":
+		return product.ProductName
+	case "drug_generic":
+		return product.DrugGeneric
+	case "company":
+		return product.Company
+	case "score":
+		return strconv.FormatFloat(product.Score, 'f', -1, 64)
+	case "created_at":
+		return exportTime(product.CreatedAt)
+	case "updated_at":
+		return exportTime(product.UpdatedAt)
+	case "deleted_at":
+		if product.DeletedAt != nil {
+			return exportTime(*product.DeletedAt)
+		}
+	}
+	return ""
+}
+
+// exportTime formats a timestamp the way product JSON does, or "" when it is unset
+func exportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
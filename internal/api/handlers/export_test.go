@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"elasticsearch/internal/api/middleware"
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/services"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestExportColumns(t *testing.T) {
+	columns := exportColumns([]string{"id", "attributes", "company"}, []string{"ambalaj_miktari", "company", "receteli"})
+
+	var names []string
+	for _, column := range columns {
+		names = append(names, column.name)
+	}
+	want := []string{"id", "ambalaj_miktari", "attributes.company", "receteli", "company"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("columns %v, want %v", names, want)
+	}
+}
+
+func TestExportRecord(t *testing.T) {
+	created := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	product := models.Product{
+		ID:          "170000000000000001",
+		ProductName: "Parol, 500 mg",
+		Company:     "Atabay",
+		Score:       1.5,
+		CreatedAt:   created,
+		Attributes: map[string]interface{}{
+			"ambalaj_miktari": float64(20),
+			"fiyat":           12.5,
+			"receteli":        true,
+			"skt":             "2027-02-01",
+			"etiketler":       []interface{}{"a", "b"},
+		},
+	}
+
+	columns := exportColumns(
+		[]string{"id", "product_name", "score", "created_at", "updated_at", "deleted_at", "attributes"},
+		[]string{"ambalaj_miktari", "etiketler", "fiyat", "receteli", "skt", "yok"},
+	)
+	got := exportRecord(product, columns)
+	want := []string{"170000000000000001", "Parol, 500 mg", "1.5", "2024-06-01T12:00:00Z", "", "",
+		"20", `["a","b"]`, "12.5", "true", "2027-02-01", ""}
+	if !slices.Equal(got, want) {
+		t.Fatalf("record %q, want %q", got, want)
+	}
+}
+
+// slowExportService exports batches one product at a time, pausing before each
+type slowExportService struct {
+	services.ProductService
+	batches int
+	pause   time.Duration
+}
+
+func (s slowExportService) ExportProducts(ctx context.Context, params models.ProductSearchParams, fn func(products []models.Product) error) error {
+	for i := 0; i < s.batches; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.pause):
+		}
+		if err := fn([]models.Product{{ID: models.ProductID(strconv.Itoa(i)), ProductName: "Parol"}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportOutlivesWriteTimeout(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Search.Fuzziness = "AUTO"
+	cfg.Server = config.ServerConfig{ReadTimeoutSec: 1, WriteTimeoutSec: 1, SearchTimeoutSec: 1, BulkTimeoutSec: 1, AdminTimeoutSec: 1, ExportTimeoutSec: 10}
+
+	app := fiber.New(fiber.Config{
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeoutSec) * time.Second,
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeoutSec) * time.Second,
+	})
+	app.Server().HeaderReceived = middleware.IOTimeouts(cfg.Server)
+	app.Use(middleware.RouteTimeouts(cfg.Server))
+	const batches = 10
+	handler := NewProductHandler(cfg, slowExportService{batches: batches, pause: 200 * time.Millisecond})
+	app.Get("/product/export.csv", handler.ExportProducts)
+
+	// Write timeouts only apply to real connections, not to the in-memory one of app.Test
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go app.Listener(listener, fiber.ListenConfig{DisableStartupMessage: true})
+	defer app.Shutdown()
+
+	// The export takes about 2s, twice SERVER_WRITE_TIMEOUT_SEC
+	res, err := http.Get("http://" + listener.Addr().String() + "/product/export.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	records, err := csv.NewReader(res.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("export broken off after %d records: %v", len(records), err)
+	}
+	if len(records) != batches+1 {
+		t.Fatalf("export has %d records, want a header and %d products", len(records), batches)
+	}
+}
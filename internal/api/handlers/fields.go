@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	"elasticsearch/internal/api/transport"
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/models"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// omitEmptyFields reports whether zero-value product fields should be pruned from the response.
+// The ?omit_empty= query parameter overrides the RESPONSE_OMIT_EMPTY_FIELDS default.
+func omitEmptyFields(c fiber.Ctx, cfg *config.Config) (bool, error) {
+	return transport.Query(c, "omit_empty", cfg.Response.OmitEmptyFields, strconv.ParseBool)
+}
+
+// presentProduct returns the product as it should be serialized
+func presentProduct(product models.Product, omitEmpty bool) any {
+	if omitEmpty {
+		return product.Compact()
+	}
+	return product
+}
+
+// presentProducts returns the products as they should be serialized, restricted to the selected fields if any
+func presentProducts(products []models.Product, omitEmpty bool, fields []string) (any, error) {
+	if len(fields) > 0 {
+		return selectFields(products, omitEmpty, fields)
+	}
+
+	if !omitEmpty {
+		return products, nil
+	}
+
+	compact := make([]models.CompactProduct, len(products))
+	for i, product := range products {
+		compact[i] = product.Compact()
+	}
+	return compact, nil
+}
+
+// selectFields serializes only the selected fields of every product
+func selectFields(products []models.Product, omitEmpty bool, fields []string) ([]map[string]json.RawMessage, error) {
+	// Highlights are returned whenever they were requested
+	keys := append(slices.Clone(fields), "highlights", "highlight_offsets")
+
+	selected := make([]map[string]json.RawMessage, 0, len(products))
+	for _, product := range products {
+		var data []byte
+		var err error
+		if omitEmpty {
+			data, err = json.Marshal(product.Compact())
+		} else {
+			data, err = json.Marshal(product)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+
+		item := make(map[string]json.RawMessage, len(keys))
+		for _, field := range keys {
+			if value, ok := all[field]; ok {
+				item[field] = value
+			}
+		}
+		selected = append(selected, item)
+	}
+	return selected, nil
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"strings"
+
+	"elasticsearch/internal/api/transport"
+	_ "elasticsearch/internal/common" // response types of the swagger annotations
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/services"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// defaultAPITriggeredBy is recorded for imports started over HTTP without a triggered_by
+const defaultAPITriggeredBy = "api:admin"
+
+// ImportHandler handles requests starting imports
+type ImportHandler struct {
+	importService services.ImportService
+}
+
+// NewImportHandler creates a new ImportHandler
+func NewImportHandler(importService services.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// StartImport handles POST requests starting an import in the background
+// @Summary     Start Import
+// @Description Starts importing a Google Sheets URL or an s3:// or gs:// object (local paths and sql: queries are refused, they only run from the command line), or a .csv, .ndjson, .jsonl, .parquet or .xlsx file uploaded as the "file" field of a multipart/form-data request (with an optional "triggered_by" field), and returns the job ID of the import; its progress and outcome are reported under that ID by the import progress and history
+// @Tags        Admin
+// @Accept      json,mpfd
+// @Produce     json
+// @Security    AdminKey
+// @Param       import body models.StartImportRequest true "Import to start"
+// @Success     202 {object} common.BaseResponse[models.ImportJob]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     409 {object} common.BaseResponse[string]
+// @Failure     413 {object} common.BaseResponse[string]
+// @Router      /admin/import [post]
+func (h *ImportHandler) StartImport(c fiber.Ctx) error {
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		return h.startUploadedImport(c)
+	}
+
+	req, err := transport.Body[models.StartImportRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	job, err := h.importService.StartImport(req.Source, apiTriggeredBy(req.TriggeredBy))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusAccepted, job, "Import started")
+}
+
+// startUploadedImport starts importing the file uploaded in a multipart form
+func (h *ImportHandler) startUploadedImport(c fiber.Ctx) error {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return transport.InvalidBody(err)
+	}
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	job, err := h.importService.StartUploadedImport(header.Filename, file, apiTriggeredBy(c.FormValue("triggered_by")))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusAccepted, job, "Import started")
+}
+
+// apiTriggeredBy returns who started an import over HTTP, defaulting to defaultAPITriggeredBy
+func apiTriggeredBy(triggeredBy string) string {
+	if triggeredBy == "" {
+		return defaultAPITriggeredBy
+	}
+	return triggeredBy
+}
+
+// GetImportProgress handles GET requests for the progress of the running import
+// @Summary     Get Import Progress
+// @Description Returns the rows read, indexed and failed, the throughput and the estimated time left of the import running in the background, or the outcome of the last import
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Success     200 {object} common.BaseResponse[models.ImportProgress]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/import/progress [get]
+func (h *ImportHandler) GetImportProgress(c fiber.Ctx) error {
+	// Domain errors are translated into status codes by the Fiber error handler
+	progress, err := h.importService.ImportProgress()
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, progress, "Import progress retrieved successfully")
+}
+
+// GetImportJob handles GET requests for the status of an import job
+// @Summary     Get Import Job
+// @Description Returns the status (queued, running, succeeded, failed or cancelled), row counts and errors of an import started over HTTP since the server started
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       id path string true "Import job ID"
+// @Success     200 {object} common.BaseResponse[models.ImportJob]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/import/jobs/{id} [get]
+func (h *ImportHandler) GetImportJob(c fiber.Ctx) error {
+	// Domain errors are translated into status codes by the Fiber error handler
+	job, err := h.importService.ImportJob(c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, job, "Import job retrieved successfully")
+}
+
+// CancelImportJob handles DELETE requests cancelling an import job
+// @Summary     Cancel Import Job
+// @Description Cancels a queued or running import started over HTTP. The import stops at its next row, keeping the rows already indexed, and is recorded in the import history as cancelled; poll the job for its final status
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       id path string true "Import job ID"
+// @Success     202 {object} common.BaseResponse[models.ImportJob]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Failure     409 {object} common.BaseResponse[string]
+// @Router      /admin/import/jobs/{id} [delete]
+func (h *ImportHandler) CancelImportJob(c fiber.Ctx) error {
+	// Domain errors are translated into status codes by the Fiber error handler
+	job, err := h.importService.CancelImportJob(c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusAccepted, job, "Import job cancellation requested")
+}
+
+// RegisterImportRoutes registers routes for the ImportHandler
+func RegisterImportRoutes(admin fiber.Router, importService services.ImportService) {
+	handler := NewImportHandler(importService)
+	admin.Post("/import", handler.StartImport)
+	admin.Get("/import/progress", handler.GetImportProgress)
+	admin.Get("/import/jobs/:id", handler.GetImportJob)
+	admin.Delete("/import/jobs/:id", handler.CancelImportJob)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"elasticsearch/internal/api/transport"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// ImportHistoryHandler handles import history HTTP requests
+type ImportHistoryHandler struct {
+	historyService services.ImportHistoryService
+}
+
+// NewImportHistoryHandler creates a new ImportHistoryHandler
+func NewImportHistoryHandler(historyService services.ImportHistoryService) *ImportHistoryHandler {
+	return &ImportHistoryHandler{
+		historyService: historyService,
+	}
+}
+
+// GetImportRuns handles GET requests listing import runs
+// @Summary     List Import Runs
+// @Description Lists import run summaries, newest first, with optional filters
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       limit        query int    false "Limit number of results"
+// @Param       offset       query int    false "Offset for pagination"
+// @Param       source       query string false "Filter by (partial) source"
+// @Param       status       query string false "Filter by status (succeeded, failed, cancelled)"
+// @Param       triggered_by query string false "Filter by who triggered the import"
+// @Param       since        query string false "Only runs started at or after this RFC3339 time"
+// @Param       until        query string false "Only runs started at or before this RFC3339 time"
+// @Success     200 {object} common.PagedResponse[[]models.ImportRun]
+// @Router      /admin/imports [get]
+func (h *ImportHistoryHandler) GetImportRuns(c fiber.Ctx) error {
+	limit, err := transport.Query(c, "limit", 10, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	offset, err := transport.Query(c, "offset", 0, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	params := models.ImportRunSearchParams{
+		Limit:       limit,
+		Offset:      offset,
+		Source:      c.Query("source"),
+		Status:      c.Query("status"),
+		TriggeredBy: c.Query("triggered_by"),
+	}
+
+	if params.Since, err = queryTime(c, "since"); err != nil {
+		return err
+	}
+
+	if params.Until, err = queryTime(c, "until"); err != nil {
+		return err
+	}
+
+	result, err := h.historyService.GetImportRuns(c.UserContext(), params)
+	if err != nil {
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to retrieve import runs", err)
+	}
+
+	pagination := common.PaginationInfo{
+		Total:       result.TotalCount,
+		Limit:       result.Limit,
+		Offset:      result.Offset,
+		CurrentPage: result.CurrentPage,
+		TotalPages:  result.TotalPages,
+	}
+
+	return transport.RespondPaged(c, common.NewPagedSuccess(result.Runs, "Import runs retrieved successfully", pagination))
+}
+
+// GetImportHistory handles GET requests listing import runs under /admin/import/history
+// @Summary     Import History
+// @Description Lists import run summaries, newest first, with optional filters; the same listing as GET /admin/imports
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       limit        query int    false "Limit number of results"
+// @Param       offset       query int    false "Offset for pagination"
+// @Param       source       query string false "Filter by (partial) source"
+// @Param       status       query string false "Filter by status (succeeded, failed, cancelled)"
+// @Param       triggered_by query string false "Filter by who triggered the import"
+// @Param       since        query string false "Only runs started at or after this RFC3339 time"
+// @Param       until        query string false "Only runs started at or before this RFC3339 time"
+// @Success     200 {object} common.PagedResponse[[]models.ImportRun]
+// @Router      /admin/import/history [get]
+func (h *ImportHistoryHandler) GetImportHistory(c fiber.Ctx) error {
+	return h.GetImportRuns(c)
+}
+
+// DiffImports handles GET requests comparing the catalogs loaded by two import runs
+// @Summary     Diff Import Runs
+// @Description Returns added, removed and changed product counts with sample IDs between import runs a and b
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       a path string true "Earlier import run ID"
+// @Param       b path string true "Later import run ID"
+// @Success     200 {object} common.BaseResponse[models.ImportDiff]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/imports/{a}/diff/{b} [get]
+func (h *ImportHistoryHandler) DiffImports(c fiber.Ctx) error {
+	// Domain errors are translated into status codes by the Fiber error handler
+	diff, err := h.historyService.DiffImports(c.UserContext(), c.Params("a"), c.Params("b"))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, diff, "Import diff computed successfully")
+}
+
+// RegisterImportHistoryRoutes registers routes for the ImportHistoryHandler
+func RegisterImportHistoryRoutes(admin fiber.Router, historyService services.ImportHistoryService) {
+	handler := NewImportHistoryHandler(historyService)
+	admin.Get("/imports", handler.GetImportRuns)
+	admin.Get("/import/history", handler.GetImportHistory)
+	admin.Get("/imports/:a/diff/:b", handler.DiffImports)
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v3"
+	"github.com/valyala/fasthttp/expvarhandler"
+)
+
+// RegisterMetricsRoutes serves the expvar counters of internal/metrics, along with the memory statistics and command
+// line of the process, at /admin/debug/vars. They describe the deployment, so they are only served to admins.
+func RegisterMetricsRoutes(admin fiber.Router) {
+	admin.Get("/debug/vars", func(c fiber.Ctx) error {
+		expvarhandler.ExpvarHandler(c.Context())
+		return nil
+	})
+}
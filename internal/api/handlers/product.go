@@ -2,11 +2,16 @@
 package handlers
 
 import (
+	"elasticsearch/internal/api/middleware"
+	"elasticsearch/internal/api/transport"
 	"elasticsearch/internal/common"
 	"elasticsearch/internal/config"
 	"elasticsearch/internal/models"
 	"elasticsearch/internal/services"
+	"errors"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v3"
 )
@@ -33,37 +38,55 @@ func NewProductHandler(cfg *config.Config, productService services.ProductServic
 // @Produce     json
 // @Param       limit   query int false "Limit number of results"
 // @Param       offset  query int false "Offset for pagination"
+// @Param       cursor  query string false "start to begin cursor pagination, then the next_cursor of the previous page (offset must be 0)"
+// @Param       pit     query string false "start to pin offset pages to a point in time, then the pit of the previous page"
 // @Param       keyword query string false "Search keyword"
+// @Param       company query []string false "Only products of these companies (exact match, repeatable)" collectionFormat(multi)
+// @Param       drug_generic query []string false "Only products with these generic names (exact match, repeatable)" collectionFormat(multi)
+// @Param       id      query []string false "Only products with these IDs (repeatable)" collectionFormat(multi)
+// @Param       created_after  query string false "Only products created at or after this RFC3339 time"
+// @Param       created_before query string false "Only products created before this RFC3339 time"
+// @Param       updated_after  query string false "Only products updated at or after this RFC3339 time"
+// @Param       updated_before query string false "Only products updated before this RFC3339 time"
+// @Param       sort    query string false "Comma-separated field:direction list, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at)"
+// @Param       fields  query string false "Comma-separated fields to return, e.g. id,product_name (fields: id, product_name, drug_generic, company, score, created_at, updated_at, deleted_at, attributes)"
+// @Param       highlight query bool false "Return highlighted fragments of the matched fields (format set by SEARCH_HIGHLIGHT_FORMAT)"
+// @Param       fuzziness query string false "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)"
+// @Param       search_fields query string false "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)"
+// @Param       mode    query string false "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)"
+// @Param       track_total_hits query string false "true to count every hit or a number to cap the count, e.g. 10000 (default SEARCH_TRACK_TOTAL_HITS)"
+// @Param       min_score query number false "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)"
+// @Param       syntax  query bool false "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)"
+// @Param       dedupe  query bool false "Merge hits with the same normalized product name and company"
+// @Param       facets  query string false "Comma-separated fields to return value counts of with the hits (fields: company, drug_generic); a facet ignores its own filter"
+// @Param       max_per_company query int false "At most this many products of one company in the top SEARCH_DIVERSITY_TOP_K results, 0 disables it (default SEARCH_DIVERSITY_MAX_PER_COMPANY; not with cursor)"
+// @Param       index   query string false "Query a non-default index or alias (admin only)"
+// @Param       include_deleted query bool false "Include soft-deleted products (admin only)"
+// @Param       envelope query bool false "Set to false to return the bare product array with X-Total-Count and Link headers"
+// @Param       omit_empty query bool false "Omit zero-value fields such as score 0 and unset timestamps"
 // @Success 	  200 {object} common.PagedResponse[[]models.Product]
 // @Router      /product [get]
 func (h *ProductHandler) GetProducts(c fiber.Ctx) error {
-	// Parse query parameters
-	limitStr := c.Query("limit", "10")
-	offsetStr := c.Query("offset", "0")
-	keyword := c.Query("keyword")
-
-	// Convert string params to integers
-	limit, err := strconv.Atoi(limitStr)
+	searchParams, err := searchParams(c, h.cfg)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(common.NewError("Invalid limit parameter", err))
+		return err
 	}
 
-	offset, err := strconv.Atoi(offsetStr)
+	omitEmpty, err := omitEmptyFields(c, h.cfg)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(common.NewError("Invalid offset parameter", err))
-	}
-
-	// Create search parameters
-	searchParams := models.ProductSearchParams{
-		Limit:   limit,
-		Offset:  offset,
-		Keyword: keyword,
+		return err
 	}
 
 	// Call service to retrieve products
-	result, err := h.productService.GetProducts(c.Context(), searchParams)
+	result, err := h.productService.GetProducts(c.UserContext(), searchParams)
+	if errors.Is(err, services.ErrPartialResults) {
+		return transport.Fail(fiber.StatusServiceUnavailable, "Search returned partial results", err)
+	}
+	if errors.Is(err, common.ErrValidation) {
+		return transport.Fail(fiber.StatusBadRequest, "Invalid search parameters", err)
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(common.NewError("Failed to retrieve products", err))
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to retrieve products", err)
 	}
 
 	// Create pagination info
@@ -74,14 +97,879 @@ func (h *ProductHandler) GetProducts(c fiber.Ctx) error {
 		CurrentPage: result.CurrentPage,
 		TotalPages:  result.TotalPages,
 	}
+	if result.TotalIsLowerBound {
+		pagination.TotalRelation = "gte"
+	}
+
+	// Return products with pagination info and any partial result warnings
+	products, err := presentProducts(result.Products, omitEmpty, searchParams.Fields)
+	if err != nil {
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to encode products", err)
+	}
+	response := common.NewPagedSuccess(products, "Products retrieved successfully", pagination)
+	response.NextCursor = result.NextCursor
+	response.PIT = result.PIT
+	response.Warnings = result.Warnings
+	response.Search = &common.SearchMeta{
+		TookMs:   result.TookMs,
+		MaxScore: result.MaxScore,
+		TimedOut: result.TimedOut,
+	}
+	response.Facets = facetBuckets(result.Facets)
+	response.Suggestions = result.Suggestions
+	return transport.RespondPaged(c, response)
+}
+
+// facetBuckets converts the facet counts of a search into their response form; nil without facets
+func facetBuckets(facets map[string][]models.FacetBucket) map[string][]common.FacetBucket {
+	if len(facets) == 0 {
+		return nil
+	}
+	converted := make(map[string][]common.FacetBucket, len(facets))
+	for facet, buckets := range facets {
+		converted[facet] = make([]common.FacetBucket, 0, len(buckets))
+		for _, bucket := range buckets {
+			converted[facet] = append(converted[facet], common.FacetBucket{Value: bucket.Value, Count: bucket.Count})
+		}
+	}
+	return converted
+}
+
+// CountProducts handles GET requests counting the products a search would return
+// @Summary     Count Products
+// @Description Counts the products matching a keyword and filters with the Count API, without fetching them
+// @Tags        Products
+// @Produce     json
+// @Param       keyword query string false "Search keyword"
+// @Param       company query []string false "Only products of these companies (exact match, repeatable)" collectionFormat(multi)
+// @Param       drug_generic query []string false "Only products with these generic names (exact match, repeatable)" collectionFormat(multi)
+// @Param       id      query []string false "Only products with these IDs (repeatable)" collectionFormat(multi)
+// @Param       created_after  query string false "Only products created at or after this RFC3339 time"
+// @Param       created_before query string false "Only products created before this RFC3339 time"
+// @Param       updated_after  query string false "Only products updated at or after this RFC3339 time"
+// @Param       updated_before query string false "Only products updated before this RFC3339 time"
+// @Param       fuzziness query string false "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)"
+// @Param       search_fields query string false "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)"
+// @Param       mode    query string false "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)"
+// @Param       min_score query number false "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)"
+// @Param       syntax  query bool false "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)"
+// @Param       index   query string false "Count in a non-default index or alias (admin only)"
+// @Param       include_deleted query bool false "Include soft-deleted products (admin only)"
+// @Success     200 {object} common.BaseResponse[models.ProductCount]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     403 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/count [get]
+func (h *ProductHandler) CountProducts(c fiber.Ctx) error {
+	params, err := filterParams(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	count, err := h.productService.CountProducts(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, models.ProductCount{Count: count}, "Products counted successfully")
+}
+
+// GetFacets handles GET requests for the value counts of faceted fields
+// @Summary     Product Facets
+// @Description Counts the products per company and drug generic matching a keyword and filters, without fetching them, for building filter UIs; a facet ignores its own filter and total counts the products matching every filter
+// @Tags        Products
+// @Produce     json
+// @Param       facets  query string false "Comma-separated fields to count values of (fields: company, drug_generic; default all)"
+// @Param       size    query int false "Number of values counted per facet, 1-1000 (default SEARCH_FACET_SIZE)"
+// @Param       keyword query string false "Search keyword"
+// @Param       company query []string false "Only products of these companies (exact match, repeatable)" collectionFormat(multi)
+// @Param       drug_generic query []string false "Only products with these generic names (exact match, repeatable)" collectionFormat(multi)
+// @Param       id      query []string false "Only products with these IDs (repeatable)" collectionFormat(multi)
+// @Param       created_after  query string false "Only products created at or after this RFC3339 time"
+// @Param       created_before query string false "Only products created before this RFC3339 time"
+// @Param       updated_after  query string false "Only products updated at or after this RFC3339 time"
+// @Param       updated_before query string false "Only products updated before this RFC3339 time"
+// @Param       fuzziness query string false "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)"
+// @Param       search_fields query string false "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)"
+// @Param       mode    query string false "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)"
+// @Param       min_score query number false "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)"
+// @Param       syntax  query bool false "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)"
+// @Param       index   query string false "Count in a non-default index or alias (admin only)"
+// @Param       include_deleted query bool false "Include soft-deleted products (admin only)"
+// @Success     200 {object} common.BaseResponse[models.ProductFacets]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     403 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/facets [get]
+func (h *ProductHandler) GetFacets(c fiber.Ctx) error {
+	params, err := filterParams(c, h.cfg)
+	if err != nil {
+		return err
+	}
+	if params.Facets, err = transport.Query(c, "facets", nil, models.ParseFacets); err != nil {
+		return err
+	}
+	if params.FacetSize, err = transport.Query(c, "size", h.cfg.Search.FacetSize, strconv.Atoi); err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	facets, err := h.productService.GetFacets(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, facets, "Product facets retrieved successfully")
+}
+
+// GetRelatedGenerics handles GET requests for the drug generics related to a keyword or company
+// @Summary     Related Generics
+// @Description Returns the drug generics that are statistically overrepresented in the products matching a keyword or company compared to the whole catalog, most significant first, for exploring the catalog; a keyword or company is required
+// @Tags        Products
+// @Produce     json
+// @Param       size    query int false "Number of generics, 1-100 (default 10)"
+// @Param       keyword query string false "Search keyword"
+// @Param       company query []string false "Only products of these companies (exact match, repeatable)" collectionFormat(multi)
+// @Param       drug_generic query []string false "Only products with these generic names (exact match, repeatable)" collectionFormat(multi)
+// @Param       id      query []string false "Only products with these IDs (repeatable)" collectionFormat(multi)
+// @Param       created_after  query string false "Only products created at or after this RFC3339 time"
+// @Param       created_before query string false "Only products created before this RFC3339 time"
+// @Param       updated_after  query string false "Only products updated at or after this RFC3339 time"
+// @Param       updated_before query string false "Only products updated before this RFC3339 time"
+// @Param       fuzziness query string false "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)"
+// @Param       search_fields query string false "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)"
+// @Param       mode    query string false "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)"
+// @Param       min_score query number false "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)"
+// @Param       syntax  query bool false "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)"
+// @Param       index   query string false "Search a non-default index or alias (admin only)"
+// @Param       include_deleted query bool false "Include soft-deleted products (admin only)"
+// @Success     200 {object} common.BaseResponse[models.SignificantTerms]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     403 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/related-generics [get]
+func (h *ProductHandler) GetRelatedGenerics(c fiber.Ctx) error {
+	params, err := filterParams(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	size, err := transport.Query(c, "size", 10, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	related, err := h.productService.RelatedGenerics(c.UserContext(), params, size)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, related, "Related generics retrieved successfully")
+}
+
+// SuggestProducts handles GET requests for autocomplete suggestions
+// @Summary     Suggest Products
+// @Description Completes a typed prefix to distinct product names with the completion suggester, for autocomplete as the user types; soft-deleted and excluded products aren't suggested. With analytics enabled, prefixes shorter than 3 characters or completing no product name are completed with popular searches first (source popular_suggestions) unless a company is given
+// @Tags        Products
+// @Produce     json
+// @Param       q    query string true "Typed prefix of a product name"
+// @Param       size query int false "Number of suggestions, 1-20 (default 5)"
+// @Param       company query string false "Only suggest names of this company's products (exact match)"
+// @Success     200 {object} common.BaseResponse[[]models.Suggestion]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/suggest [get]
+func (h *ProductHandler) SuggestProducts(c fiber.Ctx) error {
+	size, err := transport.Query(c, "size", 5, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	suggestions, err := h.productService.SuggestProducts(c.UserContext(), c.Query("q"), c.Query("company"), size)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, suggestions, "Suggestions retrieved successfully")
+}
+
+// TypeaheadProducts handles GET requests for the products matching a partially typed name
+// @Summary     Typeahead Products
+// @Description Returns the best products whose name matches what the user has typed so far: every complete word must match and the last one may be a prefix, matched on a search_as_you_type field instead of wildcards; soft-deleted and excluded products aren't returned
+// @Tags        Products
+// @Produce     json
+// @Param       q    query string true "Partially typed product name"
+// @Param       size query int false "Number of products, 1-20 (default 5)"
+// @Param       omit_empty query bool false "Omit zero-value fields such as score 0 and unset timestamps"
+// @Success     200 {object} common.BaseResponse[[]models.Product]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/typeahead [get]
+func (h *ProductHandler) TypeaheadProducts(c fiber.Ctx) error {
+	size, err := transport.Query(c, "size", 5, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	omitEmpty, err := omitEmptyFields(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	products, err := h.productService.TypeaheadProducts(c.UserContext(), c.Query("q"), size)
+	if err != nil {
+		return err
+	}
+
+	presented, err := presentProducts(products, omitEmpty, nil)
+	if err != nil {
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to encode products", err)
+	}
+	return transport.Respond(c, fiber.StatusOK, presented, "Products retrieved successfully")
+}
+
+// TypeaheadGroups handles GET requests for the products, generics and companies matching a partially typed keyword
+// @Summary     Grouped Typeahead
+// @Description Returns what the user has typed so far grouped into sections: the best products whose name matches, and the generics and companies matching it with the most products. Every complete word must match and the last one may be a prefix; soft-deleted and excluded products aren't counted
+// @Tags        Products
+// @Produce     json
+// @Param       q    query string true "Partially typed keyword"
+// @Param       size query int false "Number of entries per section, 1-20 (default 5)"
+// @Success     200 {object} common.BaseResponse[models.TypeaheadGroups]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/typeahead/grouped [get]
+func (h *ProductHandler) TypeaheadGroups(c fiber.Ctx) error {
+	size, err := transport.Query(c, "size", 5, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	groups, err := h.productService.TypeaheadGroups(c.UserContext(), c.Query("q"), size)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, groups, "Typeahead results retrieved successfully")
+}
+
+// GetDistinctValues handles GET requests for the distinct values of a field
+// @Summary     Distinct Values
+// @Description Counts the distinct companies or drug generics of the products matching a keyword and filters and lists the first of them alphabetically, for populating dropdowns; the field ignores its own filter and the count is approximate above 3000 values
+// @Tags        Products
+// @Produce     json
+// @Param       field   query string true "Field to list the values of (fields: company, drug_generic)"
+// @Param       size    query int false "Number of values listed, 1-1000 (default 100)"
+// @Param       keyword query string false "Search keyword"
+// @Param       company query []string false "Only products of these companies (exact match, repeatable)" collectionFormat(multi)
+// @Param       drug_generic query []string false "Only products with these generic names (exact match, repeatable)" collectionFormat(multi)
+// @Param       id      query []string false "Only products with these IDs (repeatable)" collectionFormat(multi)
+// @Param       created_after  query string false "Only products created at or after this RFC3339 time"
+// @Param       created_before query string false "Only products created before this RFC3339 time"
+// @Param       updated_after  query string false "Only products updated at or after this RFC3339 time"
+// @Param       updated_before query string false "Only products updated before this RFC3339 time"
+// @Param       fuzziness query string false "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)"
+// @Param       search_fields query string false "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)"
+// @Param       mode    query string false "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)"
+// @Param       min_score query number false "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)"
+// @Param       syntax  query bool false "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)"
+// @Param       index   query string false "Search a non-default index or alias (admin only)"
+// @Param       include_deleted query bool false "Include soft-deleted products (admin only)"
+// @Success     200 {object} common.BaseResponse[models.DistinctValues]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     403 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/distinct [get]
+func (h *ProductHandler) GetDistinctValues(c fiber.Ctx) error {
+	field, err := models.ParseDistinctField(c.Query("field"))
+	if err != nil {
+		return transport.InvalidParam("field", err)
+	}
+
+	params, err := filterParams(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	size, err := transport.Query(c, "size", 100, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	values, err := h.productService.DistinctValues(c.UserContext(), params, field, size)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, values, "Distinct values retrieved successfully")
+}
+
+// SampleProducts handles GET requests for random products
+// @Summary     Sample Products
+// @Description Returns random products matching an optional keyword and filters, for QA spot checks and demo pages; pass a seed to get the same sample again while the index is unchanged
+// @Tags        Products
+// @Produce     json
+// @Param       size    query int false "Number of products, 1-100 (default 10)"
+// @Param       seed    query int false "Seed making the sample reproducible"
+// @Param       keyword query string false "Search keyword"
+// @Param       company query []string false "Only products of these companies (exact match, repeatable)" collectionFormat(multi)
+// @Param       drug_generic query []string false "Only products with these generic names (exact match, repeatable)" collectionFormat(multi)
+// @Param       id      query []string false "Only products with these IDs (repeatable)" collectionFormat(multi)
+// @Param       created_after  query string false "Only products created at or after this RFC3339 time"
+// @Param       created_before query string false "Only products created before this RFC3339 time"
+// @Param       updated_after  query string false "Only products updated at or after this RFC3339 time"
+// @Param       updated_before query string false "Only products updated before this RFC3339 time"
+// @Param       fuzziness query string false "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)"
+// @Param       search_fields query string false "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)"
+// @Param       mode    query string false "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)"
+// @Param       syntax  query bool false "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)"
+// @Param       index   query string false "Sample a non-default index or alias (admin only)"
+// @Param       include_deleted query bool false "Include soft-deleted products (admin only)"
+// @Param       omit_empty query bool false "Omit zero-value fields such as score 0 and unset timestamps"
+// @Success     200 {object} common.BaseResponse[[]models.Product]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     403 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/sample [get]
+func (h *ProductHandler) SampleProducts(c fiber.Ctx) error {
+	params, err := filterParams(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	size, err := transport.Query(c, "size", 10, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	seed, err := transport.Query(c, "seed", nil, func(raw string) (*int64, error) {
+		value, err := strconv.ParseInt(raw, 10, 64)
+		return &value, err
+	})
+	if err != nil {
+		return err
+	}
+
+	omitEmpty, err := omitEmptyFields(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	products, err := h.productService.SampleProducts(c.UserContext(), params, size, seed)
+	if err != nil {
+		return err
+	}
+
+	presented, err := presentProducts(products, omitEmpty, nil)
+	if err != nil {
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to encode products", err)
+	}
+	return transport.Respond(c, fiber.StatusOK, presented, "Products sampled successfully")
+}
+
+// GroupProducts handles GET requests for the best products of each company
+// @Summary     Products Grouped by Company
+// @Description Returns the best products of each of the companies with the most products matching a keyword and filters, most matches first, for comparison views; products within a company are ordered like search results
+// @Tags        Products
+// @Produce     json
+// @Param       size    query int false "Number of companies, 1-100 (default 10)"
+// @Param       per_company query int false "Number of products per company, 1-20 (default 3)"
+// @Param       keyword query string false "Search keyword"
+// @Param       company query []string false "Only products of these companies (exact match, repeatable)" collectionFormat(multi)
+// @Param       drug_generic query []string false "Only products with these generic names (exact match, repeatable)" collectionFormat(multi)
+// @Param       id      query []string false "Only products with these IDs (repeatable)" collectionFormat(multi)
+// @Param       created_after  query string false "Only products created at or after this RFC3339 time"
+// @Param       created_before query string false "Only products created before this RFC3339 time"
+// @Param       updated_after  query string false "Only products updated at or after this RFC3339 time"
+// @Param       updated_before query string false "Only products updated before this RFC3339 time"
+// @Param       fuzziness query string false "Edit distance allowed by the keyword match: 0, 1, 2, AUTO or off (default SEARCH_FUZZINESS)"
+// @Param       search_fields query string false "Comma-separated fields the keyword is matched against (fields: product_name, drug_generic, company; default all)"
+// @Param       mode    query string false "Keyword matching: fuzzy (default), phrase (match_phrase) or exact (whole field value, case-insensitive)"
+// @Param       min_score query number false "Drop keyword hits scoring below this relevance score, 0 keeps every hit (default SEARCH_MIN_SCORE)"
+// @Param       syntax  query bool false "Interpret AND, OR, NOT / -term and quoted phrases in the keyword (ignores mode and fuzziness)"
+// @Param       sort    query string false "Comma-separated sort of the products within a company, e.g. product_name:asc,created_at:desc (fields: id, product_name, drug_generic, company, score, created_at, updated_at)"
+// @Param       index   query string false "Search a non-default index or alias (admin only)"
+// @Param       include_deleted query bool false "Include soft-deleted products (admin only)"
+// @Success     200 {object} common.BaseResponse[models.ProductGroups]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     403 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/grouped [get]
+func (h *ProductHandler) GroupProducts(c fiber.Ctx) error {
+	params, err := filterParams(c, h.cfg)
+	if err != nil {
+		return err
+	}
+	if params.Sort, err = models.ParseSort(c.Query("sort")); err != nil {
+		return transport.InvalidParam("sort", err)
+	}
+
+	groups, err := transport.Query(c, "size", 10, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	perGroup, err := transport.Query(c, "per_company", 3, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	grouped, err := h.productService.GroupProducts(c.UserContext(), params, groups, perGroup)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, grouped, "Products grouped successfully")
+}
+
+// searchParams parses the parameters of a product search: keyword and filters, paging, sorting,
+// field selection and highlighting
+func searchParams(c fiber.Ctx, cfg *config.Config) (models.ProductSearchParams, error) {
+	params, err := filterParams(c, cfg)
+	if err != nil {
+		return params, err
+	}
+	params.PIT = c.Query("pit")
+
+	if params.Limit, err = transport.Query(c, "limit", 10, strconv.Atoi); err != nil {
+		return params, err
+	}
+	if params.Offset, err = transport.Query(c, "offset", 0, strconv.Atoi); err != nil {
+		return params, err
+	}
+	if params.Sort, err = models.ParseSort(c.Query("sort")); err != nil {
+		return params, transport.InvalidParam("sort", err)
+	}
+	if params.Fields, err = models.ParseFields(c.Query("fields")); err != nil {
+		return params, transport.InvalidParam("fields", err)
+	}
+	if params.Highlight, err = transport.Query(c, "highlight", false, strconv.ParseBool); err != nil {
+		return params, err
+	}
+	if params.TrackTotalHits, err = models.ParseTrackTotalHits(c.Query("track_total_hits", cfg.Search.TrackTotalHits)); err != nil {
+		return params, transport.InvalidParam("track_total_hits", err)
+	}
+	if params.Cursor, err = transport.Query(c, "cursor", nil, models.ParseCursor); err != nil {
+		return params, err
+	}
+	if params.Dedupe, err = transport.Query(c, "dedupe", cfg.Search.Dedupe, strconv.ParseBool); err != nil {
+		return params, err
+	}
+	if params.Facets, err = models.ParseFacets(c.Query("facets")); err != nil {
+		return params, transport.InvalidParam("facets", err)
+	}
+	params.FacetSize = cfg.Search.FacetSize
+	if params.MaxPerCompany, err = transport.Query(c, "max_per_company", cfg.Search.DiversityMaxPerCompany, models.ParseMaxPerCompany); err != nil {
+		return params, err
+	}
+
+	return params, nil
+}
+
+// filterParams parses the keyword and filter parameters that select products, without paging, sorting or
+// presentation
+func filterParams(c fiber.Ctx, cfg *config.Config) (models.ProductSearchParams, error) {
+	params := models.ProductSearchParams{
+		Keyword:      c.Query("keyword"),
+		Companies:    queryValues(c, "company"),
+		DrugGenerics: queryValues(c, "drug_generic"),
+		Index:        c.Query("index"),
+		// Indices built with edge n-grams match prefixes without wildcards
+		PrefixSubfields: cfg.Elasticsearch.EdgeNGram,
+	}
+	for _, id := range queryValues(c, "id") {
+		params.IDs = append(params.IDs, models.ProductID(id))
+	}
+
+	var err error
+	if params.CreatedAfter, err = queryTime(c, "created_after"); err != nil {
+		return params, err
+	}
+	if params.CreatedBefore, err = queryTime(c, "created_before"); err != nil {
+		return params, err
+	}
+	if params.UpdatedAfter, err = queryTime(c, "updated_after"); err != nil {
+		return params, err
+	}
+	if params.UpdatedBefore, err = queryTime(c, "updated_before"); err != nil {
+		return params, err
+	}
+	if params.Fuzziness, err = models.ParseFuzziness(c.Query("fuzziness", cfg.Search.Fuzziness)); err != nil {
+		return params, transport.InvalidParam("fuzziness", err)
+	}
+	if params.SearchFields, err = models.ParseSearchFields(c.Query("search_fields")); err != nil {
+		return params, transport.InvalidParam("search_fields", err)
+	}
+	if params.Mode, err = models.ParseSearchMode(c.Query("mode")); err != nil {
+		return params, transport.InvalidParam("mode", err)
+	}
+	if params.MinScore, err = transport.Query(c, "min_score", cfg.Search.MinScore, models.ParseMinScore); err != nil {
+		return params, err
+	}
+	if params.Syntax, err = transport.Query(c, "syntax", false, strconv.ParseBool); err != nil {
+		return params, err
+	}
+
+	// Querying a non-default index or alias is reserved for admins
+	if params.Index != "" && !middleware.IsAdmin(c, cfg.Admin) {
+		return params, transport.Fail(fiber.StatusForbidden, "Index override requires admin authentication", fiber.ErrForbidden)
+	}
 
-	// Return products with pagination info
-	response := common.NewPagedSuccess(result.Products, "Products retrieved successfully", pagination)
-	return c.JSON(response)
+	// Including soft-deleted products is reserved for admins
+	if params.IncludeDeleted, err = transport.Query(c, "include_deleted", false, strconv.ParseBool); err != nil {
+		return params, err
+	}
+	if params.IncludeDeleted && !middleware.IsAdmin(c, cfg.Admin) {
+		return params, transport.Fail(fiber.StatusForbidden, "Including deleted products requires admin authentication", fiber.ErrForbidden)
+	}
+
+	return params, nil
+}
+
+// CreateProduct handles POST requests to create a product
+// @Summary     Create Product
+// @Description Indexes a new product; when the ID is omitted one is assigned by the configured ID strategy (Elasticsearch by default)
+// @Tags        Products
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       product body models.CreateProductRequest true "Product to create"
+// @Param       omit_empty query bool false "Omit zero-value fields such as score 0 and unset timestamps"
+// @Success     201 {object} common.BaseResponse[models.Product]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     409 {object} common.BaseResponse[string]
+// @Router      /product [post]
+func (h *ProductHandler) CreateProduct(c fiber.Ctx) error {
+	req, err := transport.Body[models.CreateProductRequest](c)
+	if err != nil {
+		return err
+	}
+
+	omitEmpty, err := omitEmptyFields(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	product, err := h.productService.CreateProduct(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusCreated, presentProduct(product, omitEmpty), "Product created successfully")
+}
+
+// BulkCreateProducts handles POST requests to create many products at once
+// @Summary     Bulk Create Products
+// @Description Indexes an array of products with the bulk API and returns the outcome of every item; with all_or_nothing=true nothing is written when an item is invalid, and products written before another item failed are deleted again (best effort, reported in rollback)
+// @Tags        Products
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       products body []models.CreateProductRequest true "Products to create"
+// @Param       all_or_nothing query bool false "Revert the written products when any item fails"
+// @Success     200 {object} common.BaseResponse[models.BulkResult]
+// @Success     207 {object} common.BaseResponse[models.BulkResult]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Router      /product/bulk [post]
+func (h *ProductHandler) BulkCreateProducts(c fiber.Ctx) error {
+	reqs, err := transport.Body[[]models.CreateProductRequest](c)
+	if err != nil {
+		return err
+	}
+
+	allOrNothing, err := transport.Query(c, "all_or_nothing", false, strconv.ParseBool)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	result, err := h.productService.BulkCreateProducts(c.UserContext(), reqs, allOrNothing)
+	if err != nil {
+		return err
+	}
+
+	// Report partial failures with 207 Multi-Status
+	status := fiber.StatusOK
+	if result.Failed > 0 {
+		status = fiber.StatusMultiStatus
+	}
+	return transport.Respond(c, status, result, "Bulk create processed")
+}
+
+// GetProductByID handles GET requests to fetch a single product
+// @Summary     Get Product By ID
+// @Description Retrieves a single product by its document ID
+// @Tags        Products
+// @Produce     json
+// @Param       id path string true "Product ID"
+// @Param       omit_empty query bool false "Omit zero-value fields such as score 0 and unset timestamps"
+// @Success     200 {object} common.BaseResponse[models.Product]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/{id} [get]
+func (h *ProductHandler) GetProductByID(c fiber.Ctx) error {
+	omitEmpty, err := omitEmptyFields(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	product, err := h.productService.GetProductByID(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, presentProduct(product, omitEmpty), "Product retrieved successfully")
+}
+
+// GetProductsByIDs handles POST requests to fetch several products at once
+// @Summary     Batch Get Products
+// @Description Retrieves up to 100 products by document ID in request order; unknown IDs are listed as missing
+// @Tags        Products
+// @Accept      json
+// @Produce     json
+// @Param       ids body models.BatchGetRequest true "Product IDs to fetch"
+// @Param       omit_empty query bool false "Omit zero-value fields such as score 0 and unset timestamps"
+// @Success     200 {object} common.BaseResponse[models.BatchGetResult]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/mget [post]
+func (h *ProductHandler) GetProductsByIDs(c fiber.Ctx) error {
+	omitEmpty, err := omitEmptyFields(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	req, err := transport.Body[models.BatchGetRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	result, err := h.productService.GetProductsByIDs(c.UserContext(), req.IDs)
+	if err != nil {
+		return err
+	}
+
+	products, err := presentProducts(result.Products, omitEmpty, nil)
+	if err != nil {
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to encode products", err)
+	}
+
+	return transport.Respond(c, fiber.StatusOK, batchGetResponse{
+		Products: products,
+		Missing:  result.Missing,
+	}, "Products retrieved successfully")
+}
+
+// batchGetResponse is models.BatchGetResult with the products in their presented form
+type batchGetResponse struct {
+	Products any                `json:"products"`
+	Missing  []models.ProductID `json:"missing"`
+}
+
+// UpdateProduct handles PATCH requests to partially update a product
+// @Summary     Update Product
+// @Description Updates only the provided fields of a product using doc merge semantics
+// @Tags        Products
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       id      path string                      true "Product ID"
+// @Param       product body models.UpdateProductRequest true "Fields to update"
+// @Param       omit_empty query bool false "Omit zero-value fields such as score 0 and unset timestamps"
+// @Success     200 {object} common.BaseResponse[models.Product]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /product/{id} [patch]
+func (h *ProductHandler) UpdateProduct(c fiber.Ctx) error {
+	req, err := transport.Body[models.UpdateProductRequest](c)
+	if err != nil {
+		return err
+	}
+
+	omitEmpty, err := omitEmptyFields(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	product, err := h.productService.UpdateProduct(c.UserContext(), c.Params("id"), req)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, presentProduct(product, omitEmpty), "Product updated successfully")
+}
+
+// ReplaceProduct handles PUT requests setting all fields of a product
+// @Summary     Replace Product
+// @Description Sets all catalog fields of a product; with upsert=true the product is created when missing
+// @Tags        Products
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       id      path  string                      true  "Product ID"
+// @Param       upsert  query bool                        false "Create the product when it doesn't exist"
+// @Param       product body  models.CreateProductRequest true  "Product fields"
+// @Param       omit_empty query bool false "Omit zero-value fields such as score 0 and unset timestamps"
+// @Success     200 {object} common.BaseResponse[models.Product]
+// @Success     201 {object} common.BaseResponse[models.Product]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /product/{id} [put]
+func (h *ProductHandler) ReplaceProduct(c fiber.Ctx) error {
+	req, err := transport.Body[models.CreateProductRequest](c)
+	if err != nil {
+		return err
+	}
+
+	omitEmpty, err := omitEmptyFields(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	upsert, err := transport.Query(c, "upsert", false, strconv.ParseBool)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	product, created, err := h.productService.ReplaceProduct(c.UserContext(), c.Params("id"), req, upsert)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		return transport.Respond(c, fiber.StatusCreated, presentProduct(product, omitEmpty), "Product created successfully")
+	}
+	return transport.Respond(c, fiber.StatusOK, presentProduct(product, omitEmpty), "Product updated successfully")
+}
+
+// SoftDeleteProduct handles POST requests to soft-delete a product
+// @Summary     Soft Delete Product
+// @Description Sets deleted_at on a product so it no longer appears in searches
+// @Tags        Products
+// @Produce     json
+// @Security    AdminKey
+// @Param       id path string true "Product ID"
+// @Param       omit_empty query bool false "Omit zero-value fields such as score 0 and unset timestamps"
+// @Success     200 {object} common.BaseResponse[models.Product]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /product/{id}/soft-delete [post]
+func (h *ProductHandler) SoftDeleteProduct(c fiber.Ctx) error {
+	omitEmpty, err := omitEmptyFields(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	product, err := h.productService.SoftDeleteProduct(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, presentProduct(product, omitEmpty), "Product soft-deleted successfully")
+}
+
+// ClosePIT handles DELETE requests ending a pit session
+// @Summary     Close PIT
+// @Description Releases the point in time of a pit session before it expires
+// @Tags        Products
+// @Produce     json
+// @Param       pit query string true "pit token of the session"
+// @Success     200 {object} common.BaseResponse[string]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/pit [delete]
+func (h *ProductHandler) ClosePIT(c fiber.Ctx) error {
+	// Domain errors are translated into status codes by the Fiber error handler
+	pit := c.Query("pit")
+	if err := h.productService.ClosePIT(c.UserContext(), pit); err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, pit, "PIT closed successfully")
+}
+
+// DeleteProduct handles DELETE requests to remove a product
+// @Summary     Delete Product
+// @Description Removes a product document by ID
+// @Tags        Products
+// @Produce     json
+// @Security    AdminKey
+// @Param       id path string true "Product ID"
+// @Success     200 {object} common.BaseResponse[string]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /product/{id} [delete]
+func (h *ProductHandler) DeleteProduct(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	if err := h.productService.DeleteProduct(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, id, "Product deleted successfully")
+}
+
+// queryValues returns the non-empty values of a repeatable query parameter
+func queryValues(c fiber.Ctx, key string) []string {
+	var values []string
+	for _, value := range c.Request().URI().QueryArgs().PeekMulti(key) {
+		if v := strings.TrimSpace(string(value)); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// queryTime parses an optional RFC3339 query parameter; nil when it is absent
+func queryTime(c fiber.Ctx, key string) (*time.Time, error) {
+	return transport.Query(c, key, nil, func(value string) (*time.Time, error) {
+		t, err := time.Parse(time.RFC3339, value)
+		return &t, err
+	})
 }
 
 // RegisterProductRoutes registers routes for the ProductHandler
 func RegisterProductRoutes(app fiber.Router, cfg *config.Config, productService services.ProductService) {
 	handler := NewProductHandler(cfg, productService)
 	app.Get("/product", handler.GetProducts)
+	app.Get("/product/count", handler.CountProducts)
+	app.Get("/product/sample", handler.SampleProducts)
+	app.Get("/product/facets", handler.GetFacets)
+	app.Get("/product/grouped", handler.GroupProducts)
+	app.Get("/product/distinct", handler.GetDistinctValues)
+	app.Get("/product/suggest", handler.SuggestProducts)
+	app.Get("/product/typeahead", handler.TypeaheadProducts)
+	app.Get("/product/typeahead/grouped", handler.TypeaheadGroups)
+	app.Get("/product/related-generics", handler.GetRelatedGenerics)
+	app.Get("/product/export.csv", handler.ExportProducts)
+	app.Get("/product/:id", handler.GetProductByID)
+	app.Post("/product/mget", handler.GetProductsByIDs)
+	app.Delete("/product/pit", handler.ClosePIT)
+
+	// Writes and deletes require the admin API key
+	adminOnly := middleware.AdminOnly(cfg.Admin)
+	app.Post("/product", handler.CreateProduct, adminOnly)
+	app.Post("/product/bulk", handler.BulkCreateProducts, adminOnly)
+	app.Put("/product/:id", handler.ReplaceProduct, adminOnly)
+	app.Patch("/product/:id", handler.UpdateProduct, adminOnly)
+	app.Delete("/product/:id", handler.DeleteProduct, adminOnly)
+	app.Post("/product/:id/soft-delete", handler.SoftDeleteProduct, adminOnly)
 }
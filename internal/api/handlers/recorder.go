@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"elasticsearch/internal/api/transport"
+	_ "elasticsearch/internal/common" // response types of the swagger annotations
+	"elasticsearch/internal/recorder"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RecorderHandler handles admin requests controlling the search recorder
+type RecorderHandler struct {
+	recorder *recorder.Recorder
+}
+
+// NewRecorderHandler creates a new RecorderHandler
+func NewRecorderHandler(rec *recorder.Recorder) *RecorderHandler {
+	return &RecorderHandler{
+		recorder: rec,
+	}
+}
+
+// GetStatus handles GET requests for the recorder status
+// @Summary     Search Recorder Status
+// @Description Returns whether searches are being recorded, the output file and the number of recorded searches
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Success     200 {object} common.BaseResponse[recorder.Status]
+// @Router      /admin/recorder [get]
+func (h *RecorderHandler) GetStatus(c fiber.Ctx) error {
+	return transport.Respond(c, fiber.StatusOK, h.recorder.Status(), "Recorder status retrieved successfully")
+}
+
+// Start handles POST requests enabling the recorder
+// @Summary     Start Search Recorder
+// @Description Starts appending anonymized searches and their Elasticsearch responses to the recorder file
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Success     200 {object} common.BaseResponse[recorder.Status]
+// @Router      /admin/recorder/start [post]
+func (h *RecorderHandler) Start(c fiber.Ctx) error {
+	if err := h.recorder.Start(); err != nil {
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to start recorder", err)
+	}
+	return transport.Respond(c, fiber.StatusOK, h.recorder.Status(), "Recorder started")
+}
+
+// Stop handles POST requests disabling the recorder
+// @Summary     Stop Search Recorder
+// @Description Stops recording searches and closes the recorder file
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Success     200 {object} common.BaseResponse[recorder.Status]
+// @Router      /admin/recorder/stop [post]
+func (h *RecorderHandler) Stop(c fiber.Ctx) error {
+	if err := h.recorder.Stop(); err != nil {
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to stop recorder", err)
+	}
+	return transport.Respond(c, fiber.StatusOK, h.recorder.Status(), "Recorder stopped")
+}
+
+// RegisterRecorderRoutes registers routes for the RecorderHandler
+func RegisterRecorderRoutes(admin fiber.Router, rec *recorder.Recorder) {
+	handler := NewRecorderHandler(rec)
+	admin.Get("/recorder", handler.GetStatus)
+	admin.Post("/recorder/start", handler.Start)
+	admin.Post("/recorder/stop", handler.Stop)
+}
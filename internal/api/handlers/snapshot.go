@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"elasticsearch/internal/api/transport"
+	_ "elasticsearch/internal/common" // response types of the swagger annotations
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/services"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// SnapshotHandler handles admin requests capturing searches for support tickets
+type SnapshotHandler struct {
+	snapshotService services.SnapshotService
+	cfg             *config.Config
+}
+
+// NewSnapshotHandler creates a new SnapshotHandler
+func NewSnapshotHandler(cfg *config.Config, snapshotService services.SnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{
+		snapshotService: snapshotService,
+		cfg:             cfg,
+	}
+}
+
+// CreateSnapshot handles POST requests capturing a search
+// @Summary     Create Search Snapshot
+// @Description Runs a product search with the GET /product query parameters given in the query string (all of them are accepted, cursor and pit excepted) and stores the request, the effective Elasticsearch query and the results
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       keyword query string false "Search keyword"
+// @Param       company query []string false "Only products of these companies (exact match, repeatable)" collectionFormat(multi)
+// @Param       limit   query int false "Limit number of results"
+// @Param       offset  query int false "Offset for pagination"
+// @Param       sort    query string false "Comma-separated field:direction list, e.g. product_name:asc,created_at:desc"
+// @Param       snapshot body models.SnapshotRequest false "Note stored with the snapshot"
+// @Success     201 {object} common.BaseResponse[models.SearchSnapshot]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /admin/debug/snapshot [post]
+func (h *SnapshotHandler) CreateSnapshot(c fiber.Ctx) error {
+	params, err := searchParams(c, h.cfg)
+	if err != nil {
+		return err
+	}
+
+	// The note is optional, so is the body
+	req, err := transport.OptionalBody[models.SnapshotRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	request := string(c.Request().URI().QueryString())
+	snapshot, err := h.snapshotService.CreateSnapshot(c.UserContext(), params, request, req.Note)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusCreated, snapshot, "Search snapshot created successfully")
+}
+
+// GetSnapshot handles GET requests for a captured search
+// @Summary     Get Search Snapshot
+// @Description Returns a captured search with its request, effective Elasticsearch query and results
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       id path string true "Snapshot ID"
+// @Success     200 {object} common.BaseResponse[models.SearchSnapshot]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/debug/snapshot/{id} [get]
+func (h *SnapshotHandler) GetSnapshot(c fiber.Ctx) error {
+	// Domain errors are translated into status codes by the Fiber error handler
+	snapshot, err := h.snapshotService.GetSnapshot(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, snapshot, "Search snapshot retrieved successfully")
+}
+
+// RegisterSnapshotRoutes registers routes for the SnapshotHandler
+func RegisterSnapshotRoutes(admin fiber.Router, cfg *config.Config, snapshotService services.SnapshotService) {
+	handler := NewSnapshotHandler(cfg, snapshotService)
+	admin.Post("/debug/snapshot", handler.CreateSnapshot)
+	admin.Get("/debug/snapshot/:id", handler.GetSnapshot)
+}
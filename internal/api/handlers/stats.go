@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"elasticsearch/internal/api/transport"
+	_ "elasticsearch/internal/common" // response types of the swagger annotations
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// StatsHandler handles catalog statistics HTTP requests
+type StatsHandler struct {
+	statsService services.StatsService
+	cfg          *config.Config
+}
+
+// NewStatsHandler creates a new StatsHandler
+func NewStatsHandler(cfg *config.Config, statsService services.StatsService) *StatsHandler {
+	return &StatsHandler{
+		statsService: statsService,
+		cfg:          cfg,
+	}
+}
+
+// GetCatalogStats handles GET requests for catalog statistics
+// @Summary     Get Catalog Stats
+// @Description Returns total products, distinct companies and generics, update range and recent additions
+// @Tags        Stats
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} common.BaseResponse[models.CatalogStats]
+// @Router      /stats/catalog [get]
+func (h *StatsHandler) GetCatalogStats(c fiber.Ctx) error {
+	stats, err := h.statsService.GetCatalogStats(c.UserContext())
+	if err != nil {
+		return transport.Fail(fiber.StatusInternalServerError, "Failed to retrieve catalog stats", err)
+	}
+
+	return transport.Respond[models.CatalogStats](c, fiber.StatusOK, stats, "Catalog stats retrieved successfully")
+}
+
+// GetIndexStats handles GET requests for an operational overview of the product index
+// @Summary     Get Index Stats
+// @Description Returns the total documents, the document counts of the most frequent companies and drug generics, the range of creation times and the disk size of the product index
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       size query int false "Number of companies and drug generics listed, 1-1000 (default 10)"
+// @Success     200 {object} common.BaseResponse[models.IndexStats]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /admin/stats [get]
+func (h *StatsHandler) GetIndexStats(c fiber.Ctx) error {
+	size, err := transport.Query(c, "size", 10, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	stats, err := h.statsService.GetIndexStats(c.UserContext(), size)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, stats, "Index stats retrieved successfully")
+}
+
+// GetCreationHistogram handles GET requests for the number of products created over time
+// @Summary     Get Creation Histogram
+// @Description Returns the number of products created per calendar interval, oldest interval first; intervals without products are included with a zero count
+// @Tags        Stats
+// @Produce     json
+// @Param       interval       query string false "Calendar interval: day, week, month, quarter or year (default month)"
+// @Param       created_after  query string false "Only products created at or after this RFC3339 time"
+// @Param       created_before query string false "Only products created before this RFC3339 time"
+// @Success     200 {object} common.BaseResponse[models.CreationHistogram]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     500 {object} common.BaseResponse[string]
+// @Router      /stats/created [get]
+func (h *StatsHandler) GetCreationHistogram(c fiber.Ctx) error {
+	interval, err := transport.Query(c, "interval", "month", models.ParseHistogramInterval)
+	if err != nil {
+		return err
+	}
+
+	params := models.CreationHistogramParams{Interval: interval}
+	if params.CreatedAfter, err = queryTime(c, "created_after"); err != nil {
+		return err
+	}
+	if params.CreatedBefore, err = queryTime(c, "created_before"); err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	histogram, err := h.statsService.GetCreationHistogram(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, histogram, "Creation histogram retrieved successfully")
+}
+
+// RegisterStatsRoutes registers routes for the StatsHandler
+func RegisterStatsRoutes(app fiber.Router, cfg *config.Config, statsService services.StatsService) {
+	handler := NewStatsHandler(cfg, statsService)
+	app.Get("/stats/catalog", handler.GetCatalogStats)
+	app.Get("/stats/created", handler.GetCreationHistogram)
+}
+
+// RegisterAdminStatsRoutes registers the admin routes of the StatsHandler
+func RegisterAdminStatsRoutes(app fiber.Router, cfg *config.Config, statsService services.StatsService) {
+	handler := NewStatsHandler(cfg, statsService)
+	app.Get("/stats", handler.GetIndexStats)
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"elasticsearch/internal/api/transport"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// SynonymHandler handles admin requests managing search synonyms
+type SynonymHandler struct {
+	synonymService services.SynonymService
+}
+
+// NewSynonymHandler creates a new SynonymHandler
+func NewSynonymHandler(synonymService services.SynonymService) *SynonymHandler {
+	return &SynonymHandler{
+		synonymService: synonymService,
+	}
+}
+
+// GetRules handles GET requests listing synonym rules
+// @Summary     List Synonym Rules
+// @Description Lists the synonym rules expanding product searches and suggestions, in ID order
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       limit  query int false "Limit number of results"
+// @Param       offset query int false "Offset for pagination"
+// @Success     200 {object} common.PagedResponse[[]models.SynonymRule]
+// @Router      /admin/synonyms [get]
+func (h *SynonymHandler) GetRules(c fiber.Ctx) error {
+	limit, err := transport.Query(c, "limit", 10, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	offset, err := transport.Query(c, "offset", 0, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.synonymService.GetRules(c.UserContext(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	pagination := common.PaginationInfo{
+		Total:       result.TotalCount,
+		Limit:       result.Limit,
+		Offset:      result.Offset,
+		CurrentPage: result.CurrentPage,
+		TotalPages:  result.TotalPages,
+	}
+
+	return transport.RespondPaged(c, common.NewPagedSuccess(result.Rules, "Synonym rules retrieved successfully", pagination))
+}
+
+// GetRule handles GET requests for a single synonym rule
+// @Summary     Get Synonym Rule
+// @Description Returns a synonym rule by ID
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       id path string true "Rule ID"
+// @Success     200 {object} common.BaseResponse[models.SynonymRule]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/synonyms/{id} [get]
+func (h *SynonymHandler) GetRule(c fiber.Ctx) error {
+	// Domain errors are translated into status codes by the Fiber error handler
+	rule, err := h.synonymService.GetRule(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, rule, "Synonym rule retrieved successfully")
+}
+
+// CreateRule handles POST requests creating a synonym rule
+// @Summary     Create Synonym Rule
+// @Description Makes searches for any of the terms match the others; applied to live indices without reindexing
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       rule body models.SynonymRuleRequest true "Rule to create"
+// @Success     201 {object} common.BaseResponse[models.SynonymRule]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Router      /admin/synonyms [post]
+func (h *SynonymHandler) CreateRule(c fiber.Ctx) error {
+	req, err := transport.Body[models.SynonymRuleRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	rule, err := h.synonymService.CreateRule(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusCreated, rule, "Synonym rule created successfully")
+}
+
+// ReplaceRule handles PUT requests replacing a synonym rule
+// @Summary     Replace Synonym Rule
+// @Description Replaces the terms of a synonym rule
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    AdminKey
+// @Param       id   path string                      true "Rule ID"
+// @Param       rule body models.SynonymRuleRequest true "Rule contents"
+// @Success     200 {object} common.BaseResponse[models.SynonymRule]
+// @Failure     400 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/synonyms/{id} [put]
+func (h *SynonymHandler) ReplaceRule(c fiber.Ctx) error {
+	req, err := transport.Body[models.SynonymRuleRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	rule, err := h.synonymService.ReplaceRule(c.UserContext(), c.Params("id"), req)
+	if err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, rule, "Synonym rule updated successfully")
+}
+
+// DeleteRule handles DELETE requests removing a synonym rule
+// @Summary     Delete Synonym Rule
+// @Description Removes a synonym rule
+// @Tags        Admin
+// @Produce     json
+// @Security    AdminKey
+// @Param       id path string true "Rule ID"
+// @Success     200 {object} common.BaseResponse[string]
+// @Failure     404 {object} common.BaseResponse[string]
+// @Router      /admin/synonyms/{id} [delete]
+func (h *SynonymHandler) DeleteRule(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	// Domain errors are translated into status codes by the Fiber error handler
+	if err := h.synonymService.DeleteRule(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return transport.Respond(c, fiber.StatusOK, id, "Synonym rule deleted successfully")
+}
+
+// RegisterSynonymRoutes registers routes for the SynonymHandler
+func RegisterSynonymRoutes(admin fiber.Router, synonymService services.SynonymService) {
+	handler := NewSynonymHandler(synonymService)
+	admin.Get("/synonyms", handler.GetRules)
+	admin.Post("/synonyms", handler.CreateRule)
+	admin.Get("/synonyms/:id", handler.GetRule)
+	admin.Put("/synonyms/:id", handler.ReplaceRule)
+	admin.Delete("/synonyms/:id", handler.DeleteRule)
+}
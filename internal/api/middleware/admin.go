@@ -0,0 +1,57 @@
+// Package middleware provides HTTP middleware shared by the API routes
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/config"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/keyauth"
+)
+
+// errAdminDisabled is returned when no admin API key is configured
+var errAdminDisabled = errors.New("admin API is disabled: ADMIN_API_KEY is not configured")
+
+// adminKeyLookup extracts the admin key from the "Authorization: Bearer <key>" header
+var adminKeyLookup = keyauth.KeyFromHeader(fiber.HeaderAuthorization, "Bearer")
+
+// AdminOnly rejects requests that don't carry the configured admin API key
+func AdminOnly(cfg config.AdminConfig) fiber.Handler {
+	return keyauth.New(keyauth.Config{
+		CustomKeyLookup: adminKeyLookup,
+		Validator: func(_ fiber.Ctx, key string) (bool, error) {
+			if cfg.APIKey == "" {
+				return false, errAdminDisabled
+			}
+			return validAdminKey(cfg, key), nil
+		},
+		ErrorHandler: func(c fiber.Ctx, err error) error {
+			if err == nil {
+				err = keyauth.ErrMissingOrMalformedAPIKey
+			}
+			return c.Status(fiber.StatusUnauthorized).JSON(common.NewError("Admin authentication required", err))
+		},
+	})
+}
+
+// IsAdmin reports whether the request carries the configured admin API key,
+// for public routes that unlock extra options for admins
+func IsAdmin(c fiber.Ctx, cfg config.AdminConfig) bool {
+	if cfg.APIKey == "" {
+		return false
+	}
+
+	key, err := adminKeyLookup(c)
+	if err != nil {
+		return false
+	}
+	return validAdminKey(cfg, key)
+}
+
+// validAdminKey compares the key in constant time
+func validAdminKey(cfg config.AdminConfig, key string) bool {
+	return subtle.ConstantTimeCompare([]byte(cfg.APIKey), []byte(key)) == 1
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/config"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/valyala/fasthttp"
+)
+
+// RouteTimeouts bounds the time handlers may spend on a request, with a separate limit per route group:
+// admin routes, bulk writes, CSV exports and everything else (public search and single-product routes).
+// Handlers see the deadline through c.UserContext(); a request that runs past it gets a 504.
+func RouteTimeouts(cfg config.ServerConfig) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		group, timeout := routeTimeout(c.Method(), c.Path(), cfg)
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return c.Status(fiber.StatusGatewayTimeout).JSON(common.NewError("Request timed out",
+				fmt.Errorf("%s routes time out after %s", group, timeout)))
+		}
+		return err
+	}
+}
+
+// IOTimeouts returns the fasthttp HeaderReceived hook raising the read timeout of the request body and the write
+// timeout of the response to the timeout of the route group, so SERVER_READ_TIMEOUT_SEC and
+// SERVER_WRITE_TIMEOUT_SEC don't cut off groups allowed to run longer. Headers are still read within
+// SERVER_READ_TIMEOUT_SEC, as the route isn't known before.
+func IOTimeouts(cfg config.ServerConfig) func(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+	return func(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+		path, _, _ := strings.Cut(string(header.RequestURI()), "?")
+		_, timeout := routeTimeout(string(header.Method()), path, cfg)
+
+		return fasthttp.RequestConfig{
+			ReadTimeout:  max(timeout, time.Duration(cfg.ReadTimeoutSec)*time.Second),
+			WriteTimeout: max(timeout, time.Duration(cfg.WriteTimeoutSec)*time.Second),
+		}
+	}
+}
+
+// routeTimeout returns the route group of a request and its timeout
+func routeTimeout(method, path string, cfg config.ServerConfig) (string, time.Duration) {
+	switch {
+	case path == "/admin" || strings.HasPrefix(path, "/admin/"):
+		return "admin", time.Duration(cfg.AdminTimeoutSec) * time.Second
+	case method == fiber.MethodPost && path == "/product/bulk":
+		return "bulk", time.Duration(cfg.BulkTimeoutSec) * time.Second
+	case method == fiber.MethodGet && path == "/product/export.csv":
+		return "export", time.Duration(cfg.ExportTimeoutSec) * time.Second
+	default:
+		return "search", time.Duration(cfg.SearchTimeoutSec) * time.Second
+	}
+}
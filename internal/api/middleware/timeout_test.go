@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"elasticsearch/internal/config"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// slowLines is the number of lines slowBody writes, one every slowLineInterval
+const (
+	slowLines        = 10
+	slowLineInterval = 200 * time.Millisecond
+)
+
+// slowBody streams slowLines lines, taking longer than a second in total
+func slowBody() io.Reader {
+	reader, writer := io.Pipe()
+	go func() {
+		for i := 0; i < slowLines; i++ {
+			time.Sleep(slowLineInterval)
+			if _, err := fmt.Fprintf(writer, "line %d\n", i); err != nil {
+				return
+			}
+		}
+		writer.Close()
+	}()
+	return reader
+}
+
+// serve starts app on a local port, as the write timeouts of app.Test don't apply to its in-memory connection
+func serve(t *testing.T, app *fiber.App) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go app.Listener(listener, fiber.ListenConfig{DisableStartupMessage: true})
+	t.Cleanup(func() { app.Shutdown() })
+	return "http://" + listener.Addr().String()
+}
+
+// readLines requests url and counts the lines of the response, returning the error that broke it off, if any
+func readLines(url string) (int, error) {
+	res, err := http.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines, scanner.Err()
+}
+
+func TestGroupTimeoutAboveWriteTimeout(t *testing.T) {
+	cfg := config.ServerConfig{ReadTimeoutSec: 1, WriteTimeoutSec: 1, SearchTimeoutSec: 1, BulkTimeoutSec: 1, AdminTimeoutSec: 5}
+	app := fiber.New(fiber.Config{
+		ReadTimeout:  time.Duration(cfg.ReadTimeoutSec) * time.Second,
+		WriteTimeout: time.Duration(cfg.WriteTimeoutSec) * time.Second,
+	})
+	app.Server().HeaderReceived = IOTimeouts(cfg)
+	app.Use(RouteTimeouts(cfg))
+	stream := func(c fiber.Ctx) error {
+		return c.SendStream(slowBody())
+	}
+	app.Get("/admin/stream", stream)
+	app.Get("/product/stream", stream)
+	url := serve(t, app)
+
+	type result struct {
+		lines int
+		err   error
+	}
+	admin, search := make(chan result, 1), make(chan result, 1)
+	go func() {
+		lines, err := readLines(url + "/admin/stream")
+		admin <- result{lines, err}
+	}()
+	go func() {
+		lines, err := readLines(url + "/product/stream")
+		search <- result{lines, err}
+	}()
+
+	// The admin group may write for 5s, longer than SERVER_WRITE_TIMEOUT_SEC
+	if got := <-admin; got.err != nil || got.lines != slowLines {
+		t.Errorf("admin response read %d of %d lines (%v), want all of them", got.lines, slowLines, got.err)
+	}
+	// The search group keeps the 1s write timeout, so the same response is broken off
+	if got := <-search; got.err == nil && got.lines == slowLines {
+		t.Errorf("search response read all %d lines, want it broken off by the write timeout", slowLines)
+	}
+}
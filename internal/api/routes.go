@@ -1,36 +1,243 @@
 package api
 
 import (
+	"context"
+	"elasticsearch/internal/analytics"
 	"elasticsearch/internal/api/handlers"
+	"elasticsearch/internal/api/middleware"
 	"elasticsearch/internal/config"
+	"elasticsearch/internal/enrichment"
+	"elasticsearch/internal/highlight"
+	"elasticsearch/internal/idgen"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/outbox"
+	"elasticsearch/internal/recorder"
+	"elasticsearch/internal/search/rewrite"
 	"elasticsearch/internal/services"
-	"log"
-	"os"
+	"fmt"
+	"time"
 
 	storageEs "elasticsearch/internal/storage/elasticsearch"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gofiber/fiber/v3"
+	fiberlog "github.com/gofiber/fiber/v3/log"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"github.com/valyala/fasthttp/fasthttpadaptor"
 )
 
-func RegisterRoute(cfg *config.Config, app *fiber.App, es *elasticsearch.Client) {
+// swaggerSpecPath is the swagger document generated by swag init
+const swaggerSpecPath = "./docs/swagger.json"
+
+func RegisterRoute(cfg *config.Config, app *fiber.App, es *elasticsearch.Client) error {
+	// Create the enrichment chain applied before API writes
+	enricher, err := enrichment.NewChain(cfg.Enrichment.Chain)
+	if err != nil {
+		return err
+	}
+
+	// Create the rewrite rules applied to search keywords
+	rewriter, err := rewrite.NewChain(cfg.Rewrite.Rules)
+	if err != nil {
+		return err
+	}
+
+	// Reject unknown highlight formats and fuzziness settings at startup
+	if _, err := highlight.ParseFormat(cfg.Search.HighlightFormat); err != nil {
+		return err
+	}
+	if _, err := models.ParseFuzziness(cfg.Search.Fuzziness); err != nil {
+		return err
+	}
+	if _, err := models.ParseTrackTotalHits(cfg.Search.TrackTotalHits); err != nil {
+		return err
+	}
+	if _, err := models.ParseKeepAlive(cfg.Search.PITKeepAlive); err != nil {
+		return err
+	}
+	if cfg.Search.DiversityMaxPerCompany < 0 {
+		return fmt.Errorf("invalid search diversity max per company %d, expected 0 or more", cfg.Search.DiversityMaxPerCompany)
+	}
+	if cfg.Search.DiversityTopK < 1 || cfg.Search.DiversityTopK > services.DiversityWindow {
+		return fmt.Errorf("invalid search diversity top K %d, expected 1-%d", cfg.Search.DiversityTopK, services.DiversityWindow)
+	}
+	if cfg.Search.FacetSize < 1 || cfg.Search.FacetSize > services.MaxFacetSize {
+		return fmt.Errorf("invalid search facet size %d, expected 1-%d", cfg.Search.FacetSize, services.MaxFacetSize)
+	}
+	if cfg.Search.MinScore < 0 {
+		return fmt.Errorf("invalid search min score %g, expected a non-negative number", cfg.Search.MinScore)
+	}
+	documentLimit, err := models.NewDocumentLimit(cfg.Document.MaxBytes, cfg.Document.OversizePolicy)
+	if err != nil {
+		return err
+	}
+	if cfg.Server.SearchTimeoutSec <= 0 || cfg.Server.BulkTimeoutSec <= 0 || cfg.Server.AdminTimeoutSec <= 0 || cfg.Server.ExportTimeoutSec <= 0 {
+		return fmt.Errorf("invalid route timeouts (search %ds, bulk %ds, admin %ds, export %ds), expected positive numbers",
+			cfg.Server.SearchTimeoutSec, cfg.Server.BulkTimeoutSec, cfg.Server.AdminTimeoutSec, cfg.Server.ExportTimeoutSec)
+	}
+	// Offset pages must fit in the result window of the index
+	indexWindow := cfg.Elasticsearch.MaxResultWindow
+	if indexWindow < 0 {
+		return fmt.Errorf("invalid max result window %d, expected 0 (Elasticsearch default) or a positive number", indexWindow)
+	}
+	if indexWindow == 0 {
+		indexWindow = storageEs.DefaultMaxResultWindow
+	}
+	if cfg.Search.MaxResultWindow < 1 || cfg.Search.MaxResultWindow > indexWindow {
+		return fmt.Errorf("invalid search max result window %d, expected 1-%d (the index max_result_window)", cfg.Search.MaxResultWindow, indexWindow)
+	}
+	if cfg.Elasticsearch.EdgeNGram && (cfg.Elasticsearch.EdgeNGramMinGram < 1 || cfg.Elasticsearch.EdgeNGramMaxGram < cfg.Elasticsearch.EdgeNGramMinGram) {
+		return fmt.Errorf("invalid edge n-gram sizes %d-%d, expected 1 <= min gram <= max gram",
+			cfg.Elasticsearch.EdgeNGramMinGram, cfg.Elasticsearch.EdgeNGramMaxGram)
+	}
+	if cfg.Analytics.RollupHour < 0 || cfg.Analytics.RollupHour > 23 {
+		return fmt.Errorf("invalid analytics rollup hour %d, expected 0-23", cfg.Analytics.RollupHour)
+	}
+	if _, err := models.ParseRequiredFields(cfg.Completeness.RequiredFields); err != nil {
+		return err
+	}
+	// Failing IDs are read in a single search, bounded by the default index.max_result_window
+	if cfg.Completeness.MaxReportedIDs < 1 || cfg.Completeness.MaxReportedIDs > 10000 {
+		return fmt.Errorf("invalid completeness max reported IDs %d, expected 1-10000", cfg.Completeness.MaxReportedIDs)
+	}
+	if cfg.Canary.TopK < 1 || cfg.Canary.TopK > 100 {
+		return fmt.Errorf("invalid canary top K %d, expected 1-100", cfg.Canary.TopK)
+	}
+	if cfg.Canary.MinOverlap < 0 || cfg.Canary.MinOverlap > 1 {
+		return fmt.Errorf("invalid canary min overlap %g, expected 0-1", cfg.Canary.MinOverlap)
+	}
+	if cfg.Canary.MaxCountChange < 0 {
+		return fmt.Errorf("invalid canary max count change %g, expected 0 or more", cfg.Canary.MaxCountChange)
+	}
+	if cfg.Canary.OnFailure != services.CanaryOnFailureFail && cfg.Canary.OnFailure != services.CanaryOnFailureAlert {
+		return fmt.Errorf("invalid canary on failure %q, expected %s or %s", cfg.Canary.OnFailure, services.CanaryOnFailureFail, services.CanaryOnFailureAlert)
+	}
+
+	// Raise the result window of the live index for trusted deployments
+	if cfg.Elasticsearch.MaxResultWindow > 0 {
+		if err := storageEs.SetMaxResultWindow(context.Background(), es, cfg.Elasticsearch.Index, cfg.Elasticsearch.MaxResultWindow); err != nil {
+			return fmt.Errorf("failed to set max result window on %s: %w", cfg.Elasticsearch.Index, err)
+		}
+	}
+
+	// Searches rely on the subfields of the current mapping; older indices keep working with fewer features
+	mappings, err := storageEs.GetIndexMappings(context.Background(), es, cfg.Elasticsearch.Index)
+	if err != nil {
+		fiberlog.Warnf("Failed to read the mapping of %s: %v", cfg.Elasticsearch.Index, err)
+	}
+	for index, mapping := range mappings {
+		if mapping.Version < storageEs.ProductMappingVersion || mapping.EdgeNGram != cfg.Elasticsearch.EdgeNGram {
+			fiberlog.Warnf("Index %s has mapping version %d (edge n-grams %t), expected %d (edge n-grams %t); rebuild it with --reindex or a new import",
+				index, mapping.Version, mapping.EdgeNGram, storageEs.ProductMappingVersion, cfg.Elasticsearch.EdgeNGram)
+		}
+	}
+
 	// Create repositories
-	productRepo := storageEs.NewElasticsearchProductRepository(es, "products")
+	productRepo := storageEs.NewElasticsearchProductRepository(es, cfg.Elasticsearch.Index)
+	productRepo.SetPITKeepAlive(cfg.Search.PITKeepAlive)
+
+	// Attach the search recorder, started immediately when enabled in config
+	searchRecorder := recorder.New(cfg.Recorder.Path)
+	if cfg.Recorder.Enabled {
+		if err := searchRecorder.Start(); err != nil {
+			return err
+		}
+	}
+	productRepo.SetRecorder(searchRecorder)
+	statsRepo := storageEs.NewElasticsearchStatsRepository(es, cfg.Elasticsearch.Index)
+	importHistoryRepo := storageEs.NewElasticsearchImportHistoryRepository(es, storageEs.ImportHistoryIndex)
+	if err := importHistoryRepo.EnsureIndex(context.Background()); err != nil {
+		fiberlog.Warnf("Failed to create the import history index and its %s alias: %v", storageEs.ImportsAlias, err)
+	}
+	curationRepo := storageEs.NewElasticsearchCurationRepository(es, storageEs.CurationIndex)
+	exclusionRepo := storageEs.NewElasticsearchExclusionRepository(es, storageEs.ExclusionIndex)
+	synonymRepo := storageEs.NewElasticsearchSynonymRepository(es, storageEs.ProductSynonymSet)
+	analyticsRepo := storageEs.NewElasticsearchAnalyticsRepository(es, storageEs.SearchLogIndex, storageEs.SearchAnalyticsIndex)
+	snapshotRepo := storageEs.NewElasticsearchSnapshotRepository(es, storageEs.SnapshotIndex)
 
 	// Create services
-	productService := services.NewProductService(productRepo)
+	productService := services.NewProductService(productRepo, cfg.Search, enricher)
+	productService.SetDocumentLimit(documentLimit)
+	if len(rewriter) > 0 {
+		productService.SetQueryRewriter(rewriter)
+	}
+	idGenerator, err := idgen.New(cfg.ID.Strategy, cfg.ID.SnowflakeNode, storageEs.NewElasticsearchSequenceRepository(es, storageEs.SequenceIndex))
+	if err != nil {
+		return err
+	}
+	if idGenerator != nil {
+		productService.SetIDGenerator(idGenerator)
+	}
+	statsService := services.NewStatsService(statsRepo)
+	importHistoryService := services.NewImportHistoryService(importHistoryRepo)
+	importService := services.NewImportService(es, cfg, importHistoryRepo)
+	if cfg.ProductCache.Enabled {
+		productCache := services.NewProductCache(cfg.ProductCache)
+		productService.SetProductCache(productCache)
+		importService.SetProductCache(productCache)
+	}
+	if cfg.AggCache.Enabled {
+		aggCache := services.NewAggregationCache(cfg.AggCache)
+		productService.SetAggregationCache(aggCache)
+		statsService.SetAggregationCache(aggCache)
+		importService.SetAggregationCache(aggCache)
+	}
+	// Autocomplete reads the suggest index, built in the background on first start
+	if cfg.Elasticsearch.SuggestIndex {
+		suggestIndexRepo := storageEs.NewElasticsearchSuggestIndexRepository(es, storageEs.SuggestIndex)
+		productService.SetSuggestIndex(suggestIndexRepo)
+		importService.SetSuggestIndex(suggestIndexRepo)
 
-	// Create handlers
-	app.Get("/docs/swagger.json", func(c fiber.Ctx) error {
-		file, err := os.ReadFile("./docs/swagger.json")
+		targets, err := storageEs.AliasTargets(context.Background(), es, storageEs.SuggestIndex)
 		if err != nil {
-			log.Println("Failed to read swagger.json:", err)
-			return c.Status(500).SendString("Failed to load swagger.json")
+			return err
 		}
-		return c.Send(file)
-	})
+		if len(targets) == 0 {
+			go importService.RebuildSuggestIndex(context.Background())
+		}
+	}
+	curationService := services.NewCurationService(curationRepo)
+	productService.SetPinLookup(curationService)
+	exclusionService := services.NewExclusionService(exclusionRepo)
+	productService.SetExclusionLookup(exclusionService)
+	synonymService := services.NewSynonymService(synonymRepo)
+	analyticsService := services.NewAnalyticsService(analyticsRepo)
+	snapshotService := services.NewSnapshotService(productService, snapshotRepo)
+
+	// Record change events and deliver them to the configured sinks in the background
+	if cfg.Outbox.Enabled {
+		outboxRepo := storageEs.NewElasticsearchOutboxRepository(es, storageEs.OutboxIndex)
+		productService.SetPublisher(outbox.New(outboxRepo))
+		importService.SetPublisher(outbox.New(outboxRepo))
+
+		dispatcher := outbox.NewDispatcher(outboxRepo, outbox.SinksFromConfig(cfg.Outbox), time.Duration(cfg.Outbox.PollIntervalSec)*time.Second)
+		ctx, stopDispatcher := context.WithCancel(context.Background())
+		go dispatcher.Run(ctx)
+		app.Hooks().OnShutdown(func() error {
+			stopDispatcher()
+			return nil
+		})
+	}
+
+	// Log searches and roll them up into daily summaries in the background
+	if cfg.Analytics.Enabled {
+		searchLogger := analytics.NewLogger(analyticsRepo)
+		productService.SetSearchLogger(searchLogger)
+		productService.SetPopularQueries(analyticsService)
+
+		rollup := analytics.NewRollupJob(analyticsRepo, cfg.Analytics.RollupHour, cfg.Analytics.RetentionDays)
+		ctx, stopAnalytics := context.WithCancel(context.Background())
+		go searchLogger.Run(ctx)
+		go rollup.Run(ctx)
+		app.Hooks().OnShutdown(func() error {
+			stopAnalytics()
+			return nil
+		})
+	}
+
+	// Create handlers
+	// The published document only describes the public surface; admins get theirs under /admin
+	handlers.RegisterPublicDocsRoutes(app, swaggerSpecPath)
 
 	app.Get("/swagger/*", func(c fiber.Ctx) error {
 		fasthttpadaptor.NewFastHTTPHandler(httpSwagger.Handler(httpSwagger.URL("http://localhost:8080/docs/swagger.json")))(c.Context())
@@ -38,5 +245,28 @@ func RegisterRoute(cfg *config.Config, app *fiber.App, es *elasticsearch.Client)
 	})
 
 	app.Get("/health", handlers.Health)
+
+	// Every route below runs under the timeout of its route group
+	app.Use(middleware.RouteTimeouts(cfg.Server))
 	handlers.RegisterProductRoutes(app, cfg, productService)
+	handlers.RegisterStatsRoutes(app, cfg, statsService)
+
+	// The admin UI shell is public; its API calls carry the admin API key
+	handlers.RegisterAdminUIRoutes(app)
+
+	// Admin routes require the admin API key
+	admin := app.Group("/admin", middleware.AdminOnly(cfg.Admin))
+	handlers.RegisterAdminDocsRoutes(admin, swaggerSpecPath)
+	handlers.RegisterRecorderRoutes(admin, searchRecorder)
+	handlers.RegisterImportRoutes(admin, importService)
+	handlers.RegisterImportHistoryRoutes(admin, importHistoryService)
+	handlers.RegisterCurationRoutes(admin, curationService)
+	handlers.RegisterExclusionRoutes(admin, exclusionService)
+	handlers.RegisterSynonymRoutes(admin, synonymService)
+	handlers.RegisterAnalyticsRoutes(admin, analyticsService)
+	handlers.RegisterSnapshotRoutes(admin, cfg, snapshotService)
+	handlers.RegisterAdminStatsRoutes(admin, cfg, statsService)
+	handlers.RegisterMetricsRoutes(admin)
+
+	return nil
 }
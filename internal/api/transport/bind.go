@@ -0,0 +1,46 @@
+package transport
+
+import (
+	"github.com/gofiber/fiber/v3"
+)
+
+// Validator is implemented by request types that check their own fields once decoded
+type Validator interface {
+	Validate() error
+}
+
+// Body decodes the request body into a T and validates it when T implements Validator
+func Body[T any](c fiber.Ctx) (T, error) {
+	var value T
+	if err := c.Bind().Body(&value); err != nil {
+		return value, InvalidBody(err)
+	}
+	if validator, ok := any(&value).(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return value, InvalidBody(err)
+		}
+	}
+	return value, nil
+}
+
+// OptionalBody is Body for requests whose body may be left empty, leaving the zero T
+func OptionalBody[T any](c fiber.Ctx) (T, error) {
+	if len(c.Body()) == 0 {
+		var value T
+		return value, nil
+	}
+	return Body[T](c)
+}
+
+// Query parses the named query parameter with parse, or returns fallback when it is absent
+func Query[T any](c fiber.Ctx, name string, fallback T, parse func(string) (T, error)) (T, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := parse(raw)
+	if err != nil {
+		return fallback, InvalidParam(name, err)
+	}
+	return value, nil
+}
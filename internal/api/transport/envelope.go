@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"elasticsearch/internal/common"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// rawProfile is the Accept profile clients can send to opt out of the response envelope
+const rawProfile = `profile="raw"`
+
+// wantsEnvelope reports whether the client expects the standard response wrapper.
+// Clients opt out with ?envelope=false or an Accept header carrying profile="raw".
+func wantsEnvelope(c fiber.Ctx) bool {
+	if envelope := c.Query("envelope"); envelope != "" {
+		if enabled, err := strconv.ParseBool(envelope); err == nil {
+			return enabled
+		}
+	}
+
+	return !strings.Contains(c.Get(fiber.HeaderAccept), rawProfile)
+}
+
+// Respond writes data in the standard envelope with the given status
+func Respond[T any](c fiber.Ctx, status int, data T, message string) error {
+	return c.Status(status).JSON(common.NewSuccess(data, message))
+}
+
+// RespondPaged writes a paged response either as is or, in raw mode, as the bare data
+// with pagination exposed through X-Total-Count, X-Next-Cursor, X-PIT and Link headers, warnings through X-Search-Warnings
+// and search metadata through X-Search-Took-Ms, X-Search-Timed-Out and X-Search-Max-Score
+func RespondPaged[T any](c fiber.Ctx, response *common.PagedResponse[T]) error {
+	if wantsEnvelope(c) {
+		return c.JSON(response)
+	}
+
+	c.Set("X-Total-Count", strconv.FormatInt(response.Pagination.Total, 10))
+	if response.Pagination.TotalRelation != "" {
+		c.Set("X-Total-Count-Relation", response.Pagination.TotalRelation)
+	}
+	if response.NextCursor != "" {
+		c.Set("X-Next-Cursor", response.NextCursor)
+	}
+	if response.PIT != "" {
+		c.Set("X-PIT", response.PIT)
+	}
+	if link := buildLinkHeader(c, response.Pagination, response.NextCursor, response.PIT); link != "" {
+		c.Set(fiber.HeaderLink, link)
+	}
+	if len(response.Warnings) > 0 {
+		c.Set("X-Search-Warnings", strings.Join(response.Warnings, "; "))
+	}
+	if search := response.Search; search != nil {
+		c.Set("X-Search-Took-Ms", strconv.FormatInt(search.TookMs, 10))
+		c.Set("X-Search-Timed-Out", strconv.FormatBool(search.TimedOut))
+		if search.MaxScore != nil {
+			c.Set("X-Search-Max-Score", strconv.FormatFloat(*search.MaxScore, 'f', -1, 64))
+		}
+	}
+
+	return c.JSON(response.Data)
+}
+
+// buildLinkHeader builds an RFC 8288 Link header with first, prev, next and last page URLs.
+// Cursor-paginated requests only link the next page, through nextCursor; pit sessions link pages of pit.
+func buildLinkHeader(c fiber.Ctx, pagination common.PaginationInfo, nextCursor, pit string) string {
+	if pagination.Limit <= 0 {
+		return ""
+	}
+
+	query := url.Values{}
+	c.Request().URI().QueryArgs().VisitAll(func(key, value []byte) {
+		query.Add(string(key), string(value))
+	})
+
+	if query.Has("cursor") {
+		if nextCursor == "" {
+			return ""
+		}
+		query.Set("cursor", nextCursor)
+		return fmt.Sprintf("<%s%s?%s>", c.BaseURL(), c.Path(), query.Encode()) + `; rel="next"`
+	}
+	if pit != "" {
+		query.Set("pit", pit)
+	}
+
+	pageURL := func(offset int) string {
+		query.Set("offset", strconv.Itoa(offset))
+		return fmt.Sprintf("<%s%s?%s>", c.BaseURL(), c.Path(), query.Encode())
+	}
+
+	var links []string
+	links = append(links, pageURL(0)+`; rel="first"`)
+
+	if pagination.Offset > 0 {
+		prev := pagination.Offset - pagination.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, pageURL(prev)+`; rel="prev"`)
+	}
+
+	if int64(pagination.Offset+pagination.Limit) < pagination.Total {
+		links = append(links, pageURL(pagination.Offset+pagination.Limit)+`; rel="next"`)
+	}
+
+	// The last page is unknown when hit counting was capped
+	if pagination.TotalPages > 0 && pagination.TotalRelation == "" {
+		links = append(links, pageURL((pagination.TotalPages-1)*pagination.Limit)+`; rel="last"`)
+	}
+
+	return strings.Join(links, ", ")
+}
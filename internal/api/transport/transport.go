@@ -0,0 +1,40 @@
+// Package transport binds HTTP requests to typed values and renders typed responses in the standard
+// envelope, so handlers only deal with parsed input and service results
+package transport
+
+import (
+	"github.com/gofiber/fiber/v3"
+)
+
+// Error is a request that can't be served as sent. Returned from a handler, it is answered by the Fiber
+// error handler with Status and an error response carrying Message and the cause.
+type Error struct {
+	Status  int
+	Message string
+	Err     error
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Fail returns an Error answered with status and message
+func Fail(status int, message string, err error) error {
+	return &Error{Status: status, Message: message, Err: err}
+}
+
+// InvalidParam returns the 400 Error of a query or path parameter that couldn't be parsed
+func InvalidParam(name string, err error) error {
+	return Fail(fiber.StatusBadRequest, "Invalid "+name+" parameter", err)
+}
+
+// InvalidBody returns the 400 Error of a request body that couldn't be decoded or failed validation
+func InvalidBody(err error) error {
+	return Fail(fiber.StatusBadRequest, "Invalid request body", err)
+}
@@ -0,0 +1,155 @@
+// Package apidocs derives role-scoped OpenAPI documents from the generated swagger spec
+package apidocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Scope selects the part of the API a document describes
+type Scope string
+
+const (
+	// ScopePublic covers the public search surface published to partners
+	ScopePublic Scope = "public"
+	// ScopeAdmin covers the internal /admin endpoints and the operations of public paths requiring the admin key
+	ScopeAdmin Scope = "admin"
+)
+
+// AdminPrefix is the path prefix of the admin route group
+const AdminPrefix = "/admin"
+
+// AdminSecurity is the security definition of operations requiring the admin API key
+const AdminSecurity = "AdminKey"
+
+// adminOnlyMarker tags parameters of public routes that only admins may use
+const adminOnlyMarker = "(admin only)"
+
+// Filter returns the swagger document restricted to the given scope. Operations outside the scope, paths left
+// without operations, admin-only parameters of public routes and definitions no longer referenced are removed.
+func Filter(spec []byte, scope Scope) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse swagger document: %w", err)
+	}
+
+	paths, _ := doc["paths"].(map[string]any)
+	for path, item := range paths {
+		operations, _ := item.(map[string]any)
+		for method, operation := range operations {
+			if !inScope(path, operation, scope) {
+				delete(operations, method)
+			}
+		}
+		if len(operations) == 0 {
+			delete(paths, path)
+			continue
+		}
+		if scope == ScopePublic {
+			stripAdminParameters(item)
+		}
+	}
+
+	// The admin key security definition only matters to the admin document
+	if scope == ScopePublic {
+		delete(doc, "securityDefinitions")
+	}
+
+	pruneDefinitions(doc)
+
+	return json.MarshalIndent(doc, "", "    ")
+}
+
+// inScope reports whether an operation of a path belongs to the scope. Every /admin operation is an admin one, as
+// is any operation requiring the admin key, such as the product writes.
+func inScope(path string, operation any, scope Scope) bool {
+	isAdmin := path == AdminPrefix || strings.HasPrefix(path, AdminPrefix+"/") || requiresAdminKey(operation)
+	if scope == ScopeAdmin {
+		return isAdmin
+	}
+	return !isAdmin
+}
+
+// requiresAdminKey reports whether an operation lists the admin key among its security requirements
+func requiresAdminKey(operation any) bool {
+	op, _ := operation.(map[string]any)
+	requirements, _ := op["security"].([]any)
+	for _, requirement := range requirements {
+		if schemes, ok := requirement.(map[string]any); ok {
+			if _, ok := schemes[AdminSecurity]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stripAdminParameters removes parameters documented as admin only from every operation of a path
+func stripAdminParameters(item any) {
+	operations, _ := item.(map[string]any)
+	for _, operation := range operations {
+		op, ok := operation.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		params, _ := op["parameters"].([]any)
+		kept := params[:0]
+		for _, param := range params {
+			p, _ := param.(map[string]any)
+			if description, _ := p["description"].(string); strings.Contains(description, adminOnlyMarker) {
+				continue
+			}
+			kept = append(kept, param)
+		}
+		if params != nil {
+			op["parameters"] = kept
+		}
+	}
+}
+
+// pruneDefinitions drops definitions that are not reachable from the remaining paths
+func pruneDefinitions(doc map[string]any) {
+	definitions, _ := doc["definitions"].(map[string]any)
+	if definitions == nil {
+		return
+	}
+
+	used := map[string]bool{}
+	queue := collectRefs(doc["paths"], nil)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if used[name] {
+			continue
+		}
+		used[name] = true
+		queue = collectRefs(definitions[name], queue)
+	}
+
+	for name := range definitions {
+		if !used[name] {
+			delete(definitions, name)
+		}
+	}
+}
+
+// collectRefs appends the definition names referenced anywhere inside node
+func collectRefs(node any, refs []string) []string {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if ref, ok := value.(string); ok && key == "$ref" {
+				refs = append(refs, strings.TrimPrefix(ref, "#/definitions/"))
+				continue
+			}
+			refs = collectRefs(value, refs)
+		}
+	case []any:
+		for _, value := range v {
+			refs = collectRefs(value, refs)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,59 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testSpec = `{
+	"securityDefinitions": {"AdminKey": {"type": "apiKey", "name": "Authorization", "in": "header"}},
+	"paths": {
+		"/product": {
+			"get": {"responses": {}},
+			"post": {"security": [{"AdminKey": []}], "responses": {}}
+		},
+		"/product/bulk": {
+			"post": {"security": [{"AdminKey": []}], "responses": {}}
+		},
+		"/admin/stats": {
+			"get": {"security": [{"AdminKey": []}], "responses": {}}
+		}
+	}
+}`
+
+func TestFilterScopesOperationsRequiringTheAdminKey(t *testing.T) {
+	tests := []struct {
+		scope Scope
+		want  map[string][]string
+	}{
+		{ScopePublic, map[string][]string{"/product": {"get"}}},
+		{ScopeAdmin, map[string][]string{"/product": {"post"}, "/product/bulk": {"post"}, "/admin/stats": {"get"}}},
+	}
+
+	for _, tt := range tests {
+		filtered, err := Filter([]byte(testSpec), tt.scope)
+		if err != nil {
+			t.Fatalf("Filter(%s) returned %v", tt.scope, err)
+		}
+		var doc struct {
+			Paths map[string]map[string]any `json:"paths"`
+		}
+		if err := json.Unmarshal(filtered, &doc); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(doc.Paths) != len(tt.want) {
+			t.Errorf("Filter(%s) kept paths %v, want %v", tt.scope, doc.Paths, tt.want)
+		}
+		for path, methods := range tt.want {
+			if len(doc.Paths[path]) != len(methods) {
+				t.Errorf("Filter(%s) kept %v of %s, want %v", tt.scope, doc.Paths[path], path, methods)
+			}
+			for _, method := range methods {
+				if _, ok := doc.Paths[path][method]; !ok {
+					t.Errorf("Filter(%s) dropped %s %s", tt.scope, method, path)
+				}
+			}
+		}
+	}
+}
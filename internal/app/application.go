@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"os/signal"
@@ -9,6 +10,8 @@ import (
 	"time"
 
 	"elasticsearch/internal/api"
+	"elasticsearch/internal/api/middleware"
+	"elasticsearch/internal/api/transport"
 	"elasticsearch/internal/common"
 	"elasticsearch/internal/config"
 
@@ -43,11 +46,13 @@ func New(cfg *config.Config) (*Application, error) {
 	app.fiberApp = initFiber(cfg)
 
 	// Setup routes
-	api.RegisterRoute(
+	if err := api.RegisterRoute(
 		cfg,
 		app.fiberApp,
 		app.esClient,
-	)
+	); err != nil {
+		return nil, err
+	}
 
 	return app, nil
 }
@@ -120,8 +125,13 @@ func initFiber(cfg *config.Config) *fiber.App {
 		ReadTimeout:  time.Duration(cfg.Server.ReadTimeoutSec) * time.Second,
 		WriteTimeout: time.Duration(cfg.Server.WriteTimeoutSec) * time.Second,
 		IdleTimeout:  time.Duration(cfg.Server.IdleTimeoutSec) * time.Second,
+		// Import files are uploaded in the request body
+		BodyLimit: max(fiber.DefaultBodyLimit, cfg.Import.UploadMaxMB*1024*1024),
 	})
 
+	// Route groups running longer than the server-wide read and write timeouts get their own
+	app.Server().HeaderReceived = middleware.IOTimeouts(cfg.Server)
+
 	// Apply middleware
 	app.Use(
 		logger.New(logger.Config{}),
@@ -137,10 +147,27 @@ func createErrorHandler() func(c fiber.Ctx, err error) error {
 		code := fiber.StatusInternalServerError
 		message := "Internal Server Error"
 
-		// Get specific status code if it's a Fiber error
-		if e, ok := err.(*fiber.Error); ok {
-			code = e.Code
-			message = e.Message
+		// Requests rejected by the transport layer carry their own status and message
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) {
+			return c.Status(transportErr.Status).JSON(common.NewError(transportErr.Message, transportErr.Err))
+		}
+
+		// Get specific status code if it's a Fiber error or a known domain error
+		var fiberErr *fiber.Error
+		switch {
+		case errors.As(err, &fiberErr):
+			code = fiberErr.Code
+			message = fiberErr.Message
+		case errors.Is(err, common.ErrNotFound):
+			code = fiber.StatusNotFound
+			message = "Not Found"
+		case errors.Is(err, common.ErrConflict):
+			code = fiber.StatusConflict
+			message = "Conflict"
+		case errors.Is(err, common.ErrValidation):
+			code = fiber.StatusBadRequest
+			message = "Bad Request"
 		}
 
 		// Set the status code
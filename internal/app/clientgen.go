@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"elasticsearch/internal/clientgen"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// GenerateClientsOptions holds the command-line options of a client generation run
+type GenerateClientsOptions struct {
+	SpecPath string
+	OutDir   string
+	Check    bool
+}
+
+// GenerateClients regenerates the typed API clients from the OpenAPI spec, or with Check only verifies they are up to date
+func GenerateClients(opts GenerateClientsOptions) error {
+	if opts.Check {
+		stale, err := clientgen.Stale(opts.SpecPath, opts.OutDir)
+		if err != nil {
+			return err
+		}
+		if len(stale) > 0 {
+			return fmt.Errorf("clients are out of date with %s, run --gen-client: %s", opts.SpecPath, strings.Join(stale, ", "))
+		}
+		fiberlog.Infof("✅ Clients in %s are up to date with %s", opts.OutDir, opts.SpecPath)
+		return nil
+	}
+
+	written, err := clientgen.Generate(opts.SpecPath, opts.OutDir)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range written {
+		fiberlog.Infof("Generated %s", path)
+	}
+	fiberlog.Infof("✅ Generated %d clients from %s", len(written), opts.SpecPath)
+	return nil
+}
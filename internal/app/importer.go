@@ -1,16 +1,106 @@
 package app
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"elasticsearch/internal/config"
+	"elasticsearch/internal/importer"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/outbox"
+	"elasticsearch/internal/services"
 	"elasticsearch/internal/storage/elasticsearch"
 
 	fiberlog "github.com/gofiber/fiber/v3/log"
 )
 
+// ImportOptions selects a staged import: the source is loaded into TargetIndex and checked, and the
+// live alias is only swapped to it with Promote. An empty TargetIndex imports straight into the live index.
+type ImportOptions struct {
+	TargetIndex string
+	Promote     bool
+	// MappingFile overrides IMPORT_MAPPING_FILE for this import
+	MappingFile string
+	// Mode overrides IMPORT_MODE for this import
+	Mode string
+	// DuplicatePolicy overrides IMPORT_DUPLICATE_POLICY for this import
+	DuplicatePolicy string
+	// IDPolicy overrides IMPORT_ID_POLICY for this import
+	IDPolicy string
+	// BatchSize and FailOnError override IMPORT_BATCH_SIZE and IMPORT_FAIL_ON_ERROR for this import when set
+	BatchSize   int
+	FailOnError int
+	// RejectReport overrides IMPORT_REJECT_REPORT for this import
+	RejectReport string
+	// Source overrides how the source is opened
+	Source SourceFlags
+}
+
+// SourceFlags holds the command-line options of the import source
+type SourceFlags struct {
+	// Format forces the source format; empty detects it from the path
+	Format string
+	// CSVDelimiter, CSVQuote and CSVEncoding override IMPORT_CSV_DELIMITER, IMPORT_CSV_QUOTE and IMPORT_CSV_ENCODING
+	CSVDelimiter string
+	CSVQuote     string
+	CSVEncoding  string
+	// Sheet selects a worksheet by name or 1-based position; AllSheets imports every worksheet instead
+	Sheet     string
+	AllSheets bool
+}
+
+// apply overrides the import configuration with the set flags
+func (f SourceFlags) apply(cfg *config.Config) {
+	cfg.Import.Format = f.Format
+	cfg.Import.Sheet = f.Sheet
+	cfg.Import.AllSheets = f.AllSheets
+	if f.CSVDelimiter != "" {
+		cfg.Import.CSVDelimiter = f.CSVDelimiter
+	}
+	if f.CSVQuote != "" {
+		cfg.Import.CSVQuote = f.CSVQuote
+	}
+	if f.CSVEncoding != "" {
+		cfg.Import.CSVEncoding = f.CSVEncoding
+	}
+}
+
 // ImportExcel handles importing data from an Excel file into Elasticsearch
-func ImportExcel(cfg *config.Config, importPath string) error {
+func ImportExcel(cfg *config.Config, importPath string, triggeredBy string, opts ImportOptions) error {
+	if opts.Promote && opts.TargetIndex == "" {
+		return fmt.Errorf("--promote requires --target-index")
+	}
+
+	ctx := context.Background()
+
+	if opts.MappingFile != "" {
+		cfg.Import.MappingFile = opts.MappingFile
+	}
+	if opts.Mode != "" {
+		cfg.Import.Mode = opts.Mode
+	}
+	if opts.DuplicatePolicy != "" {
+		cfg.Import.DuplicatePolicy = opts.DuplicatePolicy
+	}
+	if opts.IDPolicy != "" {
+		cfg.Import.IDPolicy = opts.IDPolicy
+	}
+	if opts.RejectReport != "" {
+		cfg.Import.RejectReport = opts.RejectReport
+	}
+	if opts.BatchSize > 0 {
+		cfg.Import.BatchSize = opts.BatchSize
+	}
+	if opts.FailOnError > 0 {
+		cfg.Import.FailOnError = opts.FailOnError
+	}
+	opts.Source.apply(cfg)
+
 	// Create temporary client for import
 	esClient, err := elasticsearch.NewClient(elasticsearch.Config{
 		Addresses: cfg.Elasticsearch.Addresses,
@@ -22,11 +112,220 @@ func ImportExcel(cfg *config.Config, importPath string) error {
 		return err
 	}
 
-	fiberlog.Info("📥 Importing spreadsheet from", importPath, "with index:", cfg.Elasticsearch.Index)
-	if err := elasticsearch.ImportFromExcel(esClient.Client, cfg.Elasticsearch.Index, importPath); err != nil {
+	historyRepo := elasticsearch.NewElasticsearchImportHistoryRepository(esClient.Client, elasticsearch.ImportHistoryIndex)
+	importService := services.NewImportService(esClient.Client, cfg, historyRepo)
+
+	// Imported products go to the outbox; the server's dispatcher delivers them
+	if cfg.Outbox.Enabled {
+		importService.SetPublisher(outbox.New(elasticsearch.NewElasticsearchOutboxRepository(esClient.Client, elasticsearch.OutboxIndex)))
+	}
+	if cfg.Elasticsearch.SuggestIndex {
+		importService.SetSuggestIndex(elasticsearch.NewElasticsearchSuggestIndexRepository(esClient.Client, elasticsearch.SuggestIndex))
+	}
+
+	if opts.TargetIndex != "" {
+		return runStagedImport(ctx, cfg, importService, importPath, triggeredBy, opts)
+	}
+
+	run, err := importService.RunImport(ctx, importPath, triggeredBy)
+	if err != nil {
+		return err
+	}
+
+	logDuplicates(run.Duplicates)
+	fiberlog.Infof("✅ Import complete: %d rows read, %d indexed, %d skipped, %d failed, %d duplicate ids, %d near duplicates in %dms",
+		run.RowsRead, run.Indexed, run.RowsSkipped, run.Failed, run.DuplicateIDs, run.NearDuplicates, run.DurationMs)
+	return nil
+}
+
+// DryRunImport reads and validates the whole import source without connecting to Elasticsearch. It logs the row
+// counts, rejected rows and duplicate IDs and prints the full report, including the index mapping that would be
+// created, as JSON.
+func DryRunImport(cfg *config.Config, importPath string, opts ImportOptions) error {
+	if opts.Promote {
+		return fmt.Errorf("--promote can't be combined with --dry-run")
+	}
+
+	if opts.MappingFile != "" {
+		cfg.Import.MappingFile = opts.MappingFile
+	}
+	if opts.DuplicatePolicy != "" {
+		cfg.Import.DuplicatePolicy = opts.DuplicatePolicy
+	}
+	if opts.IDPolicy != "" {
+		cfg.Import.IDPolicy = opts.IDPolicy
+	}
+	if opts.RejectReport != "" {
+		cfg.Import.RejectReport = opts.RejectReport
+	}
+	opts.Source.apply(cfg)
+
+	report, err := services.DryRunImport(context.Background(), cfg, importPath, opts.TargetIndex)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range report.Sources {
+		if source.Error != "" {
+			fiberlog.Warnf("Skipping %s: %s", source.Name, source.Error)
+		}
+	}
+	for _, rejected := range report.Rejected {
+		fiberlog.Warnf("❌ %s row %d: %s", rejected.Source, rejected.Row, rejected.Reason)
+	}
+	if report.RowsRejected > len(report.Rejected) {
+		fiberlog.Warnf("... and %d more rejected rows", report.RowsRejected-len(report.Rejected))
+	}
+	logDuplicates(report.Duplicates)
+	if report.DuplicatePolicy == importer.DuplicatesFail && report.DuplicateIDs+report.NearDuplicates > 0 {
+		fiberlog.Warnf("❌ The import would stop at the first duplicate under the %s duplicate policy", report.DuplicatePolicy)
+	}
+
+	fiberlog.Infof("✅ Dry run complete: %d rows read, %d valid, %d rejected, %d truncated, %d duplicate ids, %d near duplicates; nothing was written to %s",
+		report.RowsRead, report.RowsValid, report.RowsRejected, report.RowsTruncated, report.DuplicateIDs, report.NearDuplicates, report.Index)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// logDuplicates logs the rows found repeating an earlier row
+func logDuplicates(duplicates []models.ImportDuplicate) {
+	for _, duplicate := range duplicates {
+		rows := make([]string, len(duplicate.Rows))
+		for i, row := range duplicate.Rows {
+			rows[i] = fmt.Sprintf("%s row %d", row.Source, row.Row)
+		}
+		if duplicate.Kind == models.DuplicateKindID {
+			fiberlog.Warnf("Duplicate id %s on %s", duplicate.Value, strings.Join(rows, ", "))
+		} else {
+			fiberlog.Warnf("Near duplicate %s on %s", duplicate.Value, strings.Join(rows, ", "))
+		}
+	}
+}
+
+// runStagedImport loads the source into the staging index, logs the outcome of every check and promotes it if asked
+func runStagedImport(ctx context.Context, cfg *config.Config, importService services.ImportService, importPath string, triggeredBy string, opts ImportOptions) error {
+	run, err := importService.RunStagedImport(ctx, importPath, triggeredBy, opts.TargetIndex, opts.Promote)
+	logDuplicates(run.Duplicates)
+	for _, check := range run.Checks {
+		status := "✅"
+		if !check.Passed {
+			status = "❌"
+		}
+		fiberlog.Infof("%s %s: %s", status, check.Name, check.Detail)
+	}
+	if err != nil {
+		return err
+	}
+
+	fiberlog.Infof("✅ Staged import complete: %d rows read, %d indexed, %d skipped, %d failed into %s in %dms",
+		run.RowsRead, run.Indexed, run.RowsSkipped, run.Failed, run.Index, run.DurationMs)
+	if run.Promoted {
+		fiberlog.Infof("✅ Promoted %s behind %s", run.Index, cfg.Elasticsearch.Index)
+	} else {
+		fiberlog.Infof("All checks passed; %s was left unchanged (import with --promote to swap it)", cfg.Elasticsearch.Index)
+	}
+	return nil
+}
+
+// Reindex rebuilds the live catalog in a new index created with the current mapping and swaps the alias to it
+func Reindex(cfg *config.Config) error {
+	esClient, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: cfg.Elasticsearch.Addresses,
+		Username:  cfg.Elasticsearch.Username,
+		Password:  cfg.Elasticsearch.Password,
+		Timeout:   time.Duration(cfg.Elasticsearch.TimeoutSec) * time.Second,
+	})
+	if err != nil {
+		return err
+	}
+
+	historyRepo := elasticsearch.NewElasticsearchImportHistoryRepository(esClient.Client, elasticsearch.ImportHistoryIndex)
+	importService := services.NewImportService(esClient.Client, cfg, historyRepo)
+	if cfg.Elasticsearch.SuggestIndex {
+		importService.SetSuggestIndex(elasticsearch.NewElasticsearchSuggestIndexRepository(esClient.Client, elasticsearch.SuggestIndex))
+	}
+
+	fiberlog.Infof("Building a new index with mapping version %d", elasticsearch.ProductMappingVersion)
+	run, err := importService.Reindex(context.Background())
+	for _, failure := range run.Failures {
+		fiberlog.Warnf("❌ %s", failure)
+	}
+	if err != nil {
 		return err
 	}
 
-	fiberlog.Info("✅ Import complete")
+	fiberlog.Infof("✅ Reindexed %d products from %s into %s in %dms; %s now points to %s",
+		run.Created, strings.Join(run.Previous, ", "), run.Index, run.TookMs, cfg.Elasticsearch.Index, run.Index)
 	return nil
 }
+
+// InspectOptions holds the command-line options of an import inspection
+type InspectOptions struct {
+	SampleRows int
+	// MappingFile is where the best-guess column mapping is written
+	MappingFile string
+	// Attributes proposes an attribute for every column no product field is read from
+	Attributes bool
+	Source     SourceFlags
+}
+
+// InspectImport reads the header and a sample of rows of an import source without indexing anything. It logs the
+// detected columns, prints the inspection as JSON and writes the guessed mapping to a file that can be edited and
+// passed back with --import-mapping.
+func InspectImport(cfg *config.Config, importPath string, opts InspectOptions) error {
+	if opts.SampleRows < 1 {
+		return fmt.Errorf("--inspect-rows must be at least 1")
+	}
+
+	opts.Source.apply(cfg)
+	sourceOpts, err := services.SourceOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	source, err := importer.OpenSource(context.Background(), importPath, sourceOpts)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	inspection, err := importer.Inspect(source, opts.SampleRows)
+	if err != nil {
+		return err
+	}
+	if opts.Attributes {
+		inspection.ProposeAttributes()
+	}
+
+	for _, column := range inspection.Columns {
+		mappedTo := "-"
+		if column.MappedTo != "" {
+			mappedTo = column.MappedTo
+		}
+		fiberlog.Infof("%-24s %-8s filled %5.1f%%  -> %-13s e.g. %s",
+			column.Name, column.Type, column.FillRate*100, mappedTo, strings.Join(column.Samples, " | "))
+	}
+	if len(inspection.Unmapped) > 0 {
+		fiberlog.Warnf("No column found for %s; fill them in %s before importing",
+			strings.Join(inspection.Unmapped, ", "), opts.MappingFile)
+		if slices.Contains(inspection.Unmapped, "id") {
+			fiberlog.Info("Sources without an id column can be imported with --id-policy=hash or --id-policy=elasticsearch")
+		}
+	}
+
+	if err := inspection.Mapping.Save(opts.MappingFile); err != nil {
+		return err
+	}
+	fiberlog.Infof("✅ Inspected %d rows of %s; mapping written to %s (import with --import-mapping=%s)",
+		inspection.RowsSampled, inspection.Source, opts.MappingFile, opts.MappingFile)
+	if len(inspection.Mapping.Attributes) > 0 {
+		fiberlog.Infof("%d columns are kept as attributes, mapped as attribute_mapping below on import",
+			len(inspection.Mapping.Attributes))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(inspection)
+}
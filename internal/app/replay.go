@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/recorder"
+	"elasticsearch/internal/storage/elasticsearch"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// ReplayOptions holds the command-line options of a replay run
+type ReplayOptions struct {
+	Path    string
+	Targets string
+	Index   string
+	TopK    int
+}
+
+// ReplaySearches re-executes recorded searches against the target cluster and prints a JSON diff report
+func ReplaySearches(cfg *config.Config, opts ReplayOptions) error {
+	addresses := cfg.Elasticsearch.Addresses
+	if opts.Targets != "" {
+		addresses = strings.Split(opts.Targets, ",")
+	}
+
+	esClient, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: addresses,
+		Username:  cfg.Elasticsearch.Username,
+		Password:  cfg.Elasticsearch.Password,
+		Timeout:   time.Duration(cfg.Elasticsearch.TimeoutSec) * time.Second,
+	})
+	if err != nil {
+		return err
+	}
+
+	summary, err := recorder.Replay(context.Background(), esClient.Client, opts.Path, recorder.ReplayOptions{
+		Index: opts.Index,
+		TopK:  opts.TopK,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, diff := range summary.Diffs {
+		if diff.Error != "" {
+			fiberlog.Warnf("Line %d: replay failed: %s", diff.Line, diff.Error)
+		} else if !diff.OrderUnchanged || diff.RecordedTotal != diff.ReplayedTotal {
+			fiberlog.Warnf("Line %d: overlap %.2f, total %d -> %d, missing %v, added %v",
+				diff.Line, diff.Overlap, diff.RecordedTotal, diff.ReplayedTotal, diff.Missing, diff.Added)
+		}
+	}
+
+	fiberlog.Infof("✅ Replayed %d searches: %d identical, %d errors, average top-%d overlap %.2f",
+		summary.Replayed, summary.Identical, summary.Errors, opts.TopK, summary.AverageOverlap)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(summary)
+}
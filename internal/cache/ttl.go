@@ -0,0 +1,103 @@
+// Package cache provides a small in-process cache with per-entry expiry
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// TTL is a concurrency-safe map whose entries expire after the TTL they were stored with.
+// When full, expired entries are dropped first, then arbitrary ones.
+type TTL[K comparable, V any] struct {
+	mu         sync.Mutex
+	entries    map[K]entry[V]
+	maxEntries int
+}
+
+// NewTTL creates a TTL cache holding at most maxEntries entries
+func NewTTL[K comparable, V any](maxEntries int) *TTL[K, V] {
+	return &TTL[K, V]{
+		entries:    make(map[K]entry[V]),
+		maxEntries: maxEntries,
+	}
+}
+
+// Get returns the value stored for key, if present and not expired
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !time.Now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores value for key until ttl has passed
+func (c *TTL[K, V]) Set(key K, value V, ttl time.Duration) {
+	if ttl <= 0 || c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: time.Now().Add(ttl)}
+}
+
+// Delete removes the entries for the given keys
+func (c *TTL[K, V]) Delete(keys ...K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+// Clear removes every entry
+func (c *TTL[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+// Len returns the number of stored entries, including expired ones not yet dropped
+func (c *TTL[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// evict makes room for one entry; callers must hold mu
+func (c *TTL[K, V]) evict() {
+	now := time.Now()
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	// Map iteration order is random, so this drops an arbitrary entry
+	for key := range c.entries {
+		if len(c.entries) < c.maxEntries {
+			break
+		}
+		delete(c.entries, key)
+	}
+}
@@ -0,0 +1,85 @@
+// Package clientgen generates typed Go and TypeScript clients from the service's Swagger 2.0 spec
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// GoClientFile and TypeScriptClientFile are the generated files, relative to the output directory
+const (
+	GoClientFile         = "goclient/client.go"
+	TypeScriptClientFile = "typescript/client.ts"
+)
+
+// Render builds every client from the spec at specPath, keyed by path relative to the output directory
+func Render(specPath string) (map[string][]byte, error) {
+	spec, err := LoadSpec(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := buildModel(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	source := filepath.ToSlash(specPath)
+	goClient, err := renderGo(m, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		GoClientFile:         goClient,
+		TypeScriptClientFile: renderTypeScript(m, source),
+	}, nil
+}
+
+// Generate writes every client into outDir
+func Generate(specPath, outDir string) ([]string, error) {
+	files, err := Render(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	written := make([]string, 0, len(files))
+	for _, name := range sortedKeys(files) {
+		path := filepath.Join(outDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+// Stale returns the client files in outDir that differ from what the spec generates
+func Stale(specPath, outDir string) ([]string, error) {
+	files, err := Render(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for name, want := range files {
+		path := filepath.Join(outDir, name)
+		got, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if !bytes.Equal(got, want) {
+			stale = append(stale, path)
+		}
+	}
+	sort.Strings(stale)
+
+	return stale, nil
+}
@@ -0,0 +1,333 @@
+package clientgen
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// goRuntime is the hand-written part of the Go client shared by every operation
+const goRuntime = `
+// Client calls the API over HTTP
+type Client struct {
+	// BaseURL is the scheme and host of the service, e.g. http://localhost:8080
+	BaseURL string
+	// AdminKey is sent as a Bearer token on admin operations
+	AdminKey string
+	// HTTPClient sends the requests, http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// New creates a client for the service at baseURL
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// APIError is returned for every non-2xx response
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+// Ptr returns a pointer to v, for optional query parameters
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// do sends a request and decodes a 2xx JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, admin bool, out any) error {
+	endpoint := c.BaseURL + basePath + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if admin && c.AdminKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AdminKey)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		data, _ := io.ReadAll(res.Body)
+		return &APIError{StatusCode: res.StatusCode, Body: data}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+`
+
+// renderGo renders the Go client
+func renderGo(m *model, specPath string) ([]byte, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "// Code generated by server --gen-client from %s. DO NOT EDIT.\n\n", specPath)
+	fmt.Fprintf(&b, "// Package goclient is a typed client for the %s API (version %s)\n", m.Title, m.Version)
+	b.WriteString("package goclient\n\n")
+
+	b.WriteString("import (\n\t\"bytes\"\n\t\"context\"\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n\t\"net/url\"\n\t\"strings\"\n")
+	if usesTime(m) {
+		b.WriteString("\t\"time\"\n")
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(&b, "// basePath is prefixed to every operation path\nconst basePath = %q\n", m.BasePath)
+	b.WriteString(goRuntime)
+
+	for _, def := range m.Types {
+		b.WriteString("\n")
+		writeGoComment(&b, "", def.Name, def.Description)
+		fmt.Fprintf(&b, "type %s struct {\n", def.Name)
+		for _, f := range def.Fields {
+			writeGoComment(&b, "\t", "", f.Description)
+			tag := f.JSONName
+			if !f.Required {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", f.Name, goType(f.Type), tag)
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, op := range m.Operations {
+		b.WriteString("\n")
+		writeGoOperation(&b, op)
+	}
+
+	formatted, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format Go client: %w", err)
+	}
+	return formatted, nil
+}
+
+// writeGoOperation renders the params struct and method of an operation
+func writeGoOperation(b *strings.Builder, op operation) {
+	paramsType := op.Name + "Params"
+	if len(op.QueryParams) > 0 {
+		fmt.Fprintf(b, "// %s holds the query parameters of %s\n", paramsType, op.Name)
+		fmt.Fprintf(b, "type %s struct {\n", paramsType)
+		for _, p := range op.QueryParams {
+			writeGoComment(b, "\t", "", p.Description)
+			fmt.Fprintf(b, "\t%s %s\n", p.Name, goParamType(p))
+		}
+		b.WriteString("}\n\n")
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, p := range op.PathParams {
+		args = append(args, p.Name+" "+goType(p.Type))
+	}
+	if op.Body != nil {
+		args = append(args, "body "+goType(*op.Body))
+	}
+	if len(op.QueryParams) > 0 {
+		args = append(args, "params "+paramsType)
+	}
+
+	results := "error"
+	if op.Result != nil {
+		results = "(" + goResultType(*op.Result) + ", error)"
+	}
+
+	doc := op.Summary
+	if op.Description != "" {
+		doc = op.Description
+	}
+	writeGoComment(b, "", op.Name, fmt.Sprintf("%s (%s %s)", strings.TrimSuffix(doc, "."), op.Method, op.Path))
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", op.Name, strings.Join(args, ", "), results)
+
+	path := fmt.Sprintf("%q", op.Path)
+	for _, p := range op.PathParams {
+		path = strings.Replace(path, "{"+p.WireName+"}", `" + url.PathEscape(`+goStringExpr(p.Type, p.Name)+`) + "`, 1)
+	}
+	path = strings.TrimSuffix(strings.TrimPrefix(path, `"" + `), ` + ""`)
+
+	query := "nil"
+	if len(op.QueryParams) > 0 {
+		query = "query"
+		b.WriteString("\tquery := url.Values{}\n")
+		for _, p := range op.QueryParams {
+			writeGoQueryParam(b, p)
+		}
+	}
+
+	body := "nil"
+	if op.Body != nil {
+		body = "body"
+	}
+
+	if op.Result == nil {
+		fmt.Fprintf(b, "\treturn c.do(ctx, %q, %s, %s, %s, %t, nil)\n}\n", op.Method, path, query, body, op.Admin)
+		return
+	}
+
+	ret := "out"
+	if op.Result.Kind == kindRef {
+		ret = "&out"
+	}
+	fmt.Fprintf(b, "\tvar out %s\n", goType(*op.Result))
+	fmt.Fprintf(b, "\tif err := c.do(ctx, %q, %s, %s, %s, %t, &out); err != nil {\n\t\treturn nil, err\n\t}\n", op.Method, path, query, body, op.Admin)
+	fmt.Fprintf(b, "\treturn %s, nil\n}\n", ret)
+}
+
+// goResultType returns named types by pointer and slices, maps and raw JSON by value
+func goResultType(ref typeRef) string {
+	if ref.Kind == kindRef {
+		return "*" + goType(ref)
+	}
+	return goType(ref)
+}
+
+// writeGoQueryParam renders the statements adding one query parameter when it is set
+func writeGoQueryParam(b *strings.Builder, p param) {
+	field := "params." + p.Name
+	switch {
+	case p.Type.Kind == kindArray && p.CSV:
+		fmt.Fprintf(b, "\tif len(%s) > 0 {\n\t\tvalues := make([]string, 0, len(%s))\n", field, field)
+		fmt.Fprintf(b, "\t\tfor _, v := range %s {\n\t\t\tvalues = append(values, %s)\n\t\t}\n", field, goStringExpr(*p.Type.Elem, "v"))
+		fmt.Fprintf(b, "\t\tquery.Set(%q, strings.Join(values, \",\"))\n\t}\n", p.WireName)
+	case p.Type.Kind == kindArray:
+		fmt.Fprintf(b, "\tfor _, v := range %s {\n\t\tquery.Add(%q, %s)\n\t}\n", field, p.WireName, goStringExpr(*p.Type.Elem, "v"))
+	case p.Type.Kind == kindString:
+		fmt.Fprintf(b, "\tif %s != \"\" {\n\t\tquery.Set(%q, %s)\n\t}\n", field, p.WireName, field)
+	default:
+		fmt.Fprintf(b, "\tif %s != nil {\n\t\tquery.Set(%q, %s)\n\t}\n", field, p.WireName, goStringExpr(p.Type, "*"+field))
+	}
+}
+
+// goParamType is the type of a query parameter field; scalars other than strings are pointers so that zero values can be sent
+func goParamType(p param) string {
+	switch p.Type.Kind {
+	case kindString, kindArray:
+		return goType(p.Type)
+	default:
+		return "*" + goType(p.Type)
+	}
+}
+
+// goStringExpr formats a value expression as a string
+func goStringExpr(ref typeRef, expr string) string {
+	switch ref.Kind {
+	case kindString:
+		return expr
+	case kindTime:
+		return expr + ".Format(time.RFC3339)"
+	default:
+		return "fmt.Sprint(" + expr + ")"
+	}
+}
+
+// goType maps a typeRef onto a Go type
+func goType(ref typeRef) string {
+	switch ref.Kind {
+	case kindString:
+		return "string"
+	case kindInt:
+		return "int64"
+	case kindFloat:
+		return "float64"
+	case kindBool:
+		return "bool"
+	case kindTime:
+		return "time.Time"
+	case kindArray:
+		return "[]" + goType(*ref.Elem)
+	case kindMap:
+		return "map[string]" + goType(*ref.Elem)
+	case kindRef:
+		return ref.Ref
+	default:
+		return "json.RawMessage"
+	}
+}
+
+// usesTime reports whether any generated type or parameter needs the time package
+func usesTime(m *model) bool {
+	var check func(ref typeRef) bool
+	check = func(ref typeRef) bool {
+		if ref.Kind == kindTime {
+			return true
+		}
+		return ref.Elem != nil && check(*ref.Elem)
+	}
+
+	for _, def := range m.Types {
+		for _, f := range def.Fields {
+			if check(f.Type) {
+				return true
+			}
+		}
+	}
+	for _, op := range m.Operations {
+		for _, p := range append(append([]param{}, op.PathParams...), op.QueryParams...) {
+			if check(p.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeGoComment writes a doc comment, prefixed with the documented name when one is given
+func writeGoComment(b *strings.Builder, indent, name, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" && name == "" {
+		return
+	}
+	if name != "" {
+		if text == "" {
+			text = name + " is generated from the API spec"
+		} else {
+			text = name + " " + lowerFirst(text)
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(b, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+// lowerFirst lower-cases the first letter unless the first word is an acronym
+func lowerFirst(s string) string {
+	word := strings.Fields(s)[0]
+	if len(word) > 1 && strings.ToUpper(word[:2]) == word[:2] {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,387 @@
+package clientgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// kind classifies a resolved type independently of the target language
+type kind int
+
+const (
+	kindAny kind = iota
+	kindString
+	kindInt
+	kindFloat
+	kindBool
+	kindTime
+	kindArray
+	kindMap
+	kindRef
+)
+
+// typeRef is a language independent reference to a type
+type typeRef struct {
+	Kind kind
+	Elem *typeRef
+	Ref  string
+}
+
+// typeDef is a named object type built from a spec definition
+type typeDef struct {
+	Name        string
+	Description string
+	Fields      []field
+}
+
+// field is a property of a typeDef
+type field struct {
+	Name        string
+	JSONName    string
+	Description string
+	Type        typeRef
+	Required    bool
+}
+
+// param is a path or query parameter of an operation
+type param struct {
+	Name        string
+	WireName    string
+	Description string
+	Type        typeRef
+	Required    bool
+	CSV         bool
+}
+
+// operation is a single client method
+type operation struct {
+	Name        string
+	Summary     string
+	Description string
+	Method      string
+	Path        string
+	PathParams  []param
+	QueryParams []param
+	Body        *typeRef
+	Result      *typeRef
+	Admin       bool
+}
+
+// model is everything the renderers need, in a deterministic order
+type model struct {
+	Title      string
+	Version    string
+	BasePath   string
+	AdminKey   *SecurityScheme
+	Types      []typeDef
+	Operations []operation
+}
+
+// httpMethods lists the methods read from a path item, in output order
+var httpMethods = []string{"get", "post", "put", "patch", "delete"}
+
+// initialisms are kept upper-case in generated identifiers
+var initialisms = map[string]bool{
+	"api": true, "csv": true, "http": true, "id": true, "ids": true, "json": true, "url": true,
+}
+
+// buildModel resolves the spec into named types and operations
+func buildModel(spec *Spec) (*model, error) {
+	m := &model{
+		Title:    spec.Info.Title,
+		Version:  spec.Info.Version,
+		BasePath: strings.TrimSuffix(spec.BasePath, "/"),
+	}
+
+	names := definitionNames(spec.Definitions)
+
+	defKeys := sortedKeys(spec.Definitions)
+	for _, key := range defKeys {
+		def := typeDef{Name: names[key], Description: spec.Definitions[key].Description}
+		properties, required := flattenObject(spec.Definitions[key], spec.Definitions)
+		for _, prop := range sortedKeys(properties) {
+			ref, err := resolveSchema(properties[prop], names)
+			if err != nil {
+				return nil, fmt.Errorf("definition %s property %s: %w", key, prop, err)
+			}
+			def.Fields = append(def.Fields, field{
+				Name:        exportedName(prop),
+				JSONName:    prop,
+				Description: properties[prop].Description,
+				Type:        ref,
+				Required:    required[prop],
+			})
+		}
+		m.Types = append(m.Types, def)
+	}
+
+	for name, scheme := range spec.SecurityDefinitions {
+		if name == "AdminKey" {
+			scheme := scheme
+			m.AdminKey = &scheme
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op, ok := spec.Paths[path][method]
+			// Downloads such as CSV exports are left to plain HTTP clients
+			if !ok || !op.producesJSON() {
+				continue
+			}
+
+			built, err := buildOperation(method, path, op, names)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			if used[built.Name] {
+				built.Name += exportedName(method)
+			}
+			if used[built.Name] {
+				return nil, fmt.Errorf("%s %s: duplicate operation name %s", strings.ToUpper(method), path, built.Name)
+			}
+			used[built.Name] = true
+			m.Operations = append(m.Operations, built)
+		}
+	}
+
+	return m, nil
+}
+
+// buildOperation turns a spec operation into a client method
+func buildOperation(method, path string, op Operation, names map[string]string) (operation, error) {
+	built := operation{
+		Name:        operationName(method, path, op.Summary),
+		Summary:     op.Summary,
+		Description: op.Description,
+		Method:      strings.ToUpper(method),
+		Path:        path,
+		Admin:       len(op.Security) > 0,
+	}
+
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			ref, err := resolveSchema(&Schema{Type: p.Type, Format: p.Format}, names)
+			if err != nil {
+				return built, fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+			built.PathParams = append(built.PathParams, param{
+				Name:        unexportedName(p.Name),
+				WireName:    p.Name,
+				Description: p.Description,
+				Type:        ref,
+				Required:    true,
+			})
+		case "query":
+			ref, err := resolveSchema(&Schema{Type: p.Type, Format: p.Format, Items: p.Items}, names)
+			if err != nil {
+				return built, fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+			built.QueryParams = append(built.QueryParams, param{
+				Name:        exportedName(p.Name),
+				WireName:    p.Name,
+				Description: p.Description,
+				Type:        ref,
+				Required:    p.Required,
+				CSV:         p.Type == "array" && p.CollectionFormat != "multi",
+			})
+		case "body":
+			ref, err := resolveSchema(p.Schema, names)
+			if err != nil {
+				return built, fmt.Errorf("body: %w", err)
+			}
+			built.Body = &ref
+		}
+	}
+
+	// Path parameters are passed in the order they appear in the path
+	sort.SliceStable(built.PathParams, func(i, j int) bool {
+		return strings.Index(path, "{"+built.PathParams[i].WireName+"}") < strings.Index(path, "{"+built.PathParams[j].WireName+"}")
+	})
+
+	for _, code := range sortedKeys(op.Responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if schema := op.Responses[code].Schema; schema != nil {
+			ref, err := resolveSchema(schema, names)
+			if err != nil {
+				return built, fmt.Errorf("response %s: %w", code, err)
+			}
+			built.Result = &ref
+		}
+		break
+	}
+
+	return built, nil
+}
+
+// resolveSchema maps a schema onto a typeRef
+func resolveSchema(schema *Schema, names map[string]string) (typeRef, error) {
+	if schema == nil {
+		return typeRef{Kind: kindAny}, nil
+	}
+
+	if schema.Ref != "" {
+		key := strings.TrimPrefix(schema.Ref, "#/definitions/")
+		name, ok := names[key]
+		if !ok {
+			return typeRef{}, fmt.Errorf("unknown definition %s", schema.Ref)
+		}
+		return typeRef{Kind: kindRef, Ref: name}, nil
+	}
+
+	// swag wraps a referenced type in allOf when the property also carries a description
+	if len(schema.AllOf) == 1 {
+		return resolveSchema(schema.AllOf[0], names)
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "date-time" {
+			return typeRef{Kind: kindTime}, nil
+		}
+		return typeRef{Kind: kindString}, nil
+	case "integer":
+		return typeRef{Kind: kindInt}, nil
+	case "number":
+		return typeRef{Kind: kindFloat}, nil
+	case "boolean":
+		return typeRef{Kind: kindBool}, nil
+	case "array":
+		elem, err := resolveSchema(schema.Items, names)
+		if err != nil {
+			return typeRef{}, err
+		}
+		return typeRef{Kind: kindArray, Elem: &elem}, nil
+	case "object":
+		if schema.AdditionalProperties != nil {
+			elem, err := resolveSchema(schema.AdditionalProperties, names)
+			if err != nil {
+				return typeRef{}, err
+			}
+			return typeRef{Kind: kindMap, Elem: &elem}, nil
+		}
+	}
+
+	return typeRef{Kind: kindAny}, nil
+}
+
+// flattenObject merges the properties of a definition and of everything it composes with allOf
+func flattenObject(schema *Schema, definitions map[string]*Schema) (map[string]*Schema, map[string]bool) {
+	properties := make(map[string]*Schema)
+	required := make(map[string]bool)
+
+	var visit func(s *Schema)
+	visit = func(s *Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			visit(definitions[strings.TrimPrefix(s.Ref, "#/definitions/")])
+			return
+		}
+		for _, part := range s.AllOf {
+			visit(part)
+		}
+		for name, prop := range s.Properties {
+			properties[name] = prop
+		}
+		for _, name := range s.Required {
+			required[name] = true
+		}
+	}
+	visit(schema)
+
+	return properties, required
+}
+
+// definitionNames assigns every definition a type name, dropping the Go package prefix unless two definitions would collide
+func definitionNames(definitions map[string]*Schema) map[string]string {
+	names := make(map[string]string, len(definitions))
+	count := make(map[string]int)
+
+	for key := range definitions {
+		names[key] = exportedName(strings.Join(dropPackages(splitWords(key)), " "))
+		count[names[key]]++
+	}
+
+	for key, name := range names {
+		if count[name] > 1 {
+			names[key] = exportedName(key)
+		}
+	}
+
+	return names
+}
+
+// dropPackages removes the lower-case package qualifiers swag puts in front of type names, such as "models" in "common.BaseResponse-models_Product"
+func dropPackages(words []string) []string {
+	kept := make([]string, 0, len(words))
+	for i, word := range words {
+		isLast := i == len(words)-1
+		if !isLast && word != "array" && word == strings.ToLower(word) {
+			continue
+		}
+		kept = append(kept, word)
+	}
+	return kept
+}
+
+// operationName derives a method name from the operation summary, falling back to the method and path
+func operationName(method, path, summary string) string {
+	if name := exportedName(summary); name != "" {
+		return name
+	}
+	return exportedName(method + " " + path)
+}
+
+// exportedName converts snake_case, kebab-case, dotted and spaced names to CamelCase
+func exportedName(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		lower := strings.ToLower(word)
+		if initialisms[lower] {
+			if lower == "ids" {
+				b.WriteString("IDs")
+			} else {
+				b.WriteString(strings.ToUpper(lower))
+			}
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// unexportedName converts a name to lowerCamelCase
+func unexportedName(s string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.ToLower(words[0]) + exportedName(strings.Join(words[1:], " "))
+}
+
+// splitWords splits an identifier on every character that is not a letter or digit
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// sortedKeys returns the keys of a map in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
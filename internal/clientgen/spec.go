@@ -0,0 +1,113 @@
+package clientgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Spec is the subset of a Swagger 2.0 document the generator reads
+type Spec struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	BasePath            string                          `json:"basePath"`
+	Paths               map[string]map[string]Operation `json:"paths"`
+	Definitions         map[string]*Schema              `json:"definitions"`
+	SecurityDefinitions map[string]SecurityScheme       `json:"securityDefinitions"`
+}
+
+// Operation is a single method of a path
+type Operation struct {
+	Summary     string                `json:"summary"`
+	Description string                `json:"description"`
+	Produces    []string              `json:"produces"`
+	Parameters  []Parameter           `json:"parameters"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security"`
+}
+
+// producesJSON reports whether the operation responds with JSON, the only responses the clients decode
+func (op Operation) producesJSON() bool {
+	return len(op.Produces) == 0 || slices.Contains(op.Produces, "application/json")
+}
+
+// Parameter is a path, query, header or body parameter of an operation
+type Parameter struct {
+	Name             string  `json:"name"`
+	In               string  `json:"in"`
+	Description      string  `json:"description"`
+	Required         bool    `json:"required"`
+	Type             string  `json:"type"`
+	Format           string  `json:"format"`
+	Items            *Schema `json:"items"`
+	CollectionFormat string  `json:"collectionFormat"`
+	Schema           *Schema `json:"schema"`
+}
+
+// Response is a documented response of an operation
+type Response struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+// SecurityScheme describes how a security definition is sent
+type SecurityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+// Schema is a JSON schema as emitted by swag
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Items                *Schema            `json:"items"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *Schema            `json:"-"`
+	AllOf                []*Schema          `json:"allOf"`
+	Required             []string           `json:"required"`
+}
+
+// UnmarshalJSON accepts additionalProperties as either a schema or a boolean
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type plain Schema
+	var raw struct {
+		plain
+		AdditionalProperties json.RawMessage `json:"additionalProperties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = Schema(raw.plain)
+
+	if len(raw.AdditionalProperties) > 0 && raw.AdditionalProperties[0] == '{' {
+		s.AdditionalProperties = &Schema{}
+		if err := json.Unmarshal(raw.AdditionalProperties, s.AdditionalProperties); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadSpec reads and parses a Swagger 2.0 JSON document
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec %s: %w", path, err)
+	}
+	if len(spec.Paths) == 0 {
+		return nil, fmt.Errorf("spec %s documents no paths", path)
+	}
+
+	return &spec, nil
+}
@@ -0,0 +1,213 @@
+package clientgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tsRuntime is the hand-written part of the TypeScript client shared by every operation
+const tsRuntime = `
+/** Thrown for every non-2xx response */
+export class ApiError extends Error {
+  readonly status: number;
+  readonly body: string;
+
+  constructor(status: number, body: string) {
+    super(` + "`api error ${status}: ${body}`" + `);
+    this.name = "ApiError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+export interface ClientOptions {
+  /** Sent as a Bearer token on admin operations */
+  adminKey?: string;
+  /** Defaults to the global fetch */
+  fetch?: typeof fetch;
+}
+
+type Query = Record<string, string | number | boolean | Array<string | number | boolean> | undefined>;
+
+/** Typed client for the API */
+export class Client {
+  private readonly baseUrl: string;
+  private readonly adminKey?: string;
+  private readonly fetchFn: typeof fetch;
+
+  constructor(baseUrl: string, options: ClientOptions = {}) {
+    this.baseUrl = baseUrl.replace(/\/+$/, "");
+    this.adminKey = options.adminKey;
+    this.fetchFn = options.fetch ?? fetch;
+  }
+
+  private async request<T>(method: string, path: string, query: Query | undefined, body: unknown, admin: boolean): Promise<T> {
+    const search = new URLSearchParams();
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value === undefined) continue;
+      for (const item of Array.isArray(value) ? value : [value]) search.append(key, String(item));
+    }
+    const qs = search.toString();
+
+    const headers: Record<string, string> = { Accept: "application/json" };
+    if (body !== undefined) headers["Content-Type"] = "application/json";
+    if (admin && this.adminKey) headers["Authorization"] = ` + "`Bearer ${this.adminKey}`" + `;
+
+    const res = await this.fetchFn(this.baseUrl + BASE_PATH + path + (qs ? "?" + qs : ""), {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    const text = await res.text();
+    if (!res.ok) throw new ApiError(res.status, text);
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+`
+
+// renderTypeScript renders the TypeScript client
+func renderTypeScript(m *model, specPath string) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "// Code generated by server --gen-client from %s. DO NOT EDIT.\n", specPath)
+	fmt.Fprintf(&b, "// Typed client for the %s API (version %s)\n\n", m.Title, m.Version)
+	fmt.Fprintf(&b, "const BASE_PATH = %q;\n", m.BasePath)
+
+	for _, def := range m.Types {
+		b.WriteString("\n")
+		writeTSComment(&b, "", def.Description)
+		fmt.Fprintf(&b, "export interface %s {\n", def.Name)
+		for _, f := range def.Fields {
+			writeTSComment(&b, "  ", f.Description)
+			optional := "?"
+			if f.Required {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", f.JSONName, optional, tsType(f.Type))
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, op := range m.Operations {
+		if len(op.QueryParams) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n/** Query parameters of %s */\n", methodName(op.Name))
+		fmt.Fprintf(&b, "export interface %sParams {\n", op.Name)
+		for _, p := range op.QueryParams {
+			writeTSComment(&b, "  ", p.Description)
+			optional := "?"
+			if p.Required {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", p.WireName, optional, tsType(p.Type))
+		}
+		b.WriteString("}\n")
+	}
+
+	b.WriteString(tsRuntime)
+
+	for _, op := range m.Operations {
+		b.WriteString("\n")
+		writeTSOperation(&b, op)
+	}
+	b.WriteString("}\n")
+
+	return []byte(b.String())
+}
+
+// writeTSOperation renders the method of an operation
+func writeTSOperation(b *strings.Builder, op operation) {
+	var args []string
+	for _, p := range op.PathParams {
+		args = append(args, p.Name+": "+tsType(p.Type))
+	}
+	if op.Body != nil {
+		args = append(args, "body: "+tsType(*op.Body))
+	}
+	query := "undefined"
+	if len(op.QueryParams) > 0 {
+		query = "params as Query"
+		args = append(args, "params: "+op.Name+"Params = {}")
+
+		// Arrays are sent as repeated keys unless the spec asks for a comma-separated value
+		var joined []string
+		for _, p := range op.QueryParams {
+			if p.CSV {
+				joined = append(joined, fmt.Sprintf("%q: params[%q]?.join(\",\")", p.WireName, p.WireName))
+			}
+		}
+		if len(joined) > 0 {
+			query = "{ ...params, " + strings.Join(joined, ", ") + " } as Query"
+		}
+	}
+
+	result := "void"
+	if op.Result != nil {
+		result = tsType(*op.Result)
+	}
+
+	doc := op.Summary
+	if op.Description != "" {
+		doc = op.Description
+	}
+	writeTSComment(b, "  ", fmt.Sprintf("%s (%s %s)", strings.TrimSuffix(doc, "."), op.Method, op.Path))
+
+	path := "\"" + op.Path + "\""
+	if len(op.PathParams) > 0 {
+		path = "`" + op.Path + "`"
+		for _, p := range op.PathParams {
+			path = strings.Replace(path, "{"+p.WireName+"}", "${encodeURIComponent(String("+p.Name+"))}", 1)
+		}
+	}
+
+	body := "undefined"
+	if op.Body != nil {
+		body = "body"
+	}
+
+	fmt.Fprintf(b, "  %s(%s): Promise<%s> {\n", methodName(op.Name), strings.Join(args, ", "), result)
+	fmt.Fprintf(b, "    return this.request<%s>(%q, %s, %s, %s, %t);\n  }\n", result, op.Method, path, query, body, op.Admin)
+}
+
+// methodName lower-cases the first letter of an operation name
+func methodName(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// tsType maps a typeRef onto a TypeScript type; times travel as RFC 3339 strings
+func tsType(ref typeRef) string {
+	switch ref.Kind {
+	case kindString, kindTime:
+		return "string"
+	case kindInt, kindFloat:
+		return "number"
+	case kindBool:
+		return "boolean"
+	case kindArray:
+		return tsType(*ref.Elem) + "[]"
+	case kindMap:
+		return "Record<string, " + tsType(*ref.Elem) + ">"
+	case kindRef:
+		return ref.Ref
+	default:
+		return "unknown"
+	}
+}
+
+// writeTSComment writes a JSDoc comment
+func writeTSComment(b *strings.Builder, indent, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, strings.ReplaceAll(text, "*/", "*\\/"))
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s * %s\n", indent, strings.ReplaceAll(strings.TrimSpace(line), "*/", "*\\/"))
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
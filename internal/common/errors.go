@@ -0,0 +1,10 @@
+package common
+
+import "errors"
+
+// Domain errors shared across layers; handlers translate them into HTTP status codes
+var (
+	ErrNotFound   = errors.New("resource not found")
+	ErrConflict   = errors.New("resource already exists")
+	ErrValidation = errors.New("validation failed")
+)
@@ -8,6 +8,8 @@ type PaginationInfo struct {
 	Offset      int   `json:"offset"`
 	CurrentPage int   `json:"current_page"`
 	TotalPages  int   `json:"total_pages"`
+	// TotalRelation is "gte" when Total is a lower bound because hit counting was capped
+	TotalRelation string `json:"total_relation,omitempty"`
 }
 
 // PagedResponse extends BaseResponse with pagination information
@@ -17,6 +19,32 @@ type PagedResponse[T any] struct {
 	Data       T              `json:"data,omitempty"`
 	Error      string         `json:"error,omitempty"`
 	Pagination PaginationInfo `json:"pagination,omitempty"`
+	// NextCursor fetches the next page of a cursor-paginated list; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	// PIT is the token pinning the following pages of a point-in-time session
+	PIT      string   `json:"pit,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	// Search reports how Elasticsearch executed a search; only set on search responses
+	Search *SearchMeta `json:"search,omitempty"`
+	// Facets holds the value counts of the requested facets, keyed by field
+	Facets map[string][]FacetBucket `json:"facets,omitempty"`
+	// Suggestions are corrected spellings of a search keyword that matched nothing ("did you mean")
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// FacetBucket is the number of hits with one value of a faceted field
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// SearchMeta contains the timing and scoring metadata of a search response
+type SearchMeta struct {
+	// TookMs is the time Elasticsearch spent executing the search
+	TookMs int64 `json:"took_ms"`
+	// MaxScore is the highest relevance score of any hit; null when hits aren't scored or nothing matched
+	MaxScore *float64 `json:"max_score"`
+	TimedOut bool     `json:"timed_out"`
 }
 
 // BaseResponse is a generic wrapper for an API Response.
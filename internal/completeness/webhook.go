@@ -0,0 +1,58 @@
+// Package completeness notifies data owners of imported products that miss mandatory fields
+package completeness
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"elasticsearch/internal/models"
+)
+
+// webhookTimeout bounds a single notification request
+const webhookTimeout = 10 * time.Second
+
+// Webhook POSTs completeness reports as JSON to an HTTP endpoint
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook creates a Webhook posting to url
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Notify posts the report; any non-2xx response is an error
+func (w *Webhook) Notify(ctx context.Context, report models.CompletenessReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode completeness report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", res.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
@@ -22,15 +22,215 @@ type ServerConfig struct {
 	ReadTimeoutSec  int    `mapstructure:"SERVER_READ_TIMEOUT_SEC"`
 	WriteTimeoutSec int    `mapstructure:"SERVER_WRITE_TIMEOUT_SEC"`
 	IdleTimeoutSec  int    `mapstructure:"SERVER_IDLE_TIMEOUT_SEC"`
+	// SearchTimeoutSec, BulkTimeoutSec, AdminTimeoutSec and ExportTimeoutSec bound handler time per route group;
+	// the read and write timeouts above only cover network I/O, and are raised to the timeout of groups allowed
+	// to run longer
+	SearchTimeoutSec int `mapstructure:"SERVER_SEARCH_TIMEOUT_SEC"`
+	BulkTimeoutSec   int `mapstructure:"SERVER_BULK_TIMEOUT_SEC"`
+	AdminTimeoutSec  int `mapstructure:"SERVER_ADMIN_TIMEOUT_SEC"`
+	// ExportTimeoutSec bounds a whole CSV export, which keeps streaming after its handler returned
+	ExportTimeoutSec int `mapstructure:"SERVER_EXPORT_TIMEOUT_SEC"`
 }
 
 // ----- Elasticsearch configuration -----
 type ElasticsearchConfig struct {
-	Addresses  []string `mapstructure:"ELASTICSEARCH_ADDRESSES"`
-	Username   string   `mapstructure:"ELASTICSEARCH_USERNAME"`
-	Password   string   `mapstructure:"ELASTICSEARCH_PASSWORD"`
-	Index      string   `mapstructure:"ELASTICSEARCH_INDEX"`
-	TimeoutSec int      `mapstructure:"ELASTICSEARCH_TIMEOUT_SEC"`
+	Addresses []string `mapstructure:"ELASTICSEARCH_ADDRESSES"`
+	Username  string   `mapstructure:"ELASTICSEARCH_USERNAME"`
+	Password  string   `mapstructure:"ELASTICSEARCH_PASSWORD"`
+	Index     string   `mapstructure:"ELASTICSEARCH_INDEX"`
+	// IndexTemplate names the concrete indices built behind the Index alias, e.g. "products-{yyyy.MM.dd}"
+	IndexTemplate string `mapstructure:"ELASTICSEARCH_INDEX_TEMPLATE"`
+	TimeoutSec    int    `mapstructure:"ELASTICSEARCH_TIMEOUT_SEC"`
+	// MaxResultWindow raises index.max_result_window on product indices; 0 keeps the Elasticsearch default (10000)
+	MaxResultWindow int `mapstructure:"ELASTICSEARCH_MAX_RESULT_WINDOW"`
+	// EdgeNGram indexes the edge n-grams of product names and generics in new indices, so prefix matches are
+	// term lookups instead of wildcards; existing indices need a reindex
+	EdgeNGram        bool `mapstructure:"ELASTICSEARCH_EDGE_NGRAM"`
+	EdgeNGramMinGram int  `mapstructure:"ELASTICSEARCH_EDGE_NGRAM_MIN_GRAM"`
+	EdgeNGramMaxGram int  `mapstructure:"ELASTICSEARCH_EDGE_NGRAM_MAX_GRAM"`
+	// SuggestIndex serves autocomplete from a lightweight index of product names, rebuilt after imports and
+	// updated on every write
+	SuggestIndex bool `mapstructure:"ELASTICSEARCH_SUGGEST_INDEX"`
+}
+
+// ----- Search configuration -----
+type SearchConfig struct {
+	FailOnPartialResults bool   `mapstructure:"SEARCH_FAIL_ON_PARTIAL_RESULTS"`
+	Dedupe               bool   `mapstructure:"SEARCH_DEDUPE"`
+	HighlightFormat      string `mapstructure:"SEARCH_HIGHLIGHT_FORMAT"`
+	Fuzziness            string `mapstructure:"SEARCH_FUZZINESS"`
+	// TrackTotalHits is "true" to count every hit or a number to stop counting there
+	TrackTotalHits string `mapstructure:"SEARCH_TRACK_TOTAL_HITS"`
+	// MinScore drops keyword hits scoring below it; 0 keeps every hit
+	MinScore float64 `mapstructure:"SEARCH_MIN_SCORE"`
+	// PITKeepAlive is how long a point in time of a cursor or pit session stays open between two pages, e.g. "5m"
+	PITKeepAlive string `mapstructure:"SEARCH_PIT_KEEP_ALIVE"`
+	// MaxResultWindow is the largest offset+limit accepted by offset pagination; deeper pages need a cursor
+	MaxResultWindow int `mapstructure:"SEARCH_MAX_RESULT_WINDOW"`
+	// DiversityMaxPerCompany caps the products of one company in the top DiversityTopK results; 0 disables it
+	DiversityMaxPerCompany int `mapstructure:"SEARCH_DIVERSITY_MAX_PER_COMPANY"`
+	DiversityTopK          int `mapstructure:"SEARCH_DIVERSITY_TOP_K"`
+	// FacetSize is the number of values counted per facet
+	FacetSize int `mapstructure:"SEARCH_FACET_SIZE"`
+}
+
+// ----- Response configuration -----
+type ResponseConfig struct {
+	// OmitEmptyFields drops zero-value product fields (score 0, zero timestamps) instead of emitting them explicitly
+	OmitEmptyFields bool `mapstructure:"RESPONSE_OMIT_EMPTY_FIELDS"`
+}
+
+// ----- Document size configuration -----
+type DocumentConfig struct {
+	// MaxBytes caps the JSON-encoded size of a product written through the API or an import; 0 disables the cap
+	MaxBytes int `mapstructure:"DOCUMENT_MAX_BYTES"`
+	// OversizePolicy is "reject" to fail oversized writes or "truncate" to shorten their largest fields
+	OversizePolicy string `mapstructure:"DOCUMENT_OVERSIZE_POLICY"`
+}
+
+// ----- Product ID configuration -----
+type IDConfig struct {
+	// Strategy assigns IDs to products created without one: elasticsearch, sequence, uuidv7 or snowflake
+	Strategy string `mapstructure:"ID_STRATEGY"`
+	// SnowflakeNode must be unique per server instance when Strategy is snowflake
+	SnowflakeNode int64 `mapstructure:"ID_SNOWFLAKE_NODE"`
+}
+
+// ----- Product detail cache configuration -----
+type ProductCacheConfig struct {
+	Enabled bool `mapstructure:"PRODUCT_CACHE_ENABLED"`
+	TTLSec  int  `mapstructure:"PRODUCT_CACHE_TTL_SEC"`
+	// NegativeTTLSec is how long an ID that doesn't exist is remembered as missing
+	NegativeTTLSec int `mapstructure:"PRODUCT_CACHE_NEGATIVE_TTL_SEC"`
+	MaxEntries     int `mapstructure:"PRODUCT_CACHE_MAX_ENTRIES"`
+}
+
+// ----- Aggregation result cache configuration -----
+type AggregationCacheConfig struct {
+	Enabled    bool `mapstructure:"AGGREGATION_CACHE_ENABLED"`
+	TTLSec     int  `mapstructure:"AGGREGATION_CACHE_TTL_SEC"`
+	MaxEntries int  `mapstructure:"AGGREGATION_CACHE_MAX_ENTRIES"`
+}
+
+// ----- Admin configuration -----
+type AdminConfig struct {
+	APIKey string `mapstructure:"ADMIN_API_KEY"`
+}
+
+// ----- Search recorder configuration -----
+type RecorderConfig struct {
+	Enabled bool   `mapstructure:"SEARCH_RECORDER_ENABLED"`
+	Path    string `mapstructure:"SEARCH_RECORDER_PATH"`
+}
+
+// ----- Enrichment configuration -----
+type EnrichmentConfig struct {
+	Chain []string `mapstructure:"ENRICHMENT_CHAIN"`
+}
+
+// ----- Query rewrite configuration -----
+type RewriteConfig struct {
+	// Rules are the rewrite rules applied to search keywords, in order
+	Rules []string `mapstructure:"QUERY_REWRITE_RULES"`
+}
+
+// ----- Import check configuration -----
+type ImportConfig struct {
+	// Staged imports fail their checks when more than these fractions of rows failed or were skipped
+	MaxFailedRatio  float64 `mapstructure:"IMPORT_MAX_FAILED_RATIO"`
+	MaxSkippedRatio float64 `mapstructure:"IMPORT_MAX_SKIPPED_RATIO"`
+	// MaxShrinkRatio is the largest fraction of live documents a staged import may drop
+	MaxShrinkRatio float64 `mapstructure:"IMPORT_MAX_SHRINK_RATIO"`
+	// Mode is "index" to replace imported products with their rows or "upsert" to only overwrite the imported
+	// fields, keeping created_at, score and deleted_at of existing products
+	Mode string `mapstructure:"IMPORT_MODE"`
+	// DuplicatePolicy is "last-wins" to import rows repeating the ID, or the product name and company, of an earlier
+	// row, "skip" to skip them or "fail" to stop the import at the first one
+	DuplicatePolicy string `mapstructure:"IMPORT_DUPLICATE_POLICY"`
+	// IDPolicy is "column" to read product IDs from the id column every source must have, or, for rows without an
+	// id column or value, "hash" to generate them from the product name and company or "elasticsearch" to let
+	// Elasticsearch assign them
+	IDPolicy string `mapstructure:"IMPORT_ID_POLICY"`
+	// BatchSize is the number of products sent per bulk request
+	BatchSize int `mapstructure:"IMPORT_BATCH_SIZE"`
+	// FailOnError aborts an import once this many rows failed to index; 0 imports every row whatever fails
+	FailOnError int `mapstructure:"IMPORT_FAIL_ON_ERROR"`
+	// ProgressIntervalSec is how often the progress of running imports is logged and published; 0 disables it
+	ProgressIntervalSec int `mapstructure:"IMPORT_PROGRESS_INTERVAL_SEC"`
+	// RejectReport is the CSV, or NDJSON for .ndjson and .jsonl paths, file the rows skipped or failed to index by
+	// an import are written to, overwritten by every import; empty writes none
+	RejectReport string `mapstructure:"IMPORT_REJECT_REPORT"`
+	// UploadDir holds the files uploaded to POST /admin/import while they are imported; empty uses the
+	// system temporary directory
+	UploadDir string `mapstructure:"IMPORT_UPLOAD_DIR"`
+	// UploadMaxMB caps the size of uploaded import files
+	UploadMaxMB int `mapstructure:"IMPORT_UPLOAD_MAX_MB"`
+	// MappingFile maps product fields to the source columns of every import; empty reads the default column names
+	MappingFile string `mapstructure:"IMPORT_MAPPING_FILE"`
+	// CSVDelimiter and CSVQuote set the dialect of local CSV files; the delimiter is a single character or "tab"
+	CSVDelimiter string `mapstructure:"IMPORT_CSV_DELIMITER"`
+	CSVQuote     string `mapstructure:"IMPORT_CSV_QUOTE"`
+	// CSVEncoding is the character encoding of CSV files, such as "windows-1252" or "utf-16le"; "auto" detects it
+	CSVEncoding string `mapstructure:"IMPORT_CSV_ENCODING"`
+	// GoogleCredentialsFile is a service account key; with one, Google Sheets are read through the Sheets API,
+	// so private spreadsheets shared with the service account can be imported
+	GoogleCredentialsFile string `mapstructure:"IMPORT_GOOGLE_CREDENTIALS_FILE"`
+	// S3 credentials of s3:// import sources; without an access key only public buckets can be read
+	S3Region          string `mapstructure:"IMPORT_S3_REGION"`
+	S3AccessKeyID     string `mapstructure:"IMPORT_S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `mapstructure:"IMPORT_S3_SECRET_ACCESS_KEY"`
+	S3SessionToken    string `mapstructure:"IMPORT_S3_SESSION_TOKEN"`
+	// S3Endpoint addresses an S3 compatible store such as MinIO instead of AWS
+	S3Endpoint string `mapstructure:"IMPORT_S3_ENDPOINT"`
+	// SQLEnabled allows command-line imports of sql: queries, run with SQLDriver against the database of SQLDSN
+	SQLEnabled bool   `mapstructure:"IMPORT_SQL_ENABLED"`
+	SQLDriver  string `mapstructure:"IMPORT_SQL_DRIVER"`
+	SQLDSN     string `mapstructure:"IMPORT_SQL_DSN"`
+	// Format forces the source format of a command-line import (--import-csv); empty detects it from the path
+	Format string `mapstructure:"-"`
+	// Sheet selects the worksheet of a command-line workbook import (--sheet) and AllSheets imports every
+	// worksheet with the required columns (--all-sheets)
+	Sheet     string `mapstructure:"-"`
+	AllSheets bool   `mapstructure:"-"`
+}
+
+// ----- Canary search configuration -----
+type CanaryConfig struct {
+	// Keywords are searched on the new and the previous index before an alias swap; no keywords disables canaries
+	Keywords []string `mapstructure:"CANARY_KEYWORDS"`
+	TopK     int      `mapstructure:"CANARY_TOP_K"`
+	// MinOverlap is the smallest share of the previous top-K hits the new index must still return
+	MinOverlap float64 `mapstructure:"CANARY_MIN_OVERLAP"`
+	// MaxCountChange is the largest relative change of the hit count
+	MaxCountChange float64 `mapstructure:"CANARY_MAX_COUNT_CHANGE"`
+	// OnFailure is "fail" to keep the alias on the previous index or "alert" to swap anyway and log the divergence
+	OnFailure string `mapstructure:"CANARY_ON_FAILURE"`
+}
+
+// ----- Change event outbox configuration -----
+type OutboxConfig struct {
+	Enabled         bool   `mapstructure:"OUTBOX_ENABLED"`
+	WebhookURL      string `mapstructure:"OUTBOX_WEBHOOK_URL"`
+	KafkaRESTURL    string `mapstructure:"OUTBOX_KAFKA_REST_URL"`
+	KafkaTopic      string `mapstructure:"OUTBOX_KAFKA_TOPIC"`
+	PollIntervalSec int    `mapstructure:"OUTBOX_POLL_INTERVAL_SEC"`
+}
+
+// ----- Catalog completeness configuration -----
+type CompletenessConfig struct {
+	// RequiredFields must be non-empty on every live product after an import
+	RequiredFields []string `mapstructure:"COMPLETENESS_REQUIRED_FIELDS"`
+	// WebhookURL receives a report of the failing products; completeness checks are off when empty
+	WebhookURL     string `mapstructure:"COMPLETENESS_WEBHOOK_URL"`
+	MaxReportedIDs int    `mapstructure:"COMPLETENESS_MAX_REPORTED_IDS"`
+}
+
+// ----- Search analytics configuration -----
+type AnalyticsConfig struct {
+	Enabled bool `mapstructure:"ANALYTICS_ENABLED"`
+	// RollupHour is the UTC hour at which the previous day's search logs are rolled up
+	RollupHour    int `mapstructure:"ANALYTICS_ROLLUP_HOUR"`
+	RetentionDays int `mapstructure:"ANALYTICS_RETENTION_DAYS"`
 }
 
 // ----- Main configuration struct -----
@@ -38,6 +238,21 @@ type Config struct {
 	Environment   Environment `mapstructure:"ENVIRONMENT"`
 	Server        ServerConfig
 	Elasticsearch ElasticsearchConfig
+	Search        SearchConfig
+	Response      ResponseConfig
+	Document      DocumentConfig
+	ID            IDConfig
+	ProductCache  ProductCacheConfig
+	AggCache      AggregationCacheConfig
+	Admin         AdminConfig
+	Recorder      RecorderConfig
+	Enrichment    EnrichmentConfig
+	Rewrite       RewriteConfig
+	Import        ImportConfig
+	Canary        CanaryConfig
+	Outbox        OutboxConfig
+	Completeness  CompletenessConfig
+	Analytics     AnalyticsConfig
 }
 
 // Load loads the configuration from .env file
@@ -60,15 +275,82 @@ func Load() (*Config, error) {
 	cfg := Config{
 		Environment: EnvDevelopment,
 		Server: ServerConfig{
-			Address:         ":8080",
-			ReadTimeoutSec:  30,
-			WriteTimeoutSec: 30,
-			IdleTimeoutSec:  60,
+			Address:          ":8080",
+			ReadTimeoutSec:   30,
+			WriteTimeoutSec:  30,
+			IdleTimeoutSec:   60,
+			SearchTimeoutSec: 10,
+			BulkTimeoutSec:   120,
+			AdminTimeoutSec:  300,
+			ExportTimeoutSec: 1800,
 		},
 		Elasticsearch: ElasticsearchConfig{
-			Addresses:  []string{"http://localhost:9200"},
-			Index:      "documents",
-			TimeoutSec: 10,
+			Addresses:        []string{"http://localhost:9200"},
+			Index:            "documents",
+			TimeoutSec:       10,
+			EdgeNGramMinGram: 2,
+			EdgeNGramMaxGram: 15,
+		},
+		Search: SearchConfig{
+			HighlightFormat: "html",
+			Fuzziness:       "AUTO",
+			TrackTotalHits:  "true",
+			PITKeepAlive:    "2m",
+			MaxResultWindow: 10000,
+			DiversityTopK:   10,
+			FacetSize:       10,
+		},
+		Document: DocumentConfig{
+			MaxBytes:       32768,
+			OversizePolicy: "reject",
+		},
+		ID: IDConfig{
+			Strategy: "elasticsearch",
+		},
+		ProductCache: ProductCacheConfig{
+			TTLSec:         30,
+			NegativeTTLSec: 5,
+			MaxEntries:     10000,
+		},
+		AggCache: AggregationCacheConfig{
+			TTLSec:     60,
+			MaxEntries: 1000,
+		},
+		Recorder: RecorderConfig{
+			Path: "search-recordings.ndjson",
+		},
+		Import: ImportConfig{
+			MaxFailedRatio:      0.01,
+			MaxSkippedRatio:     0.05,
+			MaxShrinkRatio:      0.2,
+			Mode:                "index",
+			DuplicatePolicy:     "last-wins",
+			IDPolicy:            "column",
+			BatchSize:           100,
+			ProgressIntervalSec: 10,
+			UploadMaxMB:         100,
+			CSVDelimiter:        ",",
+			CSVQuote:            `"`,
+			CSVEncoding:         "auto",
+			SQLDriver:           "postgres",
+		},
+		Canary: CanaryConfig{
+			TopK:           10,
+			MinOverlap:     0.7,
+			MaxCountChange: 0.2,
+			OnFailure:      "fail",
+		},
+		Outbox: OutboxConfig{
+			KafkaTopic:      "product-changes",
+			PollIntervalSec: 5,
+		},
+		Completeness: CompletenessConfig{
+			RequiredFields: []string{"company", "drug_generic"},
+			MaxReportedIDs: 1000,
+		},
+		Analytics: AnalyticsConfig{
+			RollupHour:    2,
+			RetentionDays: 30,
 		},
 	}
 
@@ -96,6 +378,22 @@ func Load() (*Config, error) {
 		cfg.Server.ReadTimeoutSec = serverReadTimeout
 	}
 
+	if searchTimeout := v.GetInt("SERVER_SEARCH_TIMEOUT_SEC"); searchTimeout != 0 {
+		cfg.Server.SearchTimeoutSec = searchTimeout
+	}
+
+	if bulkTimeout := v.GetInt("SERVER_BULK_TIMEOUT_SEC"); bulkTimeout != 0 {
+		cfg.Server.BulkTimeoutSec = bulkTimeout
+	}
+
+	if adminTimeout := v.GetInt("SERVER_ADMIN_TIMEOUT_SEC"); adminTimeout != 0 {
+		cfg.Server.AdminTimeoutSec = adminTimeout
+	}
+
+	if exportTimeout := v.GetInt("SERVER_EXPORT_TIMEOUT_SEC"); exportTimeout != 0 {
+		cfg.Server.ExportTimeoutSec = exportTimeout
+	}
+
 	if esAddresses := v.GetString("ELASTICSEARCH_ADDRESSES"); esAddresses != "" {
 		cfg.Elasticsearch.Addresses = strings.Split(esAddresses, ",")
 	}
@@ -104,10 +402,34 @@ func Load() (*Config, error) {
 		cfg.Elasticsearch.Index = esIndex
 	}
 
+	if esIndexTemplate := v.GetString("ELASTICSEARCH_INDEX_TEMPLATE"); esIndexTemplate != "" {
+		cfg.Elasticsearch.IndexTemplate = esIndexTemplate
+	}
+
 	if esTimeout := v.GetInt("ELASTICSEARCH_TIMEOUT_SEC"); esTimeout != 0 {
 		cfg.Elasticsearch.TimeoutSec = esTimeout
 	}
 
+	if esMaxResultWindow := v.GetInt("ELASTICSEARCH_MAX_RESULT_WINDOW"); esMaxResultWindow != 0 {
+		cfg.Elasticsearch.MaxResultWindow = esMaxResultWindow
+	}
+
+	if edgeNGram := v.GetString("ELASTICSEARCH_EDGE_NGRAM"); edgeNGram != "" {
+		cfg.Elasticsearch.EdgeNGram = v.GetBool("ELASTICSEARCH_EDGE_NGRAM")
+	}
+
+	if minGram := v.GetInt("ELASTICSEARCH_EDGE_NGRAM_MIN_GRAM"); minGram != 0 {
+		cfg.Elasticsearch.EdgeNGramMinGram = minGram
+	}
+
+	if maxGram := v.GetInt("ELASTICSEARCH_EDGE_NGRAM_MAX_GRAM"); maxGram != 0 {
+		cfg.Elasticsearch.EdgeNGramMaxGram = maxGram
+	}
+
+	if suggestIndex := v.GetString("ELASTICSEARCH_SUGGEST_INDEX"); suggestIndex != "" {
+		cfg.Elasticsearch.SuggestIndex = v.GetBool("ELASTICSEARCH_SUGGEST_INDEX")
+	}
+
 	if esUsername := v.GetString("ELASTICSEARCH_USERNAME"); esUsername != "" {
 		cfg.Elasticsearch.Username = esUsername
 	}
@@ -116,6 +438,284 @@ func Load() (*Config, error) {
 		cfg.Elasticsearch.Password = esPassword
 	}
 
+	if failOnPartial := v.GetString("SEARCH_FAIL_ON_PARTIAL_RESULTS"); failOnPartial != "" {
+		cfg.Search.FailOnPartialResults = v.GetBool("SEARCH_FAIL_ON_PARTIAL_RESULTS")
+	}
+
+	if dedupe := v.GetString("SEARCH_DEDUPE"); dedupe != "" {
+		cfg.Search.Dedupe = v.GetBool("SEARCH_DEDUPE")
+	}
+
+	if highlightFormat := v.GetString("SEARCH_HIGHLIGHT_FORMAT"); highlightFormat != "" {
+		cfg.Search.HighlightFormat = highlightFormat
+	}
+
+	if omitEmpty := v.GetString("RESPONSE_OMIT_EMPTY_FIELDS"); omitEmpty != "" {
+		cfg.Response.OmitEmptyFields = v.GetBool("RESPONSE_OMIT_EMPTY_FIELDS")
+	}
+
+	if maxBytes := v.GetString("DOCUMENT_MAX_BYTES"); maxBytes != "" {
+		cfg.Document.MaxBytes = v.GetInt("DOCUMENT_MAX_BYTES")
+	}
+
+	if oversizePolicy := v.GetString("DOCUMENT_OVERSIZE_POLICY"); oversizePolicy != "" {
+		cfg.Document.OversizePolicy = oversizePolicy
+	}
+
+	if idStrategy := v.GetString("ID_STRATEGY"); idStrategy != "" {
+		cfg.ID.Strategy = idStrategy
+	}
+
+	// Node 0 is a valid snowflake node, so check for presence rather than a non-zero value
+	if snowflakeNode := v.GetString("ID_SNOWFLAKE_NODE"); snowflakeNode != "" {
+		cfg.ID.SnowflakeNode = v.GetInt64("ID_SNOWFLAKE_NODE")
+	}
+
+	if pitKeepAlive := v.GetString("SEARCH_PIT_KEEP_ALIVE"); pitKeepAlive != "" {
+		cfg.Search.PITKeepAlive = pitKeepAlive
+	}
+
+	if maxResultWindow := v.GetInt("SEARCH_MAX_RESULT_WINDOW"); maxResultWindow != 0 {
+		cfg.Search.MaxResultWindow = maxResultWindow
+	}
+
+	if maxPerCompany := v.GetInt("SEARCH_DIVERSITY_MAX_PER_COMPANY"); maxPerCompany != 0 {
+		cfg.Search.DiversityMaxPerCompany = maxPerCompany
+	}
+
+	if diversityTopK := v.GetInt("SEARCH_DIVERSITY_TOP_K"); diversityTopK != 0 {
+		cfg.Search.DiversityTopK = diversityTopK
+	}
+
+	if facetSize := v.GetInt("SEARCH_FACET_SIZE"); facetSize != 0 {
+		cfg.Search.FacetSize = facetSize
+	}
+
+	if fuzziness := v.GetString("SEARCH_FUZZINESS"); fuzziness != "" {
+		cfg.Search.Fuzziness = fuzziness
+	}
+
+	if productCacheEnabled := v.GetString("PRODUCT_CACHE_ENABLED"); productCacheEnabled != "" {
+		cfg.ProductCache.Enabled = v.GetBool("PRODUCT_CACHE_ENABLED")
+	}
+
+	if productCacheTTL := v.GetInt("PRODUCT_CACHE_TTL_SEC"); productCacheTTL != 0 {
+		cfg.ProductCache.TTLSec = productCacheTTL
+	}
+
+	if productCacheNegativeTTL := v.GetInt("PRODUCT_CACHE_NEGATIVE_TTL_SEC"); productCacheNegativeTTL != 0 {
+		cfg.ProductCache.NegativeTTLSec = productCacheNegativeTTL
+	}
+
+	if productCacheMaxEntries := v.GetInt("PRODUCT_CACHE_MAX_ENTRIES"); productCacheMaxEntries != 0 {
+		cfg.ProductCache.MaxEntries = productCacheMaxEntries
+	}
+
+	if aggCacheEnabled := v.GetString("AGGREGATION_CACHE_ENABLED"); aggCacheEnabled != "" {
+		cfg.AggCache.Enabled = v.GetBool("AGGREGATION_CACHE_ENABLED")
+	}
+
+	if aggCacheTTL := v.GetInt("AGGREGATION_CACHE_TTL_SEC"); aggCacheTTL != 0 {
+		cfg.AggCache.TTLSec = aggCacheTTL
+	}
+
+	if aggCacheMaxEntries := v.GetInt("AGGREGATION_CACHE_MAX_ENTRIES"); aggCacheMaxEntries != 0 {
+		cfg.AggCache.MaxEntries = aggCacheMaxEntries
+	}
+
+	if trackTotalHits := v.GetString("SEARCH_TRACK_TOTAL_HITS"); trackTotalHits != "" {
+		cfg.Search.TrackTotalHits = trackTotalHits
+	}
+
+	if minScore := v.GetString("SEARCH_MIN_SCORE"); minScore != "" {
+		cfg.Search.MinScore = v.GetFloat64("SEARCH_MIN_SCORE")
+	}
+
+	if adminAPIKey := v.GetString("ADMIN_API_KEY"); adminAPIKey != "" {
+		cfg.Admin.APIKey = adminAPIKey
+	}
+
+	if recorderEnabled := v.GetString("SEARCH_RECORDER_ENABLED"); recorderEnabled != "" {
+		cfg.Recorder.Enabled = v.GetBool("SEARCH_RECORDER_ENABLED")
+	}
+
+	if recorderPath := v.GetString("SEARCH_RECORDER_PATH"); recorderPath != "" {
+		cfg.Recorder.Path = recorderPath
+	}
+
+	if enrichmentChain := v.GetString("ENRICHMENT_CHAIN"); enrichmentChain != "" {
+		cfg.Enrichment.Chain = strings.Split(enrichmentChain, ",")
+	}
+
+	if rewriteRules := v.GetString("QUERY_REWRITE_RULES"); rewriteRules != "" {
+		cfg.Rewrite.Rules = strings.Split(rewriteRules, ",")
+	}
+
+	if maxFailedRatio := v.GetString("IMPORT_MAX_FAILED_RATIO"); maxFailedRatio != "" {
+		cfg.Import.MaxFailedRatio = v.GetFloat64("IMPORT_MAX_FAILED_RATIO")
+	}
+
+	if maxSkippedRatio := v.GetString("IMPORT_MAX_SKIPPED_RATIO"); maxSkippedRatio != "" {
+		cfg.Import.MaxSkippedRatio = v.GetFloat64("IMPORT_MAX_SKIPPED_RATIO")
+	}
+
+	if maxShrinkRatio := v.GetString("IMPORT_MAX_SHRINK_RATIO"); maxShrinkRatio != "" {
+		cfg.Import.MaxShrinkRatio = v.GetFloat64("IMPORT_MAX_SHRINK_RATIO")
+	}
+
+	if importMode := v.GetString("IMPORT_MODE"); importMode != "" {
+		cfg.Import.Mode = importMode
+	}
+
+	if duplicatePolicy := v.GetString("IMPORT_DUPLICATE_POLICY"); duplicatePolicy != "" {
+		cfg.Import.DuplicatePolicy = duplicatePolicy
+	}
+
+	if idPolicy := v.GetString("IMPORT_ID_POLICY"); idPolicy != "" {
+		cfg.Import.IDPolicy = idPolicy
+	}
+
+	if batchSize := v.GetString("IMPORT_BATCH_SIZE"); batchSize != "" {
+		cfg.Import.BatchSize = v.GetInt("IMPORT_BATCH_SIZE")
+	}
+
+	if failOnError := v.GetString("IMPORT_FAIL_ON_ERROR"); failOnError != "" {
+		cfg.Import.FailOnError = v.GetInt("IMPORT_FAIL_ON_ERROR")
+	}
+
+	if progressInterval := v.GetString("IMPORT_PROGRESS_INTERVAL_SEC"); progressInterval != "" {
+		cfg.Import.ProgressIntervalSec = v.GetInt("IMPORT_PROGRESS_INTERVAL_SEC")
+	}
+
+	if rejectReport := v.GetString("IMPORT_REJECT_REPORT"); rejectReport != "" {
+		cfg.Import.RejectReport = rejectReport
+	}
+
+	if uploadDir := v.GetString("IMPORT_UPLOAD_DIR"); uploadDir != "" {
+		cfg.Import.UploadDir = uploadDir
+	}
+
+	if uploadMax := v.GetString("IMPORT_UPLOAD_MAX_MB"); uploadMax != "" {
+		cfg.Import.UploadMaxMB = v.GetInt("IMPORT_UPLOAD_MAX_MB")
+	}
+
+	if mappingFile := v.GetString("IMPORT_MAPPING_FILE"); mappingFile != "" {
+		cfg.Import.MappingFile = mappingFile
+	}
+
+	if csvDelimiter := v.GetString("IMPORT_CSV_DELIMITER"); csvDelimiter != "" {
+		cfg.Import.CSVDelimiter = csvDelimiter
+	}
+
+	if csvQuote := v.GetString("IMPORT_CSV_QUOTE"); csvQuote != "" {
+		cfg.Import.CSVQuote = csvQuote
+	}
+
+	if csvEncoding := v.GetString("IMPORT_CSV_ENCODING"); csvEncoding != "" {
+		cfg.Import.CSVEncoding = csvEncoding
+	}
+
+	if googleCredentialsFile := v.GetString("IMPORT_GOOGLE_CREDENTIALS_FILE"); googleCredentialsFile != "" {
+		cfg.Import.GoogleCredentialsFile = googleCredentialsFile
+	}
+
+	if s3Region := v.GetString("IMPORT_S3_REGION"); s3Region != "" {
+		cfg.Import.S3Region = s3Region
+	}
+
+	if s3AccessKeyID := v.GetString("IMPORT_S3_ACCESS_KEY_ID"); s3AccessKeyID != "" {
+		cfg.Import.S3AccessKeyID = s3AccessKeyID
+	}
+
+	if s3SecretAccessKey := v.GetString("IMPORT_S3_SECRET_ACCESS_KEY"); s3SecretAccessKey != "" {
+		cfg.Import.S3SecretAccessKey = s3SecretAccessKey
+	}
+
+	if s3SessionToken := v.GetString("IMPORT_S3_SESSION_TOKEN"); s3SessionToken != "" {
+		cfg.Import.S3SessionToken = s3SessionToken
+	}
+
+	if s3Endpoint := v.GetString("IMPORT_S3_ENDPOINT"); s3Endpoint != "" {
+		cfg.Import.S3Endpoint = s3Endpoint
+	}
+
+	if sqlEnabled := v.GetString("IMPORT_SQL_ENABLED"); sqlEnabled != "" {
+		cfg.Import.SQLEnabled = v.GetBool("IMPORT_SQL_ENABLED")
+	}
+
+	if sqlDriver := v.GetString("IMPORT_SQL_DRIVER"); sqlDriver != "" {
+		cfg.Import.SQLDriver = sqlDriver
+	}
+
+	if sqlDSN := v.GetString("IMPORT_SQL_DSN"); sqlDSN != "" {
+		cfg.Import.SQLDSN = sqlDSN
+	}
+
+	if canaryKeywords := v.GetString("CANARY_KEYWORDS"); canaryKeywords != "" {
+		cfg.Canary.Keywords = strings.Split(canaryKeywords, ",")
+	}
+
+	if canaryTopK := v.GetInt("CANARY_TOP_K"); canaryTopK != 0 {
+		cfg.Canary.TopK = canaryTopK
+	}
+
+	if minOverlap := v.GetString("CANARY_MIN_OVERLAP"); minOverlap != "" {
+		cfg.Canary.MinOverlap = v.GetFloat64("CANARY_MIN_OVERLAP")
+	}
+
+	if maxCountChange := v.GetString("CANARY_MAX_COUNT_CHANGE"); maxCountChange != "" {
+		cfg.Canary.MaxCountChange = v.GetFloat64("CANARY_MAX_COUNT_CHANGE")
+	}
+
+	if canaryOnFailure := v.GetString("CANARY_ON_FAILURE"); canaryOnFailure != "" {
+		cfg.Canary.OnFailure = canaryOnFailure
+	}
+
+	if outboxEnabled := v.GetString("OUTBOX_ENABLED"); outboxEnabled != "" {
+		cfg.Outbox.Enabled = v.GetBool("OUTBOX_ENABLED")
+	}
+
+	if outboxWebhookURL := v.GetString("OUTBOX_WEBHOOK_URL"); outboxWebhookURL != "" {
+		cfg.Outbox.WebhookURL = outboxWebhookURL
+	}
+
+	if outboxKafkaRESTURL := v.GetString("OUTBOX_KAFKA_REST_URL"); outboxKafkaRESTURL != "" {
+		cfg.Outbox.KafkaRESTURL = outboxKafkaRESTURL
+	}
+
+	if outboxKafkaTopic := v.GetString("OUTBOX_KAFKA_TOPIC"); outboxKafkaTopic != "" {
+		cfg.Outbox.KafkaTopic = outboxKafkaTopic
+	}
+
+	if outboxPollInterval := v.GetInt("OUTBOX_POLL_INTERVAL_SEC"); outboxPollInterval != 0 {
+		cfg.Outbox.PollIntervalSec = outboxPollInterval
+	}
+
+	if requiredFields := v.GetString("COMPLETENESS_REQUIRED_FIELDS"); requiredFields != "" {
+		cfg.Completeness.RequiredFields = strings.Split(requiredFields, ",")
+	}
+
+	if completenessWebhookURL := v.GetString("COMPLETENESS_WEBHOOK_URL"); completenessWebhookURL != "" {
+		cfg.Completeness.WebhookURL = completenessWebhookURL
+	}
+
+	if maxReportedIDs := v.GetInt("COMPLETENESS_MAX_REPORTED_IDS"); maxReportedIDs != 0 {
+		cfg.Completeness.MaxReportedIDs = maxReportedIDs
+	}
+
+	if analyticsEnabled := v.GetString("ANALYTICS_ENABLED"); analyticsEnabled != "" {
+		cfg.Analytics.Enabled = v.GetBool("ANALYTICS_ENABLED")
+	}
+
+	// Hour 0 (midnight) is a valid rollup time, so check for presence rather than a non-zero value
+	if rollupHour := v.GetString("ANALYTICS_ROLLUP_HOUR"); rollupHour != "" {
+		cfg.Analytics.RollupHour = v.GetInt("ANALYTICS_ROLLUP_HOUR")
+	}
+
+	if retentionDays := v.GetInt("ANALYTICS_RETENTION_DAYS"); retentionDays != 0 {
+		cfg.Analytics.RetentionDays = retentionDays
+	}
+
 	return &cfg, nil
 }
 
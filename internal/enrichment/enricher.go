@@ -0,0 +1,77 @@
+// Package enrichment provides the document enrichment chain applied before indexing
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"elasticsearch/internal/models"
+)
+
+// DocumentEnricher mutates a product before it is written to the index
+type DocumentEnricher interface {
+	Name() string
+	Enrich(ctx context.Context, product *models.Product) error
+}
+
+// Factory creates a DocumentEnricher
+type Factory func() DocumentEnricher
+
+var registry = map[string]Factory{}
+
+// Register makes an enricher available by name for chain configuration
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// Available returns the names of all registered enrichers
+func Available() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Chain runs enrichers in order, stopping at the first error
+type Chain []DocumentEnricher
+
+// NewChain builds a Chain from registered enricher names, preserving their order
+func NewChain(names []string) (Chain, error) {
+	chain := make(Chain, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		factory, ok := registry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown enricher %q (available: %s)", name, strings.Join(Available(), ", "))
+		}
+		chain = append(chain, factory())
+	}
+	return chain, nil
+}
+
+// Name implements DocumentEnricher
+func (c Chain) Name() string {
+	names := make([]string, len(c))
+	for i, enricher := range c {
+		names[i] = enricher.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+// Enrich implements DocumentEnricher by applying each enricher in order
+func (c Chain) Enrich(ctx context.Context, product *models.Product) error {
+	for _, enricher := range c {
+		if err := enricher.Enrich(ctx, product); err != nil {
+			return fmt.Errorf("enricher %s: %w", enricher.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package enrichment
+
+import (
+	"context"
+	"strings"
+
+	"elasticsearch/internal/models"
+)
+
+func init() {
+	Register("normalize", func() DocumentEnricher { return NormalizeEnricher{} })
+}
+
+// NormalizeEnricher trims text fields and collapses repeated whitespace
+type NormalizeEnricher struct{}
+
+// Name implements DocumentEnricher
+func (NormalizeEnricher) Name() string {
+	return "normalize"
+}
+
+// Enrich implements DocumentEnricher
+func (NormalizeEnricher) Enrich(_ context.Context, product *models.Product) error {
+	product.ProductName = collapseWhitespace(product.ProductName)
+	product.DrugGeneric = collapseWhitespace(product.DrugGeneric)
+	product.Company = collapseWhitespace(product.Company)
+	return nil
+}
+
+// collapseWhitespace trims the value and replaces inner whitespace runs with a single space
+func collapseWhitespace(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
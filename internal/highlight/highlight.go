@@ -0,0 +1,93 @@
+// Package highlight turns Elasticsearch highlight fragments into output that is safe for any client.
+//
+// Elasticsearch is asked to wrap matches in private-use marker runes instead of HTML tags, so the
+// raw field content can be escaped (or left as plain text) before the markers are replaced.
+package highlight
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Marker runes sent to Elasticsearch as pre_tags/post_tags
+const (
+	PreTag  = "\uE000"
+	PostTag = "\uE001"
+)
+
+const (
+	preRune  = '\uE000'
+	postRune = '\uE001'
+)
+
+// Format selects how highlight fragments are emitted
+type Format string
+
+const (
+	// FormatHTML emits HTML-escaped text where only the <em> tags around matches are markup
+	FormatHTML Format = "html"
+	// FormatOffsets emits plain text with the rune offsets of every match
+	FormatOffsets Format = "offsets"
+)
+
+// ParseFormat validates a highlight format name
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case FormatHTML:
+		return FormatHTML, nil
+	case FormatOffsets:
+		return FormatOffsets, nil
+	}
+	return "", fmt.Errorf("unknown highlight format %q (expected html or offsets)", value)
+}
+
+// Span is a highlighted match given as [Start, End) rune offsets into Fragment.Text
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Fragment is a plain text highlight fragment with its match offsets
+type Fragment struct {
+	Text    string `json:"text"`
+	Matches []Span `json:"matches"`
+}
+
+// ToHTML escapes the fragment and wraps matches in <em> tags
+func ToHTML(fragment string) string {
+	escaped := html.EscapeString(fragment)
+	escaped = strings.ReplaceAll(escaped, PreTag, "<em>")
+	return strings.ReplaceAll(escaped, PostTag, "</em>")
+}
+
+// ToOffsets strips the markers and returns the plain text with match offsets
+func ToOffsets(fragment string) Fragment {
+	var text strings.Builder
+	result := Fragment{Matches: []Span{}}
+	position := 0
+	start := -1
+
+	for _, r := range fragment {
+		switch r {
+		case preRune:
+			start = position
+		case postRune:
+			if start >= 0 {
+				result.Matches = append(result.Matches, Span{Start: start, End: position})
+				start = -1
+			}
+		default:
+			text.WriteRune(r)
+			position++
+		}
+	}
+
+	result.Text = text.String()
+	return result
+}
+
+// Strip removes the markers, leaving the raw fragment text
+func Strip(fragment string) string {
+	return strings.NewReplacer(PreTag, "", PostTag, "").Replace(fragment)
+}
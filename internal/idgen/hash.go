@@ -0,0 +1,20 @@
+package idgen
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+
+	"elasticsearch/internal/models"
+)
+
+// StrategyHash derives IDs from the content of a product instead of generating them, so the same product gets the
+// same ID every time. Imports select it through IMPORT_ID_POLICY; it isn't an ID_STRATEGY, as products created
+// through the API are not keyed by their content.
+const StrategyHash = "hash"
+
+// Hash returns the ID derived from a key: the first 8 bytes of its SHA-1 in hex. Numeric looking hashes are
+// normalized like any product ID.
+func Hash(key string) (models.ProductID, error) {
+	sum := sha1.Sum([]byte(key))
+	return models.ParseProductID(hex.EncodeToString(sum[:8]))
+}
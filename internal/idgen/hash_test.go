@@ -0,0 +1,14 @@
+package idgen
+
+import "testing"
+
+func TestHashIsStable(t *testing.T) {
+	// Re-imports overwrite the products of earlier imports only as long as the IDs they derive don't change
+	id, err := Hash("parol 500 mg\x00atabay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "aaf81a9916534d3e"; id.String() != want {
+		t.Fatalf("hash ID is %s, want %s", id, want)
+	}
+}
@@ -0,0 +1,68 @@
+// Package idgen assigns server-side IDs to products created without one
+package idgen
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"elasticsearch/internal/models"
+)
+
+// ID strategies selectable through ID_STRATEGY
+const (
+	// StrategyElasticsearch leaves ID assignment to Elasticsearch (random 20 character IDs)
+	StrategyElasticsearch = "elasticsearch"
+	// StrategySequence hands out increasing numbers from a counter document stored in Elasticsearch
+	StrategySequence = "sequence"
+	// StrategyUUIDv7 generates time-ordered UUIDs
+	StrategyUUIDv7 = "uuidv7"
+	// StrategySnowflake generates time-ordered 63-bit numbers unique per node
+	StrategySnowflake = "snowflake"
+)
+
+// productSequence is the name of the counter backing the sequence strategy
+const productSequence = "products"
+
+// Generator produces the ID of a new product
+type Generator interface {
+	NextID(ctx context.Context) (models.ProductID, error)
+}
+
+// SequenceStore atomically increments a named counter and returns its new value
+type SequenceStore interface {
+	NextValue(ctx context.Context, name string) (int64, error)
+}
+
+// New returns the Generator of a strategy, or nil for StrategyElasticsearch.
+// The node ID is only used by the snowflake strategy and the store only by the sequence strategy.
+func New(strategy string, node int64, store SequenceStore) (Generator, error) {
+	switch strategy {
+	case StrategyElasticsearch:
+		return nil, nil
+	case StrategySequence:
+		return &sequenceGenerator{store: store, name: productSequence}, nil
+	case StrategyUUIDv7:
+		return UUIDv7{}, nil
+	case StrategySnowflake:
+		return NewSnowflake(node)
+	default:
+		return nil, fmt.Errorf("unknown ID strategy %q, expected %s, %s, %s or %s",
+			strategy, StrategyElasticsearch, StrategySequence, StrategyUUIDv7, StrategySnowflake)
+	}
+}
+
+// sequenceGenerator numbers products from a counter shared by every server instance
+type sequenceGenerator struct {
+	store SequenceStore
+	name  string
+}
+
+// NextID implements Generator
+func (g *sequenceGenerator) NextID(ctx context.Context) (models.ProductID, error) {
+	value, err := g.store.NextValue(ctx, g.name)
+	if err != nil {
+		return "", fmt.Errorf("failed to increment sequence %s: %w", g.name, err)
+	}
+	return models.ProductID(strconv.FormatInt(value, 10)), nil
+}
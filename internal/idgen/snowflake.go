@@ -0,0 +1,67 @@
+package idgen
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"elasticsearch/internal/models"
+)
+
+// Snowflake ID layout: 41 bits of milliseconds since snowflakeEpoch, 10 bits of node ID and a 12-bit
+// sequence within the millisecond
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	// MaxSnowflakeNode is the largest node ID
+	MaxSnowflakeNode = 1<<snowflakeNodeBits - 1
+	maxSnowflakeSeq  = 1<<snowflakeSequenceBits - 1
+)
+
+// snowflakeEpoch is the start of snowflake timestamps, which keeps IDs short for decades
+var snowflakeEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+// Snowflake generates IDs unique across nodes without coordination, as long as every server
+// instance has its own node ID
+type Snowflake struct {
+	node int64
+
+	mu       sync.Mutex
+	lastTime int64
+	sequence int64
+}
+
+// NewSnowflake creates a Snowflake generator for a node ID between 0 and MaxSnowflakeNode
+func NewSnowflake(node int64) (*Snowflake, error) {
+	if node < 0 || node > MaxSnowflakeNode {
+		return nil, fmt.Errorf("invalid snowflake node %d, expected 0-%d", node, MaxSnowflakeNode)
+	}
+	return &Snowflake{node: node}, nil
+}
+
+// NextID implements Generator
+func (s *Snowflake) NextID(ctx context.Context) (models.ProductID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Never step back in time, even if the clock does
+	now := max(time.Now().UnixMilli()-snowflakeEpoch, s.lastTime)
+	if now == s.lastTime {
+		s.sequence = (s.sequence + 1) & maxSnowflakeSeq
+		// Sequence exhausted for this millisecond: wait for the next one
+		if s.sequence == 0 {
+			for now <= s.lastTime {
+				time.Sleep(100 * time.Microsecond)
+				now = time.Now().UnixMilli() - snowflakeEpoch
+			}
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastTime = now
+
+	id := now<<(snowflakeNodeBits+snowflakeSequenceBits) | s.node<<snowflakeSequenceBits | s.sequence
+	return models.ProductID(strconv.FormatInt(id, 10)), nil
+}
@@ -0,0 +1,57 @@
+package idgen
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"elasticsearch/internal/models"
+)
+
+// maxSafeInteger is the largest integer JavaScript numbers represent exactly
+const maxSafeInteger = 1<<53 - 1
+
+func TestSnowflakeIDsKeepPrecisionInJSON(t *testing.T) {
+	generator, err := NewSnowflake(MaxSnowflakeNode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var previous uint64
+	for i := 0; i < 1000; i++ {
+		id, err := generator.NextID(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n, err := strconv.ParseUint(id.String(), 10, 64)
+		if err != nil {
+			t.Fatalf("snowflake ID %q is not a number: %v", id, err)
+		}
+		if n <= previous {
+			t.Fatalf("snowflake ID %d doesn't follow %d", n, previous)
+		}
+		previous = n
+		if n <= maxSafeInteger {
+			t.Fatalf("snowflake ID %d unexpectedly fits in 53 bits, the test no longer covers large IDs", n)
+		}
+
+		// Large IDs must reach clients as strings, or JavaScript rounds them
+		data, err := json.Marshal(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := strconv.Quote(id.String()); string(data) != want {
+			t.Fatalf("snowflake ID marshaled as %s, want %s", data, want)
+		}
+
+		var decoded models.ProductID
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded != id {
+			t.Fatalf("snowflake ID %s decoded as %s", id, decoded)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package idgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"elasticsearch/internal/models"
+)
+
+// UUIDv7 generates RFC 9562 version 7 UUIDs: a millisecond timestamp followed by random bits,
+// so IDs sort roughly by creation time
+type UUIDv7 struct{}
+
+// NextID implements Generator
+func (UUIDv7) NextID(ctx context.Context) (models.ProductID, error) {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[6:]); err != nil {
+		return "", fmt.Errorf("failed to read random bits: %w", err)
+	}
+
+	// 48-bit big-endian Unix timestamp in milliseconds
+	var millis [8]byte
+	binary.BigEndian.PutUint64(millis[:], uint64(time.Now().UnixMilli()))
+	copy(uuid[:6], millis[2:])
+
+	uuid[6] = uuid[6]&0x0f | 0x70 // version 7
+	uuid[8] = uuid[8]&0x3f | 0x80 // RFC 9562 variant
+
+	text := hex.EncodeToString(uuid[:])
+	return models.ProductID(text[:8] + "-" + text[8:12] + "-" + text[12:16] + "-" + text[16:20] + "-" + text[20:]), nil
+}
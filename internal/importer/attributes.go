@@ -0,0 +1,171 @@
+package importer
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	storageEs "elasticsearch/internal/storage/elasticsearch"
+)
+
+// attributeTypes are the column types attribute values are converted to
+var attributeTypes = []string{ColumnTypeInteger, ColumnTypeNumber, ColumnTypeBoolean, ColumnTypeDate, ColumnTypeText}
+
+// Attribute is a source column an import keeps besides the product fields, under the attributes object of products
+type Attribute struct {
+	// Column is the source column the attribute is read from; sources without it import no value
+	Column string `json:"column" yaml:"column"`
+	// Type is the column type values are converted to: integer, number, boolean, date or text (the default)
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+// attributeKey is the columnMap key of the column of an attribute
+func attributeKey(name string) string {
+	return "attributes." + name
+}
+
+// validateAttributes checks the names and types of the attributes of a mapping
+func (m Mapping) validateAttributes() error {
+	for name, attribute := range m.Attributes {
+		if name == "" || strings.ContainsAny(name, ". ") || strings.HasPrefix(name, "_") {
+			return fmt.Errorf("invalid attribute name %q: must not be empty, contain dots or spaces or start with _", name)
+		}
+		if strings.TrimSpace(attribute.Column) == "" {
+			return fmt.Errorf("attribute %s has no column", name)
+		}
+		if attribute.Type != "" && !slices.Contains(attributeTypes, attribute.Type) {
+			return fmt.Errorf("invalid type %q of attribute %s, expected %s", attribute.Type, name, strings.Join(attributeTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// attributeNames returns the names of the attributes of a mapping in alphabetical order
+func (m Mapping) attributeNames() []string {
+	names := make([]string, 0, len(m.Attributes))
+	for name := range m.Attributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// attributeProperties returns the Elasticsearch mapping of the attributes of a mapping, keyed by attribute name
+func (m Mapping) attributeProperties() map[string]interface{} {
+	if len(m.Attributes) == 0 {
+		return nil
+	}
+	properties := make(map[string]interface{}, len(m.Attributes))
+	for name, attribute := range m.Attributes {
+		properties[name] = storageEs.AttributeProperty(attribute.Type)
+	}
+	return properties
+}
+
+// readAttributes converts the values of the attribute columns of a row found in columnMap; empty values are left out
+func (m Mapping) readAttributes(fields []string, columnMap map[string]int) (map[string]interface{}, error) {
+	var attributes map[string]interface{}
+	for name, attribute := range m.Attributes {
+		i, ok := columnMap[attributeKey(name)]
+		if !ok || i >= len(fields) {
+			continue
+		}
+		value := strings.TrimSpace(fields[i])
+		if value == "" {
+			continue
+		}
+
+		converted, err := convertAttribute(attribute.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		if attributes == nil {
+			attributes = make(map[string]interface{}, len(m.Attributes))
+		}
+		attributes[name] = converted
+	}
+	return attributes, nil
+}
+
+// convertAttribute converts a non-empty value to the column type of its attribute, the way inspections detect it
+func convertAttribute(columnType, value string) (interface{}, error) {
+	switch columnType {
+	case ColumnTypeInteger:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", value)
+		}
+		return n, nil
+	case ColumnTypeNumber:
+		n, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("invalid number %q", value)
+		}
+		return n, nil
+	case ColumnTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", value)
+		}
+		return b, nil
+	case ColumnTypeDate:
+		for _, layout := range inspectDateLayouts {
+			t, err := time.Parse(layout, value)
+			if err != nil {
+				continue
+			}
+			if layout == time.RFC3339 {
+				return t.Format(time.RFC3339), nil
+			}
+			return t.Format("2006-01-02"), nil
+		}
+		return nil, fmt.Errorf("invalid date %q", value)
+	}
+	return value, nil
+}
+
+// ProposeAttributes adds an attribute to the mapping for every filled column no product field is read from, typed
+// by the type detected for the column, so imports with the mapping keep those columns instead of dropping them. The
+// Elasticsearch mapping the import will put for them is set in AttributeMapping.
+func (i *Inspection) ProposeAttributes() {
+	if i.Mapping.Attributes == nil {
+		i.Mapping.Attributes = make(map[string]Attribute)
+	}
+	for c := range i.Columns {
+		column := &i.Columns[c]
+		if column.MappedTo != "" || column.Type == ColumnTypeEmpty {
+			continue
+		}
+
+		base := attributeName(column.Name)
+		if base == "" {
+			base = fmt.Sprintf("column_%d", c+1)
+		}
+		name := base
+		for n := 2; ; n++ {
+			if _, taken := i.Mapping.Attributes[name]; !taken {
+				break
+			}
+			name = fmt.Sprintf("%s_%d", base, n)
+		}
+
+		i.Mapping.Attributes[name] = Attribute{Column: column.Name, Type: column.Type}
+		column.MappedTo = attributeKey(name)
+	}
+	if properties := i.Mapping.attributeProperties(); properties != nil {
+		i.AttributeMapping = storageEs.AttributesMapping(properties)
+	}
+}
+
+// attributeName turns a column name into an attribute name: lowercase letters and digits, Turkish letters folded
+// to ASCII, words joined by underscores, e.g. "Ambalaj Miktarı" becomes "ambalaj_miktari"
+func attributeName(column string) string {
+	words := strings.FieldsFunc(strings.ToLower(turkishFolder.Replace(column)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "_")
+}
@@ -0,0 +1,242 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CSVOptions selects the dialect of delimited files
+type CSVOptions struct {
+	// Delimiter separates the fields of a record
+	Delimiter rune
+	// Quote encloses fields containing delimiters or line breaks; a doubled quote inside a quoted field stands for one
+	Quote rune
+	// Encoding is the character encoding the data is converted to UTF-8 from; empty detects it
+	Encoding string
+}
+
+// DefaultCSVOptions reads comma separated fields quoted with double quotes
+var DefaultCSVOptions = CSVOptions{Delimiter: ',', Quote: '"'}
+
+// ParseCSVOptions parses the delimiter, quote character and character encoding of a CSV dialect. The delimiter is a
+// single character, or "tab"; the encoding is EncodingAuto or an encoding name such as "windows-1252". Empty values
+// keep the defaults.
+func ParseCSVOptions(delimiter, quote, encoding string) (CSVOptions, error) {
+	opts := DefaultCSVOptions
+
+	if strings.EqualFold(delimiter, "tab") || delimiter == `\t` {
+		delimiter = "\t"
+	}
+	if delimiter != "" {
+		r, size := utf8.DecodeRuneInString(delimiter)
+		if size != len(delimiter) || r == utf8.RuneError {
+			return CSVOptions{}, fmt.Errorf("invalid csv delimiter %q: must be a single character or \"tab\"", delimiter)
+		}
+		opts.Delimiter = r
+	}
+	if quote != "" {
+		r, size := utf8.DecodeRuneInString(quote)
+		if size != len(quote) || r == utf8.RuneError {
+			return CSVOptions{}, fmt.Errorf("invalid csv quote %q: must be a single character", quote)
+		}
+		opts.Quote = r
+	}
+
+	if opts.Delimiter == opts.Quote {
+		return CSVOptions{}, fmt.Errorf("csv delimiter and quote must differ")
+	}
+	var err error
+	if opts.Encoding, err = parseEncoding(encoding); err != nil {
+		return CSVOptions{}, err
+	}
+	for _, r := range []rune{opts.Delimiter, opts.Quote} {
+		if r == '\r' || r == '\n' {
+			return CSVOptions{}, fmt.Errorf("csv delimiter and quote can't be line breaks")
+		}
+	}
+	return opts, nil
+}
+
+// recordReader reads the records of delimited data
+type recordReader interface {
+	Read() ([]string, error)
+}
+
+// CSVSource reads rows from delimited data
+type CSVSource struct {
+	name   string
+	reader recordReader
+	closer io.Closer
+	header []string
+}
+
+// NewCSVSource opens a local CSV file as a RowSource
+func NewCSVSource(path string, opts CSVOptions) (*CSVSource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open csv file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open csv file: %w", err)
+	}
+
+	source, err := newCSVSource(path, newSizedReader(file, info.Size()), opts)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return source, nil
+}
+
+// newCSVSource wraps any reader of CSV data, converting it to UTF-8, and consumes its header row
+func newCSVSource(name string, rc io.ReadCloser, opts CSVOptions) (*CSVSource, error) {
+	if opts.Delimiter == 0 && opts.Quote == 0 {
+		opts.Delimiter, opts.Quote = DefaultCSVOptions.Delimiter, DefaultCSVOptions.Quote
+	}
+
+	text, err := decodeReader(name, rc, opts.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader recordReader
+	if opts.Quote == '"' {
+		csvReader := csv.NewReader(text)
+		csvReader.Comma = opts.Delimiter
+		csvReader.FieldsPerRecord = -1
+		csvReader.TrimLeadingSpace = true
+		reader = csvReader
+	} else {
+		// encoding/csv only supports double quotes
+		reader = &quotedReader{reader: bufio.NewReader(text), delimiter: opts.Delimiter, quote: opts.Quote, line: 1}
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("source contains no data")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	return &CSVSource{
+		name:   name,
+		reader: reader,
+		closer: rc,
+		header: header,
+	}, nil
+}
+
+// Name implements RowSource
+func (s *CSVSource) Name() string {
+	return s.name
+}
+
+// Header implements RowSource
+func (s *CSVSource) Header() []string {
+	return s.header
+}
+
+// Next implements RowSource
+func (s *CSVSource) Next() ([]string, error) {
+	return s.reader.Read()
+}
+
+// Progress implements ProgressSource for files and downloads of known size
+func (s *CSVSource) Progress() (float64, bool) {
+	return readerProgress(s.closer)
+}
+
+// Close implements RowSource
+func (s *CSVSource) Close() error {
+	return s.closer.Close()
+}
+
+// quotedReader reads delimited records whose fields are enclosed in an arbitrary quote character. Like the
+// encoding/csv reader used for double quotes, it skips blank lines and the leading spaces of fields, and quoted
+// fields may span lines.
+type quotedReader struct {
+	reader    *bufio.Reader
+	delimiter rune
+	quote     rune
+	line      int
+}
+
+// Read returns the next record, or io.EOF once the data is exhausted
+func (q *quotedReader) Read() ([]string, error) {
+	var record []string
+	var field strings.Builder
+	startLine := q.line
+	quoted := false
+	// fieldStarted is set once a field has content or an opening quote, so later spaces are kept
+	fieldStarted := false
+
+	for {
+		r, _, err := q.reader.ReadRune()
+		if err == io.EOF {
+			if quoted {
+				return nil, fmt.Errorf("record on line %d: unterminated quoted field", startLine)
+			}
+			if record == nil && !fieldStarted {
+				return nil, io.EOF
+			}
+			return append(record, field.String()), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if quoted {
+			if r == q.quote {
+				// A doubled quote is an escaped quote, anything else closes the field
+				if next, _, err := q.reader.ReadRune(); err == nil && next == q.quote {
+					field.WriteRune(r)
+					continue
+				} else if err == nil {
+					q.reader.UnreadRune()
+				}
+				quoted = false
+				continue
+			}
+			if r == '\n' {
+				q.line++
+			}
+			field.WriteRune(r)
+			continue
+		}
+
+		switch {
+		case r == '\n':
+			q.line++
+			if record == nil && !fieldStarted {
+				// Blank line
+				startLine = q.line
+				continue
+			}
+			return append(record, strings.TrimSuffix(field.String(), "\r")), nil
+		case r == q.delimiter:
+			record = append(record, field.String())
+			field.Reset()
+			fieldStarted = false
+		case !fieldStarted && r == q.quote:
+			quoted, fieldStarted = true, true
+		case !fieldStarted && unicode.IsSpace(r):
+			// Leading space of a field, or the \r of a blank line
+		default:
+			field.WriteRune(r)
+			fieldStarted = true
+		}
+	}
+}
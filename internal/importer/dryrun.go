@@ -0,0 +1,155 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"elasticsearch/internal/models"
+	storageEs "elasticsearch/internal/storage/elasticsearch"
+)
+
+// RejectedRow is a row the import would skip
+type RejectedRow struct {
+	models.ImportRowRef
+	Reason string `json:"reason"`
+}
+
+// DryRunSource summarizes the validation of one source of a dry run
+type DryRunSource struct {
+	Name         string `json:"name"`
+	RowsRead     int    `json:"rows_read"`
+	RowsValid    int    `json:"rows_valid"`
+	RowsRejected int    `json:"rows_rejected"`
+	// Error is set when the source would not be imported, e.g. for a missing column
+	Error string `json:"error,omitempty"`
+}
+
+// DryRunReport is the outcome of validating sources without importing them
+type DryRunReport struct {
+	Index        string `json:"index"`
+	RowsRead     int    `json:"rows_read"`
+	RowsValid    int    `json:"rows_valid"`
+	RowsRejected int    `json:"rows_rejected"`
+	// RowsTruncated counts the valid rows truncated to fit the document limit
+	RowsTruncated int            `json:"rows_truncated"`
+	Sources       []DryRunSource `json:"sources"`
+	// Columns maps product fields to the source columns they are read from, empty for an id generated by the ID policy
+	Columns map[string]string `json:"columns"`
+	// Rejected lists the first rejected rows with the reason they would be skipped
+	Rejected []RejectedRow `json:"rejected,omitempty"`
+	// DuplicatePolicy is applied to the rows repeating an earlier row; under the skip policy they are rejected
+	DuplicatePolicy DuplicatePolicy `json:"duplicate_policy"`
+	// IDPolicy decides where the IDs of the products come from
+	IDPolicy IDPolicy `json:"id_policy"`
+	// DuplicateIDs and NearDuplicates count the IDs, and the product names and companies under different IDs,
+	// found on several rows; Duplicates lists the first of them in order of appearance
+	DuplicateIDs   int                      `json:"duplicate_ids"`
+	NearDuplicates int                      `json:"near_duplicates"`
+	Duplicates     []models.ImportDuplicate `json:"duplicates,omitempty"`
+	// IndexBody holds the settings and mappings the index would be created with if it doesn't exist yet
+	IndexBody map[string]interface{} `json:"index_body"`
+	// AttributeMapping is the mapping of the attributes of the column mapping, put on the index before importing
+	AttributeMapping map[string]interface{} `json:"attribute_mapping,omitempty"`
+}
+
+// DryRun reads every row of the sources through the same transform, enrichment and document limit stages as an
+// import and reports what the import would do, without sending anything to Elasticsearch. Rejected rows are written
+// to the reject report, if any. As with RunAll, sources without the required columns are left out; the dry run
+// fails if no source has them.
+func (p *Pipeline) DryRun(ctx context.Context, sources []RowSource) (DryRunReport, error) {
+	report := DryRunReport{
+		Index:           p.indexName,
+		Columns:         make(map[string]string, len(requiredColumns)),
+		DuplicatePolicy: p.duplicates,
+		IDPolicy:        p.ids,
+		IndexBody:       storageEs.ProductIndexBody(p.analysis),
+	}
+	if properties := p.mapping.attributeProperties(); properties != nil {
+		report.AttributeMapping = storageEs.AttributesMapping(properties)
+	}
+	for _, field := range requiredColumns {
+		report.Columns[field] = p.mapping.column(field)
+	}
+
+	transforms, err := p.mapping.transforms()
+	if err != nil {
+		return report, err
+	}
+
+	tracker := newDuplicateTracker()
+	now := time.Now()
+	for _, source := range sources {
+		summary := DryRunSource{Name: source.Name()}
+		columnMap, err := validateHeader(source.Header(), p.mapping, p.ids)
+		if err != nil {
+			if len(sources) == 1 {
+				return report, err
+			}
+			summary.Error = err.Error()
+			report.Sources = append(report.Sources, summary)
+			continue
+		}
+		// Report the columns the fields were found in, such as columns named by an alias
+		logAliases(source, columnMap, p.mapping)
+		for _, field := range requiredColumns {
+			report.Columns[field] = ""
+			if i, ok := columnMap[field]; ok {
+				report.Columns[field] = source.Header()[i]
+			}
+		}
+
+		for rowNumber := 2; ; rowNumber++ {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+
+			fields, err := source.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return report, fmt.Errorf("%s: failed to read row %d: %w", source.Name(), rowNumber, err)
+			}
+			summary.RowsRead++
+
+			ref := models.ImportRowRef{Source: source.Name(), Row: rowNumber}
+			stage := RejectStageParse
+			product, truncated, err := p.prepareRow(ctx, fields, columnMap, transforms, now)
+			if err == nil {
+				// The skip policy rejects the rows repeating an earlier row
+				if kind, first := tracker.track(product, ref); kind != "" && p.duplicates == DuplicatesSkip {
+					stage, err = RejectStageDuplicate, errors.New(describeDuplicate(kind, product, first, ref))
+				}
+			}
+			if err != nil {
+				summary.RowsRejected++
+				p.reject(ref, stage, err.Error(), fields, columnMap)
+				if len(report.Rejected) < maxRecordedErrors {
+					report.Rejected = append(report.Rejected, RejectedRow{ImportRowRef: ref, Reason: err.Error()})
+				}
+				continue
+			}
+			summary.RowsValid++
+			if len(truncated) > 0 {
+				report.RowsTruncated++
+			}
+		}
+
+		report.Sources = append(report.Sources, summary)
+		report.RowsRead += summary.RowsRead
+		report.RowsValid += summary.RowsValid
+		report.RowsRejected += summary.RowsRejected
+	}
+
+	report.DuplicateIDs, report.NearDuplicates, report.Duplicates = tracker.summary()
+
+	for _, summary := range report.Sources {
+		if summary.Error == "" {
+			return report, nil
+		}
+	}
+	return report, fmt.Errorf("no source has the required columns")
+}
@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"elasticsearch/internal/models"
+)
+
+// DuplicatePolicy decides what an import does with a row repeating the ID, or the product name and company, of an
+// earlier row
+type DuplicatePolicy string
+
+const (
+	// DuplicatesLastWins imports every row: a repeated ID overwrites the earlier product, while near duplicates
+	// have IDs of their own and are only reported
+	DuplicatesLastWins DuplicatePolicy = "last-wins"
+	// DuplicatesSkip keeps the first row and skips the rows repeating it
+	DuplicatesSkip DuplicatePolicy = "skip"
+	// DuplicatesFail stops the import at the first repeated row
+	DuplicatesFail DuplicatePolicy = "fail"
+)
+
+// ParseDuplicatePolicy validates a duplicate policy
+func ParseDuplicatePolicy(policy string) (DuplicatePolicy, error) {
+	switch DuplicatePolicy(policy) {
+	case DuplicatesLastWins, DuplicatesSkip, DuplicatesFail:
+		return DuplicatePolicy(policy), nil
+	}
+	return "", fmt.Errorf("invalid duplicate policy %q, expected %s, %s or %s", policy, DuplicatesLastWins, DuplicatesSkip, DuplicatesFail)
+}
+
+// duplicateTracker remembers the first row of every ID and product name and company of an import, to find the
+// rows repeating them
+type duplicateTracker struct {
+	firstID   map[string]models.ImportRowRef
+	firstName map[string]models.ImportRowRef
+	// groups indexes duplicates by kind and key
+	groups     map[string]int
+	duplicates []models.ImportDuplicate
+}
+
+// newDuplicateTracker creates an empty duplicateTracker
+func newDuplicateTracker() *duplicateTracker {
+	return &duplicateTracker{
+		firstID:   make(map[string]models.ImportRowRef),
+		firstName: make(map[string]models.ImportRowRef),
+		groups:    make(map[string]int),
+	}
+}
+
+// track records the product of a row. For a row repeating an earlier one it returns the kind of duplicate and
+// the first row; rows with the same ID are not reported as near duplicates too. Products left for Elasticsearch
+// to assign an ID can only be near duplicates.
+func (t *duplicateTracker) track(product models.Product, ref models.ImportRowRef) (string, models.ImportRowRef) {
+	if id := product.ID.String(); id != "" {
+		if first, seen := t.firstID[id]; seen {
+			t.add(models.DuplicateKindID, id, id, first, ref)
+			return models.DuplicateKindID, first
+		}
+		t.firstID[id] = ref
+	}
+
+	key := nameCompanyKey(product)
+	if key == "" {
+		return "", models.ImportRowRef{}
+	}
+	if first, seen := t.firstName[key]; seen {
+		t.add(models.DuplicateKindNameCompany, key, product.ProductName+" / "+product.Company, first, ref)
+		return models.DuplicateKindNameCompany, first
+	}
+	t.firstName[key] = ref
+	return "", models.ImportRowRef{}
+}
+
+// add appends a row to the duplicates of a key, starting them with the first row
+func (t *duplicateTracker) add(kind, key, value string, first, ref models.ImportRowRef) {
+	if i, exists := t.groups[kind+"\x00"+key]; exists {
+		t.duplicates[i].Rows = append(t.duplicates[i].Rows, ref)
+		return
+	}
+	t.groups[kind+"\x00"+key] = len(t.duplicates)
+	t.duplicates = append(t.duplicates, models.ImportDuplicate{Kind: kind, Value: value, Rows: []models.ImportRowRef{first, ref}})
+}
+
+// summary counts the duplicated IDs and near duplicates and lists the first maxRecordedErrors of them
+func (t *duplicateTracker) summary() (ids int, near int, duplicates []models.ImportDuplicate) {
+	for _, duplicate := range t.duplicates {
+		if duplicate.Kind == models.DuplicateKindID {
+			ids++
+		} else {
+			near++
+		}
+	}
+	return ids, near, t.duplicates[:min(len(t.duplicates), maxRecordedErrors)]
+}
+
+// describeDuplicate explains why a row repeating first is a duplicate
+func describeDuplicate(kind string, product models.Product, first models.ImportRowRef, ref models.ImportRowRef) string {
+	at := fmt.Sprintf("row %d", first.Row)
+	if first.Source != ref.Source {
+		at = fmt.Sprintf("%s row %d", first.Source, first.Row)
+	}
+	if kind == models.DuplicateKindID {
+		return fmt.Sprintf("duplicate id %s of %s", product.ID, at)
+	}
+	return fmt.Sprintf("near duplicate of %s: same product name and company %q / %q", at, product.ProductName, product.Company)
+}
+
+// nameCompanyKey identifies near duplicates: the words of the product name and company regardless of case and
+// punctuation. Products without a name have no key.
+func nameCompanyKey(product models.Product) string {
+	name := normalizeWords(product.ProductName)
+	if name == "" {
+		return ""
+	}
+	return name + "\x00" + normalizeWords(product.Company)
+}
+
+// normalizeWords lowercases a value and keeps its letters and digits, separating words by single spaces
+func normalizeWords(value string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// EncodingAuto detects the character encoding of a source from its first bytes
+const EncodingAuto = "auto"
+
+// encodingSampleSize is the number of leading bytes the encoding of a source is detected from
+const encodingSampleSize = 64 * 1024
+
+// turkishBytes are the bytes Windows-1254 reads as the Turkish letters Ğ İ Ş ğ ı ş, where Windows-1252 has Icelandic
+// letters; the two encodings agree on the other letters
+var turkishBytes = []byte{0xd0, 0xdd, 0xde, 0xf0, 0xfd, 0xfe}
+
+// parseEncoding validates the name of a character encoding, any WHATWG label such as "windows-1252", "iso-8859-9"
+// or "utf-16le". It returns the canonical name, or "" for EncodingAuto and empty names.
+func parseEncoding(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, EncodingAuto) {
+		return "", nil
+	}
+	enc, err := htmlindex.Get(name)
+	if err != nil {
+		return "", fmt.Errorf("unsupported encoding %q, expected %s or an encoding such as utf-8, utf-16le, windows-1252 or windows-1254", name, EncodingAuto)
+	}
+	canonical, err := htmlindex.Name(enc)
+	if err != nil {
+		return "", fmt.Errorf("unsupported encoding %q", name)
+	}
+	return canonical, nil
+}
+
+// decodeReader returns the text of r converted to UTF-8 from the encoding name, see parseEncoding. Without a name,
+// the encoding is detected from a byte order mark or else guessed from the first encodingSampleSize bytes; sources
+// not read as UTF-8 are logged with the encoding they are read in.
+func decodeReader(source string, r io.Reader, name string) (io.Reader, error) {
+	if name != "" {
+		enc, err := htmlindex.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported encoding %q", name)
+		}
+		return transform.NewReader(r, enc.NewDecoder()), nil
+	}
+
+	buffered := bufio.NewReaderSize(r, encodingSampleSize)
+	sample, err := buffered.Peek(encodingSampleSize)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read %s: %w", source, err)
+	}
+	enc, detected := detectEncoding(sample, err == io.EOF)
+	if detected != "utf-8" {
+		fiberlog.Infof("Reading %s as %s", source, detected)
+	}
+	// A byte order mark overrides the guess, and is dropped
+	return transform.NewReader(buffered, unicode.BOMOverride(enc.NewDecoder())), nil
+}
+
+// detectEncoding guesses the encoding of a source from its first bytes, complete if they are all of it, and
+// returns it with its name
+func detectEncoding(sample []byte, complete bool) (encoding.Encoding, string) {
+	switch {
+	case bytes.HasPrefix(sample, []byte{0xef, 0xbb, 0xbf}):
+		return unicode.UTF8, "utf-8"
+	case bytes.HasPrefix(sample, []byte{0xff, 0xfe}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), "utf-16le"
+	case bytes.HasPrefix(sample, []byte{0xfe, 0xff}):
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), "utf-16be"
+	}
+
+	// UTF-16 text without byte order mark has a zero byte next to every ASCII character
+	var evenZeros, oddZeros int
+	for i, b := range sample {
+		if b == 0 {
+			if i%2 == 0 {
+				evenZeros++
+			} else {
+				oddZeros++
+			}
+		}
+	}
+	if half := len(sample) / 2; half > 0 {
+		switch {
+		case oddZeros > half/2 && evenZeros < half/10:
+			return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), "utf-16le"
+		case evenZeros > half/2 && oddZeros < half/10:
+			return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), "utf-16be"
+		}
+	}
+
+	if isUTF8(sample, complete) {
+		return unicode.UTF8, "utf-8"
+	}
+	if slices.ContainsFunc(sample, func(b byte) bool { return bytes.IndexByte(turkishBytes, b) >= 0 }) {
+		return charmap.Windows1254, "windows-1254"
+	}
+	return charmap.Windows1252, "windows-1252"
+}
+
+// isUTF8 reports whether a sample is valid UTF-8; unless complete, its last rune may be cut off
+func isUTF8(sample []byte, complete bool) bool {
+	for len(sample) > 0 {
+		r, size := utf8.DecodeRune(sample)
+		if r == utf8.RuneError && size == 1 {
+			return !complete && !utf8.FullRune(sample)
+		}
+		sample = sample[size:]
+	}
+	return true
+}
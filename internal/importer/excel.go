@@ -0,0 +1,347 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// headerScanRows is the number of leading rows searched for the header row of a worksheet
+const headerScanRows = 20
+
+// builtinDateFormats are the IDs of the built-in number formats rendering dates and times
+var builtinDateFormats = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	45: true, 46: true, 47: true,
+}
+
+// formatLiteralPattern matches the quoted text, escaped characters and bracketed colors or conditions of a
+// number format, none of which make it a date format
+var formatLiteralPattern = regexp.MustCompile(`"[^"]*"|\\.|\[[^\]]*\]`)
+
+// Workbook is an open local .xlsx workbook whose worksheets are read as sources. It stays open until the
+// workbook and every source opened from it are closed.
+type Workbook struct {
+	path     string
+	file     *excelize.File
+	date1904 bool
+	refs     int
+}
+
+// OpenWorkbook opens a local .xlsx workbook
+func OpenWorkbook(path string) (*Workbook, error) {
+	file, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open excel file: %w", err)
+	}
+	return newWorkbook(path, file)
+}
+
+// readWorkbook reads a .xlsx workbook from any reader, loading it in memory
+func readWorkbook(name string, r io.Reader) (*Workbook, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open excel file: %w", err)
+	}
+	return newWorkbook(name, file)
+}
+
+// newWorkbook wraps an open workbook file
+func newWorkbook(path string, file *excelize.File) (*Workbook, error) {
+	props, err := file.GetWorkbookProps()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read workbook properties: %w", err)
+	}
+
+	return &Workbook{
+		path:     path,
+		file:     file,
+		date1904: props.Date1904 != nil && *props.Date1904,
+		refs:     1,
+	}, nil
+}
+
+// Sheets returns the names of the worksheets in workbook order
+func (w *Workbook) Sheets() []string {
+	return w.file.GetSheetList()
+}
+
+// Sheet opens a worksheet selected by name or by 1-based position; an empty selection opens the first worksheet
+func (w *Workbook) Sheet(sheet string) (*ExcelSource, error) {
+	sheets := w.Sheets()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("workbook has no worksheets")
+	}
+
+	name := ""
+	switch index, err := strconv.Atoi(sheet); {
+	case sheet == "":
+		name = sheets[0]
+	case slices.Contains(sheets, sheet):
+		name = sheet
+	case err == nil && index >= 1 && index <= len(sheets):
+		name = sheets[index-1]
+	default:
+		return nil, fmt.Errorf("workbook has no worksheet %q (worksheets: %s)", sheet, strings.Join(sheets, ", "))
+	}
+
+	rows, err := w.file.Rows(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worksheet %q: %w", name, err)
+	}
+
+	source := &ExcelSource{
+		name:       w.path + "#" + name,
+		workbook:   w,
+		sheet:      name,
+		rows:       rows,
+		lastRow:    lastRow(w.file, name),
+		dateStyles: map[int]bool{},
+	}
+	if err := source.readHeader(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	w.refs++
+	return source, nil
+}
+
+// lastRow returns the last row of the used range of a worksheet, as recorded in the workbook, or 0 if unknown
+func lastRow(file *excelize.File, sheet string) int {
+	dimension, err := file.GetSheetDimension(sheet)
+	if err != nil {
+		return 0
+	}
+	_, last, _ := strings.Cut(dimension, ":")
+	if last == "" {
+		last = dimension
+	}
+	_, row, err := excelize.CellNameToCoordinates(last)
+	if err != nil {
+		return 0
+	}
+	return row
+}
+
+// Close releases the workbook; its file is closed once the sources opened from it are closed too
+func (w *Workbook) Close() error {
+	w.refs--
+	if w.refs > 0 {
+		return nil
+	}
+	return w.file.Close()
+}
+
+// ExcelSource reads rows from a worksheet of a local .xlsx workbook. Leading title and blank rows are
+// skipped: the header is the first of the leading rows with the most filled cells. Numeric cells are read
+// unformatted, so long IDs don't turn into scientific notation; date cells become ISO 8601 dates, and
+// numbers stored as text are kept as typed.
+type ExcelSource struct {
+	name     string
+	workbook *Workbook
+	sheet    string
+	rows     *excelize.Rows
+	row      int
+	// lastRow is the last row of the worksheet's used range, 0 if unknown
+	lastRow int
+	header  []string
+	pending [][]string
+	// dateStyles caches whether a cell style renders dates
+	dateStyles map[int]bool
+}
+
+// NewLocalExcelSource opens a worksheet of a local Excel workbook as a RowSource. The worksheet is selected
+// by name or 1-based position, either with sheet or with a #sheet suffix of the path, e.g.
+// "products.xlsx#Stock"; the suffix takes precedence, and without either the first worksheet is read.
+func NewLocalExcelSource(path string, sheet string) (*ExcelSource, error) {
+	path, suffix, found := strings.Cut(path, "#")
+	if found {
+		sheet = suffix
+	}
+
+	workbook, err := OpenWorkbook(path)
+	if err != nil {
+		return nil, err
+	}
+	// The source keeps the workbook open until it is closed
+	defer workbook.Close()
+
+	return workbook.Sheet(sheet)
+}
+
+// Sheet returns the name of the worksheet
+func (s *ExcelSource) Sheet() string {
+	return s.sheet
+}
+
+// readHeader reads ahead to find the header row; the rows after it are returned first
+func (s *ExcelSource) readHeader() error {
+	var leading [][]string
+	for len(leading) < headerScanRows {
+		row, err := s.readRow()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		leading = append(leading, row)
+	}
+
+	headerRow := -1
+	widest := 0
+	for i, row := range leading {
+		if filled := filledCells(row); filled > widest {
+			headerRow, widest = i, filled
+		}
+	}
+	if headerRow < 0 {
+		return fmt.Errorf("source contains no data")
+	}
+
+	s.header = leading[headerRow]
+	for i := range s.header {
+		s.header[i] = strings.TrimSpace(s.header[i])
+	}
+	s.pending = leading[headerRow+1:]
+	return nil
+}
+
+// filledCells counts the non-blank cells of a row
+func filledCells(row []string) int {
+	filled := 0
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			filled++
+		}
+	}
+	return filled
+}
+
+// readRow reads the next row of the worksheet and converts its numeric cells
+func (s *ExcelSource) readRow() ([]string, error) {
+	if !s.rows.Next() {
+		if err := s.rows.Error(); err != nil {
+			return nil, fmt.Errorf("failed to read row: %w", err)
+		}
+		return nil, io.EOF
+	}
+	s.row++
+
+	cells, err := s.rows.Columns(excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read row %d: %w", s.row, err)
+	}
+	for i, value := range cells {
+		if cells[i], err = s.cellValue(i+1, value); err != nil {
+			return nil, err
+		}
+	}
+	return cells, nil
+}
+
+// cellValue converts the raw value of a numeric cell into a date or a plain decimal number. Text cells,
+// including numbers stored as text, are returned as is.
+func (s *ExcelSource) cellValue(col int, raw string) (string, error) {
+	number, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return raw, nil
+	}
+
+	cell, err := excelize.CoordinatesToCellName(col, s.row)
+	if err != nil {
+		return "", err
+	}
+	cellType, err := s.workbook.file.GetCellType(s.sheet, cell)
+	if err != nil {
+		return "", fmt.Errorf("failed to read cell %s: %w", cell, err)
+	}
+	if cellType != excelize.CellTypeNumber && cellType != excelize.CellTypeUnset {
+		return raw, nil
+	}
+
+	isDate, err := s.isDateCell(cell)
+	if err != nil {
+		return "", err
+	}
+	if isDate {
+		t, err := excelize.ExcelDateToTime(number, s.workbook.date1904)
+		if err != nil {
+			return "", fmt.Errorf("invalid date in cell %s: %w", cell, err)
+		}
+		if number == math.Trunc(number) {
+			return t.Format("2006-01-02"), nil
+		}
+		return t.Format("2006-01-02T15:04:05"), nil
+	}
+	return strconv.FormatFloat(number, 'f', -1, 64), nil
+}
+
+// isDateCell reports whether the number format of a cell renders a date or time
+func (s *ExcelSource) isDateCell(cell string) (bool, error) {
+	styleID, err := s.workbook.file.GetCellStyle(s.sheet, cell)
+	if err != nil {
+		return false, fmt.Errorf("failed to read style of cell %s: %w", cell, err)
+	}
+	if isDate, ok := s.dateStyles[styleID]; ok {
+		return isDate, nil
+	}
+
+	style, err := s.workbook.file.GetStyle(styleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to read style of cell %s: %w", cell, err)
+	}
+	isDate := builtinDateFormats[style.NumFmt]
+	if style.CustomNumFmt != nil {
+		isDate = isDateFormat(*style.CustomNumFmt)
+	}
+	s.dateStyles[styleID] = isDate
+	return isDate, nil
+}
+
+// isDateFormat reports whether a custom number format has day, month, year or hour placeholders
+func isDateFormat(format string) bool {
+	format = strings.ToLower(formatLiteralPattern.ReplaceAllString(format, ""))
+	return strings.ContainsAny(format, "dmyh")
+}
+
+// Name implements RowSource
+func (s *ExcelSource) Name() string {
+	return s.name
+}
+
+// Header implements RowSource
+func (s *ExcelSource) Header() []string {
+	return s.header
+}
+
+// Next implements RowSource
+func (s *ExcelSource) Next() ([]string, error) {
+	if len(s.pending) > 0 {
+		row := s.pending[0]
+		s.pending = s.pending[1:]
+		return row, nil
+	}
+	return s.readRow()
+}
+
+// Progress implements ProgressSource for worksheets recording their used range
+func (s *ExcelSource) Progress() (float64, bool) {
+	return rowProgress(s.row-len(s.pending), s.lastRow)
+}
+
+// Close implements RowSource
+func (s *ExcelSource) Close() error {
+	if err := s.rows.Close(); err != nil {
+		s.workbook.Close()
+		return err
+	}
+	return s.workbook.Close()
+}
@@ -0,0 +1,249 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+	"golang.org/x/oauth2/google"
+)
+
+var spreadsheetIDPattern = regexp.MustCompile(`/d/([a-zA-Z0-9-_]+)`)
+
+// NewGoogleSheetsSource downloads a public Google Sheet as CSV and exposes it as a RowSource
+func NewGoogleSheetsSource(ctx context.Context, sheetsURL string) (*CSVSource, error) {
+	// Extract the spreadsheet ID from the URL
+	spreadsheetID, err := extractSpreadsheetID(sheetsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	exportURL := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/export?format=csv", spreadsheetID)
+	fiberlog.Infof("Downloading spreadsheet data from: %s", exportURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, exportURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download spreadsheet: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download spreadsheet, status code: %d", resp.StatusCode)
+	}
+
+	source, err := newCSVSource(sheetsURL, newSizedReader(resp.Body, resp.ContentLength), DefaultCSVOptions)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return source, nil
+}
+
+// extractSpreadsheetID extracts the Google Sheets ID from a URL
+func extractSpreadsheetID(url string) (string, error) {
+	matches := spreadsheetIDPattern.FindStringSubmatch(url)
+	if len(matches) < 2 {
+		return "", fmt.Errorf("could not extract spreadsheet ID from URL")
+	}
+
+	return matches[1], nil
+}
+
+// sheetsReadonlyScope grants read access to the spreadsheets shared with a service account
+const sheetsReadonlyScope = "https://www.googleapis.com/auth/spreadsheets.readonly"
+
+// sheetsAPIURL is the base URL of the Google Sheets API
+const sheetsAPIURL = "https://sheets.googleapis.com/v4/spreadsheets/"
+
+var sheetGIDPattern = regexp.MustCompile(`[#&?]gid=([0-9]+)`)
+
+// GoogleSheetsAPISource reads a worksheet of a Google Sheet through the Sheets API. Unlike the CSV export it
+// authenticates as a service account, so private spreadsheets shared with that account can be imported.
+type GoogleSheetsAPISource struct {
+	name   string
+	header []string
+	rows   [][]string
+	// total is the number of rows below the header
+	total int
+}
+
+// NewGoogleSheetsAPISource downloads a worksheet of a Google Sheet through the Sheets API, authenticating with the
+// service account key in credentialsFile. The worksheet is selected by name or 1-based position, or else by the
+// gid of the URL; without either the first worksheet is read.
+func NewGoogleSheetsAPISource(ctx context.Context, sheetsURL string, credentialsFile string, sheet string) (*GoogleSheetsAPISource, error) {
+	spreadsheetID, err := extractSpreadsheetID(sheetsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, account, err := googleClient(ctx, credentialsFile, sheetsReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+	client := &sheetsClient{http: httpClient, account: account}
+
+	// Resolve the worksheet to read
+	var spreadsheet struct {
+		Sheets []struct {
+			Properties struct {
+				SheetID int64  `json:"sheetId"`
+				Title   string `json:"title"`
+			} `json:"properties"`
+		} `json:"sheets"`
+	}
+	if err := client.get(ctx, sheetsAPIURL+url.PathEscape(spreadsheetID)+"?fields=sheets.properties(sheetId,title)", &spreadsheet); err != nil {
+		return nil, err
+	}
+	titles := make([]string, 0, len(spreadsheet.Sheets))
+	gids := make(map[string]string, len(spreadsheet.Sheets))
+	for _, s := range spreadsheet.Sheets {
+		titles = append(titles, s.Properties.Title)
+		gids[strconv.FormatInt(s.Properties.SheetID, 10)] = s.Properties.Title
+	}
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("spreadsheet has no worksheets")
+	}
+
+	title := titles[0]
+	if sheet != "" {
+		index, err := strconv.Atoi(sheet)
+		switch {
+		case slices.Contains(titles, sheet):
+			title = sheet
+		case err == nil && index >= 1 && index <= len(titles):
+			title = titles[index-1]
+		default:
+			return nil, fmt.Errorf("spreadsheet has no worksheet %q (worksheets: %s)", sheet, strings.Join(titles, ", "))
+		}
+	} else if matches := sheetGIDPattern.FindStringSubmatch(sheetsURL); matches != nil {
+		if gidTitle, ok := gids[matches[1]]; ok {
+			title = gidTitle
+		}
+	}
+
+	// A range of only the quoted worksheet title covers every filled cell
+	a1Range := "'" + strings.ReplaceAll(title, "'", "''") + "'"
+	fiberlog.Infof("Downloading worksheet %q of spreadsheet %s through the Sheets API as %s", title, spreadsheetID, client.account)
+
+	var values struct {
+		Values [][]string `json:"values"`
+	}
+	valuesURL := sheetsAPIURL + url.PathEscape(spreadsheetID) + "/values/" + url.PathEscape(a1Range) +
+		"?majorDimension=ROWS&valueRenderOption=FORMATTED_VALUE"
+	if err := client.get(ctx, valuesURL, &values); err != nil {
+		return nil, err
+	}
+	if len(values.Values) == 0 {
+		return nil, fmt.Errorf("source contains no data")
+	}
+
+	header := values.Values[0]
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	return &GoogleSheetsAPISource{
+		name:   sheetsURL + "#" + title,
+		header: header,
+		rows:   values.Values[1:],
+		total:  len(values.Values) - 1,
+	}, nil
+}
+
+// Name implements RowSource
+func (s *GoogleSheetsAPISource) Name() string {
+	return s.name
+}
+
+// Header implements RowSource
+func (s *GoogleSheetsAPISource) Header() []string {
+	return s.header
+}
+
+// Next implements RowSource
+func (s *GoogleSheetsAPISource) Next() ([]string, error) {
+	if len(s.rows) == 0 {
+		return nil, io.EOF
+	}
+	row := s.rows[0]
+	s.rows = s.rows[1:]
+	return row, nil
+}
+
+// Progress implements ProgressSource
+func (s *GoogleSheetsAPISource) Progress() (float64, bool) {
+	return rowProgress(s.total-len(s.rows), s.total)
+}
+
+// Close implements RowSource
+func (s *GoogleSheetsAPISource) Close() error {
+	return nil
+}
+
+// googleClient returns an HTTP client authenticating as the service account of a key file with the given scope,
+// and the email address of the account
+func googleClient(ctx context.Context, credentialsFile string, scope string) (*http.Client, string, error) {
+	key, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read google credentials: %w", err)
+	}
+	jwtConfig, err := google.JWTConfigFromJSON(key, scope)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid google service account key: %w", err)
+	}
+	return jwtConfig.Client(ctx), jwtConfig.Email, nil
+}
+
+// sheetsClient calls the Sheets API as a service account
+type sheetsClient struct {
+	http    *http.Client
+	account string
+}
+
+// get decodes the JSON response of a Sheets API request into out
+func (c *sheetsClient) get(ctx context.Context, requestURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("sheets api request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("access to the spreadsheet denied, share it with %s", c.account)
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("spreadsheet not found")
+	case resp.StatusCode != http.StatusOK:
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("sheets api request failed, status code: %d: %s", resp.StatusCode, apiErr.Error.Message)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse sheets api response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package importer
+
+import (
+	"fmt"
+
+	"elasticsearch/internal/idgen"
+	"elasticsearch/internal/models"
+)
+
+// IDPolicy decides where the IDs of imported products come from
+type IDPolicy string
+
+const (
+	// IDsColumn reads the ID of every row from the id column, which sources must have
+	IDsColumn IDPolicy = "column"
+	// IDsHash generates the ID of rows without an id column or value from a hash of their product name and
+	// company, so importing the same rows again overwrites the products they created
+	IDsHash IDPolicy = idgen.StrategyHash
+	// IDsElasticsearch leaves the ID of rows without an id column or value to Elasticsearch, so importing the
+	// same rows again creates their products again
+	IDsElasticsearch IDPolicy = idgen.StrategyElasticsearch
+)
+
+// ParseIDPolicy validates an ID policy
+func ParseIDPolicy(policy string) (IDPolicy, error) {
+	switch IDPolicy(policy) {
+	case IDsColumn, IDsHash, IDsElasticsearch:
+		return IDPolicy(policy), nil
+	}
+	return "", fmt.Errorf("invalid id policy %q, expected %s, %s or %s", policy, IDsColumn, IDsHash, IDsElasticsearch)
+}
+
+// generatesIDs reports whether rows without an ID are imported under the policy
+func (p IDPolicy) generatesIDs() bool {
+	return p == IDsHash || p == IDsElasticsearch
+}
+
+// generateID returns the ID of a product imported without one: the idgen.Hash of its product name and company
+// under IDsHash, or no ID for Elasticsearch to assign one under IDsElasticsearch
+func (p IDPolicy) generateID(product models.Product) (models.ProductID, error) {
+	if p != IDsHash {
+		return "", nil
+	}
+	// Products differing only in case and punctuation, like near duplicates, get the same ID
+	key := nameCompanyKey(product)
+	if key == "" {
+		return "", fmt.Errorf("no id and no product name to generate one from")
+	}
+	return idgen.Hash(key)
+}
@@ -0,0 +1,250 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Column types detected by an inspection
+const (
+	ColumnTypeEmpty   = "empty"
+	ColumnTypeInteger = "integer"
+	ColumnTypeNumber  = "number"
+	ColumnTypeBoolean = "boolean"
+	ColumnTypeDate    = "date"
+	ColumnTypeText    = "text"
+)
+
+// maxSampleValues is the number of distinct example values reported per column
+const maxSampleValues = 3
+
+// inspectDateLayouts are the date formats recognized in spreadsheets
+var inspectDateLayouts = []string{time.RFC3339, "2006-01-02", "02.01.2006", "02/01/2006", "01/02/2006"}
+
+// fieldAliases are the normalized column names recognized for each product field; earlier aliases are
+// stronger matches. Turkish names are included since most catalogs come from Turkish suppliers.
+var fieldAliases = map[string][]string{
+	"id":           {"id", "productid", "barcode", "barkod", "sku", "code", "kod"},
+	"product_name": {"productname", "name", "product", "urunadi", "urun", "ilacadi", "ilac", "title"},
+	"drug_generic": {"druggeneric", "generic", "genericname", "etkenmadde", "etken", "activeingredient", "ingredient", "inn"},
+	"company":      {"company", "manufacturer", "firma", "firmaadi", "sirket", "uretici", "producer", "vendor", "brand"},
+}
+
+// ColumnReport describes one column of an inspected source
+type ColumnReport struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// FillRate is the share of sampled rows with a non-empty value
+	FillRate float64 `json:"fill_rate"`
+	// Unique is set when every non-empty sampled value is distinct
+	Unique  bool     `json:"unique"`
+	Samples []string `json:"samples"`
+	// MappedTo is the product field the column is guessed to feed, if any
+	MappedTo string `json:"mapped_to,omitempty"`
+}
+
+// Inspection is the outcome of inspecting the header and first rows of a source
+type Inspection struct {
+	Source      string         `json:"source"`
+	RowsSampled int            `json:"rows_sampled"`
+	Columns     []ColumnReport `json:"columns"`
+	// Mapping is the best-guess mapping of product fields to columns; unresolved fields map to ""
+	Mapping Mapping `json:"mapping"`
+	// Unmapped lists the product fields no column was guessed for
+	Unmapped []string `json:"unmapped,omitempty"`
+	// AttributeMapping is the Elasticsearch mapping of the attributes proposed by ProposeAttributes, if called
+	AttributeMapping map[string]interface{} `json:"attribute_mapping,omitempty"`
+}
+
+// Inspect reads the header and up to sampleRows rows of source and reports the detected type, fill rate and
+// example values of every column along with a best-guess mapping to product fields. Nothing is indexed.
+func Inspect(source RowSource, sampleRows int) (Inspection, error) {
+	header := source.Header()
+	values := make([][]string, len(header))
+
+	inspection := Inspection{Source: source.Name()}
+	for inspection.RowsSampled < sampleRows {
+		fields, err := source.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Inspection{}, fmt.Errorf("failed to read row %d: %w", inspection.RowsSampled+2, err)
+		}
+		if isBlankRow(fields) {
+			continue
+		}
+		inspection.RowsSampled++
+
+		for i := range header {
+			value := ""
+			if i < len(fields) {
+				value = strings.TrimSpace(fields[i])
+			}
+			values[i] = append(values[i], value)
+		}
+	}
+
+	for i, name := range header {
+		inspection.Columns = append(inspection.Columns, inspectColumn(name, values[i]))
+	}
+
+	inspection.Mapping = guessMapping(inspection.Columns)
+	for _, field := range requiredColumns {
+		if column := inspection.Mapping.Columns[field]; column != "" {
+			for i := range inspection.Columns {
+				if inspection.Columns[i].Name == column {
+					inspection.Columns[i].MappedTo = field
+				}
+			}
+		} else {
+			inspection.Unmapped = append(inspection.Unmapped, field)
+		}
+	}
+	return inspection, nil
+}
+
+// inspectColumn summarizes the sampled values of a column
+func inspectColumn(name string, values []string) ColumnReport {
+	report := ColumnReport{Name: name, Type: ColumnTypeEmpty, Unique: true, Samples: []string{}}
+
+	seen := make(map[string]struct{}, len(values))
+	filled := 0
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		filled++
+
+		if _, ok := seen[value]; ok {
+			report.Unique = false
+			continue
+		}
+		seen[value] = struct{}{}
+		if len(report.Samples) < maxSampleValues {
+			report.Samples = append(report.Samples, value)
+		}
+		report.Type = widenType(report.Type, valueType(value))
+	}
+
+	if len(values) > 0 {
+		report.FillRate = float64(filled) / float64(len(values))
+	}
+	if filled == 0 {
+		report.Unique = false
+	}
+	return report
+}
+
+// valueType detects the most specific type of a non-empty value
+func valueType(value string) string {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ColumnTypeInteger
+	}
+	if _, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64); err == nil {
+		return ColumnTypeNumber
+	}
+	if _, err := strconv.ParseBool(value); err == nil {
+		return ColumnTypeBoolean
+	}
+	for _, layout := range inspectDateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return ColumnTypeDate
+		}
+	}
+	return ColumnTypeText
+}
+
+// widenType returns the type describing the values of both types: integers widen to numbers and
+// anything else that differs to text
+func widenType(current, next string) string {
+	switch {
+	case current == ColumnTypeEmpty || current == next:
+		return next
+	case current == ColumnTypeInteger && next == ColumnTypeNumber, current == ColumnTypeNumber && next == ColumnTypeInteger:
+		return ColumnTypeNumber
+	default:
+		return ColumnTypeText
+	}
+}
+
+// guessMapping picks a column for every product field by column name. When no column name matches the ID,
+// it falls back on the first filled column of unique integers. Each column feeds at most one field.
+func guessMapping(columns []ColumnReport) Mapping {
+	mapping := Mapping{Columns: make(map[string]string, len(requiredColumns))}
+	used := make(map[int]bool)
+	assign := func(field string, i int) {
+		mapping.Columns[field] = columns[i].Name
+		used[i] = true
+	}
+
+	// Name matches, strongest first: exact aliases in order, then columns containing an alias
+	for _, field := range requiredColumns {
+		mapping.Columns[field] = ""
+		if i := matchColumn(columns, used, fieldAliases[field]); i >= 0 {
+			assign(field, i)
+		}
+	}
+
+	// IDs are recognizable by their content
+	if mapping.Columns["id"] == "" {
+		for i, column := range columns {
+			if !used[i] && column.Type == ColumnTypeInteger && column.Unique && column.FillRate == 1 {
+				assign("id", i)
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// matchColumn returns the index of the unused column best matching the aliases, or -1
+func matchColumn(columns []ColumnReport, used map[int]bool, aliases []string) int {
+	normalized := make([]string, len(columns))
+	for i, column := range columns {
+		normalized[i] = normalizeAlias(column.Name)
+	}
+
+	if i := findAlias(normalized, used, aliases); i >= 0 {
+		return i
+	}
+	for _, alias := range aliases {
+		for i, name := range normalized {
+			if !used[i] && len(alias) > 2 && strings.Contains(name, alias) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// findAlias returns the index of the unused column, of normalized column names, named by the earliest alias, or -1
+func findAlias(normalized []string, used map[int]bool, aliases []string) int {
+	for _, alias := range aliases {
+		for i, name := range normalized {
+			if !used[i] && name == alias {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// turkishFolder folds Turkish letters to ASCII
+var turkishFolder = strings.NewReplacer("ı", "i", "İ", "i", "ş", "s", "Ş", "s", "ğ", "g", "Ğ", "g",
+	"ü", "u", "Ü", "u", "ö", "o", "Ö", "o", "ç", "c", "Ç", "c")
+
+// normalizeAlias lowercases a column name, folds Turkish letters to ASCII and drops everything but letters and digits
+func normalizeAlias(name string) string {
+	folded := turkishFolder.Replace(name)
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, folded)
+}
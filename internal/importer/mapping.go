@@ -0,0 +1,129 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Mapping tells the import which source column feeds each product field. Fields without a column
+// are read from the column named after the field, e.g. "company", or else from a column named by one of its aliases.
+type Mapping struct {
+	// Columns maps product fields (id, product_name, drug_generic, company) to source column names
+	Columns map[string]string `json:"columns" yaml:"columns"`
+	// Attributes keeps more source columns under the attributes object of products, keyed by attribute name; see
+	// Attribute
+	Attributes map[string]Attribute `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	// Aliases lists more column names of product fields without a column, e.g. {"product_name": ["Nama Produk"]},
+	// tried before the built-in ones; see headerAliases
+	Aliases map[string][]string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	// Transforms lists the transforms applied in order to the value of product fields, e.g.
+	// {"company": ["trim", "titlecase"]}; see AvailableTransforms for the supported ones
+	Transforms map[string][]string `json:"transforms,omitempty" yaml:"transforms,omitempty"`
+}
+
+// LoadMapping reads a mapping file, such as the one written by an inspection. Files ending in .yaml or .yml are
+// read as YAML, anything else as JSON.
+func LoadMapping(path string) (Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Mapping{}, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var mapping Mapping
+	if isYAMLFile(path) {
+		err = yaml.UnmarshalStrict(data, &mapping)
+	} else {
+		err = json.Unmarshal(data, &mapping)
+	}
+	if err != nil {
+		return Mapping{}, fmt.Errorf("failed to parse mapping file %s: %w", path, err)
+	}
+
+	for field := range mapping.Columns {
+		if !slices.Contains(requiredColumns, field) {
+			return Mapping{}, fmt.Errorf("unknown product field %q in mapping file %s (fields: %s)",
+				field, path, strings.Join(requiredColumns, ", "))
+		}
+	}
+	for field := range mapping.Aliases {
+		if !slices.Contains(requiredColumns, field) {
+			return Mapping{}, fmt.Errorf("unknown product field %q in aliases of mapping file %s (fields: %s)",
+				field, path, strings.Join(requiredColumns, ", "))
+		}
+	}
+	if err := mapping.validateAttributes(); err != nil {
+		return Mapping{}, fmt.Errorf("invalid mapping file %s: %w", path, err)
+	}
+	if _, err := mapping.transforms(); err != nil {
+		return Mapping{}, fmt.Errorf("invalid mapping file %s: %w", path, err)
+	}
+	return mapping, nil
+}
+
+// Save writes the mapping as an indented JSON file, or as YAML if the path ends in .yaml or .yml
+func (m Mapping) Save(path string) error {
+	var data []byte
+	var err error
+	if isYAMLFile(path) {
+		data, err = yaml.Marshal(m)
+	} else {
+		data, err = json.MarshalIndent(m, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode mapping: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write mapping file: %w", err)
+	}
+	return nil
+}
+
+// isYAMLFile reports whether a mapping file is written in YAML
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// column returns the source column read for a product field
+func (m Mapping) column(field string) string {
+	if column := strings.TrimSpace(m.Columns[field]); column != "" {
+		return column
+	}
+	return field
+}
+
+// headerAliases returns the normalized column names a product field without a column is also read from: the
+// aliases of the mapping, then the built-in fieldAliases
+func (m Mapping) headerAliases(field string) []string {
+	var aliases []string
+	for _, alias := range m.Aliases[field] {
+		aliases = append(aliases, normalizeAlias(alias))
+	}
+	return append(aliases, fieldAliases[field]...)
+}
+
+// transforms parses the transforms of every product field
+func (m Mapping) transforms() (map[string][]ValueTransform, error) {
+	transforms := make(map[string][]ValueTransform, len(m.Transforms))
+	for field, specs := range m.Transforms {
+		if !slices.Contains(requiredColumns, field) {
+			return nil, fmt.Errorf("unknown product field %q in transforms (fields: %s)",
+				field, strings.Join(requiredColumns, ", "))
+		}
+		for _, spec := range specs {
+			transform, err := parseTransform(spec)
+			if err != nil {
+				return nil, fmt.Errorf("transform of %s: %w", field, err)
+			}
+			transforms[field] = append(transforms[field], transform)
+		}
+	}
+	return transforms, nil
+}
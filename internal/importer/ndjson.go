@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// NDJSONSource reads rows from newline delimited JSON objects. The header is
+// taken from the keys of the first object; later objects are projected onto it.
+type NDJSONSource struct {
+	name    string
+	closer  io.Closer
+	scanner *bufio.Scanner
+	header  []string
+	pending map[string]interface{}
+}
+
+// NewNDJSONSource opens a local NDJSON file as a RowSource
+func NewNDJSONSource(path string) (*NDJSONSource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ndjson file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open ndjson file: %w", err)
+	}
+
+	source, err := newNDJSONSource(path, newSizedReader(file, info.Size()))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return source, nil
+}
+
+// newNDJSONSource wraps any reader of NDJSON data and takes the header from its first object
+func newNDJSONSource(name string, rc io.ReadCloser) (*NDJSONSource, error) {
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	source := &NDJSONSource{name: name, closer: rc, scanner: scanner}
+
+	first, err := source.nextObject()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("source contains no data")
+		}
+		return nil, err
+	}
+
+	for key := range first {
+		source.header = append(source.header, key)
+	}
+	sort.Strings(source.header)
+	source.pending = first
+
+	return source, nil
+}
+
+// nextObject decodes the next non-empty line
+func (s *NDJSONSource) nextObject() (map[string]interface{}, error) {
+	for s.scanner.Scan() {
+		line := bytes.TrimSpace(s.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			return nil, fmt.Errorf("invalid json line: %w", err)
+		}
+		return object, nil
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Name implements RowSource
+func (s *NDJSONSource) Name() string {
+	return s.name
+}
+
+// Header implements RowSource
+func (s *NDJSONSource) Header() []string {
+	return s.header
+}
+
+// Next implements RowSource
+func (s *NDJSONSource) Next() ([]string, error) {
+	object := s.pending
+	s.pending = nil
+
+	if object == nil {
+		var err error
+		if object, err = s.nextObject(); err != nil {
+			return nil, err
+		}
+	}
+
+	row := make([]string, len(s.header))
+	for i, key := range s.header {
+		if value, ok := object[key]; ok && value != nil {
+			row[i] = fmt.Sprint(value)
+		}
+	}
+	return row, nil
+}
+
+// Progress implements ProgressSource for files and downloads of known size
+func (s *NDJSONSource) Progress() (float64, bool) {
+	return readerProgress(s.closer)
+}
+
+// Close implements RowSource
+func (s *NDJSONSource) Close() error {
+	return s.closer.Close()
+}
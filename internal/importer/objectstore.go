@@ -0,0 +1,253 @@
+package importer
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// gcsReadonlyScope grants read access to the objects of Cloud Storage buckets
+const gcsReadonlyScope = "https://www.googleapis.com/auth/devstorage.read_only"
+
+// emptyPayloadHash is the SHA-256 of the empty body of a GET request
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Options holds the credentials and location of S3 buckets. Without an access key objects are requested
+// anonymously, which only works for public buckets.
+type S3Options struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is set for temporary credentials
+	SessionToken string
+	// Endpoint addresses an S3 compatible store such as MinIO with path-style requests; empty uses AWS
+	Endpoint string
+}
+
+// IsObjectURI reports whether a path addresses an object in S3 (s3://bucket/key) or Cloud Storage (gs://bucket/key)
+func IsObjectURI(path string) bool {
+	return strings.HasPrefix(path, "s3://") || strings.HasPrefix(path, "gs://")
+}
+
+// OpenObjectSource streams an object from S3 or Cloud Storage into the source matching the extension of its key:
+// .csv, .ndjson/.jsonl, .xlsx or .parquet. CSV and NDJSON objects are read as they download; workbooks and
+// Parquet files are loaded in memory.
+func OpenObjectSource(ctx context.Context, uri string, opts SourceOptions) (RowSource, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object uri: %w", err)
+	}
+	bucket, key := parsed.Host, strings.TrimPrefix(parsed.Path, "/")
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("object uri must be %s://bucket/key: %s", parsed.Scheme, uri)
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(key)), ".")
+	}
+	switch format {
+	case "csv", "ndjson", "jsonl", "xlsx", "parquet":
+	default:
+		return nil, fmt.Errorf("unsupported import source: %s", uri)
+	}
+
+	var body io.ReadCloser
+	if parsed.Scheme == "s3" {
+		body, err = openS3Object(ctx, opts.S3, bucket, key)
+	} else {
+		body, err = openGCSObject(ctx, opts.GoogleCredentialsFile, bucket, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	fiberlog.Infof("Streaming import source from %s", uri)
+
+	var source RowSource
+	switch format {
+	case "csv":
+		source, err = newCSVSource(uri, body, opts.CSV)
+	case "ndjson", "jsonl":
+		source, err = newNDJSONSource(uri, body)
+	case "xlsx":
+		source, err = readObjectSheet(uri, body, opts.Sheet)
+	case "parquet":
+		source, err = readParquet(uri, body)
+		body.Close()
+	}
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	return source, nil
+}
+
+// readObjectSheet loads a workbook object and opens one of its worksheets; the object body is no longer needed
+func readObjectSheet(uri string, body io.ReadCloser, sheet string) (*ExcelSource, error) {
+	workbook, err := readWorkbook(uri, body)
+	body.Close()
+	if err != nil {
+		return nil, err
+	}
+	// The source keeps the workbook open until it is closed
+	defer workbook.Close()
+
+	return workbook.Sheet(sheet)
+}
+
+// openGCSObject downloads an object from Cloud Storage, as the service account of the key file if one is given
+func openGCSObject(ctx context.Context, credentialsFile string, bucket, key string) (io.ReadCloser, error) {
+	client, account := http.DefaultClient, "anonymous"
+	if credentialsFile != "" {
+		var err error
+		if client, account, err = googleClient(ctx, credentialsFile, gcsReadonlyScope); err != nil {
+			return nil, err
+		}
+	}
+
+	objectURL := "https://storage.googleapis.com/storage/v1/b/" + url.PathEscape(bucket) + "/o/" + url.PathEscape(key) + "?alt=media"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download gs://%s/%s: %w", bucket, key, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, objectStatusError("gs", bucket, key, resp.StatusCode, account)
+	}
+	return newSizedReader(resp.Body, resp.ContentLength), nil
+}
+
+// openS3Object downloads an object from S3, signing the request with Signature Version 4 if credentials are given
+func openS3Object(ctx context.Context, opts S3Options, bucket, key string) (io.ReadCloser, error) {
+	region := opts.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	// AWS is addressed per bucket, compatible stores with the bucket in the path
+	var objectURL string
+	if opts.Endpoint != "" {
+		objectURL = strings.TrimSuffix(opts.Endpoint, "/") + "/" + s3URIEncode(bucket) + "/" + s3URIEncode(key)
+	} else {
+		objectURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, region, s3URIEncode(key))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	account := "anonymous"
+	if opts.AccessKeyID != "" {
+		signS3Request(req, opts, region, time.Now().UTC())
+		account = opts.AccessKeyID
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download s3://%s/%s: %w", bucket, key, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, objectStatusError("s3", bucket, key, resp.StatusCode, account)
+	}
+	return newSizedReader(resp.Body, resp.ContentLength), nil
+}
+
+// objectStatusError describes a failed object download
+func objectStatusError(scheme, bucket, key string, statusCode int, account string) error {
+	switch statusCode {
+	case http.StatusNotFound:
+		return fmt.Errorf("object %s://%s/%s not found", scheme, bucket, key)
+	case http.StatusForbidden, http.StatusUnauthorized:
+		return fmt.Errorf("access to %s://%s/%s denied for %s", scheme, bucket, key, account)
+	}
+	return fmt.Errorf("failed to download %s://%s/%s, status code: %d", scheme, bucket, key, statusCode)
+}
+
+// signS3Request adds the Signature Version 4 authorization of a bodiless S3 request
+func signS3Request(req *http.Request, opts S3Options, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	if opts.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", opts.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(values[0])
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+opts.SecretAccessKey), date)
+	for _, part := range []string{region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		opts.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 computes the HMAC-SHA256 of data
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3URIEncode percent-encodes an object key the way Signature Version 4 expects: every byte but the unreserved
+// characters and the slashes between path segments
+func s3URIEncode(key string) string {
+	var encoded strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			encoded.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&encoded, "%%%02X", c)
+	}
+	return encoded.String()
+}
@@ -0,0 +1,248 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/types"
+)
+
+// parquetBatchSize is the number of rows read from every column at once
+const parquetBatchSize = 1000
+
+// parquetColumn is a top-level column of a Parquet file
+type parquetColumn struct {
+	// index is the position of the column among the leaf columns of the schema
+	index   int64
+	element *parquet.SchemaElement
+}
+
+// ParquetSource reads rows from the top-level columns of a Parquet file, named after the columns. Values are
+// converted to text the way the other sources produce them: dates as ISO 8601, timestamps as RFC 3339, decimals
+// with their scale and numbers without exponents. Nested and repeated columns are left out.
+type ParquetSource struct {
+	name      string
+	file      source.ParquetFile
+	reader    *reader.ParquetReader
+	header    []string
+	columns   []parquetColumn
+	rows      int64
+	remaining int64
+	batch     [][]string
+}
+
+// NewParquetSource opens a local Parquet file as a RowSource
+func NewParquetSource(path string) (*ParquetSource, error) {
+	file, err := local.NewLocalFileReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open parquet file: %w", err)
+	}
+
+	source, err := newParquetSource(path, file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return source, nil
+}
+
+// readParquet reads a Parquet file from any reader, loading it in memory; the footer is at the end of the file
+func readParquet(name string, r io.Reader) (*ParquetSource, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet file: %w", err)
+	}
+
+	file, err := buffer.NewBufferFile(data)
+	if err != nil {
+		return nil, err
+	}
+	return newParquetSource(name, file)
+}
+
+// newParquetSource reads the schema of an open Parquet file and selects its top-level columns
+func newParquetSource(name string, file source.ParquetFile) (*ParquetSource, error) {
+	parquetReader, err := reader.NewParquetColumnReader(file, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet file: %w", err)
+	}
+
+	s := &ParquetSource{
+		name:      name,
+		file:      file,
+		reader:    parquetReader,
+		rows:      parquetReader.GetNumRows(),
+		remaining: parquetReader.GetNumRows(),
+	}
+
+	schema := parquetReader.SchemaHandler
+	for i, inPath := range schema.ValueColumns {
+		// Top-level columns are addressed by the root and their own name
+		exPath := strings.Split(schema.InPathToExPath[inPath], common.PAR_GO_PATH_DELIMITER)
+		element := schema.SchemaElements[schema.MapIndex[inPath]]
+		if len(exPath) != 2 || element.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+			continue
+		}
+		s.header = append(s.header, strings.TrimSpace(exPath[1]))
+		s.columns = append(s.columns, parquetColumn{index: int64(i), element: element})
+	}
+	if len(s.columns) == 0 {
+		return nil, fmt.Errorf("parquet file has no top-level columns")
+	}
+	return s, nil
+}
+
+// Name implements RowSource
+func (s *ParquetSource) Name() string {
+	return s.name
+}
+
+// Header implements RowSource
+func (s *ParquetSource) Header() []string {
+	return s.header
+}
+
+// Next implements RowSource
+func (s *ParquetSource) Next() ([]string, error) {
+	if len(s.batch) == 0 {
+		if s.remaining <= 0 {
+			return nil, io.EOF
+		}
+		if err := s.readBatch(); err != nil {
+			return nil, err
+		}
+	}
+
+	row := s.batch[0]
+	s.batch = s.batch[1:]
+	return row, nil
+}
+
+// readBatch reads the next rows of every column and assembles them into rows
+func (s *ParquetSource) readBatch() error {
+	size := min(s.remaining, parquetBatchSize)
+	rows := make([][]string, size)
+	for i := range rows {
+		rows[i] = make([]string, len(s.columns))
+	}
+
+	for c, column := range s.columns {
+		values, _, _, err := s.reader.ReadColumnByIndex(column.index, size)
+		if err != nil {
+			return fmt.Errorf("failed to read column %s: %w", s.header[c], err)
+		}
+		if int64(len(values)) != size {
+			return fmt.Errorf("column %s has %d values for %d rows", s.header[c], len(values), size)
+		}
+		for r, value := range values {
+			if rows[r][c], err = parquetValue(column.element, value); err != nil {
+				return fmt.Errorf("column %s: %w", s.header[c], err)
+			}
+		}
+	}
+
+	s.remaining -= size
+	s.batch = rows
+	return nil
+}
+
+// Progress implements ProgressSource
+func (s *ParquetSource) Progress() (float64, bool) {
+	return rowProgress(int(s.rows-s.remaining)-len(s.batch), int(s.rows))
+}
+
+// Close implements RowSource
+func (s *ParquetSource) Close() error {
+	s.reader.ReadStop()
+	return s.file.Close()
+}
+
+// parquetValue converts a column value to text according to its logical type; nulls become empty cells
+func parquetValue(element *parquet.SchemaElement, value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	// Files written before logical types only carry converted types
+	logical := element.GetLogicalType()
+	if logical == nil {
+		logical = parquet.NewLogicalType()
+	}
+	converted := parquet.ConvertedType(-1)
+	if element.ConvertedType != nil {
+		converted = *element.ConvertedType
+	}
+
+	switch {
+	case logical.IsSetDATE() || converted == parquet.ConvertedType_DATE:
+		if days, ok := value.(int32); ok {
+			return time.Unix(int64(days)*86400, 0).UTC().Format("2006-01-02"), nil
+		}
+	case logical.IsSetTIMESTAMP() || converted == parquet.ConvertedType_TIMESTAMP_MILLIS || converted == parquet.ConvertedType_TIMESTAMP_MICROS:
+		if ts, ok := value.(int64); ok {
+			return parquetTimestamp(logical, converted, ts).Format(time.RFC3339Nano), nil
+		}
+	case logical.IsSetDECIMAL() || converted == parquet.ConvertedType_DECIMAL:
+		return parquetDecimal(value, element.GetScale())
+	}
+
+	switch v := value.(type) {
+	case string:
+		if element.GetType() == parquet.Type_INT96 {
+			return types.INT96ToTime(v).UTC().Format(time.RFC3339Nano), nil
+		}
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+// parquetTimestamp converts a timestamp to a time in the unit of its logical or converted type
+func parquetTimestamp(logical *parquet.LogicalType, converted parquet.ConvertedType, ts int64) time.Time {
+	switch {
+	case logical.IsSetTIMESTAMP() && logical.TIMESTAMP.Unit.IsSetNANOS():
+		return time.Unix(0, ts).UTC()
+	case logical.IsSetTIMESTAMP() && logical.TIMESTAMP.Unit.IsSetMICROS(), converted == parquet.ConvertedType_TIMESTAMP_MICROS:
+		return time.UnixMicro(ts).UTC()
+	}
+	return time.UnixMilli(ts).UTC()
+}
+
+// parquetDecimal formats the unscaled integer of a decimal, stored as an integer or big-endian two's complement
+// bytes, with its scale
+func parquetDecimal(value interface{}, scale int32) (string, error) {
+	unscaled := new(big.Int)
+	switch v := value.(type) {
+	case int32:
+		unscaled.SetInt64(int64(v))
+	case int64:
+		unscaled.SetInt64(v)
+	case string:
+		unscaled.SetBytes([]byte(v))
+		if len(v) > 0 && v[0]&0x80 != 0 {
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(v)*8)))
+		}
+	default:
+		return "", fmt.Errorf("unexpected decimal value %v", value)
+	}
+	return new(big.Rat).SetFrac(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)).FloatString(int(scale)), nil
+}
@@ -0,0 +1,599 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"elasticsearch/internal/enrichment"
+	"elasticsearch/internal/models"
+	storageEs "elasticsearch/internal/storage/elasticsearch"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// requiredColumns lists the columns every source must provide; the id column is optional under an ID policy
+// generating IDs
+var requiredColumns = []string{"id", "product_name", "drug_generic", "company"}
+
+// defaultBatchSize is the number of products sent per bulk request unless set with SetBatchSize
+const defaultBatchSize = 100
+
+// Import modes select how imported rows are written
+const (
+	// ModeIndex replaces every imported product with its row
+	ModeIndex = "index"
+	// ModeUpsert only overwrites the imported fields of existing products, keeping their created_at, score and
+	// deleted_at, and creates the new ones
+	ModeUpsert = "upsert"
+)
+
+// ModeAction returns the bulk action writing products in an import mode
+func ModeAction(mode string) (storageEs.BulkAction, error) {
+	switch mode {
+	case ModeIndex:
+		return storageEs.BulkActionIndex, nil
+	case ModeUpsert:
+		return storageEs.BulkActionUpsert, nil
+	}
+	return "", fmt.Errorf("invalid import mode %q, expected %s or %s", mode, ModeIndex, ModeUpsert)
+}
+
+// maxRecordedErrors caps the number of error messages kept in a Result
+const maxRecordedErrors = 100
+
+// Result summarizes an import run
+type Result struct {
+	RowsRead    int
+	RowsSkipped int
+	Indexed     int
+	Failed      int
+	Duration    time.Duration
+	Errors      []string
+	// Fingerprints maps the ID of every indexed product to its catalog fingerprint
+	Fingerprints map[string]string
+	// Sheets breaks down the results of RunAll per source
+	Sheets []models.ImportSheet
+	// DuplicateIDs and NearDuplicates count the repeated IDs and product names and companies, Duplicates lists
+	// the first of them
+	DuplicateIDs   int
+	NearDuplicates int
+	Duplicates     []models.ImportDuplicate
+}
+
+// addError records an error message, keeping at most maxRecordedErrors
+func (r *Result) addError(format string, args ...interface{}) {
+	if len(r.Errors) < maxRecordedErrors {
+		r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+	}
+}
+
+// ChangePublisher records imported products for delivery to downstream systems
+type ChangePublisher interface {
+	Publish(ctx context.Context, events ...models.ChangeEvent) error
+}
+
+// Pipeline validates, transforms and bulk indexes the rows of a RowSource
+type Pipeline struct {
+	esClient   *elasticsearch.Client
+	indexName  string
+	enricher   enrichment.DocumentEnricher
+	publisher  ChangePublisher
+	limit      models.DocumentLimit
+	mapping    Mapping
+	analysis   storageEs.IndexAnalysis
+	action     storageEs.BulkAction
+	duplicates DuplicatePolicy
+	ids        IDPolicy
+	batchSize  int
+	// maxFailures stops the import once this many rows failed to index; 0 never stops it
+	maxFailures int
+	// progressInterval is how often the progress of an import is reported to the log and progress
+	progressInterval time.Duration
+	progress         func(models.ImportProgress)
+	// rejects receives the rows skipped or failed to index, if not nil
+	rejects *RejectReport
+}
+
+// batchRow is the source row of a product waiting in a bulk batch
+type batchRow struct {
+	ref    models.ImportRowRef
+	values []string
+}
+
+// NewPipeline creates a new import Pipeline targeting the given index
+func NewPipeline(esClient *elasticsearch.Client, indexName string, enricher enrichment.DocumentEnricher) *Pipeline {
+	return &Pipeline{
+		esClient:   esClient,
+		indexName:  indexName,
+		enricher:   enricher,
+		action:     storageEs.BulkActionIndex,
+		duplicates: DuplicatesLastWins,
+		ids:        IDsColumn,
+		batchSize:  defaultBatchSize,
+	}
+}
+
+// SetPublisher attaches the change publisher notified after every bulk batch
+func (p *Pipeline) SetPublisher(publisher ChangePublisher) {
+	p.publisher = publisher
+}
+
+// SetDocumentLimit caps the size of imported products; rows over it are truncated or skipped per its policy
+func (p *Pipeline) SetDocumentLimit(limit models.DocumentLimit) {
+	p.limit = limit
+}
+
+// SetIndexAnalysis sets the analysis settings of the index when the pipeline creates it
+func (p *Pipeline) SetIndexAnalysis(analysis storageEs.IndexAnalysis) {
+	p.analysis = analysis
+}
+
+// SetBulkAction selects how products are written, see ModeAction; the default replaces them
+func (p *Pipeline) SetBulkAction(action storageEs.BulkAction) {
+	p.action = action
+}
+
+// SetDuplicatePolicy decides what happens to rows repeating the ID, or the product name and company, of an earlier
+// row of the import; the default imports them, the last row of an ID winning
+func (p *Pipeline) SetDuplicatePolicy(policy DuplicatePolicy) {
+	p.duplicates = policy
+}
+
+// SetIDPolicy decides where the IDs of products come from; the default reads them from the id column
+func (p *Pipeline) SetIDPolicy(policy IDPolicy) {
+	p.ids = policy
+}
+
+// SetBatchSize sets the number of products sent per bulk request; sizes below 1 keep defaultBatchSize
+func (p *Pipeline) SetBatchSize(size int) {
+	if size < 1 {
+		size = defaultBatchSize
+	}
+	p.batchSize = size
+}
+
+// SetMaxFailures stops the import with an error once max rows, of all its sources, failed to index; 0 never stops
+// it. Rows skipped before indexing don't count.
+func (p *Pipeline) SetMaxFailures(max int) {
+	p.maxFailures = max
+}
+
+// SetProgress reports the progress of every source to the log and to notify, if not nil, at most once per
+// interval; a zero interval reports nothing
+func (p *Pipeline) SetProgress(interval time.Duration, notify func(models.ImportProgress)) {
+	p.progressInterval = interval
+	p.progress = notify
+}
+
+// OpenRejectReport creates the reject report at path, see NewRejectReport, and writes the rows the pipeline skips,
+// or Elasticsearch fails to index, to it. Its product columns are named by the mapping of the pipeline, so it is
+// opened after SetMapping; the caller closes it once the pipeline ran.
+func (p *Pipeline) OpenRejectReport(path string, opts CSVOptions) (*RejectReport, error) {
+	report, err := NewRejectReport(path, p.mapping, opts)
+	if err != nil {
+		return nil, err
+	}
+	p.rejects = report
+	return report, nil
+}
+
+// reject writes a rejected row to the reject report, if any
+func (p *Pipeline) reject(ref models.ImportRowRef, stage, reason string, fields []string, columnMap map[string]int) {
+	if p.rejects != nil {
+		p.rejects.add(ref, stage, reason, p.rejects.values(fields, columnMap))
+	}
+}
+
+// SetMapping reads product fields from the source columns named by mapping instead of the default column names
+func (p *Pipeline) SetMapping(mapping Mapping) {
+	p.mapping = mapping
+}
+
+// Run drains the source through the validate → transform → bulk stages
+func (p *Pipeline) Run(ctx context.Context, source RowSource) (Result, error) {
+	tracker := newDuplicateTracker()
+	result, err := p.run(ctx, source, tracker, 0)
+	result.DuplicateIDs, result.NearDuplicates, result.Duplicates = tracker.summary()
+	return result, err
+}
+
+// run imports a source, finding duplicates of the rows already recorded by tracker. failedBefore counts the rows
+// of earlier sources that failed to index, towards the failure limit.
+func (p *Pipeline) run(ctx context.Context, source RowSource, tracker *duplicateTracker, failedBefore int) (result Result, err error) {
+	start := time.Now()
+	result.Fingerprints = make(map[string]string)
+	defer func() {
+		result.Duration = time.Since(start)
+		if err != nil {
+			result.addError("%v", err)
+		}
+	}()
+
+	// Validate header and map column names to indices
+	columnMap, err := validateHeader(source.Header(), p.mapping, p.ids)
+	if err != nil {
+		return result, err
+	}
+	logAliases(source, columnMap, p.mapping)
+	transforms, err := p.mapping.transforms()
+	if err != nil {
+		return result, err
+	}
+
+	// Create index if it doesn't exist
+	if err := storageEs.CreateIndexIfNotExists(p.esClient, p.indexName, p.analysis); err != nil {
+		return result, fmt.Errorf("failed to create index: %w", err)
+	}
+	// Map the attributes of the mapping, which may be new to the index
+	if properties := p.mapping.attributeProperties(); properties != nil {
+		if err := storageEs.PutAttributeMapping(ctx, p.esClient, p.indexName, properties); err != nil {
+			return result, fmt.Errorf("failed to map attributes: %w", err)
+		}
+	}
+
+	fiberlog.Infof("Starting import of %s into %s", source.Name(), p.indexName)
+	reporter := &progressReporter{
+		interval: p.progressInterval,
+		notify:   p.progress,
+		source:   source,
+		index:    p.indexName,
+		start:    start,
+		last:     start,
+	}
+
+	batch := make([]models.Product, 0, p.batchSize)
+	// rows holds the source row of every product of the batch, for the reject report
+	rows := make([]batchRow, 0, p.batchSize)
+	// flush writes the batch; failed items are counted, but a batch whose change events can't be recorded fails
+	// the import, as the outbox would miss its products
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		defer func() {
+			batch = batch[:0]
+			rows = rows[:0]
+		}()
+		bulkResult, err := storageEs.BulkWriteProducts(ctx, p.esClient, p.indexName, p.action, batch)
+		if err != nil {
+			fiberlog.Errorf("Bulk request failed: %v", err)
+			result.addError("bulk request failed: %v", err)
+			result.Failed += len(batch)
+			for _, row := range rows {
+				p.rejects.add(row.ref, RejectStageIndex, err.Error(), row.values)
+			}
+		} else {
+			fiberlog.Infof("Successfully processed batch of %d products (%d failed)", bulkResult.Succeeded, bulkResult.Failed)
+			result.Indexed += bulkResult.Succeeded
+			result.Failed += bulkResult.Failed
+			var events []models.ChangeEvent
+			for _, item := range bulkResult.Items {
+				product := batch[item.Position]
+				if product.ID.IsZero() {
+					// Elasticsearch assigned the ID
+					product.ID = item.ID
+				}
+				if item.Error != "" {
+					result.addError("product %s: %s", product.ID, item.Error)
+					p.rejects.add(rows[item.Position].ref, RejectStageIndex, item.Error, rows[item.Position].values)
+					continue
+				}
+				result.Fingerprints[product.ID.String()] = product.Fingerprint()
+				events = append(events, models.ChangeEvent{
+					Type:      models.ChangeImported,
+					ProductID: product.ID,
+					Product:   &product,
+					Source:    models.ChangeSourceImport,
+				})
+			}
+			if err := p.publish(ctx, events); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	now := time.Now()
+	for rowNumber := 2; ; rowNumber++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		reporter.report(result)
+
+		fields, err := source.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to read row %d: %w", rowNumber, err)
+		}
+		result.RowsRead++
+
+		ref := models.ImportRowRef{Source: source.Name(), Row: rowNumber}
+		product, truncated, err := p.prepareRow(ctx, fields, columnMap, transforms, now)
+		if err != nil {
+			fiberlog.Warnf("Row %d: %v, skipping", rowNumber, err)
+			result.addError("row %d: %v", rowNumber, err)
+			result.RowsSkipped++
+			p.reject(ref, RejectStageParse, err.Error(), fields, columnMap)
+			continue
+		}
+		if len(truncated) > 0 {
+			fiberlog.Warnf("Row %d: truncated %s to fit the %d byte document limit", rowNumber, strings.Join(truncated, ", "), p.limit.MaxBytes)
+		}
+
+		if kind, first := tracker.track(product, ref); kind != "" {
+			reason := describeDuplicate(kind, product, first, ref)
+			switch p.duplicates {
+			case DuplicatesFail:
+				return result, fmt.Errorf("row %d: %s", rowNumber, reason)
+			case DuplicatesSkip:
+				fiberlog.Warnf("Row %d: %s, skipping", rowNumber, reason)
+				result.addError("row %d: %s", rowNumber, reason)
+				result.RowsSkipped++
+				p.reject(ref, RejectStageDuplicate, reason, fields, columnMap)
+				continue
+			}
+		}
+
+		batch = append(batch, product)
+		row := batchRow{ref: ref}
+		if p.rejects != nil {
+			row.values = p.rejects.values(fields, columnMap)
+		}
+		rows = append(rows, row)
+		if len(batch) >= p.batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+			if err := p.checkFailures(failedBefore + result.Failed); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+	if err := p.checkFailures(failedBefore + result.Failed); err != nil {
+		return result, err
+	}
+
+	if result.RowsRead == 0 {
+		fiberlog.Info("No products to import")
+	}
+
+	return result, nil
+}
+
+// RunAll imports several sources one after the other, such as the worksheets of a workbook. Sources without
+// the required columns are left out; the result adds up the imported sources and breaks them down in Sheets.
+func (p *Pipeline) RunAll(ctx context.Context, sources []RowSource) (result Result, err error) {
+	start := time.Now()
+	result.Fingerprints = make(map[string]string)
+	// Rows repeating rows of earlier sources are duplicates too
+	tracker := newDuplicateTracker()
+	defer func() {
+		result.Duration = time.Since(start)
+		result.DuplicateIDs, result.NearDuplicates, result.Duplicates = tracker.summary()
+	}()
+
+	for _, source := range sources {
+		sheet := models.ImportSheet{Name: source.Name()}
+		if _, err := validateHeader(source.Header(), p.mapping, p.ids); err != nil {
+			fiberlog.Warnf("Skipping %s: %v", source.Name(), err)
+			sheet.Error = err.Error()
+			result.Sheets = append(result.Sheets, sheet)
+			continue
+		}
+
+		sourceResult, err := p.run(ctx, source, tracker, result.Failed)
+		sheet.RowsRead = sourceResult.RowsRead
+		sheet.RowsSkipped = sourceResult.RowsSkipped
+		sheet.Indexed = sourceResult.Indexed
+		sheet.Failed = sourceResult.Failed
+		if err != nil {
+			sheet.Error = err.Error()
+		}
+		result.Sheets = append(result.Sheets, sheet)
+
+		result.RowsRead += sourceResult.RowsRead
+		result.RowsSkipped += sourceResult.RowsSkipped
+		result.Indexed += sourceResult.Indexed
+		result.Failed += sourceResult.Failed
+		for _, message := range sourceResult.Errors {
+			result.addError("%s: %s", source.Name(), message)
+		}
+		for id, fingerprint := range sourceResult.Fingerprints {
+			result.Fingerprints[id] = fingerprint
+		}
+		if err != nil {
+			return result, err
+		}
+
+		fiberlog.Infof("Imported %s: %d rows read, %d indexed, %d skipped, %d failed",
+			source.Name(), sheet.RowsRead, sheet.Indexed, sheet.RowsSkipped, sheet.Failed)
+	}
+
+	imported := 0
+	for _, sheet := range result.Sheets {
+		if sheet.Error == "" {
+			imported++
+		}
+	}
+	if imported == 0 {
+		return result, fmt.Errorf("no source has the required columns")
+	}
+	return result, nil
+}
+
+// checkFailures fails once the rows failed to index reach the failure limit
+func (p *Pipeline) checkFailures(failed int) error {
+	if p.maxFailures > 0 && failed >= p.maxFailures {
+		return fmt.Errorf("import aborted: %d rows failed to index, reaching the failure limit of %d", failed, p.maxFailures)
+	}
+	return nil
+}
+
+// validateHeader validates that the column of every required field exists and maps the fields to column indices.
+// Fields the mapping gives no column are read from the column named after them or else from the first column
+// matching one of their aliases, regardless of case, spaces and punctuation, e.g. "Product Name" or "MANUFACTURER".
+// Under an ID policy generating IDs, the id column may be missing unless the mapping names it.
+func validateHeader(header []string, mapping Mapping, ids IDPolicy) (map[string]int, error) {
+	indices := make(map[string]int)
+	for i, column := range header {
+		indices[normalizeColumn(column)] = i
+	}
+
+	columnMap := make(map[string]int)
+	used := make(map[int]bool)
+	for _, field := range requiredColumns {
+		column := mapping.column(field)
+		if i, exists := indices[normalizeColumn(column)]; exists {
+			columnMap[field] = i
+			used[i] = true
+		} else if column != field {
+			return nil, fmt.Errorf("column '%s' mapped to %s not found in source", column, field)
+		}
+	}
+
+	// Attribute columns are optional, and taken before aliases are tried
+	for name, attribute := range mapping.Attributes {
+		if i, exists := indices[normalizeColumn(attribute.Column)]; exists {
+			columnMap[attributeKey(name)] = i
+			used[i] = true
+		}
+	}
+
+	// Aliases only match columns no other field is read from
+	normalized := make([]string, len(header))
+	for i, column := range header {
+		normalized[i] = normalizeAlias(column)
+	}
+	for _, field := range requiredColumns {
+		if _, resolved := columnMap[field]; resolved {
+			continue
+		}
+		if i := findAlias(normalized, used, mapping.headerAliases(field)); i >= 0 {
+			columnMap[field] = i
+			used[i] = true
+			continue
+		}
+		if field == "id" && ids.generatesIDs() {
+			continue
+		}
+		return nil, fmt.Errorf("required column '%s' not found in source, nor a column named by one of its aliases", field)
+	}
+
+	return columnMap, nil
+}
+
+// logAliases logs the fields of a source read from a column named by one of their aliases
+func logAliases(source RowSource, columnMap map[string]int, mapping Mapping) {
+	header := source.Header()
+	for _, field := range requiredColumns {
+		if i, ok := columnMap[field]; ok && normalizeColumn(header[i]) != normalizeColumn(mapping.column(field)) {
+			fiberlog.Infof("Reading %s of %s from column '%s'", field, source.Name(), header[i])
+		}
+	}
+}
+
+// normalizeColumn lowercases and trims a column name so headers match regardless of case and padding
+func normalizeColumn(column string) string {
+	return strings.ToLower(strings.TrimSpace(column))
+}
+
+// prepareRow turns a row into the product to index: the row is transformed, run through the enrichment chain and
+// held to the document limit. It returns the fields truncated to fit the limit; rows it fails are skipped.
+func (p *Pipeline) prepareRow(ctx context.Context, fields []string, columnMap map[string]int, transforms map[string][]ValueTransform, now time.Time) (models.Product, []string, error) {
+	product, err := transformRow(fields, columnMap, transforms, p.ids, now)
+	if err != nil {
+		return models.Product{}, nil, err
+	}
+	if product.Attributes, err = p.mapping.readAttributes(fields, columnMap); err != nil {
+		return models.Product{}, nil, err
+	}
+
+	// Run the enrichment chain before indexing
+	if p.enricher != nil {
+		if err := p.enricher.Enrich(ctx, &product); err != nil {
+			return models.Product{}, nil, fmt.Errorf("enrichment of product %s failed: %w", product.ID, err)
+		}
+	}
+
+	// Keep a single oversized cell from bloating the index and every search that returns the product
+	truncated, err := p.limit.Apply(&product)
+	if err != nil {
+		return models.Product{}, nil, err
+	}
+	return product, truncated, nil
+}
+
+// transformRow converts a row into a Product, applying the transforms of every field to its value. Rows without an
+// ID get one from the ID policy, if it generates them.
+func transformRow(fields []string, columnMap map[string]int, transforms map[string][]ValueTransform, ids IDPolicy, now time.Time) (models.Product, error) {
+	if isBlankRow(fields) {
+		return models.Product{}, fmt.Errorf("empty row")
+	}
+
+	values := make(map[string]string, len(requiredColumns))
+	for _, field := range requiredColumns {
+		var value string
+		if i, ok := columnMap[field]; ok && i < len(fields) {
+			value = strings.TrimSpace(fields[i])
+		}
+		for _, transform := range transforms[field] {
+			var err error
+			if value, err = transform(value); err != nil {
+				return models.Product{}, fmt.Errorf("%s: %w", field, err)
+			}
+		}
+		values[field] = value
+	}
+
+	product := models.Product{
+		ProductName: values["product_name"],
+		DrugGeneric: values["drug_generic"],
+		Company:     values["company"],
+		Score:       0.0, // Default score
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// Normalize and validate the ID
+	var err error
+	if values["id"] == "" && ids.generatesIDs() {
+		product.ID, err = ids.generateID(product)
+	} else {
+		product.ID, err = models.ParseProductID(values["id"])
+	}
+	if err != nil {
+		return models.Product{}, err
+	}
+	return product, nil
+}
+
+// isBlankRow reports whether every field of the row is empty
+func isBlankRow(fields []string) bool {
+	for _, field := range fields {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// publish hands the change events of a batch to the publisher. The batch is already indexed, so a failure
+// means its changes would never be delivered and is returned to fail the import.
+func (p *Pipeline) publish(ctx context.Context, events []models.ChangeEvent) error {
+	if p.publisher == nil || len(events) == 0 {
+		return nil
+	}
+	if err := p.publisher.Publish(ctx, events...); err != nil {
+		return fmt.Errorf("failed to record the change events of %d imported product(s), import again to deliver them: %w", len(events), err)
+	}
+	return nil
+}
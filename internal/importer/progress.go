@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"io"
+	"time"
+
+	"elasticsearch/internal/models"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// ProgressSource is implemented by sources that can tell how much of their data has been read
+type ProgressSource interface {
+	// Progress returns the fraction of the source read so far, between 0 and 1; ok is false while it is unknown,
+	// e.g. for a download of unknown length
+	Progress() (fraction float64, ok bool)
+}
+
+// sizedReader counts the bytes read from a reader of known size, so streamed sources can tell their progress
+type sizedReader struct {
+	io.ReadCloser
+	size int64
+	read int64
+}
+
+// newSizedReader tracks the bytes read from rc; readers of unknown size are returned as is
+func newSizedReader(rc io.ReadCloser, size int64) io.ReadCloser {
+	if size <= 0 {
+		return rc
+	}
+	return &sizedReader{ReadCloser: rc, size: size}
+}
+
+// Read implements io.Reader
+func (r *sizedReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.read += int64(n)
+	return n, err
+}
+
+// Progress implements ProgressSource
+func (r *sizedReader) Progress() (float64, bool) {
+	return min(float64(r.read)/float64(r.size), 1), true
+}
+
+// readerProgress returns the progress of a reader wrapped by newSizedReader
+func readerProgress(rc io.Closer) (float64, bool) {
+	if sized, ok := rc.(*sizedReader); ok {
+		return sized.Progress()
+	}
+	return 0, false
+}
+
+// rowProgress returns the progress of a source of known row count
+func rowProgress(read, total int) (float64, bool) {
+	if total <= 0 {
+		return 0, false
+	}
+	return min(float64(read)/float64(total), 1), true
+}
+
+// progressReporter reports the progress of the source being imported at most once per interval
+type progressReporter struct {
+	interval time.Duration
+	notify   func(models.ImportProgress)
+	source   RowSource
+	index    string
+	start    time.Time
+	last     time.Time
+}
+
+// report logs the progress of the import and hands it to notify once the interval has passed since the last
+// report
+func (r *progressReporter) report(result Result) {
+	if r.interval <= 0 {
+		return
+	}
+	now := time.Now()
+	if now.Sub(r.last) < r.interval {
+		return
+	}
+	r.last = now
+
+	elapsed := now.Sub(r.start)
+	progress := models.ImportProgress{
+		Source:      r.source.Name(),
+		Index:       r.index,
+		Status:      models.ImportStatusRunning,
+		StartedAt:   r.start,
+		RowsRead:    result.RowsRead,
+		RowsSkipped: result.RowsSkipped,
+		Indexed:     result.Indexed,
+		Failed:      result.Failed,
+		RowsPerSec:  float64(result.RowsRead) / elapsed.Seconds(),
+	}
+
+	eta := "unknown"
+	if source, ok := r.source.(ProgressSource); ok {
+		if fraction, ok := source.Progress(); ok && fraction > 0 {
+			progress.PercentDone = fraction * 100
+			remaining := time.Duration(float64(elapsed) * (1 - fraction) / fraction)
+			progress.ETASeconds = int64(remaining.Seconds())
+			eta = remaining.Round(time.Second).String()
+		}
+	}
+
+	fiberlog.Infof("Import progress of %s: %d rows read (%.1f%%), %d indexed, %d skipped, %d failed, %.0f rows/s, ETA %s",
+		progress.Source, progress.RowsRead, progress.PercentDone, progress.Indexed, progress.RowsSkipped, progress.Failed,
+		progress.RowsPerSec, eta)
+	if r.notify != nil {
+		r.notify(progress)
+	}
+}
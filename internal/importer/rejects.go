@@ -0,0 +1,137 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"elasticsearch/internal/models"
+)
+
+// Stages of an import at which rows are rejected
+const (
+	// RejectStageParse rejects rows failing transform, enrichment or the document limit
+	RejectStageParse = "parse"
+	// RejectStageDuplicate rejects rows repeating an earlier row under the skip duplicate policy
+	RejectStageDuplicate = "duplicate"
+	// RejectStageIndex rejects rows Elasticsearch failed to index
+	RejectStageIndex = "index"
+)
+
+// rejectColumns are the columns a reject report adds in front of the product columns of a row
+var rejectColumns = []string{"reject_source", "reject_row", "reject_stage", "reject_reason"}
+
+// RejectReport writes the rows an import rejected, with the stage and reason they were rejected for, to a CSV file,
+// or an NDJSON file if the path ends in .ndjson or .jsonl. Rows keep their raw values under the source columns of
+// the product fields and attributes, so the report can be fixed and imported again with the same mapping; the
+// reject columns are ignored by imports.
+type RejectReport struct {
+	path string
+	file *os.File
+	// keys are the columnMap keys of the columns
+	keys    []string
+	columns []string
+	csv     *csv.Writer
+	json    *json.Encoder
+	rows    int
+	// err is the first write error; later rows are dropped
+	err error
+}
+
+// NewRejectReport creates, or truncates, the reject report at path. The product and attribute columns are named by
+// mapping and CSV reports are written with the delimiter of opts.
+func NewRejectReport(path string, mapping Mapping, opts CSVOptions) (*RejectReport, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reject report: %w", err)
+	}
+
+	report := &RejectReport{path: path, file: file}
+	for _, field := range requiredColumns {
+		report.keys = append(report.keys, field)
+		report.columns = append(report.columns, mapping.column(field))
+	}
+	for _, name := range mapping.attributeNames() {
+		report.keys = append(report.keys, attributeKey(name))
+		report.columns = append(report.columns, mapping.Attributes[name].Column)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		report.json = json.NewEncoder(file)
+	default:
+		report.csv = csv.NewWriter(file)
+		if opts.Delimiter != 0 {
+			report.csv.Comma = opts.Delimiter
+		}
+		report.err = report.csv.Write(slices.Concat(report.columns, rejectColumns))
+	}
+	return report, nil
+}
+
+// Path returns the file the report is written to
+func (r *RejectReport) Path() string {
+	return r.path
+}
+
+// Rows returns the number of rows written to the report
+func (r *RejectReport) Rows() int {
+	return r.rows
+}
+
+// add writes a rejected row; values holds its raw value of every column of the report, see values
+func (r *RejectReport) add(ref models.ImportRowRef, stage, reason string, values []string) {
+	if r == nil || r.err != nil {
+		return
+	}
+
+	if r.csv != nil {
+		r.err = r.csv.Write(slices.Concat(values, []string{ref.Source, strconv.Itoa(ref.Row), stage, reason}))
+	} else {
+		object := make(map[string]string, len(r.columns)+len(rejectColumns))
+		for i, column := range r.columns {
+			object[column] = values[i]
+		}
+		object["reject_source"] = ref.Source
+		object["reject_row"] = strconv.Itoa(ref.Row)
+		object["reject_stage"] = stage
+		object["reject_reason"] = reason
+		r.err = r.json.Encode(object)
+	}
+	if r.err == nil {
+		r.rows++
+	}
+}
+
+// Close flushes and closes the report; it returns the first error writing it
+func (r *RejectReport) Close() error {
+	if r.csv != nil {
+		r.csv.Flush()
+		if r.err == nil {
+			r.err = r.csv.Error()
+		}
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if r.err != nil {
+		return fmt.Errorf("failed to write reject report %s: %w", r.path, r.err)
+	}
+	return nil
+}
+
+// values returns the raw value of every column of the report of a row
+func (r *RejectReport) values(fields []string, columnMap map[string]int) []string {
+	values := make([]string, len(r.keys))
+	for i, key := range r.keys {
+		if column, ok := columnMap[key]; ok && column < len(fields) {
+			values[i] = fields[column]
+		}
+	}
+	return values
+}
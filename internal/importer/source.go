@@ -0,0 +1,140 @@
+// Package importer implements the validate, transform and bulk index pipeline fed by row sources
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// RowSource produces tabular rows from an import source
+type RowSource interface {
+	// Name identifies the source in logs
+	Name() string
+	// Header returns the column names of the source
+	Header() []string
+	// Next returns the next row, or io.EOF once the source is exhausted
+	Next() ([]string, error)
+	// Close releases any resource held by the source
+	Close() error
+}
+
+// SourceOptions tunes how import sources are opened
+type SourceOptions struct {
+	// Format forces the source format instead of detecting it from the path; only "csv" is supported
+	Format string
+	// CSV is the dialect of local CSV files; the zero value reads DefaultCSVOptions
+	CSV CSVOptions
+	// Sheet selects the worksheet of a local workbook by name or 1-based position; empty reads the first one
+	Sheet string
+	// AllSheets makes OpenSources open every worksheet of a local workbook
+	AllSheets bool
+	// GoogleCredentialsFile is the service account key Google Sheets are read with through the Sheets API;
+	// without one only public spreadsheets can be read, through their CSV export. Cloud Storage objects are read
+	// with it too.
+	GoogleCredentialsFile string
+	// S3 holds the credentials of S3 objects
+	S3 S3Options
+	// SQL holds the database sql: queries are run against
+	SQL SQLOptions
+}
+
+// OpenSource selects and opens the RowSource implementation matching the given path
+func OpenSource(ctx context.Context, path string, opts SourceOptions) (RowSource, error) {
+	switch {
+	case IsSQLSource(path):
+		return NewSQLSource(ctx, strings.TrimPrefix(path, sqlPrefix), opts.SQL)
+	case IsObjectURI(path):
+		return OpenObjectSource(ctx, path, opts)
+	case opts.Format == "csv":
+		return NewCSVSource(path, opts.CSV)
+	case opts.Format != "":
+		return nil, fmt.Errorf("unsupported import format: %s", opts.Format)
+	case isGoogleSheetsURL(path) && opts.GoogleCredentialsFile != "":
+		return NewGoogleSheetsAPISource(ctx, path, opts.GoogleCredentialsFile, opts.Sheet)
+	case isGoogleSheetsURL(path):
+		return NewGoogleSheetsSource(ctx, path)
+	}
+
+	// Excel paths may select a worksheet with a #name suffix
+	file, _, _ := strings.Cut(path, "#")
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv":
+		return NewCSVSource(path, opts.CSV)
+	case ".ndjson", ".jsonl":
+		return NewNDJSONSource(path)
+	case ".parquet":
+		return NewParquetSource(path)
+	case ".xlsx":
+		return NewLocalExcelSource(path, opts.Sheet)
+	case ".xls":
+		return nil, fmt.Errorf("legacy .xls workbooks are not supported, save %s as .xlsx", file)
+	}
+
+	return nil, fmt.Errorf("unsupported import source: %s", path)
+}
+
+// IsRemoteSource reports whether a path addresses a source fetched over the network: a Google Sheet or an object in
+// S3 or Cloud Storage. Local files and SQL queries are not remote.
+func IsRemoteSource(path string) bool {
+	return IsObjectURI(path) || isGoogleSheetsURL(path)
+}
+
+// isGoogleSheetsURL reports whether a path is the URL of a Google Sheet
+func isGoogleSheetsURL(path string) bool {
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "https" || parsed.Scheme == "http") && parsed.Host == "docs.google.com" &&
+		strings.HasPrefix(parsed.Path, "/spreadsheets/")
+}
+
+// IsFileSource reports whether a local file can be imported, judging by its extension
+func IsFileSource(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".ndjson", ".jsonl", ".parquet", ".xlsx":
+		return true
+	}
+	return false
+}
+
+// OpenSources opens the sources of an import: the source matching the given path, or with AllSheets every
+// worksheet of a local workbook. Empty worksheets are left out.
+func OpenSources(ctx context.Context, path string, opts SourceOptions) ([]RowSource, error) {
+	if !opts.AllSheets {
+		source, err := OpenSource(ctx, path, opts)
+		if err != nil {
+			return nil, err
+		}
+		return []RowSource{source}, nil
+	}
+
+	if opts.Format != "" || strings.ToLower(filepath.Ext(path)) != ".xlsx" {
+		return nil, fmt.Errorf("importing all worksheets requires a local .xlsx workbook: %s", path)
+	}
+
+	workbook, err := OpenWorkbook(path)
+	if err != nil {
+		return nil, err
+	}
+	defer workbook.Close()
+
+	var sources []RowSource
+	for _, sheet := range workbook.Sheets() {
+		source, err := workbook.Sheet(sheet)
+		if err != nil {
+			fiberlog.Warnf("Skipping worksheet %q of %s: %v", sheet, path, err)
+			continue
+		}
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("source contains no data")
+	}
+	return sources, nil
+}
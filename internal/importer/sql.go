@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	// Registers the "postgres" driver SQL sources connect with by default
+	_ "github.com/lib/pq"
+)
+
+// sqlPrefix marks an import path holding the query of a SQL source, e.g. "sql:SELECT * FROM products"
+const sqlPrefix = "sql:"
+
+// DefaultSQLDriver is the database/sql driver compiled into the binary, for PostgreSQL
+const DefaultSQLDriver = "postgres"
+
+// SQLOptions holds the database SQL sources query
+type SQLOptions struct {
+	// Enabled allows opening SQL sources; they are off unless a database is configured on purpose
+	Enabled bool
+	// Driver names a database/sql driver compiled into the binary; DefaultSQLDriver is the only one
+	Driver string
+	// DSN is the data source name the driver connects with
+	DSN string
+}
+
+// SQLSource reads the rows of a query. The header is taken from the column names of the result, and values are
+// coerced to the text the other sources produce.
+type SQLSource struct {
+	driver string
+	db     *sql.DB
+	rows   *sql.Rows
+	header []string
+	values []interface{}
+}
+
+// IsSQLSource reports whether an import path is the query of a SQL source
+func IsSQLSource(path string) bool {
+	return strings.HasPrefix(path, sqlPrefix)
+}
+
+// NewSQLSource runs a query against the database of opts and reads its result as a RowSource
+func NewSQLSource(ctx context.Context, query string, opts SQLOptions) (*SQLSource, error) {
+	if !opts.Enabled {
+		return nil, fmt.Errorf("sql sources are disabled, set IMPORT_SQL_ENABLED=true to import from a database")
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("sql source requires a query, e.g. sql:SELECT * FROM products")
+	}
+	if opts.Driver == "" || opts.DSN == "" {
+		return nil, fmt.Errorf("sql sources require IMPORT_SQL_DRIVER and IMPORT_SQL_DSN")
+	}
+	if !slices.Contains(sql.Drivers(), opts.Driver) {
+		return nil, fmt.Errorf("sql driver %q is not compiled in, registered drivers: %v", opts.Driver, sql.Drivers())
+	}
+
+	db, err := sql.Open(opts.Driver, opts.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sql database: %w", err)
+	}
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to run sql query: %w", err)
+	}
+	header, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		db.Close()
+		return nil, fmt.Errorf("failed to read sql columns: %w", err)
+	}
+
+	return &SQLSource{driver: opts.Driver, db: db, rows: rows, header: header, values: make([]interface{}, len(header))}, nil
+}
+
+// Name identifies the source in logs by its driver, leaving out the DSN and its credentials
+func (s *SQLSource) Name() string {
+	return sqlPrefix + s.driver
+}
+
+// Header returns the column names of the query result
+func (s *SQLSource) Header() []string {
+	return s.header
+}
+
+// Next returns the next row of the query result
+func (s *SQLSource) Next() ([]string, error) {
+	if !s.rows.Next() {
+		if err := s.rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read sql row: %w", err)
+		}
+		return nil, io.EOF
+	}
+
+	pointers := make([]interface{}, len(s.values))
+	for i := range s.values {
+		pointers[i] = &s.values[i]
+	}
+	if err := s.rows.Scan(pointers...); err != nil {
+		return nil, fmt.Errorf("failed to read sql row: %w", err)
+	}
+
+	row := make([]string, len(s.values))
+	for i, value := range s.values {
+		row[i] = sqlValue(value)
+	}
+	return row, nil
+}
+
+// Close releases the result and the database connections
+func (s *SQLSource) Close() error {
+	s.rows.Close()
+	return s.db.Close()
+}
+
+// sqlValue formats a value scanned from a driver: timestamps as RFC 3339 in UTC and nulls as empty cells
+func sqlValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(value)
+	case string:
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case time.Time:
+		return value.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
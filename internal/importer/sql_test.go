@@ -0,0 +1,48 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSQLSourceUsesCompiledInDriver(t *testing.T) {
+	if !slices.Contains(sql.Drivers(), DefaultSQLDriver) {
+		t.Fatalf("driver %q is not registered, registered drivers: %v", DefaultSQLDriver, sql.Drivers())
+	}
+
+	// Nothing listens on the port, so the driver is reached and fails to connect
+	opts := SourceOptions{SQL: SQLOptions{
+		Enabled: true,
+		Driver:  DefaultSQLDriver,
+		DSN:     "postgres://reader@127.0.0.1:1/erp?sslmode=disable&connect_timeout=1",
+	}}
+	_, err := OpenSource(context.Background(), "sql:SELECT 1", opts)
+	if err == nil || !strings.Contains(err.Error(), "failed to run sql query") {
+		t.Fatalf("opening the source returned %v, want a connection error from the %s driver", err, DefaultSQLDriver)
+	}
+
+	opts.SQL.Enabled = false
+	if _, err := OpenSource(context.Background(), "sql:SELECT 1", opts); err == nil || !strings.Contains(err.Error(), "IMPORT_SQL_ENABLED") {
+		t.Fatalf("opening a disabled source returned %v, want it refused", err)
+	}
+}
+
+func TestIsRemoteSource(t *testing.T) {
+	for path, want := range map[string]bool{
+		"https://docs.google.com/spreadsheets/d/abc/edit#gid=0": true,
+		"s3://catalog-drops/nightly/products.csv":               true,
+		"gs://catalog-drops/nightly/products.csv":               true,
+		"sql:SELECT * FROM products":                            false,
+		"/etc/passwd":                                           false,
+		"products.xlsx":                                         false,
+		"/data/docs.google.com/spreadsheets/products.csv":       false,
+		"https://example.com/docs.google.com/spreadsheets/x":    false,
+	} {
+		if got := IsRemoteSource(path); got != want {
+			t.Errorf("IsRemoteSource(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ValueTransform rewrites the value of a product field read from its source column
+type ValueTransform func(value string) (string, error)
+
+// TransformFactory creates a ValueTransform from the argument of its spec, e.g. "*-" for "trim:*-"; hasArg tells
+// an empty argument from none
+type TransformFactory func(arg string, hasArg bool) (ValueTransform, error)
+
+var transformRegistry = map[string]TransformFactory{}
+
+// RegisterTransform makes a transform available by name to the transforms of mapping files
+func RegisterTransform(name string, factory TransformFactory) {
+	transformRegistry[name] = factory
+}
+
+// AvailableTransforms returns the names of all registered transforms
+func AvailableTransforms() []string {
+	names := make([]string, 0, len(transformRegistry))
+	for name := range transformRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseTransform parses a transform spec, the name of a registered transform optionally followed by ':' and its
+// argument
+func parseTransform(spec string) (ValueTransform, error) {
+	name, arg, hasArg := strings.Cut(strings.TrimSpace(spec), ":")
+	factory, ok := transformRegistry[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown transform %q (available: %s)", spec, strings.Join(AvailableTransforms(), ", "))
+	}
+
+	transform, err := factory(arg, hasArg)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %w", spec, err)
+	}
+	return transform, nil
+}
+
+// The built-in transforms:
+//
+//	trim               removes surrounding whitespace, which every value already goes through
+//	trim:<chars>       removes the given surrounding characters, e.g. "trim:*-"
+//	lowercase          converts to lower case
+//	uppercase          converts to upper case
+//	titlecase          capitalizes every word and lowercases the rest, e.g. "ACME PHARMA" to "Acme Pharma"
+//	titlecase:<lang>   capitalizes by the rules of a language, e.g. "titlecase:tr" for the Turkish dotted i
+//	strip-units        removes the units of strengths, e.g. "500 mg" to "500" and "5mg/ml" to "5"
+//	strip-units:<list> removes the comma separated units of the list, e.g. "strip-units:mg,ml"
+//	unicode            normalizes to Unicode NFKC, folding full-width characters, ligatures and non-breaking spaces
+//	unicode:<form>     normalizes to the form nfc, nfd, nfkc or nfkd
+//	date               reformats a date in one of the formats recognized by inspections as an ISO 8601 date
+//	date:<layout>      reformats a date in the given Go layout, e.g. "date:02.01.2006", as an ISO 8601 date
+func init() {
+	RegisterTransform("trim", trimTransform)
+	RegisterTransform("lowercase", noArg(strings.ToLower))
+	RegisterTransform("uppercase", noArg(strings.ToUpper))
+	RegisterTransform("titlecase", titleCaseTransform)
+	RegisterTransform("strip-units", stripUnitsTransform)
+	RegisterTransform("unicode", unicodeTransform)
+	RegisterTransform("date", dateTransform)
+}
+
+// noArg creates a TransformFactory of a transform without argument
+func noArg(convert func(string) string) TransformFactory {
+	return func(_ string, hasArg bool) (ValueTransform, error) {
+		if hasArg {
+			return nil, fmt.Errorf("takes no argument")
+		}
+		return func(value string) (string, error) { return convert(value), nil }, nil
+	}
+}
+
+// trimTransform removes surrounding whitespace, or the characters of its argument
+func trimTransform(chars string, hasArg bool) (ValueTransform, error) {
+	if !hasArg {
+		return func(value string) (string, error) { return strings.TrimSpace(value), nil }, nil
+	}
+	if chars == "" {
+		return nil, fmt.Errorf("needs the characters to remove")
+	}
+	return func(value string) (string, error) { return strings.Trim(value, chars), nil }, nil
+}
+
+// titleCaseTransform capitalizes every word, by the rules of the language of its argument if any
+func titleCaseTransform(lang string, hasArg bool) (ValueTransform, error) {
+	tag := language.Und
+	if hasArg {
+		var err error
+		if tag, err = language.Parse(lang); err != nil {
+			return nil, fmt.Errorf("invalid language %q", lang)
+		}
+	}
+	return func(value string) (string, error) {
+		// A caser keeps state between calls, so every value gets its own
+		return cases.Title(tag).String(value), nil
+	}, nil
+}
+
+// defaultStrengthUnits are the units removed by strip-units without argument, longest first so "mg/ml" isn't
+// left with "/ml"
+var defaultStrengthUnits = []string{"mg/ml", "mg/g", "mcg", "µg", "μg", "mg", "kg", "ml", "iu", "ui", "g", "l", "%"}
+
+// stripUnitsTransform removes the units following numbers, the default strength units or those of its argument
+func stripUnitsTransform(list string, hasArg bool) (ValueTransform, error) {
+	units := defaultStrengthUnits
+	if hasArg {
+		units = nil
+		for _, unit := range strings.Split(list, ",") {
+			if unit = strings.TrimSpace(unit); unit != "" {
+				units = append(units, unit)
+			}
+		}
+		if len(units) == 0 {
+			return nil, fmt.Errorf("needs the units to remove")
+		}
+		// Longer units first, so a unit isn't cut short by its prefix
+		sort.SliceStable(units, func(i, j int) bool { return len(units[i]) > len(units[j]) })
+	}
+
+	quoted := make([]string, len(units))
+	for i, unit := range units {
+		quoted[i] = regexp.QuoteMeta(unit)
+	}
+	// A number, its unit, and the character after the unit, kept unless it is a letter of a longer word
+	pattern := regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)\s*(?:` + strings.Join(quoted, "|") + `)([^\pL]|$)`)
+	return func(value string) (string, error) {
+		return pattern.ReplaceAllString(value, "$1$2"), nil
+	}, nil
+}
+
+// unicodeForms are the Unicode normalization forms of the unicode transform
+var unicodeForms = map[string]norm.Form{"nfc": norm.NFC, "nfd": norm.NFD, "nfkc": norm.NFKC, "nfkd": norm.NFKD}
+
+// unicodeTransform normalizes to NFKC, or the form of its argument
+func unicodeTransform(name string, hasArg bool) (ValueTransform, error) {
+	form := norm.NFKC
+	if hasArg {
+		var ok bool
+		if form, ok = unicodeForms[strings.ToLower(name)]; !ok {
+			return nil, fmt.Errorf("unknown normalization form %q, expected nfc, nfd, nfkc or nfkd", name)
+		}
+	}
+	return func(value string) (string, error) { return form.String(value), nil }, nil
+}
+
+// dateTransform reformats dates as ISO 8601 dates, reading them in the layout of its argument or any layout
+// recognized by inspections
+func dateTransform(layout string, hasArg bool) (ValueTransform, error) {
+	layouts := inspectDateLayouts
+	if hasArg {
+		if layout == "" {
+			return nil, fmt.Errorf("needs a layout")
+		}
+		layouts = []string{layout}
+	}
+	return func(value string) (string, error) {
+		if value == "" {
+			return "", nil
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t.Format("2006-01-02"), nil
+			}
+		}
+		return "", fmt.Errorf("invalid date %q", value)
+	}, nil
+}
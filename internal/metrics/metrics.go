@@ -0,0 +1,27 @@
+// Package metrics exposes process-wide counters published through expvar, served to admins at /admin/debug/vars
+package metrics
+
+import "expvar"
+
+var (
+	// SearchRequests counts search requests sent to Elasticsearch
+	SearchRequests = expvar.NewInt("search_requests_total")
+	// SearchPartialResults counts searches where one or more shards failed
+	SearchPartialResults = expvar.NewInt("search_partial_results_total")
+	// SearchTimedOut counts searches that hit the Elasticsearch search timeout
+	SearchTimedOut = expvar.NewInt("search_timed_out_total")
+	// OutboxDelivered counts change events accepted by outbox sinks
+	OutboxDelivered = expvar.NewInt("outbox_delivered_total")
+	// OutboxDeliveryFailures counts change event deliveries rejected by outbox sinks
+	OutboxDeliveryFailures = expvar.NewInt("outbox_delivery_failures_total")
+	// ProductCacheHits counts product lookups answered from the detail cache, including cached misses
+	ProductCacheHits = expvar.NewInt("product_cache_hits_total")
+	// ProductCacheMisses counts product lookups that had to go to Elasticsearch
+	ProductCacheMisses = expvar.NewInt("product_cache_misses_total")
+	// AggregationCacheHits counts facet, distinct value and statistics queries answered from the aggregation cache
+	AggregationCacheHits = expvar.NewInt("aggregation_cache_hits_total")
+	// AggregationCacheMisses counts facet, distinct value and statistics queries that had to go to Elasticsearch
+	AggregationCacheMisses = expvar.NewInt("aggregation_cache_misses_total")
+	// SearchLogsDropped counts analytics search log entries dropped because the queue was full or the write failed
+	SearchLogsDropped = expvar.NewInt("search_logs_dropped_total")
+)
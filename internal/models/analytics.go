@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// SearchLogEntry is the raw record of a single product search kept for analytics
+type SearchLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Keyword is normalized with NormalizeKeyword; empty for browse searches without a keyword
+	Keyword   string `json:"keyword"`
+	TotalHits int64  `json:"total_hits"`
+	TookMs    int64  `json:"took_ms"`
+}
+
+// @description Number of searches for a keyword
+type QueryCount struct {
+	Query string `json:"query"`
+	Count int64  `json:"count"`
+}
+
+// @description Search activity rolled up for a single UTC day
+type SearchDailySummary struct {
+	// Date is the UTC day in YYYY-MM-DD form
+	Date               string       `json:"date"`
+	Searches           int64        `json:"searches"`
+	ZeroResultSearches int64        `json:"zero_result_searches"`
+	ZeroResultRate     float64      `json:"zero_result_rate"`
+	AvgLatencyMs       float64      `json:"avg_latency_ms"`
+	TopQueries         []QueryCount `json:"top_queries"`
+	RolledUpAt         time.Time    `json:"rolled_up_at"`
+}
+
+// SearchDailySummaryParams contains pagination for listing daily summaries
+type SearchDailySummaryParams struct {
+	Limit  int
+	Offset int
+}
+
+// SearchDailySummaryResult contains daily summaries and pagination info
+type SearchDailySummaryResult struct {
+	Summaries  []SearchDailySummary
+	TotalCount int64
+	Limit      int
+	Offset     int
+}
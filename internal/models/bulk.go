@@ -0,0 +1,41 @@
+package models
+
+// @description Outcome of a single item in a bulk write
+type BulkItemResult struct {
+	Position int       `json:"position"`
+	ID       ProductID `json:"id,omitempty" swaggertype:"string"`
+	Status   int       `json:"status"`
+	Error    string    `json:"error,omitempty"`
+	// RolledBack is set on items written and then removed again because another item of an all-or-nothing
+	// write failed
+	RolledBack bool `json:"rolled_back,omitempty"`
+}
+
+// @description Per-item outcome of a bulk write
+type BulkResult struct {
+	Items     []BulkItemResult `json:"items"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	// Rollback reports the reverted writes of an all-or-nothing write with failed items
+	Rollback *BulkRollback `json:"rollback,omitempty"`
+}
+
+// @description Reconciliation report of the writes reverted after an all-or-nothing bulk write failed
+type BulkRollback struct {
+	// Reverted is the number of written products removed again
+	Reverted int `json:"reverted"`
+	// Failed is the number of written products that couldn't be removed and are still stored
+	Failed int `json:"failed"`
+	// Items holds the outcome of reverting every written product, positions referring to the request array
+	Items []BulkItemResult `json:"items"`
+}
+
+// Add appends an item result and updates the counters
+func (r *BulkResult) Add(item BulkItemResult) {
+	r.Items = append(r.Items, item)
+	if item.Status >= 200 && item.Status < 300 {
+		r.Succeeded++
+	} else {
+		r.Failed++
+	}
+}
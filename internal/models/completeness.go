@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CompletenessReport lists the products of an import that miss mandatory fields
+type CompletenessReport struct {
+	ImportRunID string             `json:"import_run_id"`
+	Source      string             `json:"source"`
+	Index       string             `json:"index"`
+	CheckedAt   time.Time          `json:"checked_at"`
+	Rules       []CompletenessRule `json:"rules"`
+}
+
+// CompletenessRule is the outcome of one mandatory field check
+type CompletenessRule struct {
+	Field        string `json:"field"`
+	FailingCount int64  `json:"failing_count"`
+	// FailingIDs lists the first failing products, up to the configured maximum
+	FailingIDs []ProductID `json:"failing_ids"`
+}
+
+// Complete reports whether every product passed every rule
+func (r CompletenessReport) Complete() bool {
+	for _, rule := range r.Rules {
+		if rule.FailingCount > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseRequiredFields validates the mandatory fields of the completeness rules.
+// Fields outside SearchableFields are rejected; blanks and duplicates are dropped.
+func ParseRequiredFields(raw []string) ([]string, error) {
+	var fields []string
+	for _, field := range raw {
+		field = strings.TrimSpace(field)
+		if field == "" || slices.Contains(fields, field) {
+			continue
+		}
+		if !slices.Contains(SearchableFields, field) {
+			return nil, fmt.Errorf("unsupported required field %q, expected one of %s", field, strings.Join(SearchableFields, ", "))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// MaxPinnedProducts is the maximum number of products pinned for one keyword (the pinned query limit)
+const MaxPinnedProducts = 100
+
+// @description Products pinned to the top of the results for a keyword
+type Curation struct {
+	Keyword   string      `json:"keyword"`
+	PinnedIDs []ProductID `json:"pinned_ids" swaggertype:"array,string"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// @description Products to pin for a keyword, in display order
+type CurationRequest struct {
+	PinnedIDs []ProductID `json:"pinned_ids" swaggertype:"array,string"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// CursorStart is the cursor value that starts a cursor-paginated search
+const CursorStart = "start"
+
+// PITStart is the pit value that opens a point-in-time pagination session
+const PITStart = "start"
+
+// keepAlivePattern matches Elasticsearch time values usable as a point in time keep-alive
+var keepAlivePattern = regexp.MustCompile(`^[1-9][0-9]*(ms|s|m|h|d)$`)
+
+// ParseKeepAlive validates a point in time keep-alive such as 30s or 5m
+func ParseKeepAlive(raw string) (string, error) {
+	if !keepAlivePattern.MatchString(raw) {
+		return "", fmt.Errorf("invalid keep-alive %q, expected a positive number with a unit (ms, s, m, h or d), e.g. 5m", raw)
+	}
+	return raw, nil
+}
+
+// Cursor is the position of a cursor-paginated search: the point in time the search reads and the
+// sort values of the last hit returned. A cursor without a point in time starts a new search.
+type Cursor struct {
+	PIT   string        `json:"pit"`
+	After []interface{} `json:"after"`
+}
+
+// ParseCursor decodes a next_cursor token, or returns a new cursor for CursorStart
+func ParseCursor(raw string) (*Cursor, error) {
+	if raw == CursorStart {
+		return &Cursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q, expected %s or the next_cursor of a previous page", raw, CursorStart)
+	}
+
+	// Keep sort values as they were returned so large numbers survive the round trip
+	var cursor Cursor
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cursor); err != nil || cursor.PIT == "" || len(cursor.After) == 0 {
+		return nil, fmt.Errorf("invalid cursor %q, expected %s or the next_cursor of a previous page", raw, CursorStart)
+	}
+
+	return &cursor, nil
+}
+
+// Encode returns the opaque token handed to clients as next_cursor
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Oversize policies for documents larger than the configured maximum
+const (
+	// OversizeReject fails the write
+	OversizeReject = "reject"
+	// OversizeTruncate shortens the largest text fields until the document fits
+	OversizeTruncate = "truncate"
+)
+
+// minDocumentBytes leaves room for the ID and timestamps every document carries
+const minDocumentBytes = 1024
+
+// ProductSourceFields lists the _source fields read back from product documents;
+// the ID and score come from the hit metadata
+var ProductSourceFields = []string{"product_name", "drug_generic", "company", "created_at", "updated_at", "deleted_at", "attributes"}
+
+// DocumentLimit caps the size of product documents written to the index
+type DocumentLimit struct {
+	// MaxBytes is the largest JSON-encoded document accepted; 0 disables the limit
+	MaxBytes int
+	Policy   string
+}
+
+// NewDocumentLimit validates a maximum size and oversize policy
+func NewDocumentLimit(maxBytes int, policy string) (DocumentLimit, error) {
+	if policy != OversizeReject && policy != OversizeTruncate {
+		return DocumentLimit{}, fmt.Errorf("invalid oversize policy %q, expected %s or %s", policy, OversizeReject, OversizeTruncate)
+	}
+	if maxBytes != 0 && maxBytes < minDocumentBytes {
+		return DocumentLimit{}, fmt.Errorf("invalid max document size %d, expected 0 (no limit) or at least %d bytes", maxBytes, minDocumentBytes)
+	}
+	return DocumentLimit{MaxBytes: maxBytes, Policy: policy}, nil
+}
+
+// Apply checks the product against the limit. Under the truncate policy the longest text fields are
+// shortened to a common length at which the document fits and their names are returned; under the
+// reject policy an oversized product is an error naming its largest field.
+func (l DocumentLimit) Apply(product *Product) ([]string, error) {
+	if l.MaxBytes == 0 {
+		return nil, nil
+	}
+
+	size := documentSize(*product)
+	if size <= l.MaxBytes {
+		return nil, nil
+	}
+
+	fields := textFields(product)
+	if l.Policy != OversizeTruncate {
+		largest := slices.MaxFunc(fields, func(a, b textField) int { return len(*a.value) - len(*b.value) })
+		return nil, fmt.Errorf("document is %d bytes, over the %d byte limit (largest field %s is %d bytes)", size, l.MaxBytes, largest.name, len(*largest.value))
+	}
+
+	// Binary search the longest field length at which the document fits
+	fits := func(maxLen int) bool {
+		capped := *product
+		for _, field := range textFields(&capped) {
+			*field.value = truncateString(*field.value, maxLen)
+		}
+		return documentSize(capped) <= l.MaxBytes
+	}
+	low, high := 0, 0
+	for _, field := range fields {
+		high = max(high, len(*field.value))
+	}
+	for low < high {
+		mid := (low + high + 1) / 2
+		if fits(mid) {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	if low == 0 {
+		return nil, fmt.Errorf("document is %d bytes and can't be truncated to the %d byte limit", size, l.MaxBytes)
+	}
+
+	var truncated []string
+	for _, field := range fields {
+		if len(*field.value) > low {
+			*field.value = truncateString(*field.value, low)
+			truncated = append(truncated, field.name)
+		}
+	}
+	return truncated, nil
+}
+
+// truncateString cuts value to at most maxLen bytes without splitting a UTF-8 sequence
+func truncateString(value string, maxLen int) string {
+	if len(value) <= maxLen {
+		return value
+	}
+	return strings.ToValidUTF8(value[:maxLen], "")
+}
+
+// documentSize returns the size of the product as sent to Elasticsearch
+func documentSize(product Product) int {
+	data, err := json.Marshal(product)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// textField is a text field of a product document
+type textField struct {
+	name  string
+	value *string
+}
+
+// textFields returns the text fields of the product
+func textFields(product *Product) []textField {
+	return []textField{
+		{"product_name", &product.ProductName},
+		{"drug_generic", &product.DrugGeneric},
+		{"company", &product.Company},
+	}
+}
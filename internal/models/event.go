@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Change event types
+const (
+	ChangeCreated     = "product.created"
+	ChangeUpdated     = "product.updated"
+	ChangeDeleted     = "product.deleted"
+	ChangeSoftDeleted = "product.soft_deleted"
+	ChangeImported    = "product.imported"
+)
+
+// Change event sources
+const (
+	ChangeSourceAPI    = "api"
+	ChangeSourceImport = "import"
+)
+
+// @description A catalog change recorded in the outbox and delivered at least once to every configured sink
+type ChangeEvent struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	ProductID  ProductID `json:"product_id" swaggertype:"string"`
+	Product    *Product  `json:"product,omitempty"`
+	Source     string    `json:"source"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// Delivered lists the sinks that acknowledged the event
+	Delivered   []string   `json:"delivered,omitempty"`
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+}
+
+// DeliveredTo reports whether the sink already acknowledged the event
+func (e ChangeEvent) DeliveredTo(sink string) bool {
+	for _, name := range e.Delivered {
+		if name == sink {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// @description Products or companies hidden from the results of a keyword, or of every search when the keyword is empty
+type ExclusionRule struct {
+	ID         string      `json:"id"`
+	Keyword    string      `json:"keyword"`
+	ProductIDs []ProductID `json:"product_ids" swaggertype:"array,string"`
+	Companies  []string    `json:"companies"`
+	Reason     string      `json:"reason,omitempty"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// @description Exclusion rule to create or replace; leave keyword empty for a global rule
+type ExclusionRuleRequest struct {
+	Keyword    string      `json:"keyword"`
+	ProductIDs []ProductID `json:"product_ids" swaggertype:"array,string"`
+	Companies  []string    `json:"companies"`
+	Reason     string      `json:"reason,omitempty"`
+}
+
+// IsGlobal reports whether the rule applies to every search
+func (r ExclusionRule) IsGlobal() bool {
+	return r.Keyword == ""
+}
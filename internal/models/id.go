@@ -0,0 +1,118 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxProductIDLength is the Elasticsearch limit for document IDs in bytes
+const maxProductIDLength = 512
+
+var productIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:\-]*$`)
+
+// ErrInvalidProductID is returned when a product ID cannot be normalized
+var ErrInvalidProductID = errors.New("invalid product ID")
+
+// ProductID identifies a product. Both numeric IDs and alphanumeric codes (including UUIDs)
+// are supported. IDs are always serialized as JSON strings, as documented in the API spec, so typed
+// clients decode every ID alike and JavaScript clients don't round numeric IDs past 2^53.
+type ProductID string
+
+// ParseProductID normalizes and validates a raw product ID. Numeric IDs are canonicalized
+// (e.g. "007" becomes "7") and UUIDs are lowercased.
+func ParseProductID(raw string) (ProductID, error) {
+	id := strings.TrimSpace(raw)
+	if id == "" {
+		return "", fmt.Errorf("%w: empty", ErrInvalidProductID)
+	}
+
+	if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return ProductID(strconv.FormatUint(n, 10)), nil
+	}
+
+	if len(id) > maxProductIDLength {
+		return "", fmt.Errorf("%w: longer than %d bytes", ErrInvalidProductID, maxProductIDLength)
+	}
+
+	if !productIDPattern.MatchString(id) {
+		return "", fmt.Errorf("%w: %q contains unsupported characters", ErrInvalidProductID, id)
+	}
+
+	if isUUID(id) {
+		id = strings.ToLower(id)
+	}
+
+	return ProductID(id), nil
+}
+
+// String returns the ID as used for the Elasticsearch _id
+func (id ProductID) String() string {
+	return string(id)
+}
+
+// IsZero reports whether the ID is unset
+func (id ProductID) IsZero() bool {
+	return id == ""
+}
+
+// MarshalJSON emits the ID as a JSON string, numeric IDs included
+func (id ProductID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(id))
+}
+
+// UnmarshalJSON accepts both JSON numbers and strings
+func (id *ProductID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*id = ""
+		return nil
+	}
+
+	var raw string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	} else {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidProductID, string(data))
+		}
+		raw = number.String()
+	}
+
+	if strings.TrimSpace(raw) == "" {
+		*id = ""
+		return nil
+	}
+
+	parsed, err := ParseProductID(raw)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// isUUID reports whether the value has the canonical 8-4-4-4-12 hex UUID layout
+func isUUID(value string) bool {
+	if len(value) != 36 {
+		return false
+	}
+	for i, r := range value {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package models
+
+import "time"
+
+// Import run statuses
+const (
+	ImportStatusQueued    = "queued"
+	ImportStatusRunning   = "running"
+	ImportStatusSucceeded = "succeeded"
+	ImportStatusFailed    = "failed"
+	ImportStatusCancelled = "cancelled"
+)
+
+// @description Summary of a single import run
+type ImportRun struct {
+	ID          string    `json:"id"`
+	Source      string    `json:"source"`
+	Index       string    `json:"index"`
+	TriggeredBy string    `json:"triggered_by"`
+	Status      string    `json:"status"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	DurationMs  int64     `json:"duration_ms"`
+	RowsRead    int       `json:"rows_read"`
+	RowsSkipped int       `json:"rows_skipped"`
+	Indexed     int       `json:"indexed"`
+	Failed      int       `json:"failed"`
+	Errors      []string  `json:"errors,omitempty"`
+	// Staged is set for imports loaded into a staging index instead of the live alias
+	Staged   bool          `json:"staged,omitempty"`
+	Promoted bool          `json:"promoted,omitempty"`
+	Checks   []ImportCheck `json:"checks,omitempty"`
+	// Sheets breaks imports of every worksheet of a workbook down per worksheet
+	Sheets []ImportSheet `json:"sheets,omitempty"`
+	// DuplicateIDs and NearDuplicates count the IDs, and the product names and companies under different IDs,
+	// found on several rows; Duplicates lists the first of them
+	DuplicateIDs   int               `json:"duplicate_ids,omitempty"`
+	NearDuplicates int               `json:"near_duplicates,omitempty"`
+	Duplicates     []ImportDuplicate `json:"duplicates,omitempty"`
+}
+
+// Kinds of duplicate rows found by imports
+const (
+	DuplicateKindID          = "id"
+	DuplicateKindNameCompany = "name_company"
+)
+
+// @description Rows of an import sharing a product ID, or a product name and company under different IDs
+type ImportDuplicate struct {
+	// Kind is "id" for rows with the same ID or "name_company" for near duplicates
+	Kind string `json:"kind"`
+	// Value is the shared ID, or the product name and company of the first repeated row
+	Value string         `json:"value"`
+	Rows  []ImportRowRef `json:"rows"`
+}
+
+// @description Row of an import source; rows are numbered like spreadsheet rows, the header being row 1
+type ImportRowRef struct {
+	Source string `json:"source"`
+	Row    int    `json:"row"`
+}
+
+// @description Progress of the import running in the background, or outcome of the last one
+type ImportProgress struct {
+	// ID is the job ID of an import started over HTTP, also the ID of its run in the import history
+	ID          string    `json:"id,omitempty"`
+	Source      string    `json:"source"`
+	Index       string    `json:"index"`
+	Status      string    `json:"status"`
+	StartedAt   time.Time `json:"started_at"`
+	RowsRead    int       `json:"rows_read"`
+	RowsSkipped int       `json:"rows_skipped"`
+	Indexed     int       `json:"indexed"`
+	Failed      int       `json:"failed"`
+	RowsPerSec  float64   `json:"rows_per_sec"`
+	// PercentDone and ETASeconds are estimated from the share of the source read so far; they are left out
+	// for sources of unknown size
+	PercentDone float64 `json:"percent_done,omitempty"`
+	ETASeconds  int64   `json:"eta_seconds,omitempty"`
+}
+
+// @description Outcome of importing one worksheet of a workbook
+type ImportSheet struct {
+	// Name identifies the worksheet as workbook#sheet
+	Name        string `json:"name"`
+	RowsRead    int    `json:"rows_read"`
+	RowsSkipped int    `json:"rows_skipped"`
+	Indexed     int    `json:"indexed"`
+	Failed      int    `json:"failed"`
+	// Error is set for worksheets left out of the import, such as those without the required columns
+	Error string `json:"error,omitempty"`
+}
+
+// @description Outcome of a verification or data-quality check run on a staged import
+type ImportCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+	// Advisory checks are reported but don't fail the import
+	Advisory bool `json:"advisory,omitempty"`
+}
+
+// ImportRunSearchParams contains filters for listing import runs
+type ImportRunSearchParams struct {
+	Limit       int
+	Offset      int
+	Source      string
+	Status      string
+	TriggeredBy string
+	Since       *time.Time
+	Until       *time.Time
+}
+
+// ImportRunSearchResult contains import runs and pagination info
+type ImportRunSearchResult struct {
+	Runs       []ImportRun
+	TotalCount int64
+	Limit      int
+	Offset     int
+}
+
+// @description Differences between the catalogs loaded by two import runs
+type ImportDiff struct {
+	From          string   `json:"from"`
+	To            string   `json:"to"`
+	Added         int      `json:"added"`
+	Removed       int      `json:"removed"`
+	Changed       int      `json:"changed"`
+	Unchanged     int      `json:"unchanged"`
+	SampleAdded   []string `json:"sample_added"`
+	SampleRemoved []string `json:"sample_removed"`
+	SampleChanged []string `json:"sample_changed"`
+}
+
+// @description Request to start an import in the background
+type StartImportRequest struct {
+	// Source is a Google Sheets URL or an s3:// or gs:// object; local paths and sql: queries are refused
+	Source string `json:"source"`
+	// TriggeredBy identifies who started the import; defaults to "api:admin"
+	TriggeredBy string `json:"triggered_by,omitempty"`
+}
+
+// @description Import started in the background
+type ImportJob struct {
+	// ID identifies the import in its progress and, once it finished, in the import history
+	ID string `json:"id"`
+	// Source is the imported URL or file; uploads are imported from a copy saved by the server
+	Source      string `json:"source"`
+	TriggeredBy string `json:"triggered_by"`
+	// Status is queued, running, succeeded, failed or cancelled
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// The counts of a running job are those of its last progress report
+	RowsRead    int      `json:"rows_read"`
+	RowsSkipped int      `json:"rows_skipped"`
+	Indexed     int      `json:"indexed"`
+	Failed      int      `json:"failed"`
+	Errors      []string `json:"errors,omitempty"`
+}
@@ -1,16 +1,103 @@
 package models
 
-import "time"
+import (
+	"crypto/sha1"
+	"elasticsearch/internal/highlight"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // @description Represents a product object
 type Product struct {
-	ID          uint64    `json:"id"`
+	ID          ProductID  `json:"id" swaggertype:"string"`
+	ProductName string     `json:"product_name"`
+	DrugGeneric string     `json:"drug_generic"`
+	Company     string     `json:"company"`
+	Score       float64    `json:"score"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+	// Attributes holds the source columns an import keeps besides the product fields, keyed by attribute name
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// Highlights holds the highlighted fragments per field when requested with the html format
+	Highlights map[string][]string `json:"highlights,omitempty"`
+	// HighlightOffsets holds the plain-text fragments and match offsets per field with the offsets format
+	HighlightOffsets map[string][]highlight.Fragment `json:"highlight_offsets,omitempty"`
+}
+
+// @description Product with zero-value fields omitted
+type CompactProduct struct {
+	ID          ProductID  `json:"id,omitempty" swaggertype:"string"`
+	ProductName string     `json:"product_name,omitempty"`
+	DrugGeneric string     `json:"drug_generic,omitempty"`
+	Company     string     `json:"company,omitempty"`
+	Score       float64    `json:"score,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	// Attributes, Highlights and HighlightOffsets mirror the Product fields
+	Attributes       map[string]interface{}          `json:"attributes,omitempty"`
+	Highlights       map[string][]string             `json:"highlights,omitempty"`
+	HighlightOffsets map[string][]highlight.Fragment `json:"highlight_offsets,omitempty"`
+}
+
+// Compact returns the product without its zero-value fields
+func (p Product) Compact() CompactProduct {
+	compact := CompactProduct{
+		ID:               p.ID,
+		ProductName:      p.ProductName,
+		DrugGeneric:      p.DrugGeneric,
+		Company:          p.Company,
+		Score:            p.Score,
+		DeletedAt:        p.DeletedAt,
+		Attributes:       p.Attributes,
+		Highlights:       p.Highlights,
+		HighlightOffsets: p.HighlightOffsets,
+	}
+	if !p.CreatedAt.IsZero() {
+		compact.CreatedAt = &p.CreatedAt
+	}
+	if !p.UpdatedAt.IsZero() {
+		compact.UpdatedAt = &p.UpdatedAt
+	}
+	return compact
+}
+
+// Fingerprint returns a short hash of the catalog fields, used to detect changed products between imports
+func (p Product) Fingerprint() string {
+	sum := sha1.Sum([]byte(p.ProductName + "\x00" + p.DrugGeneric + "\x00" + p.Company))
+	return hex.EncodeToString(sum[:8])
+}
+
+// @description Payload for creating a product; when the ID is omitted one is assigned by the configured ID strategy
+type CreateProductRequest struct {
+	ID          ProductID `json:"id,omitempty" swaggertype:"string"`
 	ProductName string    `json:"product_name"`
 	DrugGeneric string    `json:"drug_generic"`
 	Company     string    `json:"company"`
-	Score       float64   `json:"score"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// @description Partial product update; only the provided fields are changed
+type UpdateProductRequest struct {
+	ProductName *string `json:"product_name,omitempty"`
+	DrugGeneric *string `json:"drug_generic,omitempty"`
+	Company     *string `json:"company,omitempty"`
+}
+
+// @description Product IDs to fetch in one request
+type BatchGetRequest struct {
+	IDs []ProductID `json:"ids" swaggertype:"array,string"`
+}
+
+// @description Products found for a batch get, in request order, and the IDs that don't exist
+type BatchGetResult struct {
+	Products []Product   `json:"products"`
+	Missing  []ProductID `json:"missing" swaggertype:"array,string"`
 }
 
 // ProductSearchParams contains parameters for product search
@@ -18,6 +105,116 @@ type ProductSearchParams struct {
 	Limit   int
 	Offset  int
 	Keyword string
+	Dedupe  bool
+	// Index overrides the default index or alias (admin only)
+	Index string
+	// IncludeDeleted includes soft-deleted products (admin only)
+	IncludeDeleted bool
+	// Companies, DrugGenerics and IDs restrict results to exact values; several values of one field are OR-ed
+	Companies    []string
+	DrugGenerics []string
+	IDs          []ProductID
+	// CreatedAfter, CreatedBefore, UpdatedAfter and UpdatedBefore restrict results to a time window;
+	// the After bounds are inclusive and the Before bounds exclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	// Sort overrides the default relevance ordering
+	Sort []SortField
+	// PinnedIDs are promoted to the top of keyword results, in order (set from curations)
+	PinnedIDs []ProductID
+	// Fields restricts the returned product fields; empty returns all fields
+	Fields []string
+	// Highlight requests highlighted fragments of the matched fields
+	Highlight bool
+	// Fuzziness is the edit distance allowed by the keyword match (see ParseFuzziness)
+	Fuzziness string
+	// Mode selects how the keyword is matched (see ParseSearchMode); empty means SearchModeFuzzy
+	Mode string
+	// SearchFields restricts the keyword match to these fields (see ParseSearchFields); empty means all of them
+	SearchFields []string
+	// TrackTotalHits caps hit counting (see ParseTrackTotalHits); 0 counts every hit
+	TrackTotalHits int
+	// MinScore drops keyword hits whose relevance score is below it (see ParseMinScore); 0 keeps every hit
+	MinScore float64
+	// Syntax interprets AND, OR, NOT / -term and quoted phrases in the keyword; Mode and Fuzziness are ignored
+	Syntax bool
+	// PrefixSubfields matches keyword prefixes in PrefixFields on their edge n-gram subfields instead of with
+	// wildcards (set when the index is built with edge n-grams)
+	PrefixSubfields bool
+	// Cursor switches to cursor pagination (see ParseCursor); Offset must be 0
+	Cursor *Cursor
+	// PIT pins offset pages to a point in time: PITStart opens one, any other value is the pit token of a
+	// previous page. Can't be combined with Cursor.
+	PIT string
+	// Facets lists the fields whose value counts are returned with the hits (see ParseFacets); FacetSize
+	// caps the values counted per facet
+	Facets    []string
+	FacetSize int
+	// MaxPerCompany caps the products of one company near the top of the results (see the service); 0 disables it
+	MaxPerCompany int
+	// ExcludedIDs and ExcludedCompanies are hidden from the results (set from exclusion rules)
+	ExcludedIDs       []ProductID
+	ExcludedCompanies []string
+}
+
+// @description Number of matching products with one value of a faceted field
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// @description Number of products matching a search
+type ProductCount struct {
+	Count int64 `json:"count"`
+}
+
+// @description Value counts of the faceted fields over the products matching a search
+type ProductFacets struct {
+	// Total is the number of matching products, the selections on faceted fields included
+	Total  int64                    `json:"total"`
+	Facets map[string][]FacetBucket `json:"facets"`
+}
+
+// @description Distinct values of a field over the products matching a search
+type DistinctValues struct {
+	Field string `json:"field"`
+	// Count is the approximate number of distinct values; Values lists the first of them alphabetically
+	Count  int64    `json:"count"`
+	Values []string `json:"values"`
+}
+
+// @description Values of a field that are overrepresented in the products matching a search, most significant first
+type SignificantTerms struct {
+	Field string `json:"field"`
+	// Count is the number of matching products the values were compared against the whole catalog with
+	Count int64             `json:"count"`
+	Terms []SignificantTerm `json:"terms"`
+}
+
+// @description Value of a field that is overrepresented in the products matching a search
+type SignificantTerm struct {
+	Value string `json:"value"`
+	// Count is the number of matching products with the value, BackgroundCount the number in the whole catalog
+	Count           int64   `json:"count"`
+	BackgroundCount int64   `json:"background_count"`
+	Score           float64 `json:"score"`
+}
+
+// @description Best matching products of one company
+type ProductGroup struct {
+	Company string `json:"company"`
+	// Count is the number of matching products of the company, of which Products are the best
+	Count    int64     `json:"count"`
+	Products []Product `json:"products"`
+}
+
+// @description Best matching products of the companies with the most matches, most matches first
+type ProductGroups struct {
+	// Total is the number of matching products of every company
+	Total  int64          `json:"total"`
+	Groups []ProductGroup `json:"groups"`
 }
 
 // ProductSearchResult contains products and pagination info
@@ -26,4 +223,219 @@ type ProductSearchResult struct {
 	TotalCount int64
 	Limit      int
 	Offset     int
+	TookMs     int64
+	// MaxScore is the highest score of any hit; nil when hits aren't scored
+	MaxScore *float64
+	// Facets holds the value counts of every requested facet, most frequent first
+	Facets map[string][]FacetBucket
+	// TotalIsLowerBound is set when counting stopped at the TrackTotalHits cap
+	TotalIsLowerBound bool
+	// NextCursor continues a cursor-paginated search; empty on the last page
+	NextCursor string
+	// PIT is the pit token to send with the next page of a point-in-time session
+	PIT      string
+	TimedOut bool
+	Shards   ShardStats
+	Warnings []string
+	// Query is the search request body sent to Elasticsearch
+	Query []byte
+}
+
+// ShardStats mirrors the _shards section of a search response
+type ShardStats struct {
+	Total      int
+	Successful int
+	Skipped    int
+	Failed     int
+}
+
+// IsPartial reports whether the search returned incomplete results
+func (r ProductSearchResult) IsPartial() bool {
+	return r.TimedOut || r.Shards.Failed > 0
+}
+
+// SortableFields lists the product fields clients may sort by
+var SortableFields = []string{"id", "product_name", "drug_generic", "company", "score", "created_at", "updated_at"}
+
+// SortField is a single sort criterion of a product search
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// ParseSort parses a sort expression such as "product_name:asc,created_at:desc".
+// The direction defaults to ascending; fields outside SortableFields are rejected.
+func ParseSort(raw string) ([]SortField, error) {
+	var sort []SortField
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field, direction, _ := strings.Cut(part, ":")
+		field = strings.TrimSpace(field)
+		if !slices.Contains(SortableFields, field) {
+			return nil, fmt.Errorf("unsupported sort field %q, expected one of %s", field, strings.Join(SortableFields, ", "))
+		}
+
+		criterion := SortField{Field: field}
+		switch strings.ToLower(strings.TrimSpace(direction)) {
+		case "", "asc":
+		case "desc":
+			criterion.Descending = true
+		default:
+			return nil, fmt.Errorf("invalid sort direction %q for %s, expected asc or desc", direction, field)
+		}
+		sort = append(sort, criterion)
+	}
+	return sort, nil
+}
+
+// SelectableFields lists the product fields clients may select with fields=
+var SelectableFields = []string{"id", "product_name", "drug_generic", "company", "score", "created_at", "updated_at", "deleted_at", "attributes"}
+
+// ParseFields parses a comma-separated field selection such as "id,product_name".
+// Fields outside SelectableFields are rejected; duplicates are dropped.
+func ParseFields(raw string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || slices.Contains(fields, field) {
+			continue
+		}
+		if !slices.Contains(SelectableFields, field) {
+			return nil, fmt.Errorf("unsupported field %q, expected one of %s", field, strings.Join(SelectableFields, ", "))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// SearchableFields lists the text fields the keyword can be matched against with search_fields=
+var SearchableFields = []string{"product_name", "drug_generic", "company"}
+
+// PrefixFields lists the searchable fields with an edge n-gram prefix subfield in indices built with edge n-grams
+var PrefixFields = []string{"product_name", "drug_generic"}
+
+// ParseSearchFields parses a comma-separated list of fields to match the keyword against, such as
+// "product_name,company". Fields outside SearchableFields are rejected; duplicates are dropped.
+func ParseSearchFields(raw string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || slices.Contains(fields, field) {
+			continue
+		}
+		if !slices.Contains(SearchableFields, field) {
+			return nil, fmt.Errorf("unsupported search field %q, expected one of %s", field, strings.Join(SearchableFields, ", "))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// FacetableFields lists the fields whose value counts can be requested with facets=
+var FacetableFields = []string{"company", "drug_generic"}
+
+// ParseDistinctField parses the facetable field whose distinct values are listed with field=
+func ParseDistinctField(raw string) (string, error) {
+	field := strings.TrimSpace(raw)
+	if !slices.Contains(FacetableFields, field) {
+		return "", fmt.Errorf("unsupported field %q, expected one of %s", raw, strings.Join(FacetableFields, ", "))
+	}
+	return field, nil
+}
+
+// ParseFacets parses a comma-separated list of fields to count values of, such as "company".
+// Fields outside FacetableFields are rejected; duplicates are dropped.
+func ParseFacets(raw string) ([]string, error) {
+	var facets []string
+	for _, facet := range strings.Split(raw, ",") {
+		facet = strings.TrimSpace(facet)
+		if facet == "" || slices.Contains(facets, facet) {
+			continue
+		}
+		if !slices.Contains(FacetableFields, facet) {
+			return nil, fmt.Errorf("unsupported facet %q, expected one of %s", facet, strings.Join(FacetableFields, ", "))
+		}
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
+// FuzzinessOff disables fuzzy matching of the keyword
+const FuzzinessOff = "off"
+
+// ParseFuzziness validates a fuzziness setting: 0, 1, 2, AUTO or off (case-insensitive)
+func ParseFuzziness(raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	switch strings.ToLower(value) {
+	case "0", "1", "2":
+		return value, nil
+	case "auto":
+		return "AUTO", nil
+	case FuzzinessOff:
+		return FuzzinessOff, nil
+	}
+	return "", fmt.Errorf("invalid fuzziness %q, expected 0, 1, 2, AUTO or off", raw)
+}
+
+// Keyword search modes
+const (
+	// SearchModeFuzzy combines fuzzy term matching with substring wildcards
+	SearchModeFuzzy = "fuzzy"
+	// SearchModePhrase matches the keyword as a phrase, terms adjacent and in order
+	SearchModePhrase = "phrase"
+	// SearchModeExact matches whole field values, ignoring case
+	SearchModeExact = "exact"
+)
+
+// ParseSearchMode validates a search mode: phrase, exact or fuzzy (case-insensitive); empty means fuzzy
+func ParseSearchMode(raw string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(raw)); mode {
+	case "", SearchModeFuzzy:
+		return SearchModeFuzzy, nil
+	case SearchModePhrase, SearchModeExact:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid search mode %q, expected phrase, exact or fuzzy", raw)
+}
+
+// ParseTrackTotalHits validates a track_total_hits setting: true (or exact) counts every hit and
+// returns 0, a positive number caps the count at that many hits
+func ParseTrackTotalHits(raw string) (int, error) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	if value == "true" || value == "exact" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("invalid track_total_hits %q, expected true or a positive number", raw)
+	}
+	return limit, nil
+}
+
+// ParseMinScore validates a min_score setting: a non-negative number, 0 disabling the threshold
+func ParseMinScore(raw string) (float64, error) {
+	score, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil || score < 0 || math.IsInf(score, 0) || math.IsNaN(score) {
+		return 0, fmt.Errorf("invalid min_score %q, expected a non-negative number", raw)
+	}
+	return score, nil
+}
+
+// ParseMaxPerCompany validates a max_per_company setting: a non-negative integer, 0 disabling diversity
+func ParseMaxPerCompany(raw string) (int, error) {
+	maxPerCompany, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || maxPerCompany < 0 {
+		return 0, fmt.Errorf("invalid max_per_company %q, expected a non-negative integer", raw)
+	}
+	return maxPerCompany, nil
+}
+
+// NormalizeKeyword lowercases a keyword and collapses its whitespace so curations, exclusion rules
+// and analytics match searches regardless of case and spacing
+func NormalizeKeyword(keyword string) string {
+	return strings.ToLower(strings.Join(strings.Fields(keyword), " "))
 }
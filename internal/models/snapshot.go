@@ -0,0 +1,26 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// @description Search captured with its effective Elasticsearch query and results, for reproducing reported results later
+type SearchSnapshot struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Note      string    `json:"note,omitempty"`
+	// Request is the query string of the captured search, as sent to GET /product
+	Request string `json:"request"`
+	// Query is the search request body sent to Elasticsearch
+	Query      json.RawMessage `json:"query" swaggertype:"object"`
+	TotalCount int64           `json:"total_count"`
+	TookMs     int64           `json:"took_ms"`
+	Warnings   []string        `json:"warnings,omitempty"`
+	Products   []Product       `json:"products"`
+}
+
+// @description Optional context stored with a search snapshot, such as a support ticket reference
+type SnapshotRequest struct {
+	Note string `json:"note"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// @description Aggregated overview of the product catalog
+type CatalogStats struct {
+	TotalProducts     int64      `json:"total_products"`
+	DistinctCompanies int64      `json:"distinct_companies"`
+	DistinctGenerics  int64      `json:"distinct_generics"`
+	NewestUpdatedAt   *time.Time `json:"newest_updated_at"`
+	OldestUpdatedAt   *time.Time `json:"oldest_updated_at"`
+	AddedLast30Days   int64      `json:"added_last_30_days"`
+}
+
+// @description Operational overview of the product index
+type IndexStats struct {
+	Index          string `json:"index"`
+	TotalDocuments int64  `json:"total_documents"`
+	// Companies and DrugGenerics hold the document counts of the most frequent values
+	Companies       []FacetBucket `json:"companies"`
+	DrugGenerics    []FacetBucket `json:"drug_generics"`
+	NewestCreatedAt *time.Time    `json:"newest_created_at"`
+	OldestCreatedAt *time.Time    `json:"oldest_created_at"`
+	// PrimarySizeBytes is the disk size of the primary shards, SizeBytes includes the replicas
+	PrimarySizeBytes int64 `json:"primary_size_bytes"`
+	SizeBytes        int64 `json:"size_bytes"`
+}
+
+// HistogramIntervals lists the calendar intervals products can be bucketed by with interval=
+var HistogramIntervals = []string{"day", "week", "month", "quarter", "year"}
+
+// ParseHistogramInterval parses a calendar interval such as "month"; intervals outside HistogramIntervals
+// are rejected
+func ParseHistogramInterval(raw string) (string, error) {
+	interval := strings.ToLower(strings.TrimSpace(raw))
+	if !slices.Contains(HistogramIntervals, interval) {
+		return "", fmt.Errorf("unsupported interval %q, expected one of %s", raw, strings.Join(HistogramIntervals, ", "))
+	}
+	return interval, nil
+}
+
+// CreationHistogramParams selects the interval and the time window of a creation histogram
+type CreationHistogramParams struct {
+	Interval string
+	// CreatedAfter and CreatedBefore restrict the histogram to products created in [CreatedAfter, CreatedBefore)
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// @description Number of products created per calendar interval, oldest interval first
+type CreationHistogram struct {
+	Interval string       `json:"interval"`
+	Buckets  []DateBucket `json:"buckets"`
+}
+
+// @description Number of products created in the interval starting at Date
+type DateBucket struct {
+	Date  time.Time `json:"date"`
+	Count int64     `json:"count"`
+}
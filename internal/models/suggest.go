@@ -0,0 +1,31 @@
+package models
+
+// Sources of autocomplete suggestions
+const (
+	// SuggestionSourceCompletion completes the prefix to the name of a product
+	SuggestionSourceCompletion = "completion"
+	// SuggestionSourcePopular completes the prefix to a keyword users frequently searched with results
+	SuggestionSourcePopular = "popular_suggestions"
+)
+
+// @description Product name or popular search completing a typed prefix
+type Suggestion struct {
+	Text string `json:"text"`
+	// Source is completion for product names and popular_suggestions for popular searches
+	Source string `json:"source"`
+	// ID and Company identify the product a completion was taken from
+	ID      ProductID `json:"id,omitempty" swaggertype:"string"`
+	Company string    `json:"company,omitempty"`
+	// Score ranks completions; Searches counts the searches of a popular suggestion
+	Score    float64 `json:"score,omitempty"`
+	Searches int64   `json:"searches,omitempty"`
+}
+
+// @description Typeahead matches of a partially typed keyword grouped by entity type, for sectioned autocomplete
+type TypeaheadGroups struct {
+	// Products are the best products whose name matches
+	Products []Product `json:"products"`
+	// Generics and Companies are the matching values with the most matching products first
+	Generics  []FacetBucket `json:"generics"`
+	Companies []FacetBucket `json:"companies"`
+}
@@ -0,0 +1,13 @@
+package models
+
+// @description Terms matched interchangeably when searching products, e.g. acetaminophen and paracetamol
+type SynonymRule struct {
+	ID       string   `json:"id"`
+	Synonyms []string `json:"synonyms"`
+}
+
+// @description Synonym rule to create or replace
+type SynonymRuleRequest struct {
+	// Synonyms lists at least two equivalent terms or phrases
+	Synonyms []string `json:"synonyms"`
+}
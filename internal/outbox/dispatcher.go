@@ -0,0 +1,129 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"elasticsearch/internal/metrics"
+	"elasticsearch/internal/models"
+	storageEs "elasticsearch/internal/storage/elasticsearch"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// dispatchBatchSize is the number of pending events delivered per poll
+const dispatchBatchSize = 100
+
+// Dispatcher polls the outbox and delivers pending events to every sink. Delivery state is saved
+// after a sink accepts a batch, so a crash in between leads to redelivery rather than loss.
+type Dispatcher struct {
+	repo     storageEs.OutboxRepository
+	sinks    []Sink
+	interval time.Duration
+}
+
+// NewDispatcher creates a Dispatcher polling the outbox every interval
+func NewDispatcher(repo storageEs.OutboxRepository, sinks []Sink, interval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		repo:     repo,
+		sinks:    sinks,
+		interval: interval,
+	}
+}
+
+// Run delivers pending events until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	fiberlog.Infof("Outbox dispatcher started with %d sink(s)", len(d.sinks))
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		// Keep draining while full batches are delivered
+		for {
+			delivered, err := d.DispatchOnce(ctx)
+			if err != nil {
+				fiberlog.Errorf("Outbox dispatch failed: %v", err)
+				break
+			}
+			if delivered < dispatchBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			fiberlog.Info("Outbox dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce delivers one batch of pending events and returns the number of events delivered to every sink
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	events, err := d.repo.FindPendingEvents(ctx, dispatchBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	for _, sink := range d.sinks {
+		// Skip events this sink already acknowledged
+		var positions []int
+		var batch []models.ChangeEvent
+		for i, event := range events {
+			if !event.DeliveredTo(sink.Name()) {
+				positions = append(positions, i)
+				batch = append(batch, event)
+			}
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		err := sink.Deliver(ctx, batch)
+		for _, i := range positions {
+			events[i].Attempts++
+			if err != nil {
+				events[i].LastError = sink.Name() + ": " + err.Error()
+				continue
+			}
+			events[i].Delivered = append(events[i].Delivered, sink.Name())
+		}
+		if err != nil {
+			fiberlog.Warnf("Failed to deliver %d event(s) to %s: %v", len(batch), sink.Name(), err)
+			metrics.OutboxDeliveryFailures.Add(int64(len(batch)))
+			continue
+		}
+		metrics.OutboxDelivered.Add(int64(len(batch)))
+	}
+
+	// Events are done once every sink acknowledged them
+	now := time.Now()
+	completed := 0
+	for _, event := range events {
+		if d.deliveredToAll(event) {
+			event.DeliveredAt = &now
+			event.LastError = ""
+			completed++
+		}
+		if err := d.repo.SaveEvent(ctx, event); err != nil {
+			return completed, err
+		}
+	}
+
+	return completed, nil
+}
+
+// deliveredToAll reports whether every sink acknowledged the event
+func (d *Dispatcher) deliveredToAll(event models.ChangeEvent) bool {
+	for _, sink := range d.sinks {
+		if !event.DeliveredTo(sink.Name()) {
+			return false
+		}
+	}
+	return true
+}
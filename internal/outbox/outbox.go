@@ -0,0 +1,51 @@
+// Package outbox records product change events and delivers them at least once to downstream sinks
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"elasticsearch/internal/models"
+	storageEs "elasticsearch/internal/storage/elasticsearch"
+)
+
+// Outbox appends change events to the outbox index; a Dispatcher delivers them later
+type Outbox struct {
+	repo storageEs.OutboxRepository
+}
+
+// New creates an Outbox backed by the given repository
+func New(repo storageEs.OutboxRepository) *Outbox {
+	return &Outbox{repo: repo}
+}
+
+// Publish stores the events, assigning an ID and timestamp to those without one
+func (o *Outbox) Publish(ctx context.Context, events ...models.ChangeEvent) error {
+	now := time.Now()
+	for i := range events {
+		if events[i].ID == "" {
+			id, err := newEventID()
+			if err != nil {
+				return err
+			}
+			events[i].ID = id
+		}
+		if events[i].OccurredAt.IsZero() {
+			events[i].OccurredAt = now
+		}
+	}
+
+	return o.repo.AppendEvents(ctx, events)
+}
+
+// newEventID returns a random identifier consumers can use to deduplicate redelivered events
+func newEventID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate event ID: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
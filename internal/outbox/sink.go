@@ -0,0 +1,128 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/models"
+)
+
+// sinkTimeout bounds a single delivery request
+const sinkTimeout = 10 * time.Second
+
+// Sink delivers change events to a downstream system. Deliver must only return nil once
+// every event has been accepted; events are redelivered otherwise.
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, events []models.ChangeEvent) error
+}
+
+// WebhookSink POSTs events as a JSON array to an HTTP endpoint
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a WebhookSink posting to url
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: sinkTimeout},
+	}
+}
+
+// Name identifies the sink in delivery state
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Deliver posts the events; any non-2xx response is a failed delivery
+func (s *WebhookSink) Deliver(ctx context.Context, events []models.ChangeEvent) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("failed to encode events: %w", err)
+	}
+
+	return post(ctx, s.client, s.url, "application/json", body)
+}
+
+// KafkaSink produces events to a Kafka topic through a Confluent-compatible REST proxy,
+// keyed by product ID so changes of one product stay ordered within a partition
+type KafkaSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewKafkaSink creates a KafkaSink producing to topic through the REST proxy at proxyURL
+func NewKafkaSink(proxyURL string, topic string) *KafkaSink {
+	return &KafkaSink{
+		url:    strings.TrimRight(proxyURL, "/") + "/topics/" + topic,
+		client: &http.Client{Timeout: sinkTimeout},
+	}
+}
+
+// Name identifies the sink in delivery state
+func (s *KafkaSink) Name() string {
+	return "kafka"
+}
+
+// Deliver produces the events in a single REST proxy request
+func (s *KafkaSink) Deliver(ctx context.Context, events []models.ChangeEvent) error {
+	type record struct {
+		Key   string             `json:"key"`
+		Value models.ChangeEvent `json:"value"`
+	}
+
+	records := make([]record, 0, len(events))
+	for _, event := range events {
+		records = append(records, record{Key: event.ProductID.String(), Value: event})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return fmt.Errorf("failed to encode records: %w", err)
+	}
+
+	return post(ctx, s.client, s.url, "application/vnd.kafka.json.v2+json", body)
+}
+
+// post sends body to url and treats any non-2xx response as an error
+func post(ctx context.Context, client *http.Client, url string, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", res.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	return nil
+}
+
+// SinksFromConfig creates the sinks enabled in the outbox configuration
+func SinksFromConfig(cfg config.OutboxConfig) []Sink {
+	var sinks []Sink
+	if cfg.WebhookURL != "" {
+		sinks = append(sinks, NewWebhookSink(cfg.WebhookURL))
+	}
+	if cfg.KafkaRESTURL != "" {
+		sinks = append(sinks, NewKafkaSink(cfg.KafkaRESTURL, cfg.KafkaTopic))
+	}
+	return sinks
+}
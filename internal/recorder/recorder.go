@@ -0,0 +1,116 @@
+// Package recorder captures anonymized search traffic to NDJSON and replays it against other clusters
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// Entry is a single recorded search. Only the Elasticsearch query and the response summary
+// are captured; no client identifiers (addresses, headers, credentials) are ever recorded.
+type Entry struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Index     string          `json:"index"`
+	Query     json.RawMessage `json:"query"`
+	TookMs    int64           `json:"took_ms"`
+	TotalHits int64           `json:"total_hits"`
+	HitIDs    []string        `json:"hit_ids"`
+	Scores    []float64       `json:"scores"`
+}
+
+// Status describes the current state of the recorder
+type Status struct {
+	Enabled  bool   `json:"enabled"`
+	Path     string `json:"path"`
+	Recorded int64  `json:"recorded"`
+}
+
+// Recorder appends search entries to an NDJSON file while enabled
+type Recorder struct {
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	encoder  *json.Encoder
+	recorded int64
+}
+
+// New creates a disabled Recorder writing to the given path
+func New(path string) *Recorder {
+	return &Recorder{path: path}
+}
+
+// Start enables recording, appending to the recorder file
+func (r *Recorder) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file != nil {
+		return nil
+	}
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open recorder file: %w", err)
+	}
+
+	r.file = file
+	r.encoder = json.NewEncoder(file)
+	fiberlog.Infof("Search recorder started, writing to %s", r.path)
+	return nil
+}
+
+// Stop disables recording and closes the recorder file
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+	r.encoder = nil
+	fiberlog.Info("Search recorder stopped")
+	return err
+}
+
+// Enabled reports whether searches are currently being recorded
+func (r *Recorder) Enabled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file != nil
+}
+
+// Status returns the recorder state
+func (r *Recorder) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Status{
+		Enabled:  r.file != nil,
+		Path:     r.path,
+		Recorded: r.recorded,
+	}
+}
+
+// Record appends an entry when the recorder is enabled. Failures are logged, never returned,
+// so recording can't break the search path.
+func (r *Recorder) Record(entry Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.encoder == nil {
+		return
+	}
+
+	if err := r.encoder.Encode(entry); err != nil {
+		fiberlog.Warnf("Failed to record search: %v", err)
+		return
+	}
+	r.recorded++
+}
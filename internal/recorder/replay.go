@@ -0,0 +1,196 @@
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// ReplayOptions configures a replay run
+type ReplayOptions struct {
+	// Index overrides the recorded index when set
+	Index string
+	// TopK is the number of leading hits compared between recording and replay
+	TopK int
+}
+
+// ReplayDiff compares a recorded search with its replay
+type ReplayDiff struct {
+	Line           int      `json:"line"`
+	Query          string   `json:"query"`
+	RecordedTotal  int64    `json:"recorded_total"`
+	ReplayedTotal  int64    `json:"replayed_total"`
+	Overlap        float64  `json:"overlap"`
+	Missing        []string `json:"missing,omitempty"`
+	Added          []string `json:"added,omitempty"`
+	OrderUnchanged bool     `json:"order_unchanged"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// ReplaySummary aggregates the diffs of a replay run
+type ReplaySummary struct {
+	Replayed       int          `json:"replayed"`
+	Errors         int          `json:"errors"`
+	Identical      int          `json:"identical"`
+	AverageOverlap float64      `json:"average_overlap"`
+	Diffs          []ReplayDiff `json:"diffs"`
+}
+
+// Replay re-executes every recorded search against the given cluster and diffs the top-K results
+func Replay(ctx context.Context, es *elasticsearch.Client, path string, opts ReplayOptions) (ReplaySummary, error) {
+	if opts.TopK <= 0 {
+		opts.TopK = 10
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return ReplaySummary{}, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	summary := ReplaySummary{}
+	overlapSum := 0.0
+
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return summary, fmt.Errorf("invalid recording at line %d: %w", line, err)
+		}
+
+		index := entry.Index
+		if opts.Index != "" {
+			index = opts.Index
+		}
+
+		diff := ReplayDiff{Line: line, Query: string(entry.Query), RecordedTotal: entry.TotalHits}
+		replayed, err := execute(ctx, es, index, entry.Query)
+		if err != nil {
+			diff.Error = err.Error()
+			summary.Errors++
+		} else {
+			compareHits(&diff, topK(entry.HitIDs, opts.TopK), topK(replayed.HitIDs, opts.TopK))
+			diff.ReplayedTotal = replayed.TotalHits
+			overlapSum += diff.Overlap
+			if diff.OrderUnchanged && diff.RecordedTotal == diff.ReplayedTotal {
+				summary.Identical++
+			}
+		}
+
+		summary.Replayed++
+		summary.Diffs = append(summary.Diffs, diff)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return summary, err
+	}
+
+	if succeeded := summary.Replayed - summary.Errors; succeeded > 0 {
+		summary.AverageOverlap = overlapSum / float64(succeeded)
+	}
+
+	return summary, nil
+}
+
+// execute runs a recorded query and summarizes its hits like a recorded Entry
+func execute(ctx context.Context, es *elasticsearch.Client, index string, query json.RawMessage) (Entry, error) {
+	res, err := es.Search(
+		es.Search.WithContext(ctx),
+		es.Search.WithIndex(index),
+		es.Search.WithBody(bytes.NewReader(query)),
+		es.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		return Entry{}, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return Entry{}, fmt.Errorf("[%s] %s", res.Status(), string(body))
+	}
+
+	return SummarizeResponse(res.Body)
+}
+
+// SummarizeResponse extracts took, total hits and ordered hit IDs/scores from a search response body
+func SummarizeResponse(body io.Reader) (Entry, error) {
+	var response struct {
+		Took int64 `json:"took"`
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID    string   `json:"_id"`
+				Score *float64 `json:"_score"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return Entry{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	entry := Entry{TookMs: response.Took, TotalHits: response.Hits.Total.Value}
+	for _, hit := range response.Hits.Hits {
+		entry.HitIDs = append(entry.HitIDs, hit.ID)
+		score := 0.0
+		if hit.Score != nil {
+			score = *hit.Score
+		}
+		entry.Scores = append(entry.Scores, score)
+	}
+	return entry, nil
+}
+
+// compareHits fills the overlap, missing, added and order fields of a diff
+func compareHits(diff *ReplayDiff, recorded, replayed []string) {
+	inReplay := make(map[string]bool, len(replayed))
+	for _, id := range replayed {
+		inReplay[id] = true
+	}
+	inRecording := make(map[string]bool, len(recorded))
+	for _, id := range recorded {
+		inRecording[id] = true
+		if !inReplay[id] {
+			diff.Missing = append(diff.Missing, id)
+		}
+	}
+	for _, id := range replayed {
+		if !inRecording[id] {
+			diff.Added = append(diff.Added, id)
+		}
+	}
+
+	if len(recorded) == 0 && len(replayed) == 0 {
+		diff.Overlap = 1
+	} else if longest := max(len(recorded), len(replayed)); longest > 0 {
+		diff.Overlap = float64(len(recorded)-len(diff.Missing)) / float64(longest)
+	}
+
+	diff.OrderUnchanged = len(recorded) == len(replayed)
+	for i := 0; diff.OrderUnchanged && i < len(recorded); i++ {
+		diff.OrderUnchanged = recorded[i] == replayed[i]
+	}
+}
+
+// topK returns at most k leading IDs
+func topK(ids []string, k int) []string {
+	if len(ids) > k {
+		return ids[:k]
+	}
+	return ids
+}
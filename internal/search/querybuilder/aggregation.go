@@ -0,0 +1,94 @@
+package querybuilder
+
+// TermsAggregation counts the documents per value of a keyword field, most frequent values first
+type TermsAggregation struct {
+	Field string
+	Size  int
+	// ByValue orders the values alphabetically instead of by frequency
+	ByValue bool
+	// Aggs are run on the documents of every value; omitted when empty
+	Aggs map[string]Clause
+}
+
+// Map implements Clause
+func (a TermsAggregation) Map() map[string]interface{} {
+	terms := map[string]interface{}{"field": a.Field, "size": a.Size}
+	if a.ByValue {
+		terms["order"] = map[string]interface{}{"_key": "asc"}
+	}
+	agg := map[string]interface{}{"terms": terms}
+	if len(a.Aggs) > 0 {
+		agg["aggs"] = aggregationMaps(a.Aggs)
+	}
+	return agg
+}
+
+// CardinalityAggregation approximates the number of distinct values of a keyword field
+type CardinalityAggregation struct {
+	Field string
+}
+
+// Map implements Clause
+func (a CardinalityAggregation) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"cardinality": map[string]interface{}{"field": a.Field},
+	}
+}
+
+// SignificantTermsAggregation finds the values of a keyword field that are unusually frequent in the matching
+// documents compared to the whole index
+type SignificantTermsAggregation struct {
+	Field string
+	Size  int
+}
+
+// Map implements Clause
+func (a SignificantTermsAggregation) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"significant_terms": map[string]interface{}{"field": a.Field, "size": a.Size},
+	}
+}
+
+// TopHitsAggregation returns the first Size documents of its bucket in Sort order
+type TopHitsAggregation struct {
+	Size int
+	// Sort is omitted when empty, ordering by score
+	Sort Sort
+	// Source lists the _source fields fetched; nil fetches the whole document
+	Source []string
+}
+
+// Map implements Clause
+func (a TopHitsAggregation) Map() map[string]interface{} {
+	hits := map[string]interface{}{"size": a.Size}
+	if len(a.Sort) > 0 {
+		hits["sort"] = a.Sort.Maps()
+	}
+	if a.Source != nil {
+		hits["_source"] = a.Source
+	}
+	return map[string]interface{}{"top_hits": hits}
+}
+
+// FilterAggregation runs its sub-aggregations on the documents matching Filter
+type FilterAggregation struct {
+	Filter Clause
+	Aggs   map[string]Clause
+}
+
+// Map implements Clause
+func (a FilterAggregation) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"filter": a.Filter.Map(),
+		"aggs":   aggregationMaps(a.Aggs),
+	}
+}
+
+// aggregationMaps renders named aggregations
+func aggregationMaps(aggs map[string]Clause) map[string]interface{} {
+	rendered := make(map[string]interface{}, len(aggs))
+	for name, agg := range aggs {
+		rendered[name] = agg.Map()
+	}
+	return rendered
+}
@@ -0,0 +1,252 @@
+// Package querybuilder builds Elasticsearch search requests from typed clauses, so that relevance
+// changes show up as changes to small structs rather than to nested maps
+package querybuilder
+
+import "time"
+
+// Clause is a single query DSL clause
+type Clause interface {
+	// Map renders the clause as Elasticsearch query DSL
+	Map() map[string]interface{}
+}
+
+// MatchClause is a full-text match on one field; an empty Fuzziness disables fuzzy matching
+type MatchClause struct {
+	Field     string
+	Query     string
+	Operator  string
+	Fuzziness string
+}
+
+// Map implements Clause
+func (c MatchClause) Map() map[string]interface{} {
+	match := map[string]interface{}{"query": c.Query}
+	if c.Operator != "" {
+		match["operator"] = c.Operator
+	}
+	if c.Fuzziness != "" {
+		match["fuzziness"] = c.Fuzziness
+	}
+	return map[string]interface{}{
+		"match": map[string]interface{}{c.Field: match},
+	}
+}
+
+// WildcardClause matches a wildcard pattern against one field
+type WildcardClause struct {
+	Field string
+	Value string
+}
+
+// Map implements Clause
+func (c WildcardClause) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"wildcard": map[string]interface{}{
+			c.Field: map[string]interface{}{"value": c.Value},
+		},
+	}
+}
+
+// PhraseClause matches the terms of Query adjacent and in order
+type PhraseClause struct {
+	Field string
+	Query string
+}
+
+// Map implements Clause
+func (c PhraseClause) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"match_phrase": map[string]interface{}{c.Field: c.Query},
+	}
+}
+
+// TermClause matches the exact value of one field
+type TermClause struct {
+	Field           string
+	Value           string
+	CaseInsensitive bool
+}
+
+// Map implements Clause
+func (c TermClause) Map() map[string]interface{} {
+	if !c.CaseInsensitive {
+		return map[string]interface{}{
+			"term": map[string]interface{}{c.Field: c.Value},
+		}
+	}
+	return map[string]interface{}{
+		"term": map[string]interface{}{
+			c.Field: map[string]interface{}{
+				"value":            c.Value,
+				"case_insensitive": true,
+			},
+		},
+	}
+}
+
+// TermsClause matches any of several exact values; a single value is rendered as a term query
+type TermsClause struct {
+	Field  string
+	Values []string
+}
+
+// Map implements Clause
+func (c TermsClause) Map() map[string]interface{} {
+	if len(c.Values) == 1 {
+		return TermClause{Field: c.Field, Value: c.Values[0]}.Map()
+	}
+	return map[string]interface{}{
+		"terms": map[string]interface{}{c.Field: c.Values},
+	}
+}
+
+// RangeClause restricts a date field to an inclusive lower and an exclusive upper bound; nil bounds are open
+type RangeClause struct {
+	Field  string
+	From   *time.Time
+	Before *time.Time
+}
+
+// Map implements Clause
+func (c RangeClause) Map() map[string]interface{} {
+	bounds := map[string]interface{}{}
+	if c.From != nil {
+		bounds["gte"] = c.From.Format(time.RFC3339Nano)
+	}
+	if c.Before != nil {
+		bounds["lt"] = c.Before.Format(time.RFC3339Nano)
+	}
+	return map[string]interface{}{
+		"range": map[string]interface{}{c.Field: bounds},
+	}
+}
+
+// ExistsClause matches documents with a value in Field
+type ExistsClause struct {
+	Field string
+}
+
+// Map implements Clause
+func (c ExistsClause) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"exists": map[string]interface{}{"field": c.Field},
+	}
+}
+
+// SimpleQueryStringClause runs a simple_query_string query against several fields
+type SimpleQueryStringClause struct {
+	Query           string
+	Fields          []string
+	DefaultOperator string
+	Flags           string
+}
+
+// Map implements Clause
+func (c SimpleQueryStringClause) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"simple_query_string": map[string]interface{}{
+			"query":            c.Query,
+			"fields":           c.Fields,
+			"default_operator": c.DefaultOperator,
+			"flags":            c.Flags,
+		},
+	}
+}
+
+// MultiMatchClause runs a multi_match query of the given type against several fields
+type MultiMatchClause struct {
+	Query    string
+	Type     string
+	Fields   []string
+	Operator string
+}
+
+// Map implements Clause
+func (c MultiMatchClause) Map() map[string]interface{} {
+	multiMatch := map[string]interface{}{
+		"query":  c.Query,
+		"type":   c.Type,
+		"fields": c.Fields,
+	}
+	if c.Operator != "" {
+		multiMatch["operator"] = c.Operator
+	}
+	return map[string]interface{}{"multi_match": multiMatch}
+}
+
+// PinnedClause ranks IDs above the hits of the organic query, in order
+type PinnedClause struct {
+	IDs     []string
+	Organic Clause
+}
+
+// Map implements Clause
+func (c PinnedClause) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"pinned": map[string]interface{}{
+			"ids":     c.IDs,
+			"organic": c.Organic.Map(),
+		},
+	}
+}
+
+// RandomScoreClause replaces the score of every hit of Query with a random one. With a seed the
+// order is reproducible for as long as the index isn't written to.
+type RandomScoreClause struct {
+	Query Clause
+	Seed  *int64
+}
+
+// Map implements Clause
+func (c RandomScoreClause) Map() map[string]interface{} {
+	randomScore := map[string]interface{}{}
+	if c.Seed != nil {
+		randomScore["seed"] = *c.Seed
+		randomScore["field"] = "_seq_no"
+	}
+
+	return map[string]interface{}{
+		"function_score": map[string]interface{}{
+			"query":        c.Query.Map(),
+			"random_score": randomScore,
+			"boost_mode":   "replace",
+		},
+	}
+}
+
+// BoolClause combines clauses; empty sections are omitted
+type BoolClause struct {
+	Must               []Clause
+	Should             []Clause
+	Filter             []Clause
+	MustNot            []Clause
+	MinimumShouldMatch int
+}
+
+// Map implements Clause
+func (c BoolClause) Map() map[string]interface{} {
+	boolQuery := map[string]interface{}{}
+	for name, clauses := range map[string][]Clause{
+		"must":     c.Must,
+		"should":   c.Should,
+		"filter":   c.Filter,
+		"must_not": c.MustNot,
+	} {
+		if len(clauses) > 0 {
+			boolQuery[name] = maps(clauses)
+		}
+	}
+	if c.MinimumShouldMatch > 0 {
+		boolQuery["minimum_should_match"] = c.MinimumShouldMatch
+	}
+	return map[string]interface{}{"bool": boolQuery}
+}
+
+// maps renders a list of clauses
+func maps(clauses []Clause) []map[string]interface{} {
+	rendered := make([]map[string]interface{}, 0, len(clauses))
+	for _, clause := range clauses {
+		rendered = append(rendered, clause.Map())
+	}
+	return rendered
+}
@@ -0,0 +1,35 @@
+package querybuilder
+
+import "time"
+
+// Filters collects the clauses that include or exclude documents without affecting their score
+type Filters struct {
+	Filter  []Clause
+	MustNot []Clause
+}
+
+// Terms keeps documents whose field has one of values; no-op when values is empty
+func (f *Filters) Terms(field string, values []string) {
+	if len(values) > 0 {
+		f.Filter = append(f.Filter, TermsClause{Field: field, Values: values})
+	}
+}
+
+// Range keeps documents whose date field lies in [from, before); no-op when both bounds are nil
+func (f *Filters) Range(field string, from, before *time.Time) {
+	if from != nil || before != nil {
+		f.Filter = append(f.Filter, RangeClause{Field: field, From: from, Before: before})
+	}
+}
+
+// ExcludeTerms drops documents whose field has one of values; no-op when values is empty
+func (f *Filters) ExcludeTerms(field string, values []string) {
+	if len(values) > 0 {
+		f.MustNot = append(f.MustNot, TermsClause{Field: field, Values: values})
+	}
+}
+
+// ExcludeExists drops documents with a value in field
+func (f *Filters) ExcludeExists(field string) {
+	f.MustNot = append(f.MustNot, ExistsClause{Field: field})
+}
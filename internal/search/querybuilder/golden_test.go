@@ -0,0 +1,151 @@
+package querybuilder
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"elasticsearch/internal/models"
+)
+
+// update rewrites the golden files with the current request bodies: go test ./internal/search/querybuilder -update
+var update = flag.Bool("update", false, "rewrite the golden files in testdata with the current request bodies")
+
+// goldenCase is a request body checked against testdata/<name>.golden
+type goldenCase struct {
+	name string
+	body map[string]interface{}
+}
+
+func TestRequestBodies(t *testing.T) {
+	after := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	seed := int64(42)
+
+	// search returns the parameters of a search with the defaults of the HTTP handlers
+	search := func(change func(*models.ProductSearchParams)) models.ProductSearchParams {
+		params := models.ProductSearchParams{Limit: 10, Fuzziness: "AUTO", TrackTotalHits: 10000}
+		if change != nil {
+			change(&params)
+		}
+		return params
+	}
+
+	cases := []goldenCase{
+		{"search_match_all", ProductSearch(search(nil)).Map()},
+		{"search_keyword", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "parol"
+		})).Map()},
+		{"search_keyword_prefix_subfields", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "par"
+			p.PrefixSubfields = true
+		})).Map()},
+		{"search_phrase", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "parol 500"
+			p.Mode = models.SearchModePhrase
+			p.SearchFields = []string{"product_name"}
+		})).Map()},
+		{"search_exact", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "Parol 500 mg"
+			p.Mode = models.SearchModeExact
+		})).Map()},
+		{"search_syntax", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Keyword = `parol -"film tablet" OR aferin`
+			p.Syntax = true
+		})).Map()},
+		{"search_filters", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "parol"
+			p.Companies = []string{"Atabay", "Bayer"}
+			p.DrugGenerics = []string{"parasetamol"}
+			p.IDs = []models.ProductID{"1", "abc-2"}
+			p.CreatedAfter = &after
+			p.UpdatedBefore = &before
+			p.ExcludedIDs = []models.ProductID{"3"}
+			p.ExcludedCompanies = []string{"Hidden"}
+			p.MinScore = 2.5
+		})).Map()},
+		{"search_pinned_include_deleted", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "parol"
+			p.PinnedIDs = []models.ProductID{"7", "8"}
+			p.IncludeDeleted = true
+		})).Map()},
+		{"search_sort_fields_highlight", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "parol"
+			p.Offset = 20
+			p.Sort = []models.SortField{{Field: "product_name"}, {Field: "created_at", Descending: true}}
+			p.Fields = []string{"id", "product_name", "attributes"}
+			p.Highlight = true
+		})).Map()},
+		{"search_cursor_facets", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Companies = []string{"Atabay"}
+			p.Cursor = &models.Cursor{PIT: "pit-id", After: []interface{}{json.Number("1.5"), "parol", json.Number("170000000000000001")}}
+			p.Facets = []string{"company", "drug_generic"}
+			p.FacetSize = 20
+		})).Map()},
+		{"search_dedupe_diversity", ProductSearch(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "parol"
+			p.Dedupe = true
+			p.MaxPerCompany = 2
+			p.Fields = []string{"id"}
+		})).Map()},
+		{"facets", ProductFacets(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "parol"
+			p.Companies = []string{"Atabay"}
+			p.Facets = []string{"company", "drug_generic"}
+			p.FacetSize = 10
+		})).Map()},
+		{"distinct", ProductDistinct(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "parol"
+		}), "company", 50).Map()},
+		{"significant", ProductSignificant(search(func(p *models.ProductSearchParams) {
+			p.Companies = []string{"Atabay"}
+		}), "drug_generic", 10).Map()},
+		{"groups_top_hits", ProductGroups(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "parol"
+			p.Sort = []models.SortField{{Field: "product_name"}}
+		}), 5, 3).Map()},
+		{"typeahead", ProductTypeahead(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "par"
+		}), 5).Map()},
+		{"typeahead_groups_top_hits", ProductTypeaheadGroups(search(func(p *models.ProductSearchParams) {
+			p.Keyword = "par"
+		}), 5).Map()},
+		{"sample", ProductSample(search(func(p *models.ProductSearchParams) {
+			p.Companies = []string{"Atabay"}
+		}), 10, &seed).Map()},
+		{"suggest", ProductSuggest("par", "", 5).Map()},
+		{"suggest_company", ProductSuggest("par", "Atabay", 5).Map()},
+		{"suggest_index", SuggestIndexSuggest("par", "Atabay", 5).Map()},
+		{"spelling", ProductSpelling("prol", 3).Map()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := json.MarshalIndent(c.body, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			path := filepath.Join("testdata", c.name+".golden")
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("request body differs from %s (run with -update to accept it):\n%s", path, got)
+			}
+		})
+	}
+}
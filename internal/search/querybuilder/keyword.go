@@ -0,0 +1,160 @@
+package querybuilder
+
+import (
+	"elasticsearch/internal/models"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Keyword matches params.Keyword according to the search mode and syntax of params
+func Keyword(params models.ProductSearchParams) Clause {
+	fields := searchFields(params)
+	if params.Syntax {
+		return SimpleQueryStringClause{
+			Query:           simpleQueryString(params.Keyword),
+			Fields:          fields,
+			DefaultOperator: "and",
+			Flags:           "AND|OR|NOT|PHRASE|WHITESPACE",
+		}
+	}
+
+	switch params.Mode {
+	case models.SearchModePhrase:
+		return BoolClause{Should: perField(fields, func(field string) Clause {
+			return PhraseClause{Field: field, Query: params.Keyword}
+		})}
+	case models.SearchModeExact:
+		return BoolClause{Should: perField(fields, func(field string) Clause {
+			return TermClause{Field: field + ".keyword", Value: params.Keyword, CaseInsensitive: true}
+		})}
+	default:
+		return BoolClause{
+			Should: []Clause{
+				fuzzyMatch(params.Keyword, params.Fuzziness, fields),
+				BoolClause{Should: perField(fields, func(field string) Clause {
+					return prefixMatch(params, field)
+				})},
+			},
+			MinimumShouldMatch: 1,
+		}
+	}
+}
+
+// prefixMatch matches the keyword within a field: on the indexed edge n-grams when the field has them, so every
+// term must start a word, otherwise as a substring with a wildcard
+func prefixMatch(params models.ProductSearchParams, field string) Clause {
+	if params.PrefixSubfields && slices.Contains(models.PrefixFields, field) {
+		return MatchClause{Field: field + ".prefix", Query: params.Keyword, Operator: "and"}
+	}
+	return WildcardClause{Field: field, Value: "*" + params.Keyword + "*"}
+}
+
+// fuzzyMatch requires every term of the keyword in one of the fields with the given fuzziness;
+// an empty fuzziness defaults to AUTO and "off" disables fuzzy matching
+func fuzzyMatch(keyword string, fuzziness string, fields []string) Clause {
+	switch fuzziness {
+	case "":
+		fuzziness = "AUTO"
+	case models.FuzzinessOff:
+		fuzziness = ""
+	}
+
+	return BoolClause{Should: perField(fields, func(field string) Clause {
+		return MatchClause{Field: field, Query: keyword, Operator: "and", Fuzziness: fuzziness}
+	})}
+}
+
+// perField builds one clause per field
+func perField(fields []string, build func(field string) Clause) []Clause {
+	clauses := make([]Clause, 0, len(fields))
+	for _, field := range fields {
+		clauses = append(clauses, build(field))
+	}
+	return clauses
+}
+
+// searchFields returns the fields the keyword is matched against: the requested ones, or every searchable field
+func searchFields(params models.ProductSearchParams) []string {
+	if len(params.SearchFields) > 0 {
+		return params.SearchFields
+	}
+	return models.SearchableFields
+}
+
+// simpleQueryReserved are the characters with a meaning in simple_query_string
+const simpleQueryReserved = `+|-"*()~\`
+
+// simpleQueryString translates the boolean query syntax (AND, OR, NOT or a leading -, "quoted phrases")
+// into simple_query_string operators, escaping every other reserved character so it is matched literally
+func simpleQueryString(keyword string) string {
+	var parts []string
+	negate := false
+	emit := func(part string) {
+		if negate {
+			part = "-" + part
+			negate = false
+		}
+		parts = append(parts, part)
+	}
+
+	runes := []rune(keyword)
+	for i := 0; i < len(runes); {
+		switch {
+		case unicode.IsSpace(runes[i]):
+			i++
+
+		case runes[i] == '"' || (runes[i] == '-' && i+1 < len(runes) && runes[i+1] == '"'):
+			if runes[i] == '-' {
+				negate = true
+				i++
+			}
+			// An unclosed quote runs to the end of the keyword
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			phrase := escapeSimpleQuery(string(runes[i+1 : min(end, len(runes))]))
+			i = end + 1
+			if strings.TrimSpace(phrase) != "" {
+				emit(`"` + phrase + `"`)
+			}
+
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+				end++
+			}
+			term := string(runes[i:end])
+			i = end
+
+			switch {
+			case term == "AND":
+				parts = append(parts, "+")
+			case term == "OR":
+				parts = append(parts, "|")
+			case term == "NOT":
+				negate = true
+			case strings.HasPrefix(term, "-") && len(term) > 1:
+				negate = true
+				emit(escapeSimpleQuery(term[1:]))
+			default:
+				emit(escapeSimpleQuery(term))
+			}
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// escapeSimpleQuery backslash-escapes the simple_query_string reserved characters in s
+func escapeSimpleQuery(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(simpleQueryReserved, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
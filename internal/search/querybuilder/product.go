@@ -0,0 +1,360 @@
+package querybuilder
+
+import (
+	"elasticsearch/internal/highlight"
+	"elasticsearch/internal/models"
+	"slices"
+)
+
+// productSortFields maps sortable product fields to the Elasticsearch fields sorted on
+var productSortFields = map[string]string{
+	"id":           "id",
+	"product_name": "product_name.keyword",
+	"drug_generic": "drug_generic.keyword",
+	"company":      "company.keyword",
+	"score":        "_score",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+}
+
+// facetFields maps facetable product fields to the Elasticsearch fields their values are counted on
+var facetFields = map[string]string{
+	"company":      "company.keyword",
+	"drug_generic": "drug_generic.keyword",
+}
+
+// FacetAggregation is the name of the terms aggregation holding the buckets of a facet
+const FacetAggregation = "values"
+
+// DistinctCountAggregation and DistinctValuesAggregation name the number and the list of distinct values of a
+// field
+const (
+	DistinctCountAggregation  = "distinct_count"
+	DistinctValuesAggregation = "distinct_values"
+)
+
+// SignificantAggregation names the significant_terms aggregation of a related values search
+const SignificantAggregation = "significant"
+
+// GroupAggregation and TopProductsAggregation name the company buckets of a grouped search and the products
+// fetched in each of them
+const (
+	GroupAggregation       = "companies"
+	TopProductsAggregation = "top_products"
+)
+
+// TypeaheadProductsAggregation, TypeaheadGenericsAggregation and TypeaheadCompaniesAggregation name the sections
+// of a grouped typeahead: the best products whose name matches, and the generics and companies matching
+const (
+	TypeaheadProductsAggregation  = "products"
+	TypeaheadGenericsAggregation  = "generics"
+	TypeaheadCompaniesAggregation = "companies"
+)
+
+// relevanceSort orders keyword results by score, ties broken by product name
+var relevanceSort = Sort{
+	{Field: "_score", Descending: true},
+	{Field: "product_name.keyword"},
+}
+
+// ProductSearch builds the search request of a product search
+func ProductSearch(params models.ProductSearchParams) SearchRequest {
+	request := SearchRequest{
+		From: params.Offset,
+		Size: params.Limit,
+	}
+	query := BoolClause{}
+
+	if params.Keyword != "" {
+		keyword := Keyword(params)
+
+		// Curated products rank above organic matches; filters below still apply to them
+		if len(params.PinnedIDs) > 0 {
+			keyword = PinnedClause{IDs: idStrings(params.PinnedIDs), Organic: keyword}
+		}
+		query.Must = []Clause{keyword}
+		request.Sort = relevanceSort
+
+		// Cut off weak fuzzy and wildcard matches; browsing without a keyword scores every hit alike
+		request.MinScore = params.MinScore
+
+		// Ask for highlighted fragments wrapped in marker runes; they are escaped and formatted by the service
+		if params.Highlight {
+			request.Highlight = &Highlight{
+				Fields:  models.SearchableFields,
+				PreTag:  highlight.PreTag,
+				PostTag: highlight.PostTag,
+			}
+		}
+	}
+
+	// Only fetch product fields from _source, so stray fields written around the API never reach a response;
+	// with a field selection only the selected ones
+	request.Source = models.ProductSourceFields
+	if len(params.Fields) > 0 {
+		request.Source = sourceFields(params)
+	}
+
+	// An explicit sort replaces the default ordering
+	if len(params.Sort) > 0 {
+		request.Sort = productSort(params.Sort)
+	}
+
+	// Cursor pages need a total order; _shard_doc is unique within the point in time the cursor reads
+	if params.Cursor != nil {
+		request.Sort = append(slices.Clone(request.Sort), SortField{Field: "_shard_doc"})
+		request.SearchAfter = params.Cursor.After
+	}
+
+	// Exact-value and date filters don't affect scoring. Filters on a faceted field are applied after the
+	// aggregations, so its facet still counts the values that aren't selected.
+	var filters Filters
+	var facetFilters []facetFilter
+	for _, selection := range []facetFilter{
+		{facet: "company", clause: TermsClause{Field: facetFields["company"], Values: params.Companies}},
+		{facet: "drug_generic", clause: TermsClause{Field: facetFields["drug_generic"], Values: params.DrugGenerics}},
+	} {
+		switch {
+		case len(selection.clause.Values) == 0:
+		case slices.Contains(params.Facets, selection.facet):
+			facetFilters = append(facetFilters, selection)
+		default:
+			filters.Terms(selection.clause.Field, selection.clause.Values)
+		}
+	}
+	filters.Terms("_id", idStrings(params.IDs))
+	filters.Range("created_at", params.CreatedAfter, params.CreatedBefore)
+	filters.Range("updated_at", params.UpdatedAfter, params.UpdatedBefore)
+
+	// Hide soft-deleted products unless explicitly requested
+	if !params.IncludeDeleted {
+		filters.ExcludeExists("deleted_at")
+	}
+
+	// Apply exclusion rules, including to pinned products
+	filters.ExcludeTerms("_id", idStrings(params.ExcludedIDs))
+	filters.ExcludeTerms("company.keyword", params.ExcludedCompanies)
+
+	query.Filter = filters.Filter
+	query.MustNot = filters.MustNot
+	request.Query = query
+
+	if len(params.Facets) > 0 {
+		request.Aggs = facetAggregations(params, facetFilters)
+	}
+	if len(facetFilters) > 0 {
+		post := BoolClause{}
+		for _, filter := range facetFilters {
+			post.Filter = append(post.Filter, filter.clause)
+		}
+		request.PostFilter = post
+	}
+
+	return request
+}
+
+// facetFilter is the selection of values of a faceted field
+type facetFilter struct {
+	facet  string
+	clause TermsClause
+}
+
+// facetAggregations counts the values of every requested facet over the hits matching the query and the
+// selections on the other faceted fields
+func facetAggregations(params models.ProductSearchParams, selections []facetFilter) map[string]Clause {
+	aggs := make(map[string]Clause, len(params.Facets))
+	for _, facet := range params.Facets {
+		others := BoolClause{}
+		for _, selection := range selections {
+			if selection.facet != facet {
+				others.Filter = append(others.Filter, selection.clause)
+			}
+		}
+		aggs[facet] = FilterAggregation{
+			Filter: others,
+			Aggs: map[string]Clause{
+				FacetAggregation: TermsAggregation{Field: facetFields[facet], Size: params.FacetSize},
+			},
+		}
+	}
+	return aggs
+}
+
+// ProductFacets builds the search request counting the values of the requested facets over the products
+// matching the keyword and filters of params, without fetching any of them
+func ProductFacets(params models.ProductSearchParams) SearchRequest {
+	request := ProductSearch(params)
+	request.From = 0
+	request.Size = 0
+	request.Sort = nil
+	request.Source = nil
+	request.Highlight = nil
+	request.SearchAfter = nil
+	return request
+}
+
+// ProductDistinct builds the search request counting the distinct values of a facetable field over the products
+// matching the keyword and filters of params, and listing the first size of them alphabetically. Like a facet,
+// the field ignores its own filter, so a dropdown keeps offering the values that aren't selected.
+func ProductDistinct(params models.ProductSearchParams, field string, size int) SearchRequest {
+	// Requesting the field as the only facet moves its selection into the post_filter
+	params.Facets = []string{field}
+	request := ProductFacets(params)
+	request.Aggs = map[string]Clause{
+		DistinctCountAggregation:  CardinalityAggregation{Field: facetFields[field]},
+		DistinctValuesAggregation: TermsAggregation{Field: facetFields[field], Size: size, ByValue: true},
+	}
+	return request
+}
+
+// ProductSignificant builds the search request finding the size values of a facetable field that are
+// statistically overrepresented in the products matching the keyword and filters of params
+func ProductSignificant(params models.ProductSearchParams, field string, size int) SearchRequest {
+	request := ProductFacets(params)
+	request.Aggs = map[string]Clause{
+		SignificantAggregation: SignificantTermsAggregation{Field: facetFields[field], Size: size},
+	}
+	return request
+}
+
+// ProductGroups builds the search request of the perGroup best products of each of the groups companies with
+// the most products matching the keyword and filters of params. Products within a company are ordered like
+// the results of the search.
+func ProductGroups(params models.ProductSearchParams, groups, perGroup int) SearchRequest {
+	request := ProductSearch(params)
+	request.Aggs = map[string]Clause{
+		GroupAggregation: TermsAggregation{
+			Field: facetFields["company"],
+			Size:  groups,
+			Aggs: map[string]Clause{
+				TopProductsAggregation: TopHitsAggregation{Size: perGroup, Sort: request.Sort, Source: request.Source},
+			},
+		},
+	}
+	request.From = 0
+	request.Size = 0
+	request.Sort = nil
+	request.Source = nil
+	request.Highlight = nil
+	request.SearchAfter = nil
+	return request
+}
+
+// typeaheadFields are the search_as_you_type subfields of product_name: the whole-term field and its shingles
+var typeaheadFields = []string{"product_name.typeahead", "product_name.typeahead._2gram", "product_name.typeahead._3gram"}
+
+// ProductTypeahead builds the search request of the size best products whose name matches the partially typed
+// keyword of params: every complete term must match and the last one may be a prefix. The prefix is matched
+// on the indexed edge n-grams of search_as_you_type rather than with wildcards.
+func ProductTypeahead(params models.ProductSearchParams, size int) SearchRequest {
+	prefix := params.Keyword
+	params.Keyword = ""
+	request := ProductSearch(params)
+
+	query := request.Query.(BoolClause)
+	query.Must = []Clause{typeaheadMatch(prefix, typeaheadFields)}
+	request.Query = query
+	request.From = 0
+	request.Size = size
+	request.Sort = relevanceSort
+	return request
+}
+
+// ProductTypeaheadGroups builds the search request of a typeahead grouped by entity type: the size best
+// products whose name matches the partially typed keyword of params, and the size generics and companies
+// matching it with the most products. Generics and companies have no search_as_you_type subfield, so their
+// last term is matched as a prefix of the indexed terms.
+func ProductTypeaheadGroups(params models.ProductSearchParams, size int) SearchRequest {
+	prefix := params.Keyword
+	params.Keyword = ""
+	request := ProductFacets(params)
+
+	names := typeaheadMatch(prefix, typeaheadFields)
+	generics := typeaheadMatch(prefix, []string{"drug_generic"})
+	companies := typeaheadMatch(prefix, []string{"company"})
+
+	// Products matching any section are searched once; every section only looks at its own matches
+	query := request.Query.(BoolClause)
+	query.Must = []Clause{BoolClause{Should: []Clause{names, generics, companies}}}
+	request.Query = query
+	request.Aggs = map[string]Clause{
+		TypeaheadProductsAggregation: FilterAggregation{
+			Filter: names,
+			Aggs: map[string]Clause{
+				TopProductsAggregation: TopHitsAggregation{Size: size, Sort: relevanceSort, Source: models.ProductSourceFields},
+			},
+		},
+		TypeaheadGenericsAggregation: FilterAggregation{
+			Filter: generics,
+			Aggs: map[string]Clause{
+				FacetAggregation: TermsAggregation{Field: facetFields["drug_generic"], Size: size},
+			},
+		},
+		TypeaheadCompaniesAggregation: FilterAggregation{
+			Filter: companies,
+			Aggs: map[string]Clause{
+				FacetAggregation: TermsAggregation{Field: facetFields["company"], Size: size},
+			},
+		},
+	}
+	return request
+}
+
+// typeaheadMatch matches a partially typed keyword against fields: every complete term must match and the last
+// one may be a prefix
+func typeaheadMatch(prefix string, fields []string) Clause {
+	return MultiMatchClause{Query: prefix, Type: "bool_prefix", Fields: fields, Operator: "and"}
+}
+
+// ProductSample builds the search request of a random sample of size products matching the keyword and
+// filters of params
+func ProductSample(params models.ProductSearchParams, size int, seed *int64) SearchRequest {
+	request := ProductSearch(params)
+	request.Query = RandomScoreClause{Query: request.Query, Seed: seed}
+	request.From = 0
+	request.Size = size
+	request.Sort = nil
+	request.MinScore = 0
+	request.Highlight = nil
+	return request
+}
+
+// productSort translates product sort criteria into Elasticsearch sort fields
+func productSort(criteria []models.SortField) Sort {
+	sort := make(Sort, 0, len(criteria))
+	for _, criterion := range criteria {
+		sort = append(sort, SortField{Field: productSortFields[criterion.Field], Descending: criterion.Descending})
+	}
+	return sort
+}
+
+// sourceFields lists the _source fields needed for a field selection. The ID and score come from
+// the hit metadata; the dedupe keys and the company are fetched when dedupe or diversity is on.
+func sourceFields(params models.ProductSearchParams) []string {
+	fields := []string{}
+	for _, field := range params.Fields {
+		if field != "id" && field != "score" {
+			fields = append(fields, field)
+		}
+	}
+	if params.Dedupe {
+		for _, field := range []string{"product_name", "company"} {
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	if params.MaxPerCompany > 0 && !slices.Contains(fields, "company") {
+		fields = append(fields, "company")
+	}
+	return fields
+}
+
+// idStrings converts product IDs to the strings used in queries
+func idStrings(ids []models.ProductID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = string(id)
+	}
+	return values
+}
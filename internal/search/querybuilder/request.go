@@ -0,0 +1,112 @@
+package querybuilder
+
+// SortField orders hits by one field
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// Sort is an ordered list of sort criteria
+type Sort []SortField
+
+// Maps renders the sort criteria
+func (s Sort) Maps() []map[string]interface{} {
+	clauses := make([]map[string]interface{}, 0, len(s))
+	for _, criterion := range s {
+		order := "asc"
+		if criterion.Descending {
+			order = "desc"
+		}
+		clauses = append(clauses, map[string]interface{}{
+			criterion.Field: map[string]interface{}{"order": order},
+		})
+	}
+	return clauses
+}
+
+// Highlight returns whole field values of Fields as single fragments, matches wrapped in PreTag and PostTag
+type Highlight struct {
+	Fields  []string
+	PreTag  string
+	PostTag string
+}
+
+// Map renders the highlight section
+func (h Highlight) Map() map[string]interface{} {
+	fields := make(map[string]interface{}, len(h.Fields))
+	for _, field := range h.Fields {
+		fields[field] = map[string]interface{}{"number_of_fragments": 0}
+	}
+
+	return map[string]interface{}{
+		"pre_tags":  []string{h.PreTag},
+		"post_tags": []string{h.PostTag},
+		"fields":    fields,
+	}
+}
+
+// PointInTime pins a search to a point-in-time view of an index
+type PointInTime struct {
+	ID        string
+	KeepAlive string
+}
+
+// SearchRequest is the body of a search request
+type SearchRequest struct {
+	Query Clause
+	From  int
+	Size  int
+	// Sort is omitted when empty, ordering by score
+	Sort Sort
+	// Source lists the _source fields fetched; nil fetches the whole document
+	Source []string
+	// Highlight is omitted when nil
+	Highlight *Highlight
+	// MinScore drops hits scoring below it; omitted when 0
+	MinScore float64
+	// PointInTime searches a point in time instead of the index in the request path; omitted when nil
+	PointInTime *PointInTime
+	// SearchAfter resumes after the hit with these sort values; omitted when empty
+	SearchAfter []interface{}
+	// Aggs are named aggregations computed over the query; omitted when empty
+	Aggs map[string]Clause
+	// PostFilter filters the hits after the aggregations were computed; omitted when nil
+	PostFilter Clause
+}
+
+// Map renders the request body
+func (r SearchRequest) Map() map[string]interface{} {
+	body := map[string]interface{}{
+		"query": r.Query.Map(),
+		"from":  r.From,
+		"size":  r.Size,
+	}
+	if len(r.Sort) > 0 {
+		body["sort"] = r.Sort.Maps()
+	}
+	if r.Source != nil {
+		body["_source"] = r.Source
+	}
+	if r.Highlight != nil {
+		body["highlight"] = r.Highlight.Map()
+	}
+	if r.MinScore > 0 {
+		body["min_score"] = r.MinScore
+	}
+	if r.PointInTime != nil {
+		body["pit"] = map[string]interface{}{
+			"id":         r.PointInTime.ID,
+			"keep_alive": r.PointInTime.KeepAlive,
+		}
+	}
+	if len(r.SearchAfter) > 0 {
+		body["search_after"] = r.SearchAfter
+	}
+	if len(r.Aggs) > 0 {
+		body["aggs"] = aggregationMaps(r.Aggs)
+	}
+	if r.PostFilter != nil {
+		body["post_filter"] = r.PostFilter.Map()
+	}
+	return body
+}
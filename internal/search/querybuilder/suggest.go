@@ -0,0 +1,137 @@
+package querybuilder
+
+// CompletionSuggester completes Prefix from the values of a completion field, fastest first
+type CompletionSuggester struct {
+	Prefix string
+	Field  string
+	Size   int
+	// SkipDuplicates returns every completed text once
+	SkipDuplicates bool
+	// Contexts only completes values indexed with one of the given values of each named context; omitted when empty
+	Contexts map[string][]string
+}
+
+// Map implements Clause
+func (s CompletionSuggester) Map() map[string]interface{} {
+	completion := map[string]interface{}{
+		"field":           s.Field,
+		"size":            s.Size,
+		"skip_duplicates": s.SkipDuplicates,
+	}
+	if len(s.Contexts) > 0 {
+		completion["contexts"] = s.Contexts
+	}
+	return map[string]interface{}{
+		"prefix":     s.Prefix,
+		"completion": completion,
+	}
+}
+
+// PhraseSuggester proposes corrected spellings of Text, made of the terms indexed in Field. With Collate
+// only corrections for which the collate query finds a document are proposed; the query template refers
+// to the correction as {{suggestion}}.
+type PhraseSuggester struct {
+	Text    string
+	Field   string
+	Size    int
+	Collate Clause
+}
+
+// Map implements Clause
+func (s PhraseSuggester) Map() map[string]interface{} {
+	phrase := map[string]interface{}{
+		"field": s.Field,
+		"size":  s.Size,
+		"direct_generator": []map[string]interface{}{
+			{"field": s.Field, "suggest_mode": "always"},
+		},
+	}
+	if s.Collate != nil {
+		phrase["collate"] = map[string]interface{}{
+			"query": map[string]interface{}{"source": s.Collate.Map()},
+			"prune": false,
+		}
+	}
+	return map[string]interface{}{
+		"text":   s.Text,
+		"phrase": phrase,
+	}
+}
+
+// SuggestRequest is the body of a search request that only runs suggesters
+type SuggestRequest struct {
+	Suggest map[string]Clause
+	// Source lists the _source fields returned with every completion suggestion; nil returns the whole document
+	Source []string
+}
+
+// Map renders the request body; no hits are returned
+func (r SuggestRequest) Map() map[string]interface{} {
+	body := map[string]interface{}{
+		"size":    0,
+		"suggest": aggregationMaps(r.Suggest),
+	}
+	if r.Source != nil {
+		body["_source"] = r.Source
+	}
+	return body
+}
+
+// SpellingSuggestion names the phrase suggester of corrected keywords
+const SpellingSuggestion = "did_you_mean"
+
+// ProductSpelling builds the request proposing up to size corrected spellings of a keyword, keeping only
+// corrections that match a live product name
+func ProductSpelling(keyword string, size int) SuggestRequest {
+	return SuggestRequest{
+		Suggest: map[string]Clause{
+			SpellingSuggestion: PhraseSuggester{
+				Text:  keyword,
+				Field: "product_name",
+				Size:  size,
+				Collate: BoolClause{
+					Must:    []Clause{MatchClause{Field: "product_name", Query: "{{suggestion}}", Operator: "and"}},
+					MustNot: []Clause{ExistsClause{Field: "deleted_at"}},
+				},
+			},
+		},
+	}
+}
+
+// ProductSuggestion names the completion suggester of product names
+const ProductSuggestion = "product_name"
+
+// CompanyContext names the category context of the completion fields holding the company of a product
+const CompanyContext = "company"
+
+// ProductSuggest builds the request completing prefix to up to size distinct product names, returning the
+// company and deletion time of the suggested products. With a company only names of its products are completed.
+func ProductSuggest(prefix, company string, size int) SuggestRequest {
+	return completionRequest("product_name.suggest", prefix, company, size, []string{"product_name", "company", "deleted_at"})
+}
+
+// SuggestIndexSuggest builds the request completing prefix to up to size distinct product names from the
+// suggest field of the dedicated suggest index, highest weight first. With a company only names of its products
+// are completed.
+func SuggestIndexSuggest(prefix, company string, size int) SuggestRequest {
+	return completionRequest("suggest", prefix, company, size, []string{"product_name", "company"})
+}
+
+// completionRequest builds the request of the ProductSuggestion completion suggester on field, fetching source
+func completionRequest(field, prefix, company string, size int, source []string) SuggestRequest {
+	suggester := CompletionSuggester{
+		Prefix:         prefix,
+		Field:          field,
+		Size:           size,
+		SkipDuplicates: true,
+	}
+	if company != "" {
+		suggester.Contexts = map[string][]string{CompanyContext: {company}}
+	}
+	return SuggestRequest{
+		Suggest: map[string]Clause{
+			ProductSuggestion: suggester,
+		},
+		Source: source,
+	}
+}
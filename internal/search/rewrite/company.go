@@ -0,0 +1,41 @@
+package rewrite
+
+import (
+	"strings"
+
+	"elasticsearch/internal/models"
+)
+
+func init() {
+	Register("company_phrase", func() Rule { return CompanyPhraseRule{} })
+}
+
+// CompanyPhraseRule turns a keyword quoted as a whole, e.g. "Abdi Ibrahim", into an exact,
+// case-insensitive match on the company. It only applies when the search mode and fields weren't chosen.
+type CompanyPhraseRule struct{}
+
+// Name implements Rule
+func (CompanyPhraseRule) Name() string {
+	return "company_phrase"
+}
+
+// Rewrite implements Rule
+func (CompanyPhraseRule) Rewrite(params *models.ProductSearchParams) bool {
+	if params.Mode != "" || len(params.SearchFields) > 0 {
+		return false
+	}
+
+	keyword := strings.TrimSpace(params.Keyword)
+	if len(keyword) < 2 || keyword[0] != '"' || keyword[len(keyword)-1] != '"' {
+		return false
+	}
+	phrase := strings.Join(strings.Fields(keyword[1:len(keyword)-1]), " ")
+	if phrase == "" || strings.Contains(phrase, `"`) {
+		return false
+	}
+
+	params.Keyword = phrase
+	params.Mode = models.SearchModeExact
+	params.SearchFields = []string{"company"}
+	return true
+}
@@ -0,0 +1,45 @@
+package rewrite
+
+import (
+	"strings"
+
+	"elasticsearch/internal/models"
+)
+
+func init() {
+	Register("numeric_id", func() Rule { return NumericIDRule{} })
+}
+
+// NumericIDRule turns a keyword made only of digits into a lookup of the product with that ID.
+// It doesn't apply when the search already filters by ID.
+type NumericIDRule struct{}
+
+// Name implements Rule
+func (NumericIDRule) Name() string {
+	return "numeric_id"
+}
+
+// Rewrite implements Rule
+func (NumericIDRule) Rewrite(params *models.ProductSearchParams) bool {
+	keyword := strings.TrimSpace(params.Keyword)
+	if len(params.IDs) > 0 || !isDigits(keyword) {
+		return false
+	}
+
+	params.IDs = []models.ProductID{models.ProductID(keyword)}
+	params.Keyword = ""
+	return true
+}
+
+// isDigits reports whether value is a non-empty run of ASCII digits
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
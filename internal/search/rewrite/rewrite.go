@@ -0,0 +1,77 @@
+// Package rewrite provides the rule chain that rewrites search keywords before the query is built
+package rewrite
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"elasticsearch/internal/models"
+)
+
+// Rule rewrites common keyword shapes into a more precise search
+type Rule interface {
+	Name() string
+	// Rewrite changes params in place and reports whether the rule applied
+	Rewrite(params *models.ProductSearchParams) bool
+}
+
+// Factory creates a Rule
+type Factory func() Rule
+
+var registry = map[string]Factory{}
+
+// Register makes a rule available by name for chain configuration
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// Available returns the names of all registered rules
+func Available() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Chain runs rules in order; each rule sees the keyword left by the previous ones
+type Chain []Rule
+
+// NewChain builds a Chain from registered rule names, preserving their order
+func NewChain(names []string) (Chain, error) {
+	chain := make(Chain, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		factory, ok := registry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rewrite rule %q (available: %s)", name, strings.Join(Available(), ", "))
+		}
+		chain = append(chain, factory())
+	}
+	return chain, nil
+}
+
+// Rewrite applies every rule to a keyword search and returns the names of the rules that applied.
+// Searches without a keyword and boolean syntax searches, whose quotes and operators carry meaning, are left alone.
+func (c Chain) Rewrite(params *models.ProductSearchParams) []string {
+	if params.Syntax {
+		return nil
+	}
+
+	var applied []string
+	for _, rule := range c {
+		if strings.TrimSpace(params.Keyword) == "" {
+			break
+		}
+		if rule.Rewrite(params) {
+			applied = append(applied, rule.Name())
+		}
+	}
+	return applied
+}
@@ -0,0 +1,59 @@
+package rewrite
+
+import (
+	"regexp"
+	"strings"
+
+	"elasticsearch/internal/models"
+)
+
+func init() {
+	Register("strip_units", func() Rule { return StripUnitsRule{} })
+}
+
+// dosageUnit matches the dosage units written after a strength, e.g. "mg" in "500 mg"
+const dosageUnit = `(?:mg|mcg|µg|g|ml|l|iu|ui|%)`
+
+var (
+	// attachedUnit matches a strength with its unit attached, e.g. "500mg" or "2,5ml"
+	attachedUnit = regexp.MustCompile(`(?i)^(\d+(?:[.,]\d+)?)` + dosageUnit + `$`)
+	// separateUnit matches a unit written as its own word
+	separateUnit = regexp.MustCompile(`(?i)^` + dosageUnit + `$`)
+	// strength matches a bare number
+	strength = regexp.MustCompile(`^\d+(?:[.,]\d+)?$`)
+)
+
+// StripUnitsRule drops dosage units from the keyword and keeps the strength, so "parol 500 mg" and
+// "parol 500mg" both search for "parol 500" whichever way the unit is spelled in the product name.
+// A unit is only dropped right after a number, and a keyword made only of units is left alone.
+type StripUnitsRule struct{}
+
+// Name implements Rule
+func (StripUnitsRule) Name() string {
+	return "strip_units"
+}
+
+// Rewrite implements Rule
+func (StripUnitsRule) Rewrite(params *models.ProductSearchParams) bool {
+	words := strings.Fields(params.Keyword)
+	kept := make([]string, 0, len(words))
+	stripped := false
+	for _, word := range words {
+		if match := attachedUnit.FindStringSubmatch(word); match != nil {
+			kept = append(kept, match[1])
+			stripped = true
+			continue
+		}
+		if len(kept) > 0 && strength.MatchString(kept[len(kept)-1]) && separateUnit.MatchString(word) {
+			stripped = true
+			continue
+		}
+		kept = append(kept, word)
+	}
+
+	if !stripped || len(kept) == 0 {
+		return false
+	}
+	params.Keyword = strings.Join(kept, " ")
+	return true
+}
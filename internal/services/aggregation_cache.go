@@ -0,0 +1,65 @@
+package services
+
+import (
+	"elasticsearch/internal/cache"
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/metrics"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+)
+
+// AggregationCache keeps the results of facet, distinct value and statistics queries for a short TTL, keyed
+// by the query they answer. Aggregations span the whole catalog, so any write through the API or an import
+// drops every entry.
+type AggregationCache struct {
+	entries *cache.TTL[string, any]
+	ttl     time.Duration
+	// generation is bumped by Invalidate, so results loaded while a write happened aren't stored
+	generation atomic.Uint64
+}
+
+// NewAggregationCache creates an AggregationCache from configuration
+func NewAggregationCache(cfg config.AggregationCacheConfig) *AggregationCache {
+	return &AggregationCache{
+		entries: cache.NewTTL[string, any](cfg.MaxEntries),
+		ttl:     time.Duration(cfg.TTLSec) * time.Second,
+	}
+}
+
+// Invalidate drops every cached result
+func (c *AggregationCache) Invalidate() {
+	c.generation.Add(1)
+	c.entries.Clear()
+}
+
+// cachedAggregation returns the cached result of the query named op with the given request, loading and
+// storing it on a miss. Without a cache every call loads; errors are never cached.
+func cachedAggregation[T any](c *AggregationCache, op string, request any, load func() (T, error)) (T, error) {
+	if c == nil {
+		return load()
+	}
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return load()
+	}
+	key := op + ":" + string(encoded)
+
+	if cached, ok := c.entries.Get(key); ok {
+		if result, ok := cached.(T); ok {
+			metrics.AggregationCacheHits.Add(1)
+			return result, nil
+		}
+	}
+	metrics.AggregationCacheMisses.Add(1)
+
+	generation := c.generation.Load()
+	result, err := load()
+	if err != nil {
+		return result, err
+	}
+	if c.generation.Load() == generation {
+		c.entries.Set(key, result, c.ttl)
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"math"
+)
+
+type SearchDailySummaryResult struct {
+	Summaries   []models.SearchDailySummary
+	TotalCount  int64
+	Limit       int
+	Offset      int
+	CurrentPage int
+	TotalPages  int
+}
+
+type AnalyticsService interface {
+	GetDailySummaries(ctx context.Context, params models.SearchDailySummaryParams) (SearchDailySummaryResult, error)
+	PopularQueries(ctx context.Context, prefix string, size int) ([]models.QueryCount, error)
+}
+
+type AnalyticsServiceImpl struct {
+	analyticsRepo elasticsearch.AnalyticsRepository
+}
+
+func NewAnalyticsService(analyticsRepo elasticsearch.AnalyticsRepository) *AnalyticsServiceImpl {
+	return &AnalyticsServiceImpl{
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+func (s *AnalyticsServiceImpl) GetDailySummaries(ctx context.Context, params models.SearchDailySummaryParams) (SearchDailySummaryResult, error) {
+	result, err := s.analyticsRepo.FindDailySummaries(ctx, params)
+	if err != nil {
+		return SearchDailySummaryResult{}, err
+	}
+
+	// Calculate page info
+	currentPage := 1
+	if params.Limit > 0 {
+		currentPage = (params.Offset / params.Limit) + 1
+	}
+
+	totalPages := 1
+	if params.Limit > 0 && result.TotalCount > 0 {
+		totalPages = int(math.Ceil(float64(result.TotalCount) / float64(params.Limit)))
+	}
+
+	return SearchDailySummaryResult{
+		Summaries:   result.Summaries,
+		TotalCount:  result.TotalCount,
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+// PopularQueries returns the size keywords starting with prefix that were searched most often with results
+func (s *AnalyticsServiceImpl) PopularQueries(ctx context.Context, prefix string, size int) ([]models.QueryCount, error) {
+	return s.analyticsRepo.PopularQueries(ctx, models.NormalizeKeyword(prefix), size)
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+type CurationSearchResult struct {
+	Curations   []models.Curation
+	TotalCount  int64
+	Limit       int
+	Offset      int
+	CurrentPage int
+	TotalPages  int
+}
+
+type CurationService interface {
+	GetCurations(ctx context.Context, limit int, offset int) (CurationSearchResult, error)
+	GetCuration(ctx context.Context, keyword string) (models.Curation, error)
+	SetCuration(ctx context.Context, keyword string, req models.CurationRequest) (models.Curation, error)
+	DeleteCuration(ctx context.Context, keyword string) error
+	PinnedIDs(ctx context.Context, keyword string) ([]models.ProductID, error)
+}
+
+type CurationServiceImpl struct {
+	curationRepo elasticsearch.CurationRepository
+}
+
+func NewCurationService(curationRepo elasticsearch.CurationRepository) *CurationServiceImpl {
+	return &CurationServiceImpl{
+		curationRepo: curationRepo,
+	}
+}
+
+func (s *CurationServiceImpl) GetCurations(ctx context.Context, limit int, offset int) (CurationSearchResult, error) {
+	curations, total, err := s.curationRepo.FindCurations(ctx, limit, offset)
+	if err != nil {
+		return CurationSearchResult{}, err
+	}
+
+	// Calculate page info
+	currentPage := 1
+	if limit > 0 {
+		currentPage = (offset / limit) + 1
+	}
+
+	totalPages := 1
+	if limit > 0 && total > 0 {
+		totalPages = int(math.Ceil(float64(total) / float64(limit)))
+	}
+
+	return CurationSearchResult{
+		Curations:   curations,
+		TotalCount:  total,
+		Limit:       limit,
+		Offset:      offset,
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+func (s *CurationServiceImpl) GetCuration(ctx context.Context, keyword string) (models.Curation, error) {
+	normalized, err := normalizeCurationKeyword(keyword)
+	if err != nil {
+		return models.Curation{}, err
+	}
+
+	return s.curationRepo.GetCuration(ctx, normalized)
+}
+
+// SetCuration replaces the pinned products of a keyword; pins are applied in the given order
+func (s *CurationServiceImpl) SetCuration(ctx context.Context, keyword string, req models.CurationRequest) (models.Curation, error) {
+	normalized, err := normalizeCurationKeyword(keyword)
+	if err != nil {
+		return models.Curation{}, err
+	}
+
+	if len(req.PinnedIDs) == 0 {
+		return models.Curation{}, fmt.Errorf("%w: at least one pinned ID is required", common.ErrValidation)
+	}
+	if len(req.PinnedIDs) > models.MaxPinnedProducts {
+		return models.Curation{}, fmt.Errorf("%w: at most %d pinned IDs per keyword", common.ErrValidation, models.MaxPinnedProducts)
+	}
+
+	// Normalize IDs and drop duplicates, keeping the first position
+	pinned := make([]models.ProductID, 0, len(req.PinnedIDs))
+	seen := make(map[models.ProductID]bool, len(req.PinnedIDs))
+	for _, rawID := range req.PinnedIDs {
+		id, err := parseID(string(rawID))
+		if err != nil {
+			return models.Curation{}, err
+		}
+		if !seen[id] {
+			seen[id] = true
+			pinned = append(pinned, id)
+		}
+	}
+
+	curation := models.Curation{
+		Keyword:   normalized,
+		PinnedIDs: pinned,
+		UpdatedAt: time.Now(),
+	}
+	if err := s.curationRepo.SaveCuration(ctx, curation); err != nil {
+		return models.Curation{}, err
+	}
+
+	return curation, nil
+}
+
+func (s *CurationServiceImpl) DeleteCuration(ctx context.Context, keyword string) error {
+	normalized, err := normalizeCurationKeyword(keyword)
+	if err != nil {
+		return err
+	}
+
+	return s.curationRepo.DeleteCuration(ctx, normalized)
+}
+
+// PinnedIDs returns the products pinned for a search keyword, or none if the keyword isn't curated
+func (s *CurationServiceImpl) PinnedIDs(ctx context.Context, keyword string) ([]models.ProductID, error) {
+	normalized := models.NormalizeKeyword(keyword)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	curation, err := s.curationRepo.GetCuration(ctx, normalized)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return curation.PinnedIDs, nil
+}
+
+// normalizeCurationKeyword normalizes a keyword received from a client
+func normalizeCurationKeyword(keyword string) (string, error) {
+	normalized := models.NormalizeKeyword(keyword)
+	if normalized == "" {
+		return "", fmt.Errorf("%w: keyword is required", common.ErrValidation)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,35 @@
+package services
+
+import "elasticsearch/internal/models"
+
+// DiversityWindow is the number of top hits a diversified search reorders. Pages past it keep the
+// Elasticsearch order, which is unaffected because products are only moved within the window.
+const DiversityWindow = 100
+
+// diversifyByCompany reorders products so that no company has more than maxPerCompany products among
+// the first topK. Products over the cap move down right after the first topK, making room for the next
+// best products of other companies; otherwise the relevance order is kept. Products without a company
+// aren't capped.
+func diversifyByCompany(products []models.Product, maxPerCompany int, topK int) []models.Product {
+	counts := make(map[string]int)
+	top := make([]models.Product, 0, min(topK, len(products)))
+	var demoted []models.Product
+
+	for i, product := range products {
+		if len(top) == topK {
+			// The rest keeps its order after the demoted products
+			return append(append(top, demoted...), products[i:]...)
+		}
+
+		company := normalizeForDedupe(product.Company)
+		if company != "" && counts[company] >= maxPerCompany {
+			demoted = append(demoted, product)
+			continue
+		}
+		counts[company]++
+		top = append(top, product)
+	}
+
+	// Not enough other companies to fill the top: demoted products follow in order
+	return append(top, demoted...)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+type ExclusionSearchResult struct {
+	Rules       []models.ExclusionRule
+	TotalCount  int64
+	Limit       int
+	Offset      int
+	CurrentPage int
+	TotalPages  int
+}
+
+type ExclusionService interface {
+	GetRules(ctx context.Context, limit int, offset int) (ExclusionSearchResult, error)
+	GetRule(ctx context.Context, id string) (models.ExclusionRule, error)
+	CreateRule(ctx context.Context, req models.ExclusionRuleRequest) (models.ExclusionRule, error)
+	ReplaceRule(ctx context.Context, id string, req models.ExclusionRuleRequest) (models.ExclusionRule, error)
+	DeleteRule(ctx context.Context, id string) error
+	Exclusions(ctx context.Context, keyword string) ([]models.ProductID, []string, error)
+}
+
+type ExclusionServiceImpl struct {
+	exclusionRepo elasticsearch.ExclusionRepository
+}
+
+func NewExclusionService(exclusionRepo elasticsearch.ExclusionRepository) *ExclusionServiceImpl {
+	return &ExclusionServiceImpl{
+		exclusionRepo: exclusionRepo,
+	}
+}
+
+func (s *ExclusionServiceImpl) GetRules(ctx context.Context, limit int, offset int) (ExclusionSearchResult, error) {
+	rules, total, err := s.exclusionRepo.FindRules(ctx, limit, offset)
+	if err != nil {
+		return ExclusionSearchResult{}, err
+	}
+
+	// Calculate page info
+	currentPage := 1
+	if limit > 0 {
+		currentPage = (offset / limit) + 1
+	}
+
+	totalPages := 1
+	if limit > 0 && total > 0 {
+		totalPages = int(math.Ceil(float64(total) / float64(limit)))
+	}
+
+	return ExclusionSearchResult{
+		Rules:       rules,
+		TotalCount:  total,
+		Limit:       limit,
+		Offset:      offset,
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+func (s *ExclusionServiceImpl) GetRule(ctx context.Context, id string) (models.ExclusionRule, error) {
+	return s.exclusionRepo.GetRule(ctx, id)
+}
+
+func (s *ExclusionServiceImpl) CreateRule(ctx context.Context, req models.ExclusionRuleRequest) (models.ExclusionRule, error) {
+	rule, err := buildExclusionRule(req)
+	if err != nil {
+		return models.ExclusionRule{}, err
+	}
+
+	return s.exclusionRepo.SaveRule(ctx, rule)
+}
+
+// ReplaceRule replaces an existing rule. Returns common.ErrNotFound if it doesn't exist.
+func (s *ExclusionServiceImpl) ReplaceRule(ctx context.Context, id string, req models.ExclusionRuleRequest) (models.ExclusionRule, error) {
+	if _, err := s.exclusionRepo.GetRule(ctx, id); err != nil {
+		return models.ExclusionRule{}, err
+	}
+
+	rule, err := buildExclusionRule(req)
+	if err != nil {
+		return models.ExclusionRule{}, err
+	}
+	rule.ID = id
+
+	return s.exclusionRepo.SaveRule(ctx, rule)
+}
+
+func (s *ExclusionServiceImpl) DeleteRule(ctx context.Context, id string) error {
+	return s.exclusionRepo.DeleteRule(ctx, id)
+}
+
+// Exclusions returns the product IDs and companies hidden from a search, merging global and keyword rules
+func (s *ExclusionServiceImpl) Exclusions(ctx context.Context, keyword string) ([]models.ProductID, []string, error) {
+	rules, err := s.exclusionRepo.FindRulesForKeyword(ctx, models.NormalizeKeyword(keyword))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []models.ProductID
+	var companies []string
+	for _, rule := range rules {
+		ids = append(ids, rule.ProductIDs...)
+		companies = append(companies, rule.Companies...)
+	}
+	return ids, companies, nil
+}
+
+// buildExclusionRule validates a rule request and normalizes its keyword and IDs
+func buildExclusionRule(req models.ExclusionRuleRequest) (models.ExclusionRule, error) {
+	rule := models.ExclusionRule{
+		Keyword:   models.NormalizeKeyword(req.Keyword),
+		Reason:    req.Reason,
+		UpdatedAt: time.Now(),
+	}
+
+	for _, rawID := range req.ProductIDs {
+		id, err := parseID(string(rawID))
+		if err != nil {
+			return models.ExclusionRule{}, err
+		}
+		rule.ProductIDs = append(rule.ProductIDs, id)
+	}
+
+	for _, company := range req.Companies {
+		if company = strings.TrimSpace(company); company != "" {
+			rule.Companies = append(rule.Companies, company)
+		}
+	}
+
+	if len(rule.ProductIDs) == 0 && len(rule.Companies) == 0 {
+		return models.ExclusionRule{}, fmt.Errorf("%w: at least one product ID or company is required", common.ErrValidation)
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,818 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/enrichment"
+	"elasticsearch/internal/importer"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goelasticsearch "github.com/elastic/go-elasticsearch/v8"
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+type ImportService interface {
+	RunImport(ctx context.Context, source string, triggeredBy string) (models.ImportRun, error)
+	RunStagedImport(ctx context.Context, source string, triggeredBy string, targetIndex string, promote bool) (models.ImportRun, error)
+	StartImport(source string, triggeredBy string) (models.ImportJob, error)
+	StartUploadedImport(filename string, content io.Reader, triggeredBy string) (models.ImportJob, error)
+	ImportProgress() (models.ImportProgress, error)
+	ImportJob(id string) (models.ImportJob, error)
+	CancelImportJob(id string) (models.ImportJob, error)
+	Reindex(ctx context.Context) (ReindexRun, error)
+}
+
+// ReindexRun summarizes a rebuild of the live index with the current mapping
+type ReindexRun struct {
+	// Index is the index built with the current mapping, Previous the indices it replaced behind the alias
+	Index    string
+	Previous []string
+	elasticsearch.ReindexResult
+	Promoted bool
+}
+
+// maxImportJobs is the number of import jobs kept; older ones are forgotten, their runs staying in the import
+// history
+const maxImportJobs = 100
+
+// importJob is an import started in the background and the cancellation of its context
+type importJob struct {
+	job    models.ImportJob
+	cancel context.CancelFunc
+}
+
+type ImportServiceImpl struct {
+	es          *goelasticsearch.Client
+	cfg         *config.Config
+	historyRepo elasticsearch.ImportHistoryRepository
+	publisher   importer.ChangePublisher
+	aggCache    *AggregationCache
+	cache       *ProductCache
+	suggest     elasticsearch.SuggestIndexRepository
+
+	mu      sync.Mutex
+	running bool
+	// progress is the progress of the running import, or the outcome of the last one
+	progress *models.ImportProgress
+	// jobs holds the imports started in the background by ID, jobOrder their IDs oldest first
+	jobs     map[string]*importJob
+	jobOrder []string
+}
+
+func NewImportService(es *goelasticsearch.Client, cfg *config.Config, historyRepo elasticsearch.ImportHistoryRepository) *ImportServiceImpl {
+	return &ImportServiceImpl{
+		es:          es,
+		cfg:         cfg,
+		historyRepo: historyRepo,
+		jobs:        make(map[string]*importJob),
+	}
+}
+
+// SetPublisher attaches the change publisher notified after every imported batch
+func (s *ImportServiceImpl) SetPublisher(publisher importer.ChangePublisher) {
+	s.publisher = publisher
+}
+
+// SetAggregationCache attaches the aggregation cache invalidated when an import changes the live catalog
+func (s *ImportServiceImpl) SetAggregationCache(aggCache *AggregationCache) {
+	s.aggCache = aggCache
+}
+
+// SetProductCache attaches the product cache cleared when an import changes the live catalog
+func (s *ImportServiceImpl) SetProductCache(cache *ProductCache) {
+	s.cache = cache
+}
+
+// SetSuggestIndex attaches the suggest index rebuilt whenever an import or reindex changes the live catalog
+func (s *ImportServiceImpl) SetSuggestIndex(suggest elasticsearch.SuggestIndexRepository) {
+	s.suggest = suggest
+}
+
+// StartImport runs an import in the background and returns its job. Only one import runs at a time;
+// a second request while one is running fails with common.ErrConflict.
+func (s *ImportServiceImpl) StartImport(source string, triggeredBy string) (models.ImportJob, error) {
+	if source == "" {
+		return models.ImportJob{}, fmt.Errorf("%w: source is required", common.ErrValidation)
+	}
+	// Local paths would read the server's files and sql: queries would run against its database
+	if !importer.IsRemoteSource(source) {
+		return models.ImportJob{}, fmt.Errorf("%w: only Google Sheets, s3:// and gs:// sources can be imported through the API; upload local files, and run file and sql: imports from the command line",
+			common.ErrValidation)
+	}
+
+	if err := s.reserve(); err != nil {
+		return models.ImportJob{}, err
+	}
+	return s.start(source, triggeredBy, nil), nil
+}
+
+// StartUploadedImport saves an uploaded file to IMPORT_UPLOAD_DIR and imports it in the background like StartImport.
+// The format is told by the extension of filename; the saved copy is removed once the import finished.
+func (s *ImportServiceImpl) StartUploadedImport(filename string, content io.Reader, triggeredBy string) (models.ImportJob, error) {
+	// Keep the base name of the client's path, whatever its separator; a '#' would select a worksheet
+	name := filepath.Base(strings.ReplaceAll(filename, `\`, "/"))
+	name = strings.ReplaceAll(name, "#", "_")
+	if !importer.IsFileSource(name) {
+		return models.ImportJob{}, fmt.Errorf("%w: unsupported import file %q, expected .csv, .ndjson, .jsonl, .parquet or .xlsx",
+			common.ErrValidation, filename)
+	}
+
+	// Refuse the upload before saving it while an import is running
+	if err := s.reserve(); err != nil {
+		return models.ImportJob{}, err
+	}
+	source, err := saveUpload(s.cfg.Import.UploadDir, name, content)
+	if err != nil {
+		s.release()
+		return models.ImportJob{}, err
+	}
+
+	return s.start(source, triggeredBy, func() {
+		if err := os.RemoveAll(filepath.Dir(source)); err != nil {
+			fiberlog.Warnf("Failed to remove uploaded import %s: %v", source, err)
+		}
+	}), nil
+}
+
+// reserve marks an import as running, failing with common.ErrConflict if one already is
+func (s *ImportServiceImpl) reserve() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return fmt.Errorf("%w: an import is already running", common.ErrConflict)
+	}
+	s.running = true
+	return nil
+}
+
+// release marks the running import as finished
+func (s *ImportServiceImpl) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+}
+
+// start runs the import reserved with reserve in the background as a new job, calling cleanup, if not nil, once
+// it finished
+func (s *ImportServiceImpl) start(source string, triggeredBy string, cleanup func()) models.ImportJob {
+	// Jobs are identified like import history runs, by their start time
+	now := time.Now()
+	job := models.ImportJob{
+		ID:          strconv.FormatInt(now.UnixNano(), 10),
+		Source:      source,
+		TriggeredBy: triggeredBy,
+		Status:      models.ImportStatusQueued,
+		CreatedAt:   now,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.addJob(&importJob{job: job, cancel: cancel})
+
+	go func() {
+		defer s.release()
+		defer cancel()
+		if cleanup != nil {
+			defer cleanup()
+		}
+
+		s.setJobStatus(job.ID, models.ImportStatusRunning)
+		run, err := s.runImport(ctx, job.ID, source, triggeredBy)
+		if err != nil {
+			fiberlog.Errorf("Import of %s failed: %v", source, err)
+		}
+		s.finishJob(job.ID, run, err)
+	}()
+
+	return job
+}
+
+// addJob records a new import job, forgetting the oldest one past maxImportJobs. Only one import runs at a
+// time, so the forgotten job has finished.
+func (s *ImportServiceImpl) addJob(job *importJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.job.ID] = job
+	s.jobOrder = append(s.jobOrder, job.job.ID)
+	if len(s.jobOrder) > maxImportJobs {
+		delete(s.jobs, s.jobOrder[0])
+		s.jobOrder = s.jobOrder[1:]
+	}
+}
+
+// setJobStatus updates the status of an import job
+func (s *ImportServiceImpl) setJobStatus(id string, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.jobs[id]; ok {
+		entry.job.Status = status
+	}
+}
+
+// finishJob records the outcome of an import job. Jobs cancelled before their import started have no run.
+func (s *ImportServiceImpl) finishJob(id string, run models.ImportRun, importErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.jobs[id]
+	if !ok {
+		return
+	}
+
+	job := &entry.job
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	job.RowsRead = run.RowsRead
+	job.RowsSkipped = run.RowsSkipped
+	job.Indexed = run.Indexed
+	job.Failed = run.Failed
+	job.Errors = run.Errors
+	job.Status = run.Status
+	switch {
+	case errors.Is(importErr, context.Canceled):
+		job.Status = models.ImportStatusCancelled
+	case job.Status == "":
+		job.Status = models.ImportStatusFailed
+	}
+	if importErr != nil && len(job.Errors) == 0 {
+		job.Errors = []string{importErr.Error()}
+	}
+}
+
+// ImportJob returns an import job started since startup, failing with common.ErrNotFound for unknown IDs. The
+// counts of a running job are those of its last progress report.
+func (s *ImportServiceImpl) ImportJob(id string) (models.ImportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.jobs[id]
+	if !ok {
+		return models.ImportJob{}, fmt.Errorf("%w: import job %s not found", common.ErrNotFound, id)
+	}
+
+	job := entry.job
+	if job.Status == models.ImportStatusRunning && s.progress != nil && s.progress.ID == id {
+		job.RowsRead = s.progress.RowsRead
+		job.RowsSkipped = s.progress.RowsSkipped
+		job.Indexed = s.progress.Indexed
+		job.Failed = s.progress.Failed
+	}
+	return job, nil
+}
+
+// CancelImportJob cancels a queued or running import job, failing with common.ErrNotFound for unknown IDs and
+// common.ErrConflict for finished jobs. The import stops at its next row; the rows already indexed stay and the run
+// is recorded in the import history as cancelled.
+func (s *ImportServiceImpl) CancelImportJob(id string) (models.ImportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.jobs[id]
+	if !ok {
+		return models.ImportJob{}, fmt.Errorf("%w: import job %s not found", common.ErrNotFound, id)
+	}
+	if status := entry.job.Status; status != models.ImportStatusQueued && status != models.ImportStatusRunning {
+		return models.ImportJob{}, fmt.Errorf("%w: import job %s already %s", common.ErrConflict, id, status)
+	}
+
+	entry.cancel()
+	fiberlog.Infof("Cancelling import job %s of %s", id, entry.job.Source)
+	return entry.job, nil
+}
+
+// saveUpload copies an uploaded import file into a new directory of dir, keeping its name, and returns its path
+func saveUpload(dir string, name string, content io.Reader) (string, error) {
+	uploadDir, err := os.MkdirTemp(dir, "import-upload-")
+	if err != nil {
+		return "", fmt.Errorf("failed to save uploaded import: %w", err)
+	}
+
+	source := filepath.Join(uploadDir, name)
+	file, err := os.Create(source)
+	if err == nil {
+		_, err = io.Copy(file, content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		os.RemoveAll(uploadDir)
+		return "", fmt.Errorf("failed to save uploaded import: %w", err)
+	}
+	return source, nil
+}
+
+// ImportProgress returns the progress of the running import, or the outcome of the last one. Without any import
+// since startup it fails with common.ErrNotFound.
+func (s *ImportServiceImpl) ImportProgress() (models.ImportProgress, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.progress == nil {
+		return models.ImportProgress{}, fmt.Errorf("%w: no import has run since startup", common.ErrNotFound)
+	}
+	return *s.progress, nil
+}
+
+// setProgress records the progress of the running import
+func (s *ImportServiceImpl) setProgress(progress models.ImportProgress) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress = &progress
+}
+
+// RunImport imports the source into the configured index and records the run in the import history.
+// With an index template the products go to a new concrete index and the alias is swapped on success,
+// unless the canary searches diverge from the previous index under the fail policy.
+func (s *ImportServiceImpl) RunImport(ctx context.Context, source string, triggeredBy string) (models.ImportRun, error) {
+	return s.runImport(ctx, "", source, triggeredBy)
+}
+
+// runImport is RunImport recording the run under id, if not empty
+func (s *ImportServiceImpl) runImport(ctx context.Context, id string, source string, triggeredBy string) (models.ImportRun, error) {
+	startedAt := time.Now()
+
+	// Resolve the concrete index this import writes to; imports failing to are recorded against the alias
+	namer, err := elasticsearch.NewIndexNamer(s.cfg.Elasticsearch.Index, s.cfg.Elasticsearch.IndexTemplate)
+	var targetIndex string
+	if err == nil {
+		targetIndex, err = namer.Resolve(ctx, s.es, startedAt)
+	}
+	if err != nil {
+		run := models.ImportRun{
+			ID:          id,
+			Source:      source,
+			Index:       s.cfg.Elasticsearch.Index,
+			TriggeredBy: triggeredBy,
+			StartedAt:   startedAt,
+		}
+		return s.recordImportRun(context.WithoutCancel(ctx), run, importer.Result{}, err), err
+	}
+
+	result, err := s.runPipeline(ctx, id, source, targetIndex, true)
+
+	run := models.ImportRun{
+		ID:          id,
+		Source:      source,
+		Index:       targetIndex,
+		TriggeredBy: triggeredBy,
+		StartedAt:   startedAt,
+	}
+
+	// Compare the freshly built index with the one it replaces before swapping
+	if err == nil && namer.Templated() {
+		run.Checks, err = s.runCanaries(ctx, namer.Alias(), targetIndex)
+		if err == nil {
+			err = checkCanaries(run.Checks)
+		}
+	}
+
+	// Point the alias at the freshly built index
+	if err == nil && namer.Templated() {
+		if err = elasticsearch.SwapAlias(ctx, s.es, namer.Alias(), targetIndex); err == nil {
+			fiberlog.Infof("Alias %s now points to %s", namer.Alias(), targetIndex)
+		}
+	}
+
+	// Persist a summary of the run, whatever its outcome, even once the import was cancelled
+	recordCtx := context.WithoutCancel(ctx)
+	run = s.recordImportRun(recordCtx, run, result, err)
+	s.reportCompleteness(recordCtx, run)
+
+	// Even a failed import may have written to the live catalog
+	s.invalidateCaches()
+	s.RebuildSuggestIndex(recordCtx)
+
+	return run, err
+}
+
+// RunStagedImport imports the source into a staging index and runs the import checks against it.
+// The canary searches compare the staging index with the live one. The live alias is only pointed
+// at the staging index when promote is set and every check passed;
+// a failed check is reported as common.ErrValidation. Staged products aren't published as change events.
+func (s *ImportServiceImpl) RunStagedImport(ctx context.Context, source string, triggeredBy string, targetIndex string, promote bool) (models.ImportRun, error) {
+	if err := validateIndexName(targetIndex); err != nil {
+		return models.ImportRun{}, err
+	}
+
+	alias := s.cfg.Elasticsearch.Index
+	if targetIndex == alias {
+		return models.ImportRun{}, fmt.Errorf("%w: target index must differ from the live index %s", common.ErrValidation, alias)
+	}
+
+	// Never stage into the index currently serving searches
+	live, err := elasticsearch.AliasTargets(ctx, s.es, alias)
+	if err != nil {
+		return models.ImportRun{}, err
+	}
+	if slices.Contains(live, targetIndex) {
+		return models.ImportRun{}, fmt.Errorf("%w: %s is currently behind the alias %s", common.ErrConflict, targetIndex, alias)
+	}
+
+	startedAt := time.Now()
+	result, err := s.runPipeline(ctx, "", source, targetIndex, false)
+
+	run := models.ImportRun{
+		Source:      source,
+		Index:       targetIndex,
+		TriggeredBy: triggeredBy,
+		StartedAt:   startedAt,
+		Staged:      true,
+	}
+	if err == nil {
+		run.Checks, err = s.checkImport(ctx, targetIndex, result)
+	}
+	if err == nil {
+		var canaries []models.ImportCheck
+		if canaries, err = s.runCanaries(ctx, alias, targetIndex); err == nil {
+			run.Checks = append(run.Checks, canaries...)
+			logCanaryAlerts(canaries)
+		}
+	}
+	if err == nil {
+		if failed := failedChecks(run.Checks); len(failed) > 0 {
+			err = fmt.Errorf("%w: staged import failed checks: %s", common.ErrValidation, strings.Join(failed, ", "))
+		}
+	}
+
+	// Point the alias at the verified staging index
+	if err == nil && promote {
+		if err = elasticsearch.SwapAlias(ctx, s.es, alias, targetIndex); err == nil {
+			run.Promoted = true
+			fiberlog.Infof("Alias %s now points to %s", alias, targetIndex)
+			s.invalidateCaches()
+			s.RebuildSuggestIndex(ctx)
+		}
+	}
+
+	// Persist a summary of the run, whatever its outcome
+	run = s.recordImportRun(ctx, run, result, err)
+	s.reportCompleteness(ctx, run)
+
+	return run, err
+}
+
+// Reindex rebuilds the live catalog in a new concrete index created with the current mapping and analysis
+// settings, copying every product from the alias, and swaps the alias to it once every product was copied.
+// Needs an index template, as the new index is named by it; runs are rejected while an import is running.
+// Products written to the live index during the copy are missing from the new one.
+func (s *ImportServiceImpl) Reindex(ctx context.Context) (ReindexRun, error) {
+	namer, err := elasticsearch.NewIndexNamer(s.cfg.Elasticsearch.Index, s.cfg.Elasticsearch.IndexTemplate)
+	if err != nil {
+		return ReindexRun{}, err
+	}
+	if !namer.Templated() {
+		return ReindexRun{}, fmt.Errorf("%w: reindexing needs ELASTICSEARCH_INDEX_TEMPLATE to name the new index", common.ErrValidation)
+	}
+
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return ReindexRun{}, fmt.Errorf("%w: an import is already running", common.ErrConflict)
+	}
+	s.running = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+
+	previous, err := elasticsearch.AliasTargets(ctx, s.es, namer.Alias())
+	if err != nil {
+		return ReindexRun{}, err
+	}
+	if len(previous) == 0 {
+		return ReindexRun{}, fmt.Errorf("%w: no index behind the alias %s", common.ErrNotFound, namer.Alias())
+	}
+
+	targetIndex, err := namer.Resolve(ctx, s.es, time.Now())
+	if err != nil {
+		return ReindexRun{}, err
+	}
+	run := ReindexRun{Index: targetIndex, Previous: previous}
+
+	// A date template may resolve to the live index again; copying it into itself would change nothing
+	if slices.Contains(previous, targetIndex) {
+		return run, fmt.Errorf("%w: %s is already behind the alias %s", common.ErrConflict, targetIndex, namer.Alias())
+	}
+
+	if err := elasticsearch.CreateIndexIfNotExists(s.es, targetIndex, indexAnalysis(s.cfg)); err != nil {
+		return run, fmt.Errorf("failed to create index: %w", err)
+	}
+	if run.ReindexResult, err = elasticsearch.Reindex(ctx, s.es, namer.Alias(), targetIndex); err != nil {
+		return run, err
+	}
+	if len(run.Failures) > 0 {
+		return run, fmt.Errorf("%d of %d products failed to reindex, the alias was left unchanged", len(run.Failures), run.Total)
+	}
+
+	// New indices start with the default result window
+	if window := s.cfg.Elasticsearch.MaxResultWindow; window > 0 {
+		if err := elasticsearch.SetMaxResultWindow(ctx, s.es, targetIndex, window); err != nil {
+			return run, fmt.Errorf("failed to set max result window on %s: %w", targetIndex, err)
+		}
+	}
+
+	if err := elasticsearch.SwapAlias(ctx, s.es, namer.Alias(), targetIndex); err != nil {
+		return run, err
+	}
+	run.Promoted = true
+	fiberlog.Infof("Alias %s now points to %s", namer.Alias(), targetIndex)
+	s.invalidateCaches()
+	s.RebuildSuggestIndex(ctx)
+	return run, nil
+}
+
+// indexAnalysis returns the analysis settings of new product indices
+func indexAnalysis(cfg *config.Config) elasticsearch.IndexAnalysis {
+	return elasticsearch.IndexAnalysis{
+		EdgeNGram: cfg.Elasticsearch.EdgeNGram,
+		MinGram:   cfg.Elasticsearch.EdgeNGramMinGram,
+		MaxGram:   cfg.Elasticsearch.EdgeNGramMaxGram,
+	}
+}
+
+// SourceOptions returns the options import sources are opened with
+func SourceOptions(cfg *config.Config) (importer.SourceOptions, error) {
+	csvOpts, err := importer.ParseCSVOptions(cfg.Import.CSVDelimiter, cfg.Import.CSVQuote, cfg.Import.CSVEncoding)
+	if err != nil {
+		return importer.SourceOptions{}, err
+	}
+	return importer.SourceOptions{
+		Format:                cfg.Import.Format,
+		CSV:                   csvOpts,
+		Sheet:                 cfg.Import.Sheet,
+		AllSheets:             cfg.Import.AllSheets,
+		GoogleCredentialsFile: cfg.Import.GoogleCredentialsFile,
+		S3: importer.S3Options{
+			Region:          cfg.Import.S3Region,
+			AccessKeyID:     cfg.Import.S3AccessKeyID,
+			SecretAccessKey: cfg.Import.S3SecretAccessKey,
+			SessionToken:    cfg.Import.S3SessionToken,
+			Endpoint:        cfg.Import.S3Endpoint,
+		},
+		SQL: importer.SQLOptions{
+			Enabled: cfg.Import.SQLEnabled,
+			Driver:  cfg.Import.SQLDriver,
+			DSN:     cfg.Import.SQLDSN,
+		},
+	}, nil
+}
+
+// invalidateCaches drops the cached product lookups and aggregations of the live catalog
+func (s *ImportServiceImpl) invalidateCaches() {
+	if s.cache != nil {
+		s.cache.Clear()
+	}
+	if s.aggCache != nil {
+		s.aggCache.Invalidate()
+	}
+}
+
+// RebuildSuggestIndex refills the suggest index from the live catalog. The catalog itself is already up to date,
+// so failures are logged, not returned.
+func (s *ImportServiceImpl) RebuildSuggestIndex(ctx context.Context) {
+	if s.suggest == nil {
+		return
+	}
+
+	result, err := s.suggest.Rebuild(ctx, s.cfg.Elasticsearch.Index)
+	if err != nil {
+		fiberlog.Errorf("Failed to rebuild the suggest index: %v", err)
+		return
+	}
+	fiberlog.Infof("Rebuilt the suggest index with %d products in %dms", result.Created, result.TookMs)
+}
+
+// runPipeline resolves the row source and drains it through the import pipeline, reporting its progress under
+// the job id, if any. Imported products are published as change events when publish is set.
+func (s *ImportServiceImpl) runPipeline(ctx context.Context, id string, path string, targetIndex string, publish bool) (importer.Result, error) {
+	pipeline, err := newPipeline(s.es, s.cfg, targetIndex)
+	if err != nil {
+		return importer.Result{}, err
+	}
+
+	sourceOpts, err := SourceOptions(s.cfg)
+	if err != nil {
+		return importer.Result{}, err
+	}
+
+	// Resolve the row sources for the given path, one per worksheet when importing a whole workbook
+	sources, err := importer.OpenSources(ctx, path, sourceOpts)
+	if err != nil {
+		return importer.Result{}, err
+	}
+	defer func() {
+		for _, source := range sources {
+			source.Close()
+		}
+	}()
+
+	// Rows skipped or failed to index go to the reject report
+	if s.cfg.Import.RejectReport != "" {
+		report, err := pipeline.OpenRejectReport(s.cfg.Import.RejectReport, sourceOpts.CSV)
+		if err != nil {
+			return importer.Result{}, err
+		}
+		defer closeRejectReport(report)
+	}
+
+	fiberlog.Info("📥 Importing spreadsheet from", path, "with index:", targetIndex)
+	s.setProgress(models.ImportProgress{ID: id, Source: path, Index: targetIndex, Status: models.ImportStatusRunning, StartedAt: time.Now()})
+	pipeline.SetProgress(time.Duration(s.cfg.Import.ProgressIntervalSec)*time.Second, func(progress models.ImportProgress) {
+		progress.ID = id
+		s.setProgress(progress)
+	})
+	if s.publisher != nil && publish {
+		pipeline.SetPublisher(s.publisher)
+	}
+
+	var result importer.Result
+	if sourceOpts.AllSheets {
+		result, err = pipeline.RunAll(ctx, sources)
+	} else {
+		result, err = pipeline.Run(ctx, sources[0])
+	}
+	if err != nil {
+		return result, err
+	}
+
+	// New indices start with the default result window
+	if window := s.cfg.Elasticsearch.MaxResultWindow; window > 0 {
+		if err := elasticsearch.SetMaxResultWindow(ctx, s.es, targetIndex, window); err != nil {
+			return result, fmt.Errorf("failed to set max result window on %s: %w", targetIndex, err)
+		}
+	}
+	return result, nil
+}
+
+// newPipeline builds the import pipeline of the configuration: its import mode, duplicate policy, batch size,
+// failure limit, enrichment chain, document limit, column mapping and index analysis
+func newPipeline(es *goelasticsearch.Client, cfg *config.Config, targetIndex string) (*importer.Pipeline, error) {
+	// Build the enrichment chain applied before indexing
+	enricher, err := enrichment.NewChain(cfg.Enrichment.Chain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Oversized rows are truncated or skipped after enrichment
+	limit, err := models.NewDocumentLimit(cfg.Document.MaxBytes, cfg.Document.OversizePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read product fields from the mapped columns
+	var mapping importer.Mapping
+	if cfg.Import.MappingFile != "" {
+		if mapping, err = importer.LoadMapping(cfg.Import.MappingFile); err != nil {
+			return nil, err
+		}
+	}
+
+	// Upserts keep the fields the source doesn't have
+	action, err := importer.ModeAction(cfg.Import.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicatePolicy, err := importer.ParseDuplicatePolicy(cfg.Import.DuplicatePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	idPolicy, err := importer.ParseIDPolicy(cfg.Import.IDPolicy)
+	if err != nil {
+		return nil, err
+	}
+	// Upserts address existing products by ID
+	if idPolicy == importer.IDsElasticsearch && cfg.Import.Mode == importer.ModeUpsert {
+		return nil, fmt.Errorf("the %s id policy can't be combined with the %s import mode", idPolicy, importer.ModeUpsert)
+	}
+
+	if cfg.Import.BatchSize < 1 {
+		return nil, fmt.Errorf("invalid import batch size %d, expected at least 1", cfg.Import.BatchSize)
+	}
+	if cfg.Import.FailOnError < 0 {
+		return nil, fmt.Errorf("invalid import failure limit %d, expected 0 or more", cfg.Import.FailOnError)
+	}
+
+	pipeline := importer.NewPipeline(es, targetIndex, enricher)
+	pipeline.SetBulkAction(action)
+	pipeline.SetDuplicatePolicy(duplicatePolicy)
+	pipeline.SetIDPolicy(idPolicy)
+	pipeline.SetBatchSize(cfg.Import.BatchSize)
+	pipeline.SetMaxFailures(cfg.Import.FailOnError)
+	pipeline.SetDocumentLimit(limit)
+	pipeline.SetMapping(mapping)
+	pipeline.SetIndexAnalysis(indexAnalysis(cfg))
+	return pipeline, nil
+}
+
+// DryRunImport validates every row of an import source the way an import into targetIndex, or the configured index
+// if empty, would, without connecting to Elasticsearch
+func DryRunImport(ctx context.Context, cfg *config.Config, path string, targetIndex string) (importer.DryRunReport, error) {
+	if targetIndex == "" {
+		targetIndex = cfg.Elasticsearch.Index
+	}
+	pipeline, err := newPipeline(nil, cfg, targetIndex)
+	if err != nil {
+		return importer.DryRunReport{}, err
+	}
+
+	sourceOpts, err := SourceOptions(cfg)
+	if err != nil {
+		return importer.DryRunReport{}, err
+	}
+	sources, err := importer.OpenSources(ctx, path, sourceOpts)
+	if err != nil {
+		return importer.DryRunReport{}, err
+	}
+	defer func() {
+		for _, source := range sources {
+			source.Close()
+		}
+	}()
+
+	if cfg.Import.RejectReport != "" {
+		report, err := pipeline.OpenRejectReport(cfg.Import.RejectReport, sourceOpts.CSV)
+		if err != nil {
+			return importer.DryRunReport{}, err
+		}
+		defer closeRejectReport(report)
+	}
+
+	return pipeline.DryRun(ctx, sources)
+}
+
+// closeRejectReport closes the reject report of an import and logs how many rows were written to it. The import
+// itself already happened, so failing to write the report is only logged.
+func closeRejectReport(report *importer.RejectReport) {
+	if err := report.Close(); err != nil {
+		fiberlog.Errorf("%v", err)
+		return
+	}
+	fiberlog.Infof("Wrote %d rejected rows to %s", report.Rows(), report.Path())
+}
+
+// recordImportRun stores the import summary in the import history index
+func (s *ImportServiceImpl) recordImportRun(ctx context.Context, run models.ImportRun, result importer.Result, importErr error) models.ImportRun {
+	run.FinishedAt = time.Now()
+	run.DurationMs = run.FinishedAt.Sub(run.StartedAt).Milliseconds()
+	run.RowsRead = result.RowsRead
+	run.RowsSkipped = result.RowsSkipped
+	run.Indexed = result.Indexed
+	run.Failed = result.Failed
+	run.Errors = result.Errors
+	run.Sheets = result.Sheets
+	run.DuplicateIDs = result.DuplicateIDs
+	run.NearDuplicates = result.NearDuplicates
+	run.Duplicates = result.Duplicates
+	run.Status = models.ImportStatusSucceeded
+	if importErr != nil {
+		run.Status = models.ImportStatusFailed
+		if errors.Is(importErr, context.Canceled) {
+			run.Status = models.ImportStatusCancelled
+		}
+		if len(run.Errors) == 0 {
+			run.Errors = []string{importErr.Error()}
+		}
+	}
+
+	// The progress of the run ends with its outcome
+	progress := models.ImportProgress{
+		ID:          run.ID,
+		Source:      run.Source,
+		Index:       run.Index,
+		Status:      run.Status,
+		StartedAt:   run.StartedAt,
+		RowsRead:    run.RowsRead,
+		RowsSkipped: run.RowsSkipped,
+		Indexed:     run.Indexed,
+		Failed:      run.Failed,
+	}
+	if elapsed := run.FinishedAt.Sub(run.StartedAt).Seconds(); elapsed > 0 {
+		progress.RowsPerSec = float64(run.RowsRead) / elapsed
+	}
+	s.setProgress(progress)
+
+	saved, err := s.historyRepo.SaveImportRun(ctx, run)
+	if err != nil {
+		fiberlog.Warnf("Failed to record import history: %v", err)
+		return run
+	}
+
+	// Keep per-product fingerprints so later imports can be diffed against this one
+	if err := s.historyRepo.SaveFingerprints(ctx, saved.ID, result.Fingerprints); err != nil {
+		fiberlog.Warnf("Failed to record import fingerprints: %v", err)
+		return saved
+	}
+	fiberlog.Infof("Recorded import run %s", saved.ID)
+	return saved
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// Canary failure policies
+const (
+	CanaryOnFailureFail  = "fail"
+	CanaryOnFailureAlert = "alert"
+)
+
+// canaryResult is the top of a canary search on one index
+type canaryResult struct {
+	ids   []models.ProductID
+	count int64
+}
+
+// runCanaries searches every canary keyword on the indices currently behind the alias and on
+// targetIndex, and returns one check per keyword comparing the top-K overlap and the hit count.
+// No checks are returned when canaries are disabled or there is no previous index to compare with.
+// With the alert policy the checks are advisory, so a divergence is reported without failing the import.
+func (s *ImportServiceImpl) runCanaries(ctx context.Context, alias string, targetIndex string) ([]models.ImportCheck, error) {
+	cfg := s.cfg.Canary
+	keywords := canaryKeywords(cfg.Keywords)
+	if len(keywords) == 0 {
+		return nil, nil
+	}
+
+	previous, err := elasticsearch.AliasTargets(ctx, s.es, alias)
+	if err != nil {
+		return nil, err
+	}
+	previous = slices.DeleteFunc(previous, func(index string) bool { return index == targetIndex })
+	if len(previous) == 0 {
+		return nil, nil
+	}
+	slices.Sort(previous)
+
+	// Make the freshly imported products searchable
+	if err := elasticsearch.RefreshIndex(ctx, s.es, targetIndex); err != nil {
+		return nil, err
+	}
+
+	previousRepo := elasticsearch.NewElasticsearchProductRepository(s.es, strings.Join(previous, ","))
+	targetRepo := elasticsearch.NewElasticsearchProductRepository(s.es, targetIndex)
+
+	checks := make([]models.ImportCheck, 0, len(keywords))
+	for _, keyword := range keywords {
+		before, err := canarySearch(ctx, previousRepo, keyword, cfg.TopK)
+		if err != nil {
+			return nil, fmt.Errorf("canary %q on %s: %w", keyword, strings.Join(previous, ","), err)
+		}
+		after, err := canarySearch(ctx, targetRepo, keyword, cfg.TopK)
+		if err != nil {
+			return nil, fmt.Errorf("canary %q on %s: %w", keyword, targetIndex, err)
+		}
+
+		overlap := topKOverlap(before.ids, after.ids)
+		change := countChange(before.count, after.count)
+		checks = append(checks, models.ImportCheck{
+			Name:   "canary:" + keyword,
+			Passed: overlap >= cfg.MinOverlap && change <= cfg.MaxCountChange,
+			Detail: fmt.Sprintf("top %d overlap %.0f%% (min %.0f%%), %d hits vs %d before (%.1f%% change, max %.1f%%)",
+				cfg.TopK, overlap*100, cfg.MinOverlap*100, after.count, before.count, change*100, cfg.MaxCountChange*100),
+			Advisory: cfg.OnFailure == CanaryOnFailureAlert,
+		})
+	}
+	return checks, nil
+}
+
+// checkCanaries logs advisory canary failures and reports failing canaries as common.ErrValidation
+func checkCanaries(checks []models.ImportCheck) error {
+	logCanaryAlerts(checks)
+	if failed := failedChecks(checks); len(failed) > 0 {
+		return fmt.Errorf("%w: canary searches diverged from the previous index: %s", common.ErrValidation, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// logCanaryAlerts warns about the canaries that diverged under the alert policy
+func logCanaryAlerts(checks []models.ImportCheck) {
+	for _, check := range checks {
+		if check.Advisory && !check.Passed {
+			fiberlog.Warnf("Canary %s diverged: %s", strings.TrimPrefix(check.Name, "canary:"), check.Detail)
+		}
+	}
+}
+
+// canarySearch runs a canary keyword the way a client search would
+func canarySearch(ctx context.Context, repo *elasticsearch.ElasticsearchProductRepository, keyword string, topK int) (canaryResult, error) {
+	result, err := repo.FindProducts(ctx, models.ProductSearchParams{Keyword: keyword, Limit: topK})
+	if err != nil {
+		return canaryResult{}, err
+	}
+	ids := make([]models.ProductID, 0, len(result.Products))
+	for _, product := range result.Products {
+		ids = append(ids, product.ID)
+	}
+	return canaryResult{ids: ids, count: result.TotalCount}, nil
+}
+
+// canaryKeywords trims the configured keywords and drops empty entries
+func canaryKeywords(configured []string) []string {
+	var keywords []string
+	for _, keyword := range configured {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+// topKOverlap returns the share of the previous top hits still in the new top hits.
+// Two empty results fully overlap.
+func topKOverlap(before, after []models.ProductID) float64 {
+	if len(before) == 0 {
+		if len(after) == 0 {
+			return 1
+		}
+		return 0
+	}
+	kept := 0
+	for _, id := range before {
+		if slices.Contains(after, id) {
+			kept++
+		}
+	}
+	return float64(kept) / float64(len(before))
+}
+
+// countChange returns the relative change between two hit counts
+func countChange(before, after int64) float64 {
+	if before == 0 {
+		if after == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return math.Abs(float64(after-before)) / float64(before)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/completeness"
+	"elasticsearch/internal/importer"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"fmt"
+	"time"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
+)
+
+// checkImport runs the verification and data-quality checks on an import loaded into index
+func (s *ImportServiceImpl) checkImport(ctx context.Context, index string, result importer.Result) ([]models.ImportCheck, error) {
+	limits := s.cfg.Import
+	var checks []models.ImportCheck
+	add := func(name string, passed bool, format string, args ...interface{}) {
+		checks = append(checks, models.ImportCheck{Name: name, Passed: passed, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	add("rows_read", result.RowsRead > 0, "%d rows read", result.RowsRead)
+
+	failedRatio := ratio(result.Failed, result.RowsRead)
+	add("failed_rows", failedRatio <= limits.MaxFailedRatio,
+		"%d of %d rows failed to index (%.1f%%, max %.1f%%)", result.Failed, result.RowsRead, failedRatio*100, limits.MaxFailedRatio*100)
+
+	skippedRatio := ratio(result.RowsSkipped, result.RowsRead)
+	add("skipped_rows", skippedRatio <= limits.MaxSkippedRatio,
+		"%d of %d rows skipped (%.1f%%, max %.1f%%)", result.RowsSkipped, result.RowsRead, skippedRatio*100, limits.MaxSkippedRatio*100)
+
+	// Every product that was indexed must be searchable in the staging index
+	staged, err := elasticsearch.CountDocuments(ctx, s.es, index)
+	if err != nil {
+		return nil, err
+	}
+	imported := int64(len(result.Fingerprints))
+	add("document_count", staged == imported, "%d documents in %s, %d distinct products imported", staged, index, imported)
+
+	// Guard against truncated spreadsheets replacing a much larger catalog
+	live, err := elasticsearch.CountDocuments(ctx, s.es, s.cfg.Elasticsearch.Index)
+	if err != nil {
+		return nil, err
+	}
+	if live == 0 {
+		add("catalog_shrink", true, "no live documents to compare against")
+	} else {
+		shrink := 1 - float64(staged)/float64(live)
+		add("catalog_shrink", shrink <= limits.MaxShrinkRatio,
+			"%d documents staged vs %d live (%.1f%% fewer, max %.1f%%)", staged, live, max(shrink, 0)*100, limits.MaxShrinkRatio*100)
+	}
+
+	return checks, nil
+}
+
+// reportCompleteness checks the products of a successful import against the completeness rules and
+// notifies the configured webhook of the failing IDs. It is best effort: failures are only logged.
+func (s *ImportServiceImpl) reportCompleteness(ctx context.Context, run models.ImportRun) {
+	cfg := s.cfg.Completeness
+	if cfg.WebhookURL == "" || run.Status != models.ImportStatusSucceeded {
+		return
+	}
+
+	fields, err := models.ParseRequiredFields(cfg.RequiredFields)
+	if err != nil {
+		fiberlog.Warnf("Skipping completeness check: %v", err)
+		return
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	rules, err := elasticsearch.FindIncompleteProducts(ctx, s.es, run.Index, fields, cfg.MaxReportedIDs)
+	if err != nil {
+		fiberlog.Warnf("Completeness check of %s failed: %v", run.Index, err)
+		return
+	}
+
+	report := models.CompletenessReport{
+		ImportRunID: run.ID,
+		Source:      run.Source,
+		Index:       run.Index,
+		CheckedAt:   time.Now(),
+		Rules:       rules,
+	}
+	if report.Complete() {
+		fiberlog.Infof("Every product in %s passed the completeness rules", run.Index)
+		return
+	}
+
+	if err := completeness.NewWebhook(cfg.WebhookURL).Notify(ctx, report); err != nil {
+		fiberlog.Warnf("Failed to send completeness report: %v", err)
+		return
+	}
+	fiberlog.Infof("Sent completeness report for import run %s", run.ID)
+}
+
+// failedChecks returns the names of the checks that didn't pass; advisory checks never fail an import
+func failedChecks(checks []models.ImportCheck) []string {
+	var failed []string
+	for _, check := range checks {
+		if !check.Passed && !check.Advisory {
+			failed = append(failed, check.Name)
+		}
+	}
+	return failed
+}
+
+// ratio returns part/total, or 0 when total is 0
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
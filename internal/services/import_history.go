@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"math"
+	"sort"
+)
+
+type ImportRunSearchResult struct {
+	Runs        []models.ImportRun
+	TotalCount  int64
+	Limit       int
+	Offset      int
+	CurrentPage int
+	TotalPages  int
+}
+
+type ImportHistoryService interface {
+	GetImportRuns(ctx context.Context, params models.ImportRunSearchParams) (ImportRunSearchResult, error)
+	DiffImports(ctx context.Context, fromRunID, toRunID string) (models.ImportDiff, error)
+}
+
+// maxDiffSamples caps the number of sample IDs returned per diff category
+const maxDiffSamples = 20
+
+type ImportHistoryServiceImpl struct {
+	historyRepo elasticsearch.ImportHistoryRepository
+}
+
+func NewImportHistoryService(historyRepo elasticsearch.ImportHistoryRepository) *ImportHistoryServiceImpl {
+	return &ImportHistoryServiceImpl{
+		historyRepo: historyRepo,
+	}
+}
+
+func (s *ImportHistoryServiceImpl) GetImportRuns(ctx context.Context, params models.ImportRunSearchParams) (ImportRunSearchResult, error) {
+	result, err := s.historyRepo.FindImportRuns(ctx, params)
+	if err != nil {
+		return ImportRunSearchResult{}, err
+	}
+
+	// Calculate page info
+	currentPage := 1
+	if params.Limit > 0 {
+		currentPage = (params.Offset / params.Limit) + 1
+	}
+
+	totalPages := 1
+	if params.Limit > 0 && result.TotalCount > 0 {
+		totalPages = int(math.Ceil(float64(result.TotalCount) / float64(params.Limit)))
+	}
+
+	return ImportRunSearchResult{
+		Runs:        result.Runs,
+		TotalCount:  result.TotalCount,
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+func (s *ImportHistoryServiceImpl) DiffImports(ctx context.Context, fromRunID, toRunID string) (models.ImportDiff, error) {
+	from, err := s.historyRepo.GetFingerprints(ctx, fromRunID)
+	if err != nil {
+		return models.ImportDiff{}, err
+	}
+
+	to, err := s.historyRepo.GetFingerprints(ctx, toRunID)
+	if err != nil {
+		return models.ImportDiff{}, err
+	}
+
+	diff := models.ImportDiff{
+		From:          fromRunID,
+		To:            toRunID,
+		SampleAdded:   []string{},
+		SampleRemoved: []string{},
+		SampleChanged: []string{},
+	}
+
+	var added, removed, changed []string
+	for id, fingerprint := range to {
+		previous, ok := from[id]
+		switch {
+		case !ok:
+			added = append(added, id)
+		case previous != fingerprint:
+			changed = append(changed, id)
+		default:
+			diff.Unchanged++
+		}
+	}
+	for id := range from {
+		if _, ok := to[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+
+	diff.Added, diff.Removed, diff.Changed = len(added), len(removed), len(changed)
+	diff.SampleAdded = sampleIDs(added)
+	diff.SampleRemoved = sampleIDs(removed)
+	diff.SampleChanged = sampleIDs(changed)
+
+	return diff, nil
+}
+
+// sampleIDs returns a sorted sample of at most maxDiffSamples IDs
+func sampleIDs(ids []string) []string {
+	sort.Strings(ids)
+	if len(ids) > maxDiffSamples {
+		ids = ids[:maxDiffSamples]
+	}
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
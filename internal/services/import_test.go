@@ -0,0 +1,18 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/config"
+)
+
+func TestStartImportRefusesLocalSources(t *testing.T) {
+	service := NewImportService(nil, &config.Config{}, nil)
+	for _, source := range []string{"sql:SELECT * FROM products", "/etc/passwd", "products.xlsx"} {
+		if _, err := service.StartImport(source, "api:admin"); !errors.Is(err, common.ErrValidation) {
+			t.Errorf("StartImport(%q) returned %v, want a validation error", source, err)
+		}
+	}
+}
@@ -3,44 +3,333 @@ package services
 
 import (
 	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/enrichment"
+	"elasticsearch/internal/highlight"
+	"elasticsearch/internal/metrics"
 	"elasticsearch/internal/models"
 	"elasticsearch/internal/storage/elasticsearch"
+	"errors"
+	"fmt"
 	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	fiberlog "github.com/gofiber/fiber/v3/log"
 )
 
+// ErrPartialResults is returned when a search is incomplete and partial results are configured to fail
+var ErrPartialResults = errors.New("search returned partial results")
+
 type ProductSearchResult struct {
-	Products    []models.Product
-	TotalCount  int64
-	Limit       int
-	Offset      int
-	CurrentPage int
-	TotalPages  int
+	Products   []models.Product
+	TotalCount int64
+	// TotalIsLowerBound is set when TotalCount stopped at the track_total_hits cap
+	TotalIsLowerBound bool
+	Limit             int
+	Offset            int
+	CurrentPage       int
+	TotalPages        int
+	// NextCursor continues a cursor-paginated search; empty on the last page
+	NextCursor string
+	// PIT is the token pinning the next page of a pit session
+	PIT      string
+	Warnings []string
+	TookMs   int64
+	// MaxScore is the highest score of any hit; nil when hits aren't scored
+	MaxScore *float64
+	TimedOut bool
+	// Facets holds the value counts of every requested facet
+	Facets map[string][]models.FacetBucket
+	// Suggestions are corrected spellings of a keyword that matched nothing
+	Suggestions []string
+	// Query is the search request body sent to Elasticsearch
+	Query []byte
 }
 
 type ProductService interface {
 	GetProducts(ctx context.Context, params models.ProductSearchParams) (ProductSearchResult, error)
+	CreateProduct(ctx context.Context, req models.CreateProductRequest) (models.Product, error)
+	DeleteProduct(ctx context.Context, rawID string) error
+	GetProductByID(ctx context.Context, rawID string) (models.Product, error)
+	GetProductsByIDs(ctx context.Context, ids []models.ProductID) (models.BatchGetResult, error)
+	BulkCreateProducts(ctx context.Context, reqs []models.CreateProductRequest, allOrNothing bool) (models.BulkResult, error)
+	UpdateProduct(ctx context.Context, rawID string, req models.UpdateProductRequest) (models.Product, error)
+	ReplaceProduct(ctx context.Context, rawID string, req models.CreateProductRequest, upsert bool) (models.Product, bool, error)
+	SoftDeleteProduct(ctx context.Context, rawID string) (models.Product, error)
+	ClosePIT(ctx context.Context, pit string) error
+	CountProducts(ctx context.Context, params models.ProductSearchParams) (int64, error)
+	ExportProducts(ctx context.Context, params models.ProductSearchParams, fn func(products []models.Product) error) error
+	AttributeNames(ctx context.Context, params models.ProductSearchParams) ([]string, error)
+	GetFacets(ctx context.Context, params models.ProductSearchParams) (models.ProductFacets, error)
+	SampleProducts(ctx context.Context, params models.ProductSearchParams, size int, seed *int64) ([]models.Product, error)
+	GroupProducts(ctx context.Context, params models.ProductSearchParams, groups, perGroup int) (models.ProductGroups, error)
+	DistinctValues(ctx context.Context, params models.ProductSearchParams, field string, size int) (models.DistinctValues, error)
+	RelatedGenerics(ctx context.Context, params models.ProductSearchParams, size int) (models.SignificantTerms, error)
+	SuggestProducts(ctx context.Context, prefix, company string, size int) ([]models.Suggestion, error)
+	TypeaheadProducts(ctx context.Context, prefix string, size int) ([]models.Product, error)
+	TypeaheadGroups(ctx context.Context, prefix string, size int) (models.TypeaheadGroups, error)
+}
+
+// MaxBulkItems is the maximum number of products accepted in a single bulk request
+const MaxBulkItems = 1000
+
+// MaxSampleSize is the maximum number of products returned by a random sample
+const MaxSampleSize = 100
+
+// MaxFacetSize is the maximum number of values counted per facet
+const MaxFacetSize = 1000
+
+// MaxGroups is the maximum number of companies returned by a grouped search
+const MaxGroups = 100
+
+// MaxProductsPerGroup is the maximum number of products returned per company by a grouped search
+const MaxProductsPerGroup = 20
+
+// MaxRelatedTerms is the maximum number of significant values returned by a related values search
+const MaxRelatedTerms = 100
+
+// DidYouMeanSize is the number of corrected spellings proposed when a keyword matches nothing
+const DidYouMeanSize = 3
+
+// MaxSuggestions is the maximum number of product names or products returned by an autocomplete or typeahead
+// request
+const MaxSuggestions = 20
+
+// PopularPrefixLength is the length below which a prefix is completed with popular searches first; product names
+// completing one or two characters are mostly arbitrary
+const PopularPrefixLength = 3
+
+// MaxBatchGetItems is the maximum number of IDs accepted in a single batch get
+const MaxBatchGetItems = 100
+
+// ChangePublisher records product changes for delivery to downstream systems
+type ChangePublisher interface {
+	Publish(ctx context.Context, events ...models.ChangeEvent) error
+}
+
+// IDGenerator assigns the ID of a product created without one
+type IDGenerator interface {
+	NextID(ctx context.Context) (models.ProductID, error)
+}
+
+// PinLookup resolves the products curated to the top of a keyword search
+type PinLookup interface {
+	PinnedIDs(ctx context.Context, keyword string) ([]models.ProductID, error)
+}
+
+// ExclusionLookup resolves the products and companies hidden from a search
+type ExclusionLookup interface {
+	Exclusions(ctx context.Context, keyword string) ([]models.ProductID, []string, error)
+}
+
+// QueryRewriter rewrites the keyword of a search before the query is built and returns the names of the
+// rules that applied
+type QueryRewriter interface {
+	Rewrite(params *models.ProductSearchParams) []string
+}
+
+// SearchLogger records completed searches for analytics; it must not block the search path
+type SearchLogger interface {
+	LogSearch(entry models.SearchLogEntry)
+}
+
+// PopularQueries looks up the keywords starting with a prefix that users searched most often with results
+type PopularQueries interface {
+	PopularQueries(ctx context.Context, prefix string, size int) ([]models.QueryCount, error)
 }
 
 type ProductServiceImpl struct {
 	productRepo elasticsearch.ProductRepository
+	searchCfg   config.SearchConfig
+	enricher    enrichment.DocumentEnricher
+	publisher   ChangePublisher
+	pins        PinLookup
+	exclusions  ExclusionLookup
+	searchLog   SearchLogger
+	popular     PopularQueries
+	suggest     elasticsearch.SuggestIndexRepository
+	cache       *ProductCache
+	aggCache    *AggregationCache
+	limit       models.DocumentLimit
+	ids         IDGenerator
+	rewriter    QueryRewriter
 }
 
-func NewProductService(productRepo elasticsearch.ProductRepository) *ProductServiceImpl {
+func NewProductService(productRepo elasticsearch.ProductRepository, searchCfg config.SearchConfig, enricher enrichment.DocumentEnricher) *ProductServiceImpl {
 	return &ProductServiceImpl{
 		productRepo: productRepo,
+		searchCfg:   searchCfg,
+		enricher:    enricher,
 	}
 }
 
+// SetAggregationCache attaches the cache of facet, distinct value and related generics results
+func (s *ProductServiceImpl) SetAggregationCache(aggCache *AggregationCache) {
+	s.aggCache = aggCache
+}
+
+// SetPinLookup attaches the curation lookup applied to keyword searches
+func (s *ProductServiceImpl) SetPinLookup(pins PinLookup) {
+	s.pins = pins
+}
+
+// SetExclusionLookup attaches the exclusion rules applied to every search
+func (s *ProductServiceImpl) SetExclusionLookup(exclusions ExclusionLookup) {
+	s.exclusions = exclusions
+}
+
+// SetSearchLogger attaches the analytics logger notified after every successful search
+func (s *ProductServiceImpl) SetSearchLogger(searchLog SearchLogger) {
+	s.searchLog = searchLog
+}
+
+// SetPopularQueries attaches the popular searches offered as autocomplete suggestions
+func (s *ProductServiceImpl) SetPopularQueries(popular PopularQueries) {
+	s.popular = popular
+}
+
+// SetSuggestIndex serves autocomplete suggestions from the dedicated suggest index and mirrors every successful
+// write into it
+func (s *ProductServiceImpl) SetSuggestIndex(suggest elasticsearch.SuggestIndexRepository) {
+	s.suggest = suggest
+}
+
+// SetQueryRewriter attaches the rewrite rules applied to keywords before every search
+func (s *ProductServiceImpl) SetQueryRewriter(rewriter QueryRewriter) {
+	s.rewriter = rewriter
+}
+
+// SetProductCache attaches the read-through cache used by product detail lookups
+func (s *ProductServiceImpl) SetProductCache(cache *ProductCache) {
+	s.cache = cache
+}
+
+// SetPublisher attaches the change publisher notified after every successful write
+func (s *ProductServiceImpl) SetPublisher(publisher ChangePublisher) {
+	s.publisher = publisher
+}
+
+// SetIDGenerator assigns server-side IDs to products created without one;
+// without a generator Elasticsearch assigns them
+func (s *ProductServiceImpl) SetIDGenerator(ids IDGenerator) {
+	s.ids = ids
+}
+
+// SetDocumentLimit caps the size of products written through the service
+func (s *ProductServiceImpl) SetDocumentLimit(limit models.DocumentLimit) {
+	s.limit = limit
+}
+
 func (s *ProductServiceImpl) GetProducts(ctx context.Context, params models.ProductSearchParams) (ProductSearchResult, error) {
+	if params.Index != "" {
+		if err := validateIndexName(params.Index); err != nil {
+			return ProductSearchResult{}, err
+		}
+	}
+
+	// Cursor pages are positioned by the cursor alone
+	if params.Cursor != nil && params.Offset != 0 {
+		return ProductSearchResult{}, fmt.Errorf("%w: offset can't be combined with cursor", common.ErrValidation)
+	}
+	if params.Cursor != nil && params.PIT != "" {
+		return ProductSearchResult{}, fmt.Errorf("%w: pit can't be combined with cursor, which pins its own point in time", common.ErrValidation)
+	}
+
+	// Elasticsearch can't page past its result window; deeper pages must be read with a cursor
+	if window := s.searchCfg.MaxResultWindow; params.Cursor == nil && window > 0 && params.Offset+params.Limit > window {
+		return ProductSearchResult{}, fmt.Errorf("%w: offset+limit %d exceeds the maximum of %d, use cursor=%s to page deeper",
+			common.ErrValidation, params.Offset+params.Limit, window, models.CursorStart)
+	}
+
+	if err := normalizeIDFilters(params.IDs); err != nil {
+		return ProductSearchResult{}, err
+	}
+
+	// Diversified pages are cut from the top hits reordered as a whole, so they are read from the top
+	if params.MaxPerCompany > 0 && params.Cursor != nil {
+		return ProductSearchResult{}, fmt.Errorf("%w: max_per_company can't be combined with cursor", common.ErrValidation)
+	}
+	page := params
+	diversify := params.MaxPerCompany > 0 && params.Offset < DiversityWindow
+	if diversify {
+		params.Offset = 0
+		params.Limit = max(DiversityWindow, page.Offset+page.Limit)
+	}
+
+	// Promote curated products; searches still work when curations can't be loaded
+	if s.pins != nil && params.Keyword != "" {
+		pinned, err := s.pins.PinnedIDs(ctx, params.Keyword)
+		if err != nil {
+			fiberlog.Warnf("Failed to load curation for %q: %v", params.Keyword, err)
+		}
+		params.PinnedIDs = pinned
+	}
+
+	s.applyExclusions(ctx, &params)
+
+	// Curations, exclusions and analytics keep seeing the keyword as typed
+	keyword := params.Keyword
+	s.rewriteQuery(&params)
+
 	// Call repository to get products
 	result, err := s.productRepo.FindProducts(ctx, params)
 	if err != nil {
 		return ProductSearchResult{}, err
 	}
 
-	// Calculate page info
+	// Fail incomplete searches when configured to do so
+	if result.IsPartial() && s.searchCfg.FailOnPartialResults {
+		return ProductSearchResult{}, fmt.Errorf("%w: %s", ErrPartialResults, strings.Join(result.Warnings, "; "))
+	}
+
+	if s.searchLog != nil {
+		s.searchLog.LogSearch(models.SearchLogEntry{
+			Timestamp: time.Now().UTC(),
+			Keyword:   models.NormalizeKeyword(keyword),
+			TotalHits: result.TotalCount,
+			TookMs:    result.TookMs,
+		})
+	}
+
+	// Help users recover from typos; searches still work when no corrections can be proposed
+	var suggestions []string
+	if result.TotalCount == 0 && keyword != "" {
+		suggestions, err = s.productRepo.SpellingSuggestions(ctx, keyword, DidYouMeanSize)
+		if err != nil {
+			fiberlog.Warnf("Failed to suggest spellings for %q: %v", keyword, err)
+		}
+	}
+
+	// Merge near-duplicate hits, keeping the most relevant one
+	products := result.Products
+	if params.Dedupe {
+		products = dedupeProducts(products)
+	}
+
+	// Keep one company from monopolizing the top results
+	if diversify {
+		products = diversifyByCompany(products, page.MaxPerCompany, s.searchCfg.DiversityTopK)
+		products = products[min(page.Offset, len(products)):min(page.Offset+page.Limit, len(products))]
+		params.Offset, params.Limit = page.Offset, page.Limit
+	}
+
+	// Turn raw highlight fragments into the configured output format
+	if params.Highlight {
+		s.formatHighlights(products)
+	}
+
+	// Calculate page info; cursor pages have no page number
 	currentPage := 1
-	if params.Limit > 0 {
+	if params.Cursor != nil {
+		currentPage = 0
+	} else if params.Limit > 0 {
 		currentPage = (params.Offset / params.Limit) + 1
 	}
 
@@ -51,11 +340,979 @@ func (s *ProductServiceImpl) GetProducts(ctx context.Context, params models.Prod
 
 	// Return products with pagination info
 	return ProductSearchResult{
-		Products:    result.Products,
-		TotalCount:  result.TotalCount,
-		Limit:       params.Limit,
-		Offset:      params.Offset,
-		CurrentPage: currentPage,
-		TotalPages:  totalPages,
+		Products:          products,
+		TotalCount:        result.TotalCount,
+		TotalIsLowerBound: result.TotalIsLowerBound,
+		Limit:             params.Limit,
+		Offset:            params.Offset,
+		CurrentPage:       currentPage,
+		TotalPages:        totalPages,
+		NextCursor:        result.NextCursor,
+		PIT:               result.PIT,
+		Warnings:          result.Warnings,
+		TookMs:            result.TookMs,
+		MaxScore:          result.MaxScore,
+		TimedOut:          result.TimedOut,
+		Facets:            result.Facets,
+		Suggestions:       suggestions,
+		Query:             result.Query,
 	}, nil
 }
+
+func (s *ProductServiceImpl) CreateProduct(ctx context.Context, req models.CreateProductRequest) (models.Product, error) {
+	product, err := s.prepareProduct(ctx, req, time.Now())
+	if err != nil {
+		return models.Product{}, err
+	}
+	if err := s.assignID(ctx, &product); err != nil {
+		return models.Product{}, err
+	}
+
+	created, err := s.productRepo.CreateProduct(ctx, product)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	s.invalidate(created.ID)
+	if err := s.publish(ctx, productEvent(models.ChangeCreated, created)); err != nil {
+		return models.Product{}, err
+	}
+	return created, nil
+}
+
+// BulkCreateProducts creates products with the bulk API, reporting the outcome of every item.
+// With allOrNothing nothing is written when an item is invalid, and when Elasticsearch rejects an item the
+// products written by the others are deleted again. Rolling back is best effort: concurrent readers may see
+// the products in between, and the products that couldn't be deleted are listed in the rollback report.
+func (s *ProductServiceImpl) BulkCreateProducts(ctx context.Context, reqs []models.CreateProductRequest, allOrNothing bool) (models.BulkResult, error) {
+	if len(reqs) == 0 {
+		return models.BulkResult{}, fmt.Errorf("%w: no products provided", common.ErrValidation)
+	}
+	if len(reqs) > MaxBulkItems {
+		return models.BulkResult{}, fmt.Errorf("%w: at most %d products per request", common.ErrValidation, MaxBulkItems)
+	}
+
+	// Invalid items are reported individually and never sent to Elasticsearch
+	result := models.BulkResult{}
+	products := make([]models.Product, 0, len(reqs))
+	positions := make([]int, 0, len(reqs))
+	now := time.Now()
+	for i, req := range reqs {
+		product, err := s.prepareProduct(ctx, req, now)
+		if err != nil {
+			result.Add(models.BulkItemResult{Position: i, ID: req.ID, Status: http.StatusBadRequest, Error: err.Error()})
+			continue
+		}
+		if err := s.assignID(ctx, &product); err != nil {
+			result.Add(models.BulkItemResult{Position: i, Status: http.StatusInternalServerError, Error: err.Error()})
+			continue
+		}
+		products = append(products, product)
+		positions = append(positions, i)
+	}
+
+	// An all-or-nothing write with invalid items is refused before anything is written
+	if allOrNothing && result.Failed > 0 {
+		for i, product := range products {
+			result.Add(models.BulkItemResult{Position: positions[i], ID: product.ID, Status: http.StatusFailedDependency, Error: errBulkNotWritten})
+		}
+		sortBulkItems(result.Items)
+		return result, nil
+	}
+
+	written, err := s.productRepo.BulkCreateProducts(ctx, products)
+	if err != nil {
+		return models.BulkResult{}, err
+	}
+
+	var reverted map[models.ProductID]bool
+	if allOrNothing && written.Failed > 0 {
+		result.Rollback, reverted = s.rollbackBulkCreate(ctx, written, positions)
+	}
+
+	// Map item positions back onto the request array
+	var events []models.ChangeEvent
+	for _, item := range written.Items {
+		if item.Error == "" {
+			product := products[item.Position]
+			product.ID = item.ID
+			s.invalidate(product.ID)
+			if reverted[item.ID] {
+				item.RolledBack = true
+			} else {
+				events = append(events, productEvent(models.ChangeCreated, product))
+			}
+		}
+		item.Position = positions[item.Position]
+		result.Add(item)
+	}
+	if err := s.publish(ctx, events...); err != nil {
+		return models.BulkResult{}, err
+	}
+	sortBulkItems(result.Items)
+
+	return result, nil
+}
+
+// errBulkNotWritten is the error of the valid items of an all-or-nothing write refused because of other items
+const errBulkNotWritten = "not written: another item of the all-or-nothing request is invalid"
+
+// rollbackBulkCreate deletes the products written by a bulk create again and reports the outcome of every
+// deletion, positions mapped onto the request array. It returns the IDs no longer stored; products already
+// deleted by someone else count as reverted.
+func (s *ProductServiceImpl) rollbackBulkCreate(ctx context.Context, written models.BulkResult, positions []int) (*models.BulkRollback, map[models.ProductID]bool) {
+	var ids []models.ProductID
+	var idPositions []int
+	for _, item := range written.Items {
+		if item.Error == "" {
+			ids = append(ids, item.ID)
+			idPositions = append(idPositions, positions[item.Position])
+		}
+	}
+
+	rollback := &models.BulkRollback{Items: []models.BulkItemResult{}}
+	reverted := make(map[models.ProductID]bool, len(ids))
+	deleted, err := s.productRepo.BulkDeleteProducts(ctx, ids)
+	if err != nil {
+		fiberlog.Errorf("Failed to roll back bulk create of %d product(s): %v", len(ids), err)
+		for i, id := range ids {
+			rollback.Items = append(rollback.Items, models.BulkItemResult{Position: idPositions[i], ID: id, Status: http.StatusInternalServerError, Error: err.Error()})
+			rollback.Failed++
+		}
+		return rollback, reverted
+	}
+
+	for _, item := range deleted.Items {
+		item.Position = idPositions[item.Position]
+		if item.Error == "" || item.Status == http.StatusNotFound {
+			reverted[item.ID] = true
+			rollback.Reverted++
+		} else {
+			fiberlog.Errorf("Failed to roll back bulk create of product %s: %s", item.ID, item.Error)
+			rollback.Failed++
+		}
+		rollback.Items = append(rollback.Items, item)
+	}
+	sortBulkItems(rollback.Items)
+	return rollback, reverted
+}
+
+// sortBulkItems orders item results by their position in the request array
+func sortBulkItems(items []models.BulkItemResult) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Position < items[j].Position
+	})
+}
+
+// prepareProduct builds a product from a create request, enriches and validates it
+func (s *ProductServiceImpl) prepareProduct(ctx context.Context, req models.CreateProductRequest, now time.Time) (models.Product, error) {
+	product := models.Product{
+		ID:          req.ID,
+		ProductName: req.ProductName,
+		DrugGeneric: req.DrugGeneric,
+		Company:     req.Company,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// Run the enrichment chain before validating and indexing
+	if err := s.enrich(ctx, &product); err != nil {
+		return models.Product{}, err
+	}
+
+	if err := s.applyDocumentLimit(&product); err != nil {
+		return models.Product{}, err
+	}
+
+	if err := validateProduct(product); err != nil {
+		return models.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (s *ProductServiceImpl) GetProductByID(ctx context.Context, rawID string) (models.Product, error) {
+	id, err := parseID(rawID)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	if s.cache == nil {
+		return s.productRepo.GetProductByID(ctx, id)
+	}
+
+	if product, found, ok := s.cache.Get(id); ok {
+		metrics.ProductCacheHits.Add(1)
+		if !found {
+			return models.Product{}, fmt.Errorf("product %s: %w", id, common.ErrNotFound)
+		}
+		return product, nil
+	}
+	metrics.ProductCacheMisses.Add(1)
+
+	generation := s.cache.Generation()
+	product, err := s.productRepo.GetProductByID(ctx, id)
+	switch {
+	case errors.Is(err, common.ErrNotFound):
+		s.cache.SetMissing(id, generation)
+	case err == nil:
+		s.cache.SetProduct(product, generation)
+	}
+	return product, err
+}
+
+// GetProductsByIDs fetches several products at once, answering from the cache where possible.
+// Products are returned in request order without duplicates; unknown IDs are listed as missing.
+func (s *ProductServiceImpl) GetProductsByIDs(ctx context.Context, rawIDs []models.ProductID) (models.BatchGetResult, error) {
+	if len(rawIDs) == 0 {
+		return models.BatchGetResult{}, fmt.Errorf("%w: no ids provided", common.ErrValidation)
+	}
+	if len(rawIDs) > MaxBatchGetItems {
+		return models.BatchGetResult{}, fmt.Errorf("%w: at most %d ids per request", common.ErrValidation, MaxBatchGetItems)
+	}
+
+	ids := make([]models.ProductID, 0, len(rawIDs))
+	seen := make(map[models.ProductID]bool, len(rawIDs))
+	for _, rawID := range rawIDs {
+		id, err := parseID(string(rawID))
+		if err != nil {
+			return models.BatchGetResult{}, err
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	// Resolve what the cache knows and fetch the rest with one request
+	products := make(map[models.ProductID]models.Product, len(ids))
+	var misses []models.ProductID
+	for _, id := range ids {
+		if s.cache != nil {
+			if product, found, ok := s.cache.Get(id); ok {
+				metrics.ProductCacheHits.Add(1)
+				if found {
+					products[id] = product
+				}
+				continue
+			}
+			metrics.ProductCacheMisses.Add(1)
+		}
+		misses = append(misses, id)
+	}
+
+	if len(misses) > 0 {
+		var generation uint64
+		if s.cache != nil {
+			generation = s.cache.Generation()
+		}
+		fetched, err := s.productRepo.GetProductsByIDs(ctx, misses)
+		if err != nil {
+			return models.BatchGetResult{}, err
+		}
+		for _, id := range misses {
+			product, found := fetched[id]
+			if found {
+				products[id] = product
+			}
+			if s.cache == nil {
+				continue
+			}
+			if found {
+				s.cache.SetProduct(product, generation)
+			} else {
+				s.cache.SetMissing(id, generation)
+			}
+		}
+	}
+
+	result := models.BatchGetResult{
+		Products: make([]models.Product, 0, len(ids)),
+		Missing:  []models.ProductID{},
+	}
+	for _, id := range ids {
+		if product, ok := products[id]; ok {
+			result.Products = append(result.Products, product)
+		} else {
+			result.Missing = append(result.Missing, id)
+		}
+	}
+
+	return result, nil
+}
+
+// invalidate drops the cached lookups of products changed through the API and every cached aggregation
+func (s *ProductServiceImpl) invalidate(ids ...models.ProductID) {
+	if s.cache != nil {
+		s.cache.Invalidate(ids...)
+	}
+	if s.aggCache != nil {
+		s.aggCache.Invalidate()
+	}
+}
+
+func (s *ProductServiceImpl) UpdateProduct(ctx context.Context, rawID string, req models.UpdateProductRequest) (models.Product, error) {
+	id, err := parseID(rawID)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	fields, err := s.buildUpdateFields(ctx, req)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	updated, err := s.productRepo.UpdateProduct(ctx, id, fields)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	s.invalidate(id)
+	if err := s.publish(ctx, productEvent(models.ChangeUpdated, updated)); err != nil {
+		return models.Product{}, err
+	}
+	return updated, nil
+}
+
+// ReplaceProduct sets all catalog fields of a product. With upsert the product is created when
+// missing; otherwise a missing product is reported as common.ErrNotFound. Reports whether it was created.
+func (s *ProductServiceImpl) ReplaceProduct(ctx context.Context, rawID string, req models.CreateProductRequest, upsert bool) (models.Product, bool, error) {
+	id, err := parseID(rawID)
+	if err != nil {
+		return models.Product{}, false, err
+	}
+
+	if !req.ID.IsZero() && req.ID != id {
+		return models.Product{}, false, fmt.Errorf("%w: body id %s does not match path id %s", common.ErrValidation, req.ID, id)
+	}
+	req.ID = id
+
+	product, err := s.prepareProduct(ctx, req, time.Now())
+	if err != nil {
+		return models.Product{}, false, err
+	}
+
+	if upsert {
+		saved, created, err := s.productRepo.UpsertProduct(ctx, product)
+		if err != nil {
+			return models.Product{}, false, err
+		}
+
+		eventType := models.ChangeUpdated
+		if created {
+			eventType = models.ChangeCreated
+		}
+		s.invalidate(id)
+		if err := s.publish(ctx, productEvent(eventType, saved)); err != nil {
+			return models.Product{}, false, err
+		}
+		return saved, created, nil
+	}
+
+	updated, err := s.productRepo.UpdateProduct(ctx, id, map[string]interface{}{
+		"product_name": product.ProductName,
+		"drug_generic": product.DrugGeneric,
+		"company":      product.Company,
+		"updated_at":   product.UpdatedAt,
+	})
+	if err != nil {
+		return models.Product{}, false, err
+	}
+
+	s.invalidate(id)
+	if err := s.publish(ctx, productEvent(models.ChangeUpdated, updated)); err != nil {
+		return models.Product{}, false, err
+	}
+	return updated, false, nil
+}
+
+// buildUpdateFields enriches and validates the provided fields of a partial update
+// and returns them as a partial document, stamped with a new updated_at
+func (s *ProductServiceImpl) buildUpdateFields(ctx context.Context, req models.UpdateProductRequest) (map[string]interface{}, error) {
+	if req.ProductName == nil && req.DrugGeneric == nil && req.Company == nil {
+		return nil, fmt.Errorf("%w: no fields to update", common.ErrValidation)
+	}
+
+	// Enrich the provided values through a scratch product
+	var product models.Product
+	if req.ProductName != nil {
+		product.ProductName = *req.ProductName
+	}
+	if req.DrugGeneric != nil {
+		product.DrugGeneric = *req.DrugGeneric
+	}
+	if req.Company != nil {
+		product.Company = *req.Company
+	}
+	if err := s.enrich(ctx, &product); err != nil {
+		return nil, err
+	}
+
+	// Only the provided fields are checked; the stored ones already passed the limit when written
+	if err := s.applyDocumentLimit(&product); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{"updated_at": time.Now()}
+	var empty []string
+	setField := func(name string, provided *string, value string) {
+		if provided == nil {
+			return
+		}
+		if strings.TrimSpace(value) == "" {
+			empty = append(empty, name)
+			return
+		}
+		fields[name] = value
+	}
+	setField("product_name", req.ProductName, product.ProductName)
+	setField("drug_generic", req.DrugGeneric, product.DrugGeneric)
+	setField("company", req.Company, product.Company)
+
+	if len(empty) > 0 {
+		return nil, fmt.Errorf("%w: required fields cannot be empty: %s", common.ErrValidation, strings.Join(empty, ", "))
+	}
+
+	return fields, nil
+}
+
+// SoftDeleteProduct marks a product as deleted so it is excluded from searches while remaining restorable
+func (s *ProductServiceImpl) SoftDeleteProduct(ctx context.Context, rawID string) (models.Product, error) {
+	id, err := parseID(rawID)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	now := time.Now()
+	deleted, err := s.productRepo.UpdateProduct(ctx, id, map[string]interface{}{
+		"deleted_at": now,
+		"updated_at": now,
+	})
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	s.invalidate(id)
+	if err := s.publish(ctx, productEvent(models.ChangeSoftDeleted, deleted)); err != nil {
+		return models.Product{}, err
+	}
+	return deleted, nil
+}
+
+func (s *ProductServiceImpl) DeleteProduct(ctx context.Context, rawID string) error {
+	id, err := parseID(rawID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.productRepo.DeleteProduct(ctx, id); err != nil {
+		return err
+	}
+
+	s.invalidate(id)
+	return s.publish(ctx, models.ChangeEvent{Type: models.ChangeDeleted, ProductID: id, Source: models.ChangeSourceAPI})
+}
+
+// CountProducts counts the products a search with the same keyword and filters would match,
+// without fetching them
+func (s *ProductServiceImpl) CountProducts(ctx context.Context, params models.ProductSearchParams) (int64, error) {
+	if params.Index != "" {
+		if err := validateIndexName(params.Index); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := normalizeIDFilters(params.IDs); err != nil {
+		return 0, err
+	}
+	s.applyExclusions(ctx, &params)
+	s.rewriteQuery(&params)
+
+	return s.productRepo.CountProducts(ctx, params)
+}
+
+// ExportProducts walks every product a search with the same keyword and filters would match, in the order of
+// params.Sort, calling fn with one batch at a time; see ElasticsearchProductRepository.StreamAllProducts.
+// Curations, dedupe and diversity only shape result pages and don't apply.
+func (s *ProductServiceImpl) ExportProducts(ctx context.Context, params models.ProductSearchParams, fn func(products []models.Product) error) error {
+	if params.Index != "" {
+		if err := validateIndexName(params.Index); err != nil {
+			return err
+		}
+	}
+
+	if err := normalizeIDFilters(params.IDs); err != nil {
+		return err
+	}
+	s.applyExclusions(ctx, &params)
+	s.rewriteQuery(&params)
+
+	return s.productRepo.StreamAllProducts(ctx, params, fn)
+}
+
+// AttributeNames returns the names of the product attributes mapped in the index a search reads, in alphabetical
+// order, e.g. to name the attribute columns of an export before its first product is read
+func (s *ProductServiceImpl) AttributeNames(ctx context.Context, params models.ProductSearchParams) ([]string, error) {
+	if params.Index != "" {
+		if err := validateIndexName(params.Index); err != nil {
+			return nil, err
+		}
+	}
+	return s.productRepo.AttributeNames(ctx, params)
+}
+
+// GetFacets counts the values of the requested facets, every facetable field when none is requested, over
+// the products a search with the same keyword and filters would match
+func (s *ProductServiceImpl) GetFacets(ctx context.Context, params models.ProductSearchParams) (models.ProductFacets, error) {
+	if params.Index != "" {
+		if err := validateIndexName(params.Index); err != nil {
+			return models.ProductFacets{}, err
+		}
+	}
+	if params.FacetSize < 1 || params.FacetSize > MaxFacetSize {
+		return models.ProductFacets{}, fmt.Errorf("%w: size must be between 1 and %d", common.ErrValidation, MaxFacetSize)
+	}
+	if len(params.Facets) == 0 {
+		params.Facets = models.FacetableFields
+	}
+
+	if err := normalizeIDFilters(params.IDs); err != nil {
+		return models.ProductFacets{}, err
+	}
+	s.applyExclusions(ctx, &params)
+	s.rewriteQuery(&params)
+
+	return cachedAggregation(s.aggCache, "facets", params, func() (models.ProductFacets, error) {
+		return s.productRepo.FacetProducts(ctx, params)
+	})
+}
+
+// SampleProducts returns up to size random products matching the keyword and filters of params.
+// Products hidden by exclusion rules are never sampled; the score of sampled products is random.
+func (s *ProductServiceImpl) SampleProducts(ctx context.Context, params models.ProductSearchParams, size int, seed *int64) ([]models.Product, error) {
+	if size < 1 || size > MaxSampleSize {
+		return nil, fmt.Errorf("%w: size must be between 1 and %d", common.ErrValidation, MaxSampleSize)
+	}
+	if params.Index != "" {
+		if err := validateIndexName(params.Index); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := normalizeIDFilters(params.IDs); err != nil {
+		return nil, err
+	}
+	s.applyExclusions(ctx, &params)
+	s.rewriteQuery(&params)
+
+	return s.productRepo.SampleProducts(ctx, params, size, seed)
+}
+
+// GroupProducts returns the perGroup best products of each of the groups companies with the most products
+// matching the keyword and filters of params, for side-by-side comparisons of companies
+func (s *ProductServiceImpl) GroupProducts(ctx context.Context, params models.ProductSearchParams, groups, perGroup int) (models.ProductGroups, error) {
+	if groups < 1 || groups > MaxGroups {
+		return models.ProductGroups{}, fmt.Errorf("%w: size must be between 1 and %d", common.ErrValidation, MaxGroups)
+	}
+	if perGroup < 1 || perGroup > MaxProductsPerGroup {
+		return models.ProductGroups{}, fmt.Errorf("%w: per_company must be between 1 and %d", common.ErrValidation, MaxProductsPerGroup)
+	}
+	if params.Index != "" {
+		if err := validateIndexName(params.Index); err != nil {
+			return models.ProductGroups{}, err
+		}
+	}
+
+	if err := normalizeIDFilters(params.IDs); err != nil {
+		return models.ProductGroups{}, err
+	}
+	s.applyExclusions(ctx, &params)
+	s.rewriteQuery(&params)
+
+	return cachedAggregation(s.aggCache, "grouped", []any{params, groups, perGroup}, func() (models.ProductGroups, error) {
+		return s.productRepo.GroupProducts(ctx, params, groups, perGroup)
+	})
+}
+
+// DistinctValues counts the distinct values of a facetable field over the products a search with the same
+// keyword and filters would match, and lists the first size of them alphabetically for dropdowns
+func (s *ProductServiceImpl) DistinctValues(ctx context.Context, params models.ProductSearchParams, field string, size int) (models.DistinctValues, error) {
+	if size < 1 || size > MaxFacetSize {
+		return models.DistinctValues{}, fmt.Errorf("%w: size must be between 1 and %d", common.ErrValidation, MaxFacetSize)
+	}
+	if params.Index != "" {
+		if err := validateIndexName(params.Index); err != nil {
+			return models.DistinctValues{}, err
+		}
+	}
+
+	if err := normalizeIDFilters(params.IDs); err != nil {
+		return models.DistinctValues{}, err
+	}
+	s.applyExclusions(ctx, &params)
+	s.rewriteQuery(&params)
+
+	return cachedAggregation(s.aggCache, "distinct", []any{params, field, size}, func() (models.DistinctValues, error) {
+		return s.productRepo.DistinctValues(ctx, params, field, size)
+	})
+}
+
+// RelatedGenerics returns the drug generics that are statistically overrepresented in the products matching a
+// keyword or company compared to the whole catalog, most significant first
+func (s *ProductServiceImpl) RelatedGenerics(ctx context.Context, params models.ProductSearchParams, size int) (models.SignificantTerms, error) {
+	if size < 1 || size > MaxRelatedTerms {
+		return models.SignificantTerms{}, fmt.Errorf("%w: size must be between 1 and %d", common.ErrValidation, MaxRelatedTerms)
+	}
+	// Without a keyword or company the matching products are the whole catalog, so nothing stands out
+	if params.Keyword == "" && len(params.Companies) == 0 {
+		return models.SignificantTerms{}, fmt.Errorf("%w: keyword or company is required", common.ErrValidation)
+	}
+	if params.Index != "" {
+		if err := validateIndexName(params.Index); err != nil {
+			return models.SignificantTerms{}, err
+		}
+	}
+
+	if err := normalizeIDFilters(params.IDs); err != nil {
+		return models.SignificantTerms{}, err
+	}
+	s.applyExclusions(ctx, &params)
+	s.rewriteQuery(&params)
+
+	return cachedAggregation(s.aggCache, "related_generics", []any{params, size}, func() (models.SignificantTerms, error) {
+		return s.productRepo.SignificantValues(ctx, params, "drug_generic", size)
+	})
+}
+
+// SuggestProducts completes a typed prefix to up to size distinct product names for autocomplete, only names of
+// the products of company when given. Products hidden by exclusion rules for the prefix aren't suggested. With
+// popular queries attached and no company, short prefixes are completed with popular searches first, as are
+// prefixes completing no product name.
+func (s *ProductServiceImpl) SuggestProducts(ctx context.Context, prefix, company string, size int) ([]models.Suggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	company = strings.TrimSpace(company)
+	if prefix == "" {
+		return nil, fmt.Errorf("%w: q is required", common.ErrValidation)
+	}
+	if size < 1 || size > MaxSuggestions {
+		return nil, fmt.Errorf("%w: size must be between 1 and %d", common.ErrValidation, MaxSuggestions)
+	}
+
+	params := models.ProductSearchParams{Keyword: prefix}
+	s.applyExclusions(ctx, &params)
+
+	// Ask for spare options to make up for the deleted and excluded products dropped afterwards
+	var suggestions []models.Suggestion
+	var err error
+	if s.suggest != nil {
+		suggestions, err = s.suggest.Suggest(ctx, prefix, company, 2*size)
+	} else {
+		suggestions, err = s.productRepo.SuggestProducts(ctx, prefix, company, 2*size)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	visible := suggestions[:0]
+	for _, suggestion := range suggestions {
+		if slices.Contains(params.ExcludedIDs, suggestion.ID) || slices.Contains(params.ExcludedCompanies, suggestion.Company) {
+			continue
+		}
+		visible = append(visible, suggestion)
+	}
+
+	// Popular searches aren't tied to a company
+	if s.popular != nil && company == "" && (utf8.RuneCountInString(prefix) < PopularPrefixLength || len(visible) == 0) {
+		visible = append(s.popularSuggestions(ctx, prefix, size), visible...)
+	}
+	return visible[:min(size, len(visible))], nil
+}
+
+// popularSuggestions turns the size most popular searches starting with prefix into suggestions; autocomplete
+// still works when they can't be loaded
+func (s *ProductServiceImpl) popularSuggestions(ctx context.Context, prefix string, size int) []models.Suggestion {
+	queries, err := s.popular.PopularQueries(ctx, prefix, size)
+	if err != nil {
+		fiberlog.Warnf("Failed to load popular searches for %q: %v", prefix, err)
+		return nil
+	}
+
+	suggestions := make([]models.Suggestion, 0, len(queries))
+	for _, query := range queries {
+		suggestions = append(suggestions, models.Suggestion{
+			Text:     query.Query,
+			Source:   models.SuggestionSourcePopular,
+			Searches: query.Count,
+		})
+	}
+	return suggestions
+}
+
+// TypeaheadProducts returns up to size products whose name matches a partially typed keyword, best first, for
+// showing results while the user types. Products hidden by exclusion rules for the keyword aren't returned.
+func (s *ProductServiceImpl) TypeaheadProducts(ctx context.Context, prefix string, size int) ([]models.Product, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, fmt.Errorf("%w: q is required", common.ErrValidation)
+	}
+	if size < 1 || size > MaxSuggestions {
+		return nil, fmt.Errorf("%w: size must be between 1 and %d", common.ErrValidation, MaxSuggestions)
+	}
+
+	params := models.ProductSearchParams{Keyword: prefix}
+	s.applyExclusions(ctx, &params)
+	return s.productRepo.TypeaheadProducts(ctx, params, size)
+}
+
+// TypeaheadGroups returns up to size products whose name matches a partially typed keyword, and up to size
+// generics and companies matching it, for sectioned autocomplete. Products hidden by exclusion rules for the
+// keyword are left out of every section.
+func (s *ProductServiceImpl) TypeaheadGroups(ctx context.Context, prefix string, size int) (models.TypeaheadGroups, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return models.TypeaheadGroups{}, fmt.Errorf("%w: q is required", common.ErrValidation)
+	}
+	if size < 1 || size > MaxSuggestions {
+		return models.TypeaheadGroups{}, fmt.Errorf("%w: size must be between 1 and %d", common.ErrValidation, MaxSuggestions)
+	}
+
+	params := models.ProductSearchParams{Keyword: prefix}
+	s.applyExclusions(ctx, &params)
+	return s.productRepo.TypeaheadGroups(ctx, params, size)
+}
+
+// normalizeIDFilters normalizes ID filters in place the same way stored IDs are
+func normalizeIDFilters(ids []models.ProductID) error {
+	for i, rawID := range ids {
+		id, err := parseID(string(rawID))
+		if err != nil {
+			return err
+		}
+		ids[i] = id
+	}
+	return nil
+}
+
+// applyExclusions hides excluded products and companies; searches still work when rules can't be loaded
+func (s *ProductServiceImpl) applyExclusions(ctx context.Context, params *models.ProductSearchParams) {
+	if s.exclusions == nil {
+		return
+	}
+	ids, companies, err := s.exclusions.Exclusions(ctx, params.Keyword)
+	if err != nil {
+		fiberlog.Warnf("Failed to load exclusion rules for %q: %v", params.Keyword, err)
+	}
+	params.ExcludedIDs = ids
+	params.ExcludedCompanies = companies
+}
+
+// rewriteQuery applies the query rewrite rules to the keyword of params
+func (s *ProductServiceImpl) rewriteQuery(params *models.ProductSearchParams) {
+	if s.rewriter == nil || params.Keyword == "" {
+		return
+	}
+	keyword := params.Keyword
+	if applied := s.rewriter.Rewrite(params); len(applied) > 0 {
+		fiberlog.Debugf("Rewrote %q with %s", keyword, strings.Join(applied, ", "))
+	}
+}
+
+// ClosePIT ends a pit session before its point in time expires
+func (s *ProductServiceImpl) ClosePIT(ctx context.Context, pit string) error {
+	if pit == "" || pit == models.PITStart {
+		return fmt.Errorf("%w: pit token is required", common.ErrValidation)
+	}
+	return s.productRepo.ClosePointInTime(ctx, pit)
+}
+
+// productEvent builds an API change event carrying a snapshot of the product
+func productEvent(eventType string, product models.Product) models.ChangeEvent {
+	return models.ChangeEvent{
+		Type:      eventType,
+		ProductID: product.ID,
+		Product:   &product,
+		Source:    models.ChangeSourceAPI,
+	}
+}
+
+// publish mirrors change events into the suggest index and hands them to the publisher. Suggest index failures
+// are logged, as the index is rebuilt after imports. A publisher failure is returned to fail the request: the
+// write is done, but the outbox would never deliver it, and retrying the request records the change again.
+func (s *ProductServiceImpl) publish(ctx context.Context, events ...models.ChangeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	s.syncSuggestIndex(ctx, events)
+
+	if s.publisher == nil {
+		return nil
+	}
+	if err := s.publisher.Publish(ctx, events...); err != nil {
+		return fmt.Errorf("product written, but recording its change event failed: %w", err)
+	}
+	return nil
+}
+
+// syncSuggestIndex writes the changed products to the suggest index and removes the deleted ones; soft-deleted
+// products are removed by IndexProducts
+func (s *ProductServiceImpl) syncSuggestIndex(ctx context.Context, events []models.ChangeEvent) {
+	if s.suggest == nil {
+		return
+	}
+
+	var products []models.Product
+	var deleted []models.ProductID
+	for _, event := range events {
+		switch {
+		case event.Product != nil:
+			products = append(products, *event.Product)
+		case event.Type == models.ChangeDeleted:
+			deleted = append(deleted, event.ProductID)
+		}
+	}
+
+	if err := s.suggest.IndexProducts(ctx, products); err != nil {
+		fiberlog.Warnf("Failed to update %d product(s) in the suggest index: %v", len(products), err)
+	}
+	if err := s.suggest.DeleteProducts(ctx, deleted); err != nil {
+		fiberlog.Warnf("Failed to remove %d product(s) from the suggest index: %v", len(deleted), err)
+	}
+}
+
+// formatHighlights escapes highlight fragments as HTML or converts them to plain text with match offsets
+func (s *ProductServiceImpl) formatHighlights(products []models.Product) {
+	format, err := highlight.ParseFormat(s.searchCfg.HighlightFormat)
+	if err != nil {
+		format = highlight.FormatHTML
+	}
+
+	for i := range products {
+		raw := products[i].Highlights
+		if len(raw) == 0 {
+			continue
+		}
+
+		if format == highlight.FormatOffsets {
+			offsets := make(map[string][]highlight.Fragment, len(raw))
+			for field, fragments := range raw {
+				for _, fragment := range fragments {
+					offsets[field] = append(offsets[field], highlight.ToOffsets(fragment))
+				}
+			}
+			products[i].Highlights = nil
+			products[i].HighlightOffsets = offsets
+			continue
+		}
+
+		for field, fragments := range raw {
+			for j, fragment := range fragments {
+				fragments[j] = highlight.ToHTML(fragment)
+			}
+			raw[field] = fragments
+		}
+	}
+}
+
+// parseID validates a product ID received from a client
+func parseID(rawID string) (models.ProductID, error) {
+	id, err := models.ParseProductID(rawID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", common.ErrValidation, err)
+	}
+	return id, nil
+}
+
+// validateIndexName checks an index or alias name against the Elasticsearch naming rules
+func validateIndexName(name string) error {
+	invalid := name != strings.ToLower(name) ||
+		strings.ContainsAny(name, `\/*?"<>| ,#:`) ||
+		strings.HasPrefix(name, "-") || strings.HasPrefix(name, "_") || strings.HasPrefix(name, "+") ||
+		name == "." || name == ".." || len(name) > 255
+
+	if invalid {
+		return fmt.Errorf("%w: invalid index name %q", common.ErrValidation, name)
+	}
+	return nil
+}
+
+// dedupeProducts removes products whose normalized name and company match an earlier product.
+// Products are expected in relevance order, so the best scoring duplicate is kept.
+func dedupeProducts(products []models.Product) []models.Product {
+	seen := make(map[string]struct{}, len(products))
+	deduped := make([]models.Product, 0, len(products))
+
+	for _, product := range products {
+		key := normalizeForDedupe(product.ProductName) + "|" + normalizeForDedupe(product.Company)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		deduped = append(deduped, product)
+	}
+
+	return deduped
+}
+
+// normalizeForDedupe lowercases the value and drops punctuation and repeated whitespace
+func normalizeForDedupe(value string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, value)
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+// assignID gives a product created without an ID one from the configured generator. Only products
+// that passed validation get one, so rejected writes don't consume sequence numbers.
+func (s *ProductServiceImpl) assignID(ctx context.Context, product *models.Product) error {
+	if product.ID != "" || s.ids == nil {
+		return nil
+	}
+	id, err := s.ids.NextID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate product ID: %w", err)
+	}
+	product.ID = id
+	return nil
+}
+
+// enrich applies the configured enrichment chain to a product
+func (s *ProductServiceImpl) enrich(ctx context.Context, product *models.Product) error {
+	if s.enricher == nil {
+		return nil
+	}
+	return s.enricher.Enrich(ctx, product)
+}
+
+// applyDocumentLimit enforces the document size limit, logging the fields it truncates
+func (s *ProductServiceImpl) applyDocumentLimit(product *models.Product) error {
+	truncated, err := s.limit.Apply(product)
+	if err != nil {
+		return fmt.Errorf("%w: %v", common.ErrValidation, err)
+	}
+	if len(truncated) > 0 {
+		fiberlog.Warnf("Truncated %s of product %q to fit the %d byte document limit", strings.Join(truncated, ", "), product.ID, s.limit.MaxBytes)
+	}
+	return nil
+}
+
+// validateProduct checks that all required product fields are present
+func validateProduct(product models.Product) error {
+	var missing []string
+	if strings.TrimSpace(product.ProductName) == "" {
+		missing = append(missing, "product_name")
+	}
+	if strings.TrimSpace(product.DrugGeneric) == "" {
+		missing = append(missing, "drug_generic")
+	}
+	if strings.TrimSpace(product.Company) == "" {
+		missing = append(missing, "company")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing required fields: %s", common.ErrValidation, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"elasticsearch/internal/cache"
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/models"
+	"sync/atomic"
+	"time"
+)
+
+// cachedProduct is a cached product lookup; found is false for IDs known not to exist
+type cachedProduct struct {
+	product models.Product
+	found   bool
+}
+
+// ProductCache is a read-through cache for product detail lookups. Products are kept for a short
+// TTL and missing IDs for an even shorter one; API writes invalidate the affected IDs and imports clear it.
+type ProductCache struct {
+	entries     *cache.TTL[models.ProductID, cachedProduct]
+	ttl         time.Duration
+	negativeTTL time.Duration
+	// generation is bumped by Invalidate and Clear, so lookups loaded while a write happened aren't stored
+	generation atomic.Uint64
+}
+
+// NewProductCache creates a ProductCache from configuration
+func NewProductCache(cfg config.ProductCacheConfig) *ProductCache {
+	return &ProductCache{
+		entries:     cache.NewTTL[models.ProductID, cachedProduct](cfg.MaxEntries),
+		ttl:         time.Duration(cfg.TTLSec) * time.Second,
+		negativeTTL: time.Duration(cfg.NegativeTTLSec) * time.Second,
+	}
+}
+
+// Get returns the cached lookup for id; ok is false when the ID isn't cached
+func (c *ProductCache) Get(id models.ProductID) (product models.Product, found bool, ok bool) {
+	entry, ok := c.entries.Get(id)
+	return entry.product, entry.found, ok
+}
+
+// Generation returns the current generation, to be read before loading a lookup that is later stored
+func (c *ProductCache) Generation() uint64 {
+	return c.generation.Load()
+}
+
+// SetProduct caches an existing product loaded at generation, unless a write happened since
+func (c *ProductCache) SetProduct(product models.Product, generation uint64) {
+	if c.generation.Load() == generation {
+		c.entries.Set(product.ID, cachedProduct{product: product, found: true}, c.ttl)
+	}
+}
+
+// SetMissing caches an ID found not to exist at generation, unless a write happened since
+func (c *ProductCache) SetMissing(id models.ProductID, generation uint64) {
+	if c.generation.Load() == generation {
+		c.entries.Set(id, cachedProduct{}, c.negativeTTL)
+	}
+}
+
+// Invalidate drops the cached lookups of the given IDs
+func (c *ProductCache) Invalidate(ids ...models.ProductID) {
+	c.generation.Add(1)
+	c.entries.Delete(ids...)
+}
+
+// Clear drops every cached lookup
+func (c *ProductCache) Clear() {
+	c.generation.Add(1)
+	c.entries.Clear()
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"testing"
+
+	"elasticsearch/internal/config"
+	"elasticsearch/internal/models"
+)
+
+func TestProductCacheDropsFillsRacingAWrite(t *testing.T) {
+	cache := NewProductCache(config.ProductCacheConfig{TTLSec: 60, NegativeTTLSec: 60, MaxEntries: 10})
+
+	// A lookup loads the old product while an update invalidates it
+	generation := cache.Generation()
+	cache.Invalidate("1")
+	cache.SetProduct(models.Product{ID: "1", ProductName: "old"}, generation)
+	if _, _, ok := cache.Get("1"); ok {
+		t.Error("product loaded before an invalidation was cached")
+	}
+
+	// A lookup finds an ID missing while an import creates it
+	generation = cache.Generation()
+	cache.Clear()
+	cache.SetMissing("2", generation)
+	if _, _, ok := cache.Get("2"); ok {
+		t.Error("missing ID loaded before a clear was cached")
+	}
+
+	generation = cache.Generation()
+	cache.SetProduct(models.Product{ID: "1", ProductName: "new"}, generation)
+	if product, found, ok := cache.Get("1"); !ok || !found || product.ProductName != "new" {
+		t.Errorf("Get(1) = %v, %v, %v, want the product stored without a write in between", product, found, ok)
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type SnapshotService interface {
+	CreateSnapshot(ctx context.Context, params models.ProductSearchParams, request string, note string) (models.SearchSnapshot, error)
+	GetSnapshot(ctx context.Context, id string) (models.SearchSnapshot, error)
+}
+
+type SnapshotServiceImpl struct {
+	productService ProductService
+	snapshotRepo   elasticsearch.SnapshotRepository
+}
+
+func NewSnapshotService(productService ProductService, snapshotRepo elasticsearch.SnapshotRepository) *SnapshotServiceImpl {
+	return &SnapshotServiceImpl{
+		productService: productService,
+		snapshotRepo:   snapshotRepo,
+	}
+}
+
+// CreateSnapshot runs a product search exactly as GET /product would, curations and exclusions included,
+// and stores the request, the effective Elasticsearch query and the results. Only offset pages can be
+// captured: cursor and pit sessions would leave a point in time open behind the snapshot.
+func (s *SnapshotServiceImpl) CreateSnapshot(ctx context.Context, params models.ProductSearchParams, request string, note string) (models.SearchSnapshot, error) {
+	if params.Cursor != nil || params.PIT != "" {
+		return models.SearchSnapshot{}, fmt.Errorf("%w: cursor and pit searches can't be captured, snapshot an offset page instead", common.ErrValidation)
+	}
+
+	result, err := s.productService.GetProducts(ctx, params)
+	if err != nil {
+		return models.SearchSnapshot{}, err
+	}
+
+	snapshot := models.SearchSnapshot{
+		CreatedAt:  time.Now().UTC(),
+		Note:       note,
+		Request:    request,
+		Query:      json.RawMessage(result.Query),
+		TotalCount: result.TotalCount,
+		TookMs:     result.TookMs,
+		Warnings:   result.Warnings,
+		Products:   result.Products,
+	}
+	return s.snapshotRepo.SaveSnapshot(ctx, snapshot)
+}
+
+func (s *SnapshotServiceImpl) GetSnapshot(ctx context.Context, id string) (models.SearchSnapshot, error) {
+	if id == "" {
+		return models.SearchSnapshot{}, fmt.Errorf("%w: snapshot id is required", common.ErrValidation)
+	}
+	return s.snapshotRepo.GetSnapshot(ctx, id)
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"fmt"
+)
+
+type StatsService interface {
+	GetCatalogStats(ctx context.Context) (models.CatalogStats, error)
+	GetIndexStats(ctx context.Context, size int) (models.IndexStats, error)
+	GetCreationHistogram(ctx context.Context, params models.CreationHistogramParams) (models.CreationHistogram, error)
+}
+
+type StatsServiceImpl struct {
+	statsRepo elasticsearch.StatsRepository
+	aggCache  *AggregationCache
+}
+
+func NewStatsService(statsRepo elasticsearch.StatsRepository) *StatsServiceImpl {
+	return &StatsServiceImpl{
+		statsRepo: statsRepo,
+	}
+}
+
+// SetAggregationCache attaches the cache of statistics results
+func (s *StatsServiceImpl) SetAggregationCache(aggCache *AggregationCache) {
+	s.aggCache = aggCache
+}
+
+func (s *StatsServiceImpl) GetCatalogStats(ctx context.Context) (models.CatalogStats, error) {
+	return cachedAggregation(s.aggCache, "catalog_stats", nil, func() (models.CatalogStats, error) {
+		return s.statsRepo.GetCatalogStats(ctx)
+	})
+}
+
+// GetIndexStats returns an operational overview of the product index with the size most frequent companies
+// and drug generics
+func (s *StatsServiceImpl) GetIndexStats(ctx context.Context, size int) (models.IndexStats, error) {
+	if size < 1 || size > MaxFacetSize {
+		return models.IndexStats{}, fmt.Errorf("%w: size must be between 1 and %d", common.ErrValidation, MaxFacetSize)
+	}
+	return cachedAggregation(s.aggCache, "index_stats", size, func() (models.IndexStats, error) {
+		return s.statsRepo.GetIndexStats(ctx, size)
+	})
+}
+
+// GetCreationHistogram returns the number of products created per calendar interval in the requested window
+func (s *StatsServiceImpl) GetCreationHistogram(ctx context.Context, params models.CreationHistogramParams) (models.CreationHistogram, error) {
+	if params.CreatedAfter != nil && params.CreatedBefore != nil && !params.CreatedAfter.Before(*params.CreatedBefore) {
+		return models.CreationHistogram{}, fmt.Errorf("%w: created_after must be before created_before", common.ErrValidation)
+	}
+	return cachedAggregation(s.aggCache, "creation_histogram", params, func() (models.CreationHistogram, error) {
+		return s.statsRepo.GetCreationHistogram(ctx, params)
+	})
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/idgen"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/storage/elasticsearch"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type SynonymSearchResult struct {
+	Rules       []models.SynonymRule
+	TotalCount  int64
+	Limit       int
+	Offset      int
+	CurrentPage int
+	TotalPages  int
+}
+
+type SynonymService interface {
+	GetRules(ctx context.Context, limit int, offset int) (SynonymSearchResult, error)
+	GetRule(ctx context.Context, id string) (models.SynonymRule, error)
+	CreateRule(ctx context.Context, req models.SynonymRuleRequest) (models.SynonymRule, error)
+	ReplaceRule(ctx context.Context, id string, req models.SynonymRuleRequest) (models.SynonymRule, error)
+	DeleteRule(ctx context.Context, id string) error
+}
+
+type SynonymServiceImpl struct {
+	synonymRepo elasticsearch.SynonymRepository
+}
+
+func NewSynonymService(synonymRepo elasticsearch.SynonymRepository) *SynonymServiceImpl {
+	return &SynonymServiceImpl{
+		synonymRepo: synonymRepo,
+	}
+}
+
+func (s *SynonymServiceImpl) GetRules(ctx context.Context, limit int, offset int) (SynonymSearchResult, error) {
+	rules, total, err := s.synonymRepo.FindRules(ctx, limit, offset)
+	if err != nil {
+		return SynonymSearchResult{}, err
+	}
+
+	// Calculate page info
+	currentPage := 1
+	if limit > 0 {
+		currentPage = (offset / limit) + 1
+	}
+
+	totalPages := 1
+	if limit > 0 && total > 0 {
+		totalPages = int(math.Ceil(float64(total) / float64(limit)))
+	}
+
+	return SynonymSearchResult{
+		Rules:       rules,
+		TotalCount:  total,
+		Limit:       limit,
+		Offset:      offset,
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+func (s *SynonymServiceImpl) GetRule(ctx context.Context, id string) (models.SynonymRule, error) {
+	return s.synonymRepo.GetRule(ctx, id)
+}
+
+func (s *SynonymServiceImpl) CreateRule(ctx context.Context, req models.SynonymRuleRequest) (models.SynonymRule, error) {
+	rule, err := buildSynonymRule(req)
+	if err != nil {
+		return models.SynonymRule{}, err
+	}
+
+	id, err := idgen.UUIDv7{}.NextID(ctx)
+	if err != nil {
+		return models.SynonymRule{}, err
+	}
+	rule.ID = string(id)
+
+	return s.synonymRepo.SaveRule(ctx, rule)
+}
+
+// ReplaceRule replaces an existing rule. Returns common.ErrNotFound if it doesn't exist.
+func (s *SynonymServiceImpl) ReplaceRule(ctx context.Context, id string, req models.SynonymRuleRequest) (models.SynonymRule, error) {
+	if _, err := s.synonymRepo.GetRule(ctx, id); err != nil {
+		return models.SynonymRule{}, err
+	}
+
+	rule, err := buildSynonymRule(req)
+	if err != nil {
+		return models.SynonymRule{}, err
+	}
+	rule.ID = id
+
+	return s.synonymRepo.SaveRule(ctx, rule)
+}
+
+func (s *SynonymServiceImpl) DeleteRule(ctx context.Context, id string) error {
+	return s.synonymRepo.DeleteRule(ctx, id)
+}
+
+// buildSynonymRule validates a rule request, trimming and deduplicating its terms case-insensitively
+func buildSynonymRule(req models.SynonymRuleRequest) (models.SynonymRule, error) {
+	rule := models.SynonymRule{}
+	seen := make(map[string]bool, len(req.Synonyms))
+
+	for _, synonym := range req.Synonyms {
+		synonym = strings.TrimSpace(synonym)
+		if synonym == "" {
+			continue
+		}
+		// Rules are stored in the Solr format, where commas and arrows separate terms
+		if strings.Contains(synonym, ",") || strings.Contains(synonym, "=>") {
+			return models.SynonymRule{}, fmt.Errorf("%w: synonym %q must not contain ',' or '=>'", common.ErrValidation, synonym)
+		}
+		if key := strings.ToLower(synonym); !seen[key] {
+			seen[key] = true
+			rule.Synonyms = append(rule.Synonyms, synonym)
+		}
+	}
+
+	if len(rule.Synonyms) < 2 {
+		return models.SynonymRule{}, fmt.Errorf("%w: at least two distinct synonyms are required", common.ErrValidation)
+	}
+
+	return rule, nil
+}
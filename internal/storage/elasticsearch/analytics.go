@@ -0,0 +1,440 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// SearchLogIndex holds one raw document per product search until it ages out of retention
+const SearchLogIndex = "search_logs"
+
+// SearchAnalyticsIndex holds the daily search summaries rolled up from the raw logs
+const SearchAnalyticsIndex = "search_analytics_daily"
+
+// analyticsTopQueries is the number of keywords kept in a daily summary
+const analyticsTopQueries = 20
+
+// AnalyticsRepository defines the interface for search log and daily summary operations
+type AnalyticsRepository interface {
+	AppendSearchLogs(ctx context.Context, entries []models.SearchLogEntry) error
+	SummarizeDay(ctx context.Context, day time.Time) (models.SearchDailySummary, error)
+	SaveDailySummary(ctx context.Context, summary models.SearchDailySummary) error
+	FindDailySummaries(ctx context.Context, params models.SearchDailySummaryParams) (models.SearchDailySummaryResult, error)
+	PruneSearchLogs(ctx context.Context, before time.Time) (int64, error)
+	PopularQueries(ctx context.Context, prefix string, size int) ([]models.QueryCount, error)
+}
+
+// ElasticsearchAnalyticsRepository implements AnalyticsRepository using Elasticsearch
+type ElasticsearchAnalyticsRepository struct {
+	es           *elasticsearch.Client
+	logIndex     string
+	summaryIndex string
+}
+
+// NewElasticsearchAnalyticsRepository creates a new ElasticsearchAnalyticsRepository
+func NewElasticsearchAnalyticsRepository(es *elasticsearch.Client, logIndex string, summaryIndex string) *ElasticsearchAnalyticsRepository {
+	return &ElasticsearchAnalyticsRepository{
+		es:           es,
+		logIndex:     logIndex,
+		summaryIndex: summaryIndex,
+	}
+}
+
+// AppendSearchLogs stores raw search log entries with a single bulk request, creating the log index on first use
+func (r *ElasticsearchAnalyticsRepository) AppendSearchLogs(ctx context.Context, entries []models.SearchLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := createIndexWithMapping(r.es, r.logIndex, `{
+		"mappings": {
+			"properties": {
+				"timestamp": {"type": "date"},
+				"keyword": {"type": "keyword"},
+				"total_hits": {"type": "long"},
+				"took_ms": {"type": "long"}
+			}
+		}
+	}`); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		meta := map[string]interface{}{
+			"index": map[string]interface{}{"_index": r.logIndex},
+		}
+		if err := encoder.Encode(meta); err != nil {
+			return fmt.Errorf("failed to encode bulk metadata: %w", err)
+		}
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode search log entry: %w", err)
+		}
+	}
+
+	res, err := r.es.Bulk(
+		&buf,
+		r.es.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("bulk request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  struct {
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if response.Errors {
+		for _, item := range response.Items {
+			for _, result := range item {
+				if result.Status >= 300 {
+					return fmt.Errorf("failed to append search log entry: %s", result.Error.Reason)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// SummarizeDay aggregates the raw logs of the UTC day containing day into a summary
+func (r *ElasticsearchAnalyticsRepository) SummarizeDay(ctx context.Context, day time.Time) (models.SearchDailySummary, error) {
+	start := day.UTC().Truncate(24 * time.Hour)
+	end := start.Add(24 * time.Hour)
+
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"range": map[string]interface{}{
+				"timestamp": map[string]interface{}{
+					"gte": start.Format(time.RFC3339),
+					"lt":  end.Format(time.RFC3339),
+				},
+			},
+		},
+		"aggs": map[string]interface{}{
+			"zero_results": map[string]interface{}{
+				"filter": map[string]interface{}{
+					"term": map[string]interface{}{"total_hits": 0},
+				},
+			},
+			"avg_latency": map[string]interface{}{
+				"avg": map[string]interface{}{"field": "took_ms"},
+			},
+			// Browse searches without a keyword are counted but never ranked as a query
+			"top_queries": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field":   "keyword",
+					"size":    analyticsTopQueries,
+					"exclude": "",
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return models.SearchDailySummary{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.logIndex),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+		r.es.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.SearchDailySummary{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.SearchDailySummary{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+		} `json:"hits"`
+		Aggregations struct {
+			ZeroResults struct {
+				DocCount int64 `json:"doc_count"`
+			} `json:"zero_results"`
+			AvgLatency struct {
+				Value *float64 `json:"value"`
+			} `json:"avg_latency"`
+			TopQueries struct {
+				Buckets []struct {
+					Key      string `json:"key"`
+					DocCount int64  `json:"doc_count"`
+				} `json:"buckets"`
+			} `json:"top_queries"`
+		} `json:"aggregations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.SearchDailySummary{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	summary := models.SearchDailySummary{
+		Date:               start.Format(time.DateOnly),
+		Searches:           response.Hits.Total.Value,
+		ZeroResultSearches: response.Aggregations.ZeroResults.DocCount,
+		TopQueries:         make([]models.QueryCount, 0, len(response.Aggregations.TopQueries.Buckets)),
+	}
+	if summary.Searches > 0 {
+		summary.ZeroResultRate = float64(summary.ZeroResultSearches) / float64(summary.Searches)
+	}
+	if avg := response.Aggregations.AvgLatency.Value; avg != nil {
+		summary.AvgLatencyMs = *avg
+	}
+	for _, bucket := range response.Aggregations.TopQueries.Buckets {
+		summary.TopQueries = append(summary.TopQueries, models.QueryCount{Query: bucket.Key, Count: bucket.DocCount})
+	}
+
+	return summary, nil
+}
+
+// SaveDailySummary stores a summary under its date, replacing an earlier rollup of the same day
+func (r *ElasticsearchAnalyticsRepository) SaveDailySummary(ctx context.Context, summary models.SearchDailySummary) error {
+	if err := createIndexWithMapping(r.es, r.summaryIndex, `{
+		"mappings": {
+			"properties": {
+				"date": {"type": "date", "format": "yyyy-MM-dd"},
+				"searches": {"type": "long"},
+				"zero_result_searches": {"type": "long"},
+				"zero_result_rate": {"type": "double"},
+				"avg_latency_ms": {"type": "double"},
+				"top_queries": {
+					"properties": {
+						"query": {"type": "keyword"},
+						"count": {"type": "long"}
+					}
+				},
+				"rolled_up_at": {"type": "date"}
+			}
+		}
+	}`); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("failed to encode daily summary: %w", err)
+	}
+
+	res, err := r.es.Index(
+		r.summaryIndex,
+		bytes.NewReader(body),
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithDocumentID(summary.Date),
+		r.es.Index.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		return fmt.Errorf("index request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	return nil
+}
+
+// FindDailySummaries lists daily summaries, newest day first
+func (r *ElasticsearchAnalyticsRepository) FindDailySummaries(ctx context.Context, params models.SearchDailySummaryParams) (models.SearchDailySummaryResult, error) {
+	query := map[string]interface{}{
+		"from": params.Offset,
+		"size": params.Limit,
+		"sort": []map[string]interface{}{
+			{"date": map[string]interface{}{"order": "desc"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return models.SearchDailySummaryResult{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.summaryIndex),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+		r.es.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.SearchDailySummaryResult{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.SearchDailySummaryResult{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Source models.SearchDailySummary `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.SearchDailySummaryResult{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	summaries := make([]models.SearchDailySummary, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		summaries = append(summaries, hit.Source)
+	}
+
+	return models.SearchDailySummaryResult{
+		Summaries:  summaries,
+		TotalCount: response.Hits.Total.Value,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	}, nil
+}
+
+// PopularQueries returns the size keywords starting with prefix that were searched most often over the retained
+// logs, most searched first. Keywords whose searches found nothing aren't counted; prefix must be normalized
+// like the logged keywords.
+func (r *ElasticsearchAnalyticsRepository) PopularQueries(ctx context.Context, prefix string, size int) ([]models.QueryCount, error) {
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []map[string]interface{}{
+					{"prefix": map[string]interface{}{"keyword": prefix}},
+					{"range": map[string]interface{}{"total_hits": map[string]interface{}{"gt": 0}}},
+				},
+			},
+		},
+		"aggs": map[string]interface{}{
+			"popular": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field":   "keyword",
+					"size":    size,
+					"exclude": "",
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.logIndex),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Aggregations struct {
+			Popular struct {
+				Buckets []struct {
+					Key      string `json:"key"`
+					DocCount int64  `json:"doc_count"`
+				} `json:"buckets"`
+			} `json:"popular"`
+		} `json:"aggregations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	queries := make([]models.QueryCount, 0, len(response.Aggregations.Popular.Buckets))
+	for _, bucket := range response.Aggregations.Popular.Buckets {
+		queries = append(queries, models.QueryCount{Query: bucket.Key, Count: bucket.DocCount})
+	}
+	return queries, nil
+}
+
+// PruneSearchLogs deletes raw search logs older than before and returns the number deleted
+func (r *ElasticsearchAnalyticsRepository) PruneSearchLogs(ctx context.Context, before time.Time) (int64, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"range": map[string]interface{}{
+				"timestamp": map[string]interface{}{"lt": before.UTC().Format(time.RFC3339)},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return 0, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.DeleteByQuery(
+		[]string{r.logIndex},
+		&buf,
+		r.es.DeleteByQuery.WithContext(ctx),
+		r.es.DeleteByQuery.WithConflicts("proceed"),
+		r.es.DeleteByQuery.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete by query request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	// Nothing to prune before the first search has been logged
+	if res.StatusCode == http.StatusNotFound {
+		return 0, nil
+	}
+
+	if res.IsError() {
+		return 0, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Deleted int64 `json:"deleted"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return response.Deleted, nil
+}
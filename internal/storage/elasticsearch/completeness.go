@@ -0,0 +1,99 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"elasticsearch/internal/models"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// FindIncompleteProducts refreshes an index and checks that every live product has a non-empty value
+// for each field. It returns one rule per field with the failing count and the first limit failing IDs;
+// a missing index has no failing products.
+func FindIncompleteProducts(ctx context.Context, esClient *elasticsearch.Client, indexName string, fields []string, limit int) ([]models.CompletenessRule, error) {
+	if err := RefreshIndex(ctx, esClient, indexName); err != nil {
+		return nil, err
+	}
+
+	rules := make([]models.CompletenessRule, 0, len(fields))
+	for _, field := range fields {
+		rule, err := findMissingField(ctx, esClient, indexName, field, limit)
+		if err != nil {
+			return nil, fmt.Errorf("completeness of %s: %w", field, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// findMissingField finds the live products whose field is missing or empty
+func findMissingField(ctx context.Context, esClient *elasticsearch.Client, indexName string, field string, limit int) (models.CompletenessRule, error) {
+	keyword := field + ".keyword"
+	query := map[string]interface{}{
+		"size": limit,
+		"sort": []string{"_doc"},
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should": []interface{}{
+					map[string]interface{}{"bool": map[string]interface{}{
+						"must_not": map[string]interface{}{"exists": map[string]interface{}{"field": keyword}},
+					}},
+					map[string]interface{}{"term": map[string]interface{}{keyword: ""}},
+				},
+				"minimum_should_match": 1,
+				// Soft-deleted products are no longer part of the catalog
+				"must_not": map[string]interface{}{"exists": map[string]interface{}{"field": "deleted_at"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return models.CompletenessRule{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := esClient.Search(
+		esClient.Search.WithContext(ctx),
+		esClient.Search.WithIndex(indexName),
+		esClient.Search.WithBody(&buf),
+		esClient.Search.WithSource("false"),
+		esClient.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		return models.CompletenessRule{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	rule := models.CompletenessRule{Field: field, FailingIDs: []models.ProductID{}}
+	if res.StatusCode == http.StatusNotFound {
+		return rule, nil
+	}
+	if res.IsError() {
+		return models.CompletenessRule{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.CompletenessRule{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	rule.FailingCount = response.Hits.Total.Value
+	for _, hit := range response.Hits.Hits {
+		rule.FailingIDs = append(rule.FailingIDs, models.ProductID(hit.ID))
+	}
+	return rule, nil
+}
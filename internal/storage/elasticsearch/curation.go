@@ -0,0 +1,186 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// CurationIndex holds the pinned products of curated keywords, one document per keyword
+const CurationIndex = "search_curations"
+
+// CurationRepository defines the interface for search curation operations
+type CurationRepository interface {
+	SaveCuration(ctx context.Context, curation models.Curation) error
+	GetCuration(ctx context.Context, keyword string) (models.Curation, error)
+	DeleteCuration(ctx context.Context, keyword string) error
+	FindCurations(ctx context.Context, limit int, offset int) ([]models.Curation, int64, error)
+}
+
+// ElasticsearchCurationRepository implements CurationRepository using Elasticsearch
+type ElasticsearchCurationRepository struct {
+	es        *elasticsearch.Client
+	indexName string
+}
+
+// NewElasticsearchCurationRepository creates a new ElasticsearchCurationRepository
+func NewElasticsearchCurationRepository(es *elasticsearch.Client, indexName string) *ElasticsearchCurationRepository {
+	return &ElasticsearchCurationRepository{
+		es:        es,
+		indexName: indexName,
+	}
+}
+
+// SaveCuration creates or replaces the curation of a keyword, using the keyword as document ID
+func (r *ElasticsearchCurationRepository) SaveCuration(ctx context.Context, curation models.Curation) error {
+	if err := createIndexWithMapping(r.es, r.indexName, `{
+		"mappings": {
+			"properties": {
+				"keyword": {"type": "keyword"},
+				"pinned_ids": {"type": "keyword"},
+				"updated_at": {"type": "date"}
+			}
+		}
+	}`); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(curation)
+	if err != nil {
+		return fmt.Errorf("failed to encode curation: %w", err)
+	}
+
+	res, err := r.es.Index(
+		r.indexName,
+		bytes.NewReader(body),
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithDocumentID(curation.Keyword),
+		r.es.Index.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		return fmt.Errorf("index request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	return nil
+}
+
+// GetCuration loads the curation of a keyword. Returns common.ErrNotFound if the keyword isn't curated.
+func (r *ElasticsearchCurationRepository) GetCuration(ctx context.Context, keyword string) (models.Curation, error) {
+	res, err := r.es.Get(
+		r.indexName,
+		keyword,
+		r.es.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return models.Curation{}, fmt.Errorf("get request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	// A missing index simply means nothing has been curated yet
+	if res.StatusCode == http.StatusNotFound {
+		return models.Curation{}, fmt.Errorf("curation %q: %w", keyword, common.ErrNotFound)
+	}
+
+	if res.IsError() {
+		return models.Curation{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Source models.Curation `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.Curation{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return response.Source, nil
+}
+
+// DeleteCuration removes the curation of a keyword. Returns common.ErrNotFound if the keyword isn't curated.
+func (r *ElasticsearchCurationRepository) DeleteCuration(ctx context.Context, keyword string) error {
+	res, err := r.es.Delete(
+		r.indexName,
+		keyword,
+		r.es.Delete.WithContext(ctx),
+		r.es.Delete.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		return fmt.Errorf("delete request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("curation %q: %w", keyword, common.ErrNotFound)
+	}
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	return nil
+}
+
+// FindCurations lists curations ordered by keyword
+func (r *ElasticsearchCurationRepository) FindCurations(ctx context.Context, limit int, offset int) ([]models.Curation, int64, error) {
+	query := map[string]interface{}{
+		"from": offset,
+		"size": limit,
+		"sort": []map[string]interface{}{
+			{"keyword": map[string]interface{}{"order": "asc"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, 0, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.indexName),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+		r.es.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return nil, 0, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, 0, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Source models.Curation `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	curations := make([]models.Curation, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		curations = append(curations, hit.Source)
+	}
+
+	return curations, response.Hits.Total.Value, nil
+}
@@ -0,0 +1,107 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// defaultPITKeepAlive is how long a point in time stays open between two pages unless configured otherwise
+const defaultPITKeepAlive = "2m"
+
+// pointInTime returns the point in time a search reads: the one carried by a cursor or pit token,
+// a new one for the first page of a session, or "" for a plain search
+func (r *ElasticsearchProductRepository) pointInTime(ctx context.Context, params models.ProductSearchParams) (string, error) {
+	switch {
+	case params.Cursor != nil && params.Cursor.PIT != "":
+		return params.Cursor.PIT, nil
+	case params.Cursor != nil, params.PIT == models.PITStart:
+		return r.openPointInTime(ctx, r.searchIndex(params))
+	default:
+		return params.PIT, nil
+	}
+}
+
+// expiredSessionError explains a 404 from a search resuming a cursor or pit session, whose point in
+// time has expired or been closed; nil for other searches
+func expiredSessionError(params models.ProductSearchParams) error {
+	switch {
+	case params.Cursor != nil && params.Cursor.PIT != "":
+		return fmt.Errorf("%w: cursor expired, start again with cursor=%s", common.ErrValidation, models.CursorStart)
+	case params.PIT != "" && params.PIT != models.PITStart:
+		return fmt.Errorf("%w: pit expired or closed, start again with pit=%s", common.ErrValidation, models.PITStart)
+	default:
+		return nil
+	}
+}
+
+// openPointInTime opens a point in time on index for a new cursor or pit session
+func (r *ElasticsearchProductRepository) openPointInTime(ctx context.Context, index string) (string, error) {
+	res, err := r.es.OpenPointInTime(
+		[]string{index},
+		r.pitKeepAlive,
+		r.es.OpenPointInTime.WithContext(ctx),
+	)
+	if err != nil {
+		return "", fmt.Errorf("open point in time request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", decodeErrorResponse(res)
+	}
+
+	var response struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("failed to parse open point in time response: %w", err)
+	}
+	return response.ID, nil
+}
+
+// ClosePointInTime releases a point in time before it expires. Returns common.ErrNotFound if it
+// doesn't exist, e.g. because it already expired.
+func (r *ElasticsearchProductRepository) ClosePointInTime(ctx context.Context, pit string) error {
+	body, err := json.Marshal(map[string]string{"id": pit})
+	if err != nil {
+		return fmt.Errorf("failed to encode close point in time request: %w", err)
+	}
+
+	res, err := r.es.ClosePointInTime(
+		r.es.ClosePointInTime.WithContext(ctx),
+		r.es.ClosePointInTime.WithBody(bytes.NewReader(body)),
+	)
+	if err != nil {
+		return fmt.Errorf("close point in time request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("point in time: %w", common.ErrNotFound)
+	}
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+	return nil
+}
+
+// nextCursor returns the cursor continuing after the last hit of a full page, or nil on the last page
+func nextCursor(response map[string]interface{}, pit string, limit int) *models.Cursor {
+	hitsSection, _ := response["hits"].(map[string]interface{})
+	hits, _ := hitsSection["hits"].([]interface{})
+	if limit <= 0 || len(hits) < limit {
+		return nil
+	}
+
+	last, _ := hits[len(hits)-1].(map[string]interface{})
+	after, _ := last["sort"].([]interface{})
+	if len(after) == 0 {
+		return nil
+	}
+	return &models.Cursor{PIT: pit, After: after}
+}
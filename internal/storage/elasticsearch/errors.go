@@ -0,0 +1,25 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// decodeErrorResponse converts an Elasticsearch error response into a Go error
+func decodeErrorResponse(res *esapi.Response) error {
+	var e map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
+		return fmt.Errorf("error parsing elasticsearch error response: %w", err)
+	}
+
+	errorMsg := fmt.Sprintf("[%s]", res.Status())
+	if details, ok := e["error"].(map[string]interface{}); ok {
+		errorMsg = fmt.Sprintf("[%s] %s: %s", res.Status(), details["type"], details["reason"])
+	}
+
+	log.Print(errorMsg)
+	return fmt.Errorf("%s", errorMsg)
+}
@@ -0,0 +1,222 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// ExclusionIndex holds the rules hiding products or companies from search results
+const ExclusionIndex = "search_exclusions"
+
+// ExclusionRepository defines the interface for search exclusion rule operations
+type ExclusionRepository interface {
+	SaveRule(ctx context.Context, rule models.ExclusionRule) (models.ExclusionRule, error)
+	GetRule(ctx context.Context, id string) (models.ExclusionRule, error)
+	DeleteRule(ctx context.Context, id string) error
+	FindRules(ctx context.Context, limit int, offset int) ([]models.ExclusionRule, int64, error)
+	FindRulesForKeyword(ctx context.Context, keyword string) ([]models.ExclusionRule, error)
+}
+
+// ElasticsearchExclusionRepository implements ExclusionRepository using Elasticsearch
+type ElasticsearchExclusionRepository struct {
+	es        *elasticsearch.Client
+	indexName string
+}
+
+// NewElasticsearchExclusionRepository creates a new ElasticsearchExclusionRepository
+func NewElasticsearchExclusionRepository(es *elasticsearch.Client, indexName string) *ElasticsearchExclusionRepository {
+	return &ElasticsearchExclusionRepository{
+		es:        es,
+		indexName: indexName,
+	}
+}
+
+// SaveRule creates a rule, letting Elasticsearch assign its ID, or replaces the rule with the given ID
+func (r *ElasticsearchExclusionRepository) SaveRule(ctx context.Context, rule models.ExclusionRule) (models.ExclusionRule, error) {
+	if err := createIndexWithMapping(r.es, r.indexName, `{
+		"mappings": {
+			"properties": {
+				"id": {"type": "keyword"},
+				"keyword": {"type": "keyword"},
+				"product_ids": {"type": "keyword"},
+				"companies": {"type": "keyword"},
+				"reason": {"type": "text"},
+				"updated_at": {"type": "date"}
+			}
+		}
+	}`); err != nil {
+		return models.ExclusionRule{}, err
+	}
+
+	body, err := json.Marshal(rule)
+	if err != nil {
+		return models.ExclusionRule{}, fmt.Errorf("failed to encode exclusion rule: %w", err)
+	}
+
+	opts := []func(*esapi.IndexRequest){
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithRefresh("wait_for"),
+	}
+	if rule.ID != "" {
+		opts = append(opts, r.es.Index.WithDocumentID(rule.ID))
+	}
+
+	res, err := r.es.Index(r.indexName, bytes.NewReader(body), opts...)
+	if err != nil {
+		return models.ExclusionRule{}, fmt.Errorf("index request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.ExclusionRule{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		ID string `json:"_id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.ExclusionRule{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	rule.ID = response.ID
+	return rule, nil
+}
+
+// GetRule loads a rule by ID. Returns common.ErrNotFound if it doesn't exist.
+func (r *ElasticsearchExclusionRepository) GetRule(ctx context.Context, id string) (models.ExclusionRule, error) {
+	res, err := r.es.Get(
+		r.indexName,
+		id,
+		r.es.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return models.ExclusionRule{}, fmt.Errorf("get request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return models.ExclusionRule{}, fmt.Errorf("exclusion rule %s: %w", id, common.ErrNotFound)
+	}
+
+	if res.IsError() {
+		return models.ExclusionRule{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		ID     string               `json:"_id"`
+		Source models.ExclusionRule `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.ExclusionRule{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	response.Source.ID = response.ID
+	return response.Source, nil
+}
+
+// DeleteRule removes a rule. Returns common.ErrNotFound if it doesn't exist.
+func (r *ElasticsearchExclusionRepository) DeleteRule(ctx context.Context, id string) error {
+	res, err := r.es.Delete(
+		r.indexName,
+		id,
+		r.es.Delete.WithContext(ctx),
+		r.es.Delete.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		return fmt.Errorf("delete request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("exclusion rule %s: %w", id, common.ErrNotFound)
+	}
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	return nil
+}
+
+// FindRules lists rules, global rules first, then by keyword
+func (r *ElasticsearchExclusionRepository) FindRules(ctx context.Context, limit int, offset int) ([]models.ExclusionRule, int64, error) {
+	return r.search(ctx, map[string]interface{}{
+		"from": offset,
+		"size": limit,
+		"sort": []map[string]interface{}{
+			{"keyword": map[string]interface{}{"order": "asc"}},
+			{"updated_at": map[string]interface{}{"order": "desc"}},
+		},
+	})
+}
+
+// maxRulesPerSearch caps the number of rules applied to a single search
+const maxRulesPerSearch = 100
+
+// FindRulesForKeyword returns the global rules plus the rules of the (normalized) keyword
+func (r *ElasticsearchExclusionRepository) FindRulesForKeyword(ctx context.Context, keyword string) ([]models.ExclusionRule, error) {
+	rules, _, err := r.search(ctx, map[string]interface{}{
+		"size": maxRulesPerSearch,
+		"query": map[string]interface{}{
+			"terms": map[string]interface{}{"keyword": []string{"", keyword}},
+		},
+	})
+	return rules, err
+}
+
+// search runs a query against the exclusion index and returns the rules with the total hit count
+func (r *ElasticsearchExclusionRepository) search(ctx context.Context, query map[string]interface{}) ([]models.ExclusionRule, int64, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, 0, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.indexName),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+		r.es.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return nil, 0, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, 0, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID     string               `json:"_id"`
+				Source models.ExclusionRule `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	rules := make([]models.ExclusionRule, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		hit.Source.ID = hit.ID
+		rules = append(rules, hit.Source)
+	}
+
+	return rules, response.Hits.Total.Value, nil
+}
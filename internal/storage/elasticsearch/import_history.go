@@ -0,0 +1,365 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// ImportHistoryIndex is the index holding one summary document per import run
+const ImportHistoryIndex = "import_history"
+
+// ImportsAlias is the alias the import history index is also read through, as the imports index
+const ImportsAlias = "imports"
+
+// ImportFingerprintsIndex holds, per import run, the fingerprint of every imported product
+const ImportFingerprintsIndex = "import_fingerprints"
+
+// ImportHistoryRepository defines the interface for import run history operations
+type ImportHistoryRepository interface {
+	SaveImportRun(ctx context.Context, run models.ImportRun) (models.ImportRun, error)
+	FindImportRuns(ctx context.Context, params models.ImportRunSearchParams) (models.ImportRunSearchResult, error)
+	SaveFingerprints(ctx context.Context, runID string, fingerprints map[string]string) error
+	GetFingerprints(ctx context.Context, runID string) (map[string]string, error)
+}
+
+// ElasticsearchImportHistoryRepository implements ImportHistoryRepository using Elasticsearch
+type ElasticsearchImportHistoryRepository struct {
+	es        *elasticsearch.Client
+	indexName string
+}
+
+// NewElasticsearchImportHistoryRepository creates a new ElasticsearchImportHistoryRepository
+func NewElasticsearchImportHistoryRepository(es *elasticsearch.Client, indexName string) *ElasticsearchImportHistoryRepository {
+	return &ElasticsearchImportHistoryRepository{
+		es:        es,
+		indexName: indexName,
+	}
+}
+
+// EnsureIndex creates the history index with its imports alias, or adds the alias to an index created before it
+// existed. It runs once at startup so saving a run never depends on the alias.
+func (r *ElasticsearchImportHistoryRepository) EnsureIndex(ctx context.Context) error {
+	if err := r.createIndexIfNotExists(); err != nil {
+		return err
+	}
+
+	res, err := r.es.Indices.ExistsAlias(
+		[]string{ImportsAlias},
+		r.es.Indices.ExistsAlias.WithContext(ctx),
+		r.es.Indices.ExistsAlias.WithIndex(r.indexName),
+	)
+	if err != nil {
+		return fmt.Errorf("alias exists request failed: %w", err)
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return r.putAlias(ctx)
+}
+
+// SaveImportRun stores an import run summary, creating the history index with its imports alias on first use
+func (r *ElasticsearchImportHistoryRepository) SaveImportRun(ctx context.Context, run models.ImportRun) (models.ImportRun, error) {
+	if err := r.createIndexIfNotExists(); err != nil {
+		return models.ImportRun{}, err
+	}
+
+	if run.ID == "" {
+		run.ID = fmt.Sprintf("%d", run.StartedAt.UnixNano())
+	}
+
+	body, err := json.Marshal(run)
+	if err != nil {
+		return models.ImportRun{}, fmt.Errorf("failed to encode import run: %w", err)
+	}
+
+	res, err := r.es.Index(
+		r.indexName,
+		bytes.NewReader(body),
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithDocumentID(run.ID),
+	)
+	if err != nil {
+		return models.ImportRun{}, fmt.Errorf("index request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.ImportRun{}, decodeErrorResponse(res)
+	}
+
+	return run, nil
+}
+
+// FindImportRuns lists import runs, newest first, matching the given filters
+func (r *ElasticsearchImportHistoryRepository) FindImportRuns(ctx context.Context, params models.ImportRunSearchParams) (models.ImportRunSearchResult, error) {
+	var filters []map[string]interface{}
+	if params.Source != "" {
+		filters = append(filters, map[string]interface{}{
+			"wildcard": map[string]interface{}{"source": map[string]interface{}{"value": "*" + params.Source + "*"}},
+		})
+	}
+	if params.Status != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"status": params.Status}})
+	}
+	if params.TriggeredBy != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"triggered_by": params.TriggeredBy}})
+	}
+	if params.Since != nil || params.Until != nil {
+		dateRange := map[string]interface{}{}
+		if params.Since != nil {
+			dateRange["gte"] = params.Since.Format(time.RFC3339)
+		}
+		if params.Until != nil {
+			dateRange["lte"] = params.Until.Format(time.RFC3339)
+		}
+		filters = append(filters, map[string]interface{}{"range": map[string]interface{}{"started_at": dateRange}})
+	}
+
+	query := map[string]interface{}{
+		"from": params.Offset,
+		"size": params.Limit,
+		"sort": []map[string]interface{}{
+			{"started_at": map[string]interface{}{"order": "desc"}},
+		},
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{"filter": filters},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return models.ImportRunSearchResult{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.indexName),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+		r.es.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.ImportRunSearchResult{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.ImportRunSearchResult{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Source models.ImportRun `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.ImportRunSearchResult{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	runs := make([]models.ImportRun, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		runs = append(runs, hit.Source)
+	}
+
+	return models.ImportRunSearchResult{
+		Runs:       runs,
+		TotalCount: response.Hits.Total.Value,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	}, nil
+}
+
+// SaveFingerprints stores the product fingerprints of an import run as a single unindexed document
+func (r *ElasticsearchImportHistoryRepository) SaveFingerprints(ctx context.Context, runID string, fingerprints map[string]string) error {
+	if err := createIndexWithMapping(r.es, ImportFingerprintsIndex, `{
+		"mappings": {
+			"properties": {
+				"run_id": {"type": "keyword"},
+				"fingerprints": {"type": "object", "enabled": false}
+			}
+		}
+	}`); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"run_id":       runID,
+		"fingerprints": fingerprints,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode fingerprints: %w", err)
+	}
+
+	res, err := r.es.Index(
+		ImportFingerprintsIndex,
+		bytes.NewReader(body),
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithDocumentID(runID),
+	)
+	if err != nil {
+		return fmt.Errorf("index request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	return nil
+}
+
+// GetFingerprints loads the product fingerprints of an import run. Returns common.ErrNotFound if none were stored.
+func (r *ElasticsearchImportHistoryRepository) GetFingerprints(ctx context.Context, runID string) (map[string]string, error) {
+	res, err := r.es.Get(
+		ImportFingerprintsIndex,
+		runID,
+		r.es.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("fingerprints of import %s: %w", runID, common.ErrNotFound)
+	}
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Source struct {
+			Fingerprints map[string]string `json:"fingerprints"`
+		} `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return response.Source.Fingerprints, nil
+}
+
+// putAlias points the imports alias at the history index
+func (r *ElasticsearchImportHistoryRepository) putAlias(ctx context.Context) error {
+	res, err := r.es.Indices.PutAlias(
+		[]string{r.indexName},
+		ImportsAlias,
+		r.es.Indices.PutAlias.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("put alias request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	return nil
+}
+
+// createIndexIfNotExists creates the import history index with its imports alias and keyword fields for filtering
+func (r *ElasticsearchImportHistoryRepository) createIndexIfNotExists() error {
+	return createIndexWithMapping(r.es, r.indexName, `{
+		"aliases": {
+			"`+ImportsAlias+`": {}
+		},
+		"mappings": {
+			"properties": {
+				"id": {"type": "keyword"},
+				"source": {"type": "keyword"},
+				"index": {"type": "keyword"},
+				"triggered_by": {"type": "keyword"},
+				"status": {"type": "keyword"},
+				"started_at": {"type": "date"},
+				"finished_at": {"type": "date"},
+				"duration_ms": {"type": "long"},
+				"rows_read": {"type": "integer"},
+				"rows_skipped": {"type": "integer"},
+				"indexed": {"type": "integer"},
+				"failed": {"type": "integer"},
+				"errors": {"type": "text"},
+				"staged": {"type": "boolean"},
+				"promoted": {"type": "boolean"},
+				"checks": {
+					"properties": {
+						"name": {"type": "keyword"},
+						"passed": {"type": "boolean"},
+						"detail": {"type": "text"}
+					}
+				},
+				"sheets": {
+					"properties": {
+						"name": {"type": "keyword"},
+						"rows_read": {"type": "integer"},
+						"rows_skipped": {"type": "integer"},
+						"indexed": {"type": "integer"},
+						"failed": {"type": "integer"},
+						"error": {"type": "text"}
+					}
+				},
+				"duplicate_ids": {"type": "integer"},
+				"near_duplicates": {"type": "integer"},
+				"duplicates": {
+					"properties": {
+						"kind": {"type": "keyword"},
+						"value": {"type": "text"},
+						"rows": {
+							"properties": {
+								"source": {"type": "keyword"},
+								"row": {"type": "integer"}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`)
+}
+
+// createIndexWithMapping creates an index with the given body unless it already exists
+func createIndexWithMapping(es *elasticsearch.Client, indexName string, body string) error {
+	res, err := es.Indices.Exists([]string{indexName})
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	if res.StatusCode == 200 {
+		return nil
+	}
+
+	res, err = es.Indices.Create(
+		indexName,
+		es.Indices.Create.WithBody(strings.NewReader(body)),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to create index: %s", res.String())
+	}
+
+	return nil
+}
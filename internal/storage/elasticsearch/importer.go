@@ -1,252 +1,164 @@
 package elasticsearch
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"regexp"
-	"strconv"
-	"strings"
 	"time"
 
 	"elasticsearch/internal/models"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
-	fiberlog "github.com/gofiber/fiber/v3/log"
 )
 
-// ImportFromExcel imports data from an Excel file or Google Sheets URL
-func ImportFromExcel(esClient *elasticsearch.Client, indexName string, filePath string) error {
-	// Check if the path is a Google Sheets URL
-	if strings.Contains(filePath, "docs.google.com/spreadsheets") {
-		return importFromGoogleSheets(esClient, indexName, filePath)
-	}
+// BulkAction is the bulk API operation used for each document
+type BulkAction string
+
+const (
+	// BulkActionIndex creates or replaces documents
+	BulkActionIndex BulkAction = "index"
+	// BulkActionCreate only creates documents, failing items whose ID already exists
+	BulkActionCreate BulkAction = "create"
+	// BulkActionUpsert updates the imported fields of existing documents, keeping created_at, score and deleted_at,
+	// and creates the missing ones; products must have an ID
+	BulkActionUpsert BulkAction = "update"
+)
 
-	// Handle local file import (implementation would be similar but using excelize)
-	return fmt.Errorf("local file import not implemented")
+// upsertDocument is the body of an update item: doc is merged into an existing document, upsert is indexed as is
+// when the document doesn't exist. Unlike doc_as_upsert, new documents get their created_at and score.
+type upsertDocument struct {
+	Doc    upsertFields   `json:"doc"`
+	Upsert models.Product `json:"upsert"`
 }
 
-// importFromGoogleSheets imports data from a Google Sheets URL
-func importFromGoogleSheets(esClient *elasticsearch.Client, indexName string, sheetsURL string) error {
-	// Extract the spreadsheet ID from the URL
-	spreadsheetID, err := extractSpreadsheetID(sheetsURL)
-	if err != nil {
-		return err
-	}
-
-	// Download the CSV data
-	csvData, err := downloadGoogleSheetCSV(spreadsheetID)
-	if err != nil {
-		return err
-	}
-
-	// Parse CSV data
-	lines := strings.Split(csvData, "\n")
-	if len(lines) < 2 {
-		return fmt.Errorf("spreadsheet contains no data")
-	}
-
-	// Process header and validate columns
-	columnMap, err := validateCSVHeaders(lines[0])
-	if err != nil {
-		return err
-	}
-
-	// Create index if it doesn't exist
-	err = createIndexIfNotExists(esClient, indexName)
-	if err != nil {
-		return fmt.Errorf("failed to create index: %w", err)
-	}
-
-	// Process data lines and create products
-	products := processCSVDataLines(lines, columnMap)
-
-	// Import products in batches using bulk API
-	return importProductsBulk(esClient, indexName, products)
+// upsertFields are the fields of a product that an upsert overwrites
+type upsertFields struct {
+	ID          models.ProductID `json:"id"`
+	ProductName string           `json:"product_name"`
+	DrugGeneric string           `json:"drug_generic"`
+	Company     string           `json:"company"`
+	// Attributes are merged into the attributes of existing products
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	UpdatedAt  time.Time              `json:"updated_at"`
 }
 
-// downloadGoogleSheetCSV downloads CSV data from Google Sheets
-func downloadGoogleSheetCSV(spreadsheetID string) (string, error) {
-	exportURL := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/export?format=csv", spreadsheetID)
-	fiberlog.Infof("Downloading spreadsheet data from: %s", exportURL)
-
-	resp, err := http.Get(exportURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to download spreadsheet: %w", err)
+// bulkDocument returns the body of the bulk item writing product with action
+func bulkDocument(action BulkAction, product models.Product) interface{} {
+	if action != BulkActionUpsert {
+		return product
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to download spreadsheet, status code: %d", resp.StatusCode)
+	return upsertDocument{
+		Doc: upsertFields{
+			ID:          product.ID,
+			ProductName: product.ProductName,
+			DrugGeneric: product.DrugGeneric,
+			Company:     product.Company,
+			Attributes:  product.Attributes,
+			UpdatedAt:   product.UpdatedAt,
+		},
+		Upsert: product,
 	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	return string(body), nil
 }
 
-// validateCSVHeaders validates that required columns exist in the CSV
-func validateCSVHeaders(headerLine string) (map[string]int, error) {
-	headerFields := parseCSVLine(headerLine)
-	columnMap := make(map[string]int)
-	requiredColumns := []string{"id", "product_name", "drug_generic", "company"}
-
-	// Map column names to indices
-	for i, header := range headerFields {
-		columnMap[strings.ToLower(header)] = i
-	}
-
-	// Verify all required columns exist
-	for _, col := range requiredColumns {
-		if _, exists := columnMap[col]; !exists {
-			return nil, fmt.Errorf("required column '%s' not found in spreadsheet", col)
-		}
-	}
-
-	return columnMap, nil
+// bulkResponse mirrors the parts of the bulk API response used to report per-item outcomes
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
 }
 
-// processCSVDataLines processes CSV data lines into Product objects
-func processCSVDataLines(lines []string, columnMap map[string]int) []models.Product {
-	var products []models.Product
-	now := time.Now()
-	requiredColumns := []string{"id", "product_name", "drug_generic", "company"}
-
-	for i := 1; i < len(lines); i++ {
-		line := lines[i]
-		if len(line) == 0 {
-			continue // Skip empty lines
-		}
+// BulkWriteProducts writes a batch of products with a single bulk request and reports the outcome of every item.
+// Products without an ID get one assigned by Elasticsearch. Item positions refer to the products slice.
+func BulkWriteProducts(ctx context.Context, esClient *elasticsearch.Client, indexName string, action BulkAction, products []models.Product) (models.BulkResult, error) {
+	result := models.BulkResult{}
+	if len(products) == 0 {
+		return result, nil
+	}
 
-		fields := parseCSVLine(line)
-		if len(fields) < len(requiredColumns) {
-			fiberlog.Warnf("Row %d has fewer fields than expected, skipping", i+1)
+	var bulkBody bytes.Buffer
+	positions := make([]int, 0, len(products))
+	for i, product := range products {
+		// Add document data
+		productJSON, err := json.Marshal(bulkDocument(action, product))
+		if err != nil {
+			result.Add(models.BulkItemResult{Position: i, ID: product.ID, Status: http.StatusBadRequest, Error: err.Error()})
 			continue
 		}
 
-		// Parse ID to uint64
-		idStr := fields[columnMap["id"]]
-		id, err := strconv.ParseUint(idStr, 10, 64)
+		// Add bulk action - using string ID for Elasticsearch
+		meta := map[string]string{"_index": indexName}
+		if !product.ID.IsZero() {
+			meta["_id"] = product.ID.String()
+		}
+		actionJSON, err := json.Marshal(map[string]interface{}{string(action): meta})
 		if err != nil {
-			fiberlog.Warnf("Invalid ID at row %d: %v, skipping", i+1, err)
+			result.Add(models.BulkItemResult{Position: i, ID: product.ID, Status: http.StatusBadRequest, Error: err.Error()})
 			continue
 		}
 
-		product := models.Product{
-			ID:          id,
-			ProductName: fields[columnMap["product_name"]],
-			DrugGeneric: fields[columnMap["drug_generic"]],
-			Company:     fields[columnMap["company"]],
-			Score:       0.0, // Default score
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
-
-		products = append(products, product)
+		bulkBody.Write(actionJSON)
+		bulkBody.WriteString("\n")
+		bulkBody.Write(productJSON)
+		bulkBody.WriteString("\n")
+		positions = append(positions, i)
 	}
 
-	return products
-}
-
-// parseCSVLine properly handles CSV lines, considering quoted values that might contain commas
-func parseCSVLine(line string) []string {
-	var result []string
-	var currentField strings.Builder
-	inQuotes := false
-
-	for _, char := range line {
-		switch char {
-		case '"':
-			inQuotes = !inQuotes
-		case ',':
-			if inQuotes {
-				currentField.WriteRune(char)
-			} else {
-				result = append(result, currentField.String())
-				currentField.Reset()
-			}
-		default:
-			currentField.WriteRune(char)
-		}
+	if len(positions) == 0 {
+		return result, nil
 	}
 
-	// Add the last field
-	result = append(result, currentField.String())
-
-	// Clean up fields (remove surrounding quotes and whitespace)
-	for i := range result {
-		result[i] = strings.Trim(result[i], "\" \t\r\n")
+	req := esapi.BulkRequest{
+		Body: &bulkBody,
 	}
 
-	return result
-}
-
-// importProductsBulk imports products using the Elasticsearch bulk API
-func importProductsBulk(esClient *elasticsearch.Client, indexName string, products []models.Product) error {
-	if len(products) == 0 {
-		fiberlog.Info("No products to import")
-		return nil
+	res, err := req.Do(ctx, esClient)
+	if err != nil {
+		return result, fmt.Errorf("bulk request failed: %w", err)
 	}
+	defer res.Body.Close()
 
-	fiberlog.Infof("Starting bulk import of %d products", len(products))
-
-	// Create a bulk request
-	var bulkBody strings.Builder
-	batchSize := 100
+	if res.IsError() {
+		responseBody, _ := io.ReadAll(res.Body)
+		return result, fmt.Errorf("bulk request returned error: %s", string(responseBody))
+	}
 
-	for i, product := range products {
-		// Add bulk action - using string ID for Elasticsearch
-		actionLine := fmt.Sprintf(`{"index":{"_index":"%s","_id":"%d"}}`, indexName, product.ID)
-		bulkBody.WriteString(actionLine)
-		bulkBody.WriteString("\n")
+	var response bulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return result, fmt.Errorf("failed to parse bulk response: %w", err)
+	}
 
-		// Add document data
-		productJSON, err := json.Marshal(product)
-		if err != nil {
-			fiberlog.Warnf("Failed to marshal product %d: %v", product.ID, err)
-			continue
+	for i, item := range response.Items {
+		if i >= len(positions) {
+			break
 		}
-
-		bulkBody.Write(productJSON)
-		bulkBody.WriteString("\n")
-
-		// Process in batches
-		if (i+1)%batchSize == 0 || i == len(products)-1 {
-			// Send the batch
-			req := esapi.BulkRequest{
-				Body: strings.NewReader(bulkBody.String()),
+		for _, outcome := range item {
+			itemResult := models.BulkItemResult{
+				Position: positions[i],
+				ID:       models.ProductID(outcome.ID),
+				Status:   outcome.Status,
 			}
-
-			res, err := req.Do(context.Background(), esClient)
-			if err != nil {
-				fiberlog.Errorf("Bulk request failed: %v", err)
-				continue
+			if outcome.Error != nil {
+				itemResult.Error = fmt.Sprintf("%s: %s", outcome.Error.Type, outcome.Error.Reason)
 			}
-
-			if res.IsError() {
-				responseBody, _ := io.ReadAll(res.Body)
-				fiberlog.Errorf("Bulk request returned error: %s", string(responseBody))
-			} else {
-				fiberlog.Infof("Successfully processed batch of %d products", min(batchSize, len(products)-i+batchSize-1))
-			}
-
-			res.Body.Close()
-			bulkBody.Reset()
+			result.Add(itemResult)
 		}
 	}
 
-	fiberlog.Info("✅ Bulk import completed")
-	return nil
+	return result, nil
 }
 
-// createIndexIfNotExists creates the Elasticsearch index if it doesn't already exist
-func createIndexIfNotExists(esClient *elasticsearch.Client, indexName string) error {
+// CreateIndexIfNotExists creates the Elasticsearch index with the product mapping if it doesn't already exist
+func CreateIndexIfNotExists(esClient *elasticsearch.Client, indexName string, analysis IndexAnalysis) error {
 	// Check if index exists
 	res, err := esClient.Indices.Exists([]string{indexName})
 	if err != nil {
@@ -258,24 +170,20 @@ func createIndexIfNotExists(esClient *elasticsearch.Client, indexName string) er
 		return nil
 	}
 
+	// The search analyzers need their synonyms set to exist
+	if err := EnsureSynonymSet(context.Background(), esClient, ProductSynonymSet); err != nil {
+		return fmt.Errorf("failed to create synonyms set: %w", err)
+	}
+
 	// Create index with mapping for our Product struct
-	mapping := `{
-		"mappings": {
-			"properties": {
-				"id": {"type": "long"},
-				"product_name": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
-				"drug_generic": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
-				"company": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
-				"score": {"type": "float"},
-				"created_at": {"type": "date"},
-				"updated_at": {"type": "date"}
-			}
-		}
-	}`
+	body, err := json.Marshal(ProductIndexBody(analysis))
+	if err != nil {
+		return fmt.Errorf("failed to encode index mapping: %w", err)
+	}
 
 	res, err = esClient.Indices.Create(
 		indexName,
-		esClient.Indices.Create.WithBody(strings.NewReader(mapping)),
+		esClient.Indices.Create.WithBody(bytes.NewReader(body)),
 	)
 
 	if err != nil {
@@ -289,23 +197,79 @@ func createIndexIfNotExists(esClient *elasticsearch.Client, indexName string) er
 	return nil
 }
 
-// extractSpreadsheetID extracts the Google Sheets ID from a URL
-func extractSpreadsheetID(url string) (string, error) {
-	// Pattern for Google Sheets URLs
-	r := regexp.MustCompile(`/d/([a-zA-Z0-9-_]+)`)
-	matches := r.FindStringSubmatch(url)
+// DefaultMaxResultWindow is the index.max_result_window of indices that don't set it
+const DefaultMaxResultWindow = 10000
 
-	if len(matches) < 2 {
-		return "", fmt.Errorf("could not extract spreadsheet ID from URL")
+// SetMaxResultWindow sets index.max_result_window on an index or every index behind an alias;
+// a missing index is left alone and gets the setting once an import creates it
+func SetMaxResultWindow(ctx context.Context, esClient *elasticsearch.Client, indexName string, window int) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"index": map[string]interface{}{"max_result_window": window},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode index settings: %w", err)
 	}
 
-	return matches[1], nil
+	res, err := esClient.Indices.PutSettings(
+		bytes.NewReader(body),
+		esClient.Indices.PutSettings.WithContext(ctx),
+		esClient.Indices.PutSettings.WithIndex(indexName),
+	)
+	if err != nil {
+		return fmt.Errorf("update index settings request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+	return nil
 }
 
-// min returns the minimum of two integers
-func min(a, b int) int {
-	if a < b {
-		return a
+// RefreshIndex makes everything indexed so far searchable; a missing index is ignored
+func RefreshIndex(ctx context.Context, esClient *elasticsearch.Client, indexName string) error {
+	res, err := esClient.Indices.Refresh(
+		esClient.Indices.Refresh.WithContext(ctx),
+		esClient.Indices.Refresh.WithIndex(indexName),
+		esClient.Indices.Refresh.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return fmt.Errorf("refresh request failed: %w", err)
+	}
+	res.Body.Close()
+	return nil
+}
+
+// CountDocuments refreshes an index or alias and returns its document count; a missing index counts as empty
+func CountDocuments(ctx context.Context, esClient *elasticsearch.Client, indexName string) (int64, error) {
+	if err := RefreshIndex(ctx, esClient, indexName); err != nil {
+		return 0, err
+	}
+
+	res, err := esClient.Count(
+		esClient.Count.WithContext(ctx),
+		esClient.Count.WithIndex(indexName),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("count request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return 0, nil
+	}
+	if res.IsError() {
+		return 0, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
 	}
-	return b
+	return response.Count, nil
 }
@@ -0,0 +1,319 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"elasticsearch/internal/search/querybuilder"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// ProductMappingVersion is recorded in the _meta of every product index created by this build. Bump it whenever
+// the mapping or analysis settings change, so outdated live indices are reported at startup. Indices created
+// before versioning have no _meta and count as version 1.
+const ProductMappingVersion = 4
+
+// PrefixAnalyzer is the index analyzer of the edge n-gram prefix subfields
+const PrefixAnalyzer = "prefix_edge_ngram"
+
+// SearchAnalyzer and SuggestSearchAnalyzer expand query terms with the rules of ProductSynonymSet; they are only
+// used at search time, so the rules can change without reindexing
+const (
+	SearchAnalyzer        = "product_search"
+	SuggestSearchAnalyzer = "product_suggest_search"
+)
+
+// IndexAnalysis holds the configurable analysis settings of product indices
+type IndexAnalysis struct {
+	// EdgeNGram adds a prefix subfield to product_name and drug_generic holding the edge n-grams of every term
+	EdgeNGram bool
+	MinGram   int
+	MaxGram   int
+}
+
+// IndexMapping describes the mapping a product index was created with
+type IndexMapping struct {
+	Version   int
+	EdgeNGram bool
+	// Attributes are the names of the product attributes mapped in the index, in alphabetical order
+	Attributes []string
+}
+
+// textField maps a full-text field with an exact keyword subfield and the given extra subfields; with synonyms
+// its query terms are expanded by SearchAnalyzer
+func textField(synonyms bool, subfields map[string]interface{}) map[string]interface{} {
+	fields := map[string]interface{}{"keyword": map[string]interface{}{"type": "keyword"}}
+	for name, field := range subfields {
+		fields[name] = field
+	}
+	field := map[string]interface{}{"type": "text", "fields": fields}
+	if synonyms {
+		field["search_analyzer"] = SearchAnalyzer
+	}
+	return field
+}
+
+// ProductIndexBody builds the settings and mappings of a new product index
+func ProductIndexBody(analysis IndexAnalysis) map[string]interface{} {
+	nameFields := map[string]interface{}{
+		// The suggester indexes with the default simple analyzer; its search analyzer tokenizes the same way.
+		// Every name is indexed with the exact company of its product as context, so suggestions can be
+		// limited to one company; products are always written with a company, empty if unknown.
+		"suggest": map[string]interface{}{
+			"type":            "completion",
+			"analyzer":        "simple",
+			"search_analyzer": SuggestSearchAnalyzer,
+			"contexts": []map[string]interface{}{
+				{"name": querybuilder.CompanyContext, "type": "category", "path": "company.keyword"},
+			},
+		},
+		// Its shingle subfields need their own search analyzers, so typeahead is not synonym-aware
+		"typeahead": map[string]interface{}{"type": "search_as_you_type"},
+	}
+	genericFields := map[string]interface{}{}
+	if analysis.EdgeNGram {
+		// Query terms are matched as typed against the indexed prefixes
+		prefix := map[string]interface{}{
+			"type":            "text",
+			"analyzer":        PrefixAnalyzer,
+			"search_analyzer": "standard",
+		}
+		nameFields["prefix"] = prefix
+		genericFields["prefix"] = prefix
+	}
+
+	body := map[string]interface{}{
+		"mappings": map[string]interface{}{
+			"_meta": map[string]interface{}{
+				"mapping_version": ProductMappingVersion,
+				"edge_ngram":      analysis.EdgeNGram,
+			},
+			"properties": map[string]interface{}{
+				"id":           map[string]interface{}{"type": "keyword"},
+				"product_name": textField(true, nameFields),
+				"drug_generic": textField(true, genericFields),
+				"company":      textField(false, nil),
+				"score":        map[string]interface{}{"type": "float"},
+				"created_at":   map[string]interface{}{"type": "date"},
+				"updated_at":   map[string]interface{}{"type": "date"},
+				"deleted_at":   map[string]interface{}{"type": "date"},
+			},
+		},
+	}
+
+	filters, analyzers := synonymAnalysis()
+	if analysis.EdgeNGram {
+		filters["prefix_edge_ngram"] = map[string]interface{}{
+			"type":     "edge_ngram",
+			"min_gram": analysis.MinGram,
+			"max_gram": analysis.MaxGram,
+		}
+		analyzers[PrefixAnalyzer] = map[string]interface{}{
+			"type":      "custom",
+			"tokenizer": "standard",
+			"filter":    []string{"lowercase", "prefix_edge_ngram"},
+		}
+	}
+	body["settings"] = map[string]interface{}{
+		"analysis": map[string]interface{}{
+			"filter":   filters,
+			"analyzer": analyzers,
+		},
+	}
+	return body
+}
+
+// synonymAnalysis returns the token filters and analyzers expanding query terms with the rules of
+// ProductSynonymSet
+func synonymAnalysis() (filters, analyzers map[string]interface{}) {
+	// Updateable filters are reloaded when their synonyms set changes
+	filters = map[string]interface{}{
+		"product_synonyms": map[string]interface{}{
+			"type":         "synonym_graph",
+			"synonyms_set": ProductSynonymSet,
+			"updateable":   true,
+		},
+	}
+	analyzers = map[string]interface{}{
+		SearchAnalyzer: map[string]interface{}{
+			"type":      "custom",
+			"tokenizer": "standard",
+			"filter":    []string{"lowercase", "product_synonyms"},
+		},
+		SuggestSearchAnalyzer: map[string]interface{}{
+			"type":      "custom",
+			"tokenizer": "lowercase",
+			"filter":    []string{"product_synonyms"},
+		},
+	}
+	return filters, analyzers
+}
+
+// GetIndexMappings returns the mapping version and attributes of an index or of every index behind an alias, keyed by
+// index name; a missing index yields no entries
+func GetIndexMappings(ctx context.Context, esClient *elasticsearch.Client, indexName string) (map[string]IndexMapping, error) {
+	res, err := esClient.Indices.GetMapping(
+		esClient.Indices.GetMapping.WithContext(ctx),
+		esClient.Indices.GetMapping.WithIndex(indexName),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get mapping request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response map[string]struct {
+		Mappings struct {
+			Meta struct {
+				MappingVersion int  `json:"mapping_version"`
+				EdgeNGram      bool `json:"edge_ngram"`
+			} `json:"_meta"`
+			Properties struct {
+				Attributes struct {
+					Properties map[string]json.RawMessage `json:"properties"`
+				} `json:"attributes"`
+			} `json:"properties"`
+		} `json:"mappings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	mappings := make(map[string]IndexMapping, len(response))
+	for index, entry := range response {
+		mapping := IndexMapping{Version: entry.Mappings.Meta.MappingVersion, EdgeNGram: entry.Mappings.Meta.EdgeNGram}
+		if mapping.Version == 0 {
+			mapping.Version = 1
+		}
+		for name := range entry.Mappings.Properties.Attributes.Properties {
+			mapping.Attributes = append(mapping.Attributes, name)
+		}
+		slices.Sort(mapping.Attributes)
+		mappings[index] = mapping
+	}
+	return mappings, nil
+}
+
+// AttributeProperty maps a product attribute holding values of a column type detected by import inspections:
+// integer, number, boolean, date or text
+func AttributeProperty(columnType string) map[string]interface{} {
+	switch columnType {
+	case "integer":
+		return map[string]interface{}{"type": "long"}
+	case "number":
+		return map[string]interface{}{"type": "double"}
+	case "boolean":
+		return map[string]interface{}{"type": "boolean"}
+	case "date":
+		return map[string]interface{}{"type": "date"}
+	}
+	return map[string]interface{}{
+		"type":   "text",
+		"fields": map[string]interface{}{"keyword": map[string]interface{}{"type": "keyword", "ignore_above": 256}},
+	}
+}
+
+// AttributesMapping maps the attributes object of products, which holds the source columns an import keeps besides
+// the product fields; attributes without one of the given properties are mapped dynamically
+func AttributesMapping(properties map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "object", "dynamic": true, "properties": properties}
+}
+
+// PutAttributeMapping adds the properties of product attributes to the mapping of an index or every index behind an
+// alias. Properties already mapped with another type are rejected by Elasticsearch.
+func PutAttributeMapping(ctx context.Context, esClient *elasticsearch.Client, indexName string, properties map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"properties": map[string]interface{}{"attributes": AttributesMapping(properties)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode attribute mapping: %w", err)
+	}
+
+	res, err := esClient.Indices.PutMapping(
+		[]string{indexName},
+		bytes.NewReader(body),
+		esClient.Indices.PutMapping.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("put mapping request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+	return nil
+}
+
+// ReindexResult summarizes a reindex
+type ReindexResult struct {
+	Total    int64
+	Created  int64
+	TookMs   int64
+	Failures []string
+}
+
+// Reindex copies every document of the source index or alias into dest, waiting for completion and refreshing
+// dest afterwards. Documents are re-analyzed with the mapping of dest, which must exist beforehand.
+func Reindex(ctx context.Context, esClient *elasticsearch.Client, source, dest string) (ReindexResult, error) {
+	return reindex(ctx, esClient, map[string]interface{}{
+		"source": map[string]interface{}{"index": source},
+		"dest":   map[string]interface{}{"index": dest},
+	})
+}
+
+// reindex runs a reindex request, waiting for completion and refreshing the destination afterwards
+func reindex(ctx context.Context, esClient *elasticsearch.Client, request map[string]interface{}) (ReindexResult, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return ReindexResult{}, fmt.Errorf("failed to encode reindex request: %w", err)
+	}
+
+	res, err := esClient.Reindex(
+		bytes.NewReader(body),
+		esClient.Reindex.WithContext(ctx),
+		esClient.Reindex.WithWaitForCompletion(true),
+		esClient.Reindex.WithRefresh(true),
+	)
+	if err != nil {
+		return ReindexResult{}, fmt.Errorf("reindex request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return ReindexResult{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Took     int64 `json:"took"`
+		Total    int64 `json:"total"`
+		Created  int64 `json:"created"`
+		Failures []struct {
+			ID    string `json:"id"`
+			Cause struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"cause"`
+		} `json:"failures"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return ReindexResult{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	result := ReindexResult{Total: response.Total, Created: response.Created, TookMs: response.Took}
+	for _, failure := range response.Failures {
+		result.Failures = append(result.Failures, fmt.Sprintf("product %s: %s: %s", failure.ID, failure.Cause.Type, failure.Cause.Reason))
+	}
+	return result, nil
+}
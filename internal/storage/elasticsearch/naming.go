@@ -0,0 +1,217 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// templateTokenPattern matches {...} placeholders in an index name template
+var templateTokenPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// dateLayoutReplacer converts the Java-style date tokens used in templates into Go layouts
+var dateLayoutReplacer = strings.NewReplacer(
+	"yyyy", "2006",
+	"MM", "01",
+	"dd", "02",
+	"HH", "15",
+	"mm", "04",
+	"ss", "05",
+)
+
+// IndexNamer resolves concrete index names from a template such as "products-{yyyy.MM.dd}"
+// or "products-v{n}". Reads always go through the alias; each build writes to a fresh
+// concrete index that is swapped behind the alias once complete.
+type IndexNamer struct {
+	alias    string
+	template string
+}
+
+// NewIndexNamer creates an IndexNamer. An empty template means the alias is used as a plain index name.
+func NewIndexNamer(alias, template string) (*IndexNamer, error) {
+	if template != "" && !templateTokenPattern.MatchString(template) {
+		return nil, fmt.Errorf("index template %q has no {…} placeholder", template)
+	}
+	if template != "" && template == alias {
+		return nil, fmt.Errorf("index template must differ from the alias %q", alias)
+	}
+
+	return &IndexNamer{alias: alias, template: template}, nil
+}
+
+// Alias returns the name used for reads
+func (n *IndexNamer) Alias() string {
+	return n.alias
+}
+
+// Templated reports whether builds write to versioned concrete indices behind the alias
+func (n *IndexNamer) Templated() bool {
+	return n.template != ""
+}
+
+// Pattern returns a wildcard pattern matching every index produced by the template
+func (n *IndexNamer) Pattern() string {
+	if !n.Templated() {
+		return n.alias
+	}
+	return templateTokenPattern.ReplaceAllString(n.template, "*")
+}
+
+// Resolve returns the concrete index name for a new build. Date placeholders are rendered
+// from now; {n} becomes one more than the highest existing version.
+func (n *IndexNamer) Resolve(ctx context.Context, es *elasticsearch.Client, now time.Time) (string, error) {
+	if !n.Templated() {
+		return n.alias, nil
+	}
+
+	name := templateTokenPattern.ReplaceAllStringFunc(n.template, func(token string) string {
+		inner := token[1 : len(token)-1]
+		if inner == "n" {
+			return token
+		}
+		return now.UTC().Format(dateLayoutReplacer.Replace(inner))
+	})
+
+	if !strings.Contains(name, "{n}") {
+		return name, nil
+	}
+
+	version, err := n.nextVersion(ctx, es, name)
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(name, "{n}", strconv.Itoa(version), 1), nil
+}
+
+// nextVersion finds the highest existing {n} for the partially resolved name and returns the next one
+func (n *IndexNamer) nextVersion(ctx context.Context, es *elasticsearch.Client, name string) (int, error) {
+	existing, err := listIndices(ctx, es, strings.Replace(name, "{n}", "*", 1))
+	if err != nil {
+		return 0, err
+	}
+
+	parts := strings.SplitN(name, "{n}", 2)
+	versionPattern := regexp.MustCompile("^" + regexp.QuoteMeta(parts[0]) + `(\d+)` + regexp.QuoteMeta(parts[1]) + "$")
+
+	highest := 0
+	for _, index := range existing {
+		if matches := versionPattern.FindStringSubmatch(index); len(matches) == 2 {
+			if v, err := strconv.Atoi(matches[1]); err == nil && v > highest {
+				highest = v
+			}
+		}
+	}
+	return highest + 1, nil
+}
+
+// listIndices returns the names of indices matching the pattern
+func listIndices(ctx context.Context, es *elasticsearch.Client, pattern string) ([]string, error) {
+	res, err := es.Cat.Indices(
+		es.Cat.Indices.WithContext(ctx),
+		es.Cat.Indices.WithIndex(pattern),
+		es.Cat.Indices.WithFormat("json"),
+		es.Cat.Indices.WithH("index"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cat indices request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var rows []struct {
+		Index string `json:"index"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Index)
+	}
+	return names, nil
+}
+
+// AliasTargets returns the indices currently behind an alias
+func AliasTargets(ctx context.Context, es *elasticsearch.Client, alias string) ([]string, error) {
+	res, err := es.Indices.GetAlias(
+		es.Indices.GetAlias.WithContext(ctx),
+		es.Indices.GetAlias.WithName(alias),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get alias request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	targets := make([]string, 0, len(response))
+	for index := range response {
+		targets = append(targets, index)
+	}
+	return targets, nil
+}
+
+// SwapAlias atomically points the alias at the given index, detaching it from every other index
+func SwapAlias(ctx context.Context, es *elasticsearch.Client, alias, index string) error {
+	current, err := AliasTargets(ctx, es, alias)
+	if err != nil {
+		return err
+	}
+
+	actions := []map[string]interface{}{}
+	for _, previous := range current {
+		if previous != index {
+			actions = append(actions, map[string]interface{}{
+				"remove": map[string]interface{}{"index": previous, "alias": alias},
+			})
+		}
+	}
+	actions = append(actions, map[string]interface{}{
+		"add": map[string]interface{}{"index": index, "alias": alias},
+	})
+
+	body, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return fmt.Errorf("failed to encode alias actions: %w", err)
+	}
+
+	res, err := es.Indices.UpdateAliases(
+		bytes.NewReader(body),
+		es.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("update aliases request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	return nil
+}
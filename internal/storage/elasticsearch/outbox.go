@@ -0,0 +1,201 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// OutboxIndex holds product change events waiting to be delivered to downstream systems
+const OutboxIndex = "product_outbox"
+
+// OutboxRepository defines the interface for change event outbox operations
+type OutboxRepository interface {
+	AppendEvents(ctx context.Context, events []models.ChangeEvent) error
+	FindPendingEvents(ctx context.Context, limit int) ([]models.ChangeEvent, error)
+	SaveEvent(ctx context.Context, event models.ChangeEvent) error
+}
+
+// ElasticsearchOutboxRepository implements OutboxRepository using Elasticsearch
+type ElasticsearchOutboxRepository struct {
+	es        *elasticsearch.Client
+	indexName string
+}
+
+// NewElasticsearchOutboxRepository creates a new ElasticsearchOutboxRepository
+func NewElasticsearchOutboxRepository(es *elasticsearch.Client, indexName string) *ElasticsearchOutboxRepository {
+	return &ElasticsearchOutboxRepository{
+		es:        es,
+		indexName: indexName,
+	}
+}
+
+// AppendEvents stores new change events with a single bulk request, creating the outbox index on first use
+func (r *ElasticsearchOutboxRepository) AppendEvents(ctx context.Context, events []models.ChangeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := r.createIndexIfNotExists(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		meta := map[string]interface{}{
+			"create": map[string]interface{}{"_index": r.indexName, "_id": event.ID},
+		}
+		if err := encoder.Encode(meta); err != nil {
+			return fmt.Errorf("failed to encode bulk metadata: %w", err)
+		}
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to encode change event: %w", err)
+		}
+	}
+
+	res, err := r.es.Bulk(
+		&buf,
+		r.es.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("bulk request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  struct {
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if response.Errors {
+		for _, item := range response.Items {
+			for _, result := range item {
+				if result.Status >= 300 {
+					return fmt.Errorf("failed to append change event: %s", result.Error.Reason)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindPendingEvents returns the oldest events that haven't been delivered to every sink yet
+func (r *ElasticsearchOutboxRepository) FindPendingEvents(ctx context.Context, limit int) ([]models.ChangeEvent, error) {
+	query := map[string]interface{}{
+		"size": limit,
+		"sort": []map[string]interface{}{
+			{"occurred_at": map[string]interface{}{"order": "asc"}},
+		},
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must_not": []map[string]interface{}{
+					{"exists": map[string]interface{}{"field": "delivered_at"}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.indexName),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return nil, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Source models.ChangeEvent `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	events := make([]models.ChangeEvent, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		events = append(events, hit.Source)
+	}
+
+	return events, nil
+}
+
+// SaveEvent overwrites the delivery state of an event
+func (r *ElasticsearchOutboxRepository) SaveEvent(ctx context.Context, event models.ChangeEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode change event: %w", err)
+	}
+
+	res, err := r.es.Index(
+		r.indexName,
+		bytes.NewReader(body),
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithDocumentID(event.ID),
+		r.es.Index.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		return fmt.Errorf("index request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	return nil
+}
+
+// createIndexIfNotExists creates the outbox index; product snapshots are stored but not indexed
+func (r *ElasticsearchOutboxRepository) createIndexIfNotExists() error {
+	return createIndexWithMapping(r.es, r.indexName, `{
+		"mappings": {
+			"properties": {
+				"id": {"type": "keyword"},
+				"type": {"type": "keyword"},
+				"product_id": {"type": "keyword"},
+				"product": {"type": "object", "enabled": false},
+				"source": {"type": "keyword"},
+				"occurred_at": {"type": "date"},
+				"delivered": {"type": "keyword"},
+				"attempts": {"type": "integer"},
+				"last_error": {"type": "text"},
+				"delivered_at": {"type": "date"}
+			}
+		}
+	}`)
+}
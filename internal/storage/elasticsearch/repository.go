@@ -3,38 +3,98 @@ package elasticsearch
 import (
 	"bytes"
 	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/metrics"
 	"elasticsearch/internal/models"
+	"elasticsearch/internal/recorder"
+	"elasticsearch/internal/search/querybuilder"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"strconv"
+	"net/http"
+	"slices"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
 )
 
 // ProductRepository defines the interface for product data operations
 type ProductRepository interface {
 	FindProducts(ctx context.Context, params models.ProductSearchParams) (models.ProductSearchResult, error)
+	CreateProduct(ctx context.Context, product models.Product) (models.Product, error)
+	DeleteProduct(ctx context.Context, id models.ProductID) error
+	BulkDeleteProducts(ctx context.Context, ids []models.ProductID) (models.BulkResult, error)
+	GetProductByID(ctx context.Context, id models.ProductID) (models.Product, error)
+	GetProductsByIDs(ctx context.Context, ids []models.ProductID) (map[models.ProductID]models.Product, error)
+	BulkCreateProducts(ctx context.Context, products []models.Product) (models.BulkResult, error)
+	UpdateProduct(ctx context.Context, id models.ProductID, fields map[string]interface{}) (models.Product, error)
+	UpsertProduct(ctx context.Context, product models.Product) (models.Product, bool, error)
+	ClosePointInTime(ctx context.Context, pit string) error
+	CountProducts(ctx context.Context, params models.ProductSearchParams) (int64, error)
+	FacetProducts(ctx context.Context, params models.ProductSearchParams) (models.ProductFacets, error)
+	SampleProducts(ctx context.Context, params models.ProductSearchParams, size int, seed *int64) ([]models.Product, error)
+	DistinctValues(ctx context.Context, params models.ProductSearchParams, field string, size int) (models.DistinctValues, error)
+	SignificantValues(ctx context.Context, params models.ProductSearchParams, field string, size int) (models.SignificantTerms, error)
+	SuggestProducts(ctx context.Context, prefix, company string, size int) ([]models.Suggestion, error)
+	TypeaheadProducts(ctx context.Context, params models.ProductSearchParams, size int) ([]models.Product, error)
+	TypeaheadGroups(ctx context.Context, params models.ProductSearchParams, size int) (models.TypeaheadGroups, error)
+	SpellingSuggestions(ctx context.Context, keyword string, size int) ([]string, error)
+	GroupProducts(ctx context.Context, params models.ProductSearchParams, groups, perGroup int) (models.ProductGroups, error)
+	StreamAllProducts(ctx context.Context, params models.ProductSearchParams, fn func(products []models.Product) error) error
+	AttributeNames(ctx context.Context, params models.ProductSearchParams) ([]string, error)
+}
+
+// SearchRecorder receives a summary of every search executed by the repository
+type SearchRecorder interface {
+	Enabled() bool
+	Record(entry recorder.Entry)
 }
 
 // ElasticsearchProductRepository implements ProductRepository using Elasticsearch
 type ElasticsearchProductRepository struct {
-	es        *elasticsearch.Client
-	indexName string
+	es           *elasticsearch.Client
+	indexName    string
+	recorder     SearchRecorder
+	pitKeepAlive string
 }
 
 // NewElasticsearchProductRepository creates a new ElasticsearchProductRepository
 func NewElasticsearchProductRepository(es *elasticsearch.Client, indexName string) *ElasticsearchProductRepository {
 	return &ElasticsearchProductRepository{
-		es:        es,
-		indexName: indexName,
+		es:           es,
+		indexName:    indexName,
+		pitKeepAlive: defaultPITKeepAlive,
 	}
 }
 
+// SetPITKeepAlive sets how long points in time opened for pagination stay open between two pages
+func (r *ElasticsearchProductRepository) SetPITKeepAlive(keepAlive string) {
+	r.pitKeepAlive = keepAlive
+}
+
+// SetRecorder attaches a SearchRecorder that captures executed searches
+func (r *ElasticsearchProductRepository) SetRecorder(rec SearchRecorder) {
+	r.recorder = rec
+}
+
 // FindProducts retrieves products from Elasticsearch based on search parameters
 func (r *ElasticsearchProductRepository) FindProducts(ctx context.Context, params models.ProductSearchParams) (models.ProductSearchResult, error) {
 	// Build the elasticsearch query
-	query := r.buildProductQuery(params)
+	request := querybuilder.ProductSearch(params)
+
+	// Cursor and pit sessions read a point in time, opened by the first page and carried by a token after that
+	searchOptions := []func(*esapi.SearchRequest){r.es.Search.WithIndex(r.searchIndex(params))}
+	pit, err := r.pointInTime(ctx, params)
+	if err != nil {
+		return models.ProductSearchResult{}, err
+	}
+	if pit != "" {
+		request.PointInTime = &querybuilder.PointInTime{ID: pit, KeepAlive: r.pitKeepAlive}
+		searchOptions = nil
+	}
+	query := request.Map()
 
 	// Encode query to JSON
 	var buf bytes.Buffer
@@ -42,15 +102,15 @@ func (r *ElasticsearchProductRepository) FindProducts(ctx context.Context, param
 		log.Printf("Error encoding query: %s", err)
 		return models.ProductSearchResult{}, fmt.Errorf("failed to encode query: %w", err)
 	}
+	queryJSON := bytes.TrimSpace(buf.Bytes())
 
 	// Perform the search request
-	res, err := r.es.Search(
+	res, err := r.es.Search(append(searchOptions,
 		r.es.Search.WithContext(ctx),
-		r.es.Search.WithIndex(r.indexName),
-		r.es.Search.WithBody(&buf),
-		r.es.Search.WithTrackTotalHits(true),
+		r.es.Search.WithBody(bytes.NewReader(queryJSON)),
+		r.es.Search.WithTrackTotalHits(trackTotalHits(params)),
 		r.es.Search.WithPretty(),
-	)
+	)...)
 	if err != nil {
 		log.Printf("Error getting response: %s", err)
 		return models.ProductSearchResult{}, fmt.Errorf("search request failed: %w", err)
@@ -59,18 +119,12 @@ func (r *ElasticsearchProductRepository) FindProducts(ctx context.Context, param
 
 	// Check for Elasticsearch errors
 	if res.IsError() {
-		var e map[string]interface{}
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return models.ProductSearchResult{}, fmt.Errorf("error parsing elasticsearch error response: %w", err)
+		if res.StatusCode == http.StatusNotFound {
+			if err := expiredSessionError(params); err != nil {
+				return models.ProductSearchResult{}, err
+			}
 		}
-
-		errorMsg := fmt.Sprintf("[%s] %s: %s",
-			res.Status(),
-			e["error"].(map[string]interface{})["type"],
-			e["error"].(map[string]interface{})["reason"],
-		)
-		log.Print(errorMsg)
-		return models.ProductSearchResult{}, fmt.Errorf(errorMsg)
+		return models.ProductSearchResult{}, decodeErrorResponse(res)
 	}
 
 	// Parse response
@@ -87,125 +141,1005 @@ func (r *ElasticsearchProductRepository) FindProducts(ctx context.Context, param
 	}
 
 	// Extract total count
-	totalCount := r.extractTotalCount(response)
+	totalCount, lowerBound := r.extractTotalCount(response)
 
 	// Create and return search result with pagination info
 	result := models.ProductSearchResult{
-		Products:   products,
-		TotalCount: totalCount,
-		Limit:      params.Limit,
-		Offset:     params.Offset,
+		Products:          products,
+		TotalCount:        totalCount,
+		TotalIsLowerBound: lowerBound,
+		Limit:             params.Limit,
+		Offset:            params.Offset,
+	}
+	if took, ok := response["took"].(float64); ok {
+		result.TookMs = int64(took)
+	}
+	if hits, ok := response["hits"].(map[string]interface{}); ok {
+		if maxScore, ok := hits["max_score"].(float64); ok {
+			result.MaxScore = &maxScore
+		}
+	}
+	if len(params.Facets) > 0 {
+		result.Facets = extractFacets(response, params.Facets)
+	}
+	result.Query = queryJSON
+
+	// Elasticsearch may return a new point in time id with every response, which supersedes the one sent
+	if id, ok := response["pit_id"].(string); ok && id != "" {
+		pit = id
 	}
 
+	// Hand out a cursor while pages are full and release the point in time after the last one;
+	// pit sessions stay open until the client closes them or they expire
+	switch {
+	case params.Cursor != nil:
+		if next := nextCursor(response, pit, params.Limit); next != nil {
+			result.NextCursor = next.Encode()
+		} else if err := r.ClosePointInTime(ctx, pit); err != nil && !errors.Is(err, common.ErrNotFound) {
+			log.Printf("Error closing point in time: %s", err)
+		}
+	case params.PIT != "":
+		result.PIT = pit
+	}
+
+	// Report timeouts and shard failures instead of silently returning partial results
+	r.extractPartialResultInfo(response, &result)
+	metrics.SearchRequests.Add(1)
+	if result.TimedOut {
+		metrics.SearchTimedOut.Add(1)
+	}
+	if result.Shards.Failed > 0 {
+		metrics.SearchPartialResults.Add(1)
+	}
+
+	r.recordSearch(r.searchIndex(params), queryJSON, result)
+
 	return result, nil
 }
 
-// extractTotalCount extracts the total hit count from Elasticsearch response
-func (r *ElasticsearchProductRepository) extractTotalCount(response map[string]interface{}) int64 {
+// CountProducts counts the products matching the keyword and filters of params with the Count API.
+// A min_score cut-off isn't supported by the Count API (it only takes whole numbers), so keyword counts
+// with a minimum score run a search without hits instead.
+func (r *ElasticsearchProductRepository) CountProducts(ctx context.Context, params models.ProductSearchParams) (int64, error) {
+	request := querybuilder.ProductSearch(params)
+	if request.MinScore > 0 {
+		return r.countWithMinScore(ctx, params, request)
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{"query": request.Query.Map()}); err != nil {
+		return 0, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Count(
+		r.es.Count.WithContext(ctx),
+		r.es.Count.WithIndex(r.searchIndex(params)),
+		r.es.Count.WithBody(&buf),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("count request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return response.Count, nil
+}
+
+// countWithMinScore counts the hits of a search scoring at least its minimum score
+func (r *ElasticsearchProductRepository) countWithMinScore(ctx context.Context, params models.ProductSearchParams, request querybuilder.SearchRequest) (int64, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{
+		"query":     request.Query.Map(),
+		"min_score": request.MinScore,
+		"size":      0,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.searchIndex(params)),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, decodeErrorResponse(res)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	count, _ := r.extractTotalCount(response)
+	return count, nil
+}
+
+// FacetProducts counts the values of the requested facets over the products matching the keyword and
+// filters of params. The search fetches no hits, so Elasticsearch serves repeated ones from its request cache.
+func (r *ElasticsearchProductRepository) FacetProducts(ctx context.Context, params models.ProductSearchParams) (models.ProductFacets, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.ProductFacets(params).Map()); err != nil {
+		return models.ProductFacets{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.searchIndex(params)),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		return models.ProductFacets{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.ProductFacets{}, decodeErrorResponse(res)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.ProductFacets{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	total, _ := r.extractTotalCount(response)
+	return models.ProductFacets{
+		Total:  total,
+		Facets: extractFacets(response, params.Facets),
+	}, nil
+}
+
+// SampleProducts returns up to size random products matching the keyword and filters of params,
+// reproducibly ordered when a seed is given
+func (r *ElasticsearchProductRepository) SampleProducts(ctx context.Context, params models.ProductSearchParams, size int, seed *int64) ([]models.Product, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.ProductSample(params, size, seed).Map()); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.searchIndex(params)),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(false),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	products, err := r.extractProductsFromResponse(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract products from response: %w", err)
+	}
+	return products, nil
+}
+
+// DistinctValues counts the distinct values of a facetable field over the products matching the keyword and
+// filters of params and lists the first size of them alphabetically
+func (r *ElasticsearchProductRepository) DistinctValues(ctx context.Context, params models.ProductSearchParams, field string, size int) (models.DistinctValues, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.ProductDistinct(params, field, size).Map()); err != nil {
+		return models.DistinctValues{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.searchIndex(params)),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(false),
+	)
+	if err != nil {
+		return models.DistinctValues{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.DistinctValues{}, decodeErrorResponse(res)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.DistinctValues{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	result := models.DistinctValues{Field: field, Values: []string{}}
+	aggregations, _ := response["aggregations"].(map[string]interface{})
+	if count, ok := aggregations[querybuilder.DistinctCountAggregation].(map[string]interface{}); ok {
+		value, _ := count["value"].(float64)
+		result.Count = int64(value)
+	}
+	values, _ := aggregations[querybuilder.DistinctValuesAggregation].(map[string]interface{})
+	rawBuckets, _ := values["buckets"].([]interface{})
+	for _, raw := range rawBuckets {
+		bucket, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := bucket["key"].(string); ok {
+			result.Values = append(result.Values, value)
+		}
+	}
+	return result, nil
+}
+
+// SignificantValues finds the size values of a facetable field that are statistically overrepresented in the
+// products matching the keyword and filters of params compared to the whole index
+func (r *ElasticsearchProductRepository) SignificantValues(ctx context.Context, params models.ProductSearchParams, field string, size int) (models.SignificantTerms, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.ProductSignificant(params, field, size).Map()); err != nil {
+		return models.SignificantTerms{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.searchIndex(params)),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(false),
+	)
+	if err != nil {
+		return models.SignificantTerms{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.SignificantTerms{}, decodeErrorResponse(res)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.SignificantTerms{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	result := models.SignificantTerms{Field: field, Terms: []models.SignificantTerm{}}
+	aggregations, _ := response["aggregations"].(map[string]interface{})
+	significant, _ := aggregations[querybuilder.SignificantAggregation].(map[string]interface{})
+	count, _ := significant["doc_count"].(float64)
+	result.Count = int64(count)
+
+	rawBuckets, _ := significant["buckets"].([]interface{})
+	for _, raw := range rawBuckets {
+		bucket, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, _ := bucket["key"].(string)
+		docCount, _ := bucket["doc_count"].(float64)
+		bgCount, _ := bucket["bg_count"].(float64)
+		score, _ := bucket["score"].(float64)
+		result.Terms = append(result.Terms, models.SignificantTerm{
+			Value:           value,
+			Count:           int64(docCount),
+			BackgroundCount: int64(bgCount),
+			Score:           score,
+		})
+	}
+	return result, nil
+}
+
+// TypeaheadProducts returns the size best products whose name matches the partially typed keyword of params
+// and its filters
+func (r *ElasticsearchProductRepository) TypeaheadProducts(ctx context.Context, params models.ProductSearchParams, size int) ([]models.Product, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.ProductTypeahead(params, size).Map()); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.searchIndex(params)),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(false),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	products, err := r.extractProductsFromResponse(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract products from response: %w", err)
+	}
+	return products, nil
+}
+
+// TypeaheadGroups returns the size best products whose name matches the partially typed keyword of params and
+// its filters, and the size generics and companies matching it
+func (r *ElasticsearchProductRepository) TypeaheadGroups(ctx context.Context, params models.ProductSearchParams, size int) (models.TypeaheadGroups, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.ProductTypeaheadGroups(params, size).Map()); err != nil {
+		return models.TypeaheadGroups{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.searchIndex(params)),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(false),
+	)
+	if err != nil {
+		return models.TypeaheadGroups{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.TypeaheadGroups{}, decodeErrorResponse(res)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.TypeaheadGroups{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	aggregations, _ := response["aggregations"].(map[string]interface{})
+	section := func(name string) map[string]interface{} {
+		agg, _ := aggregations[name].(map[string]interface{})
+		return agg
+	}
+
+	result := models.TypeaheadGroups{Products: []models.Product{}}
+	// The top_hits aggregation holds a hits section shaped like the one of a search response
+	if topHits, ok := section(querybuilder.TypeaheadProductsAggregation)[querybuilder.TopProductsAggregation].(map[string]interface{}); ok {
+		if result.Products, err = r.extractProductsFromResponse(topHits); err != nil {
+			return models.TypeaheadGroups{}, fmt.Errorf("failed to extract products from response: %w", err)
+		}
+	}
+	generics, _ := section(querybuilder.TypeaheadGenericsAggregation)[querybuilder.FacetAggregation].(map[string]interface{})
+	result.Generics = extractBuckets(generics)
+	companies, _ := section(querybuilder.TypeaheadCompaniesAggregation)[querybuilder.FacetAggregation].(map[string]interface{})
+	result.Companies = extractBuckets(companies)
+	return result, nil
+}
+
+// suggestResponse mirrors the parts of the search response used by SuggestProducts and SpellingSuggestions
+type suggestResponse struct {
+	Suggest map[string][]struct {
+		Options []struct {
+			Text   string  `json:"text"`
+			ID     string  `json:"_id"`
+			Score  float64 `json:"_score"`
+			Source struct {
+				Company   string     `json:"company"`
+				DeletedAt *time.Time `json:"deleted_at"`
+			} `json:"_source"`
+		} `json:"options"`
+	} `json:"suggest"`
+}
+
+// SuggestProducts completes prefix to up to size distinct product names with the completion suggester on
+// product_name.suggest, only from products of company when given. The suggester can't filter, so soft-deleted
+// products are dropped from the options.
+func (r *ElasticsearchProductRepository) SuggestProducts(ctx context.Context, prefix, company string, size int) ([]models.Suggestion, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.ProductSuggest(prefix, company, size).Map()); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.indexName),
+		r.es.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("suggest request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response suggestResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return response.completions(), nil
+}
+
+// completions returns the options of the ProductSuggestion completion suggester, leaving out soft-deleted products
+func (r suggestResponse) completions() []models.Suggestion {
+	suggestions := []models.Suggestion{}
+	for _, entry := range r.Suggest[querybuilder.ProductSuggestion] {
+		for _, option := range entry.Options {
+			if option.Source.DeletedAt != nil {
+				continue
+			}
+			suggestions = append(suggestions, models.Suggestion{
+				Text:    option.Text,
+				Source:  models.SuggestionSourceCompletion,
+				ID:      models.ProductID(option.ID),
+				Company: option.Source.Company,
+				Score:   option.Score,
+			})
+		}
+	}
+	return suggestions
+}
+
+// SpellingSuggestions proposes up to size corrected spellings of a keyword with the phrase suggester on
+// product_name, best first. Only corrections matching a live product name are proposed.
+func (r *ElasticsearchProductRepository) SpellingSuggestions(ctx context.Context, keyword string, size int) ([]string, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.ProductSpelling(keyword, size).Map()); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.indexName),
+		r.es.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("suggest request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response suggestResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	suggestions := []string{}
+	for _, entry := range response.Suggest[querybuilder.SpellingSuggestion] {
+		for _, option := range entry.Options {
+			suggestions = append(suggestions, option.Text)
+		}
+	}
+	return suggestions, nil
+}
+
+// GroupProducts returns the perGroup best products of each of the groups companies with the most products
+// matching the keyword and filters of params
+func (r *ElasticsearchProductRepository) GroupProducts(ctx context.Context, params models.ProductSearchParams, groups, perGroup int) (models.ProductGroups, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.ProductGroups(params, groups, perGroup).Map()); err != nil {
+		return models.ProductGroups{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.searchIndex(params)),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		return models.ProductGroups{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.ProductGroups{}, decodeErrorResponse(res)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.ProductGroups{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	total, _ := r.extractTotalCount(response)
+	result := models.ProductGroups{Total: total, Groups: []models.ProductGroup{}}
+
+	aggregations, _ := response["aggregations"].(map[string]interface{})
+	companies, _ := aggregations[querybuilder.GroupAggregation].(map[string]interface{})
+	rawBuckets, _ := companies["buckets"].([]interface{})
+	for _, raw := range rawBuckets {
+		bucket, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		company, _ := bucket["key"].(string)
+		count, _ := bucket["doc_count"].(float64)
+
+		// The top_hits aggregation holds a hits section shaped like the one of a search response
+		products := []models.Product{}
+		if topHits, ok := bucket[querybuilder.TopProductsAggregation].(map[string]interface{}); ok {
+			if products, err = r.extractProductsFromResponse(topHits); err != nil {
+				return models.ProductGroups{}, fmt.Errorf("failed to extract products from response: %w", err)
+			}
+		}
+
+		result.Groups = append(result.Groups, models.ProductGroup{
+			Company:  company,
+			Count:    int64(count),
+			Products: products,
+		})
+	}
+	return result, nil
+}
+
+// searchIndex returns the index or alias a search should target
+func (r *ElasticsearchProductRepository) searchIndex(params models.ProductSearchParams) string {
+	if params.Index != "" {
+		return params.Index
+	}
+	return r.indexName
+}
+
+// AttributeNames returns the names of the product attributes mapped in the index searched with params, in every
+// index behind an alias, in alphabetical order
+func (r *ElasticsearchProductRepository) AttributeNames(ctx context.Context, params models.ProductSearchParams) ([]string, error) {
+	mappings, err := GetIndexMappings(ctx, r.es, r.searchIndex(params))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, mapping := range mappings {
+		for _, name := range mapping.Attributes {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// recordSearch hands the executed query and a summary of its response to the recorder, if enabled
+func (r *ElasticsearchProductRepository) recordSearch(index string, query []byte, result models.ProductSearchResult) {
+	if r.recorder == nil || !r.recorder.Enabled() {
+		return
+	}
+
+	entry := recorder.Entry{
+		Timestamp: time.Now().UTC(),
+		Index:     index,
+		Query:     json.RawMessage(append([]byte(nil), query...)),
+		TookMs:    result.TookMs,
+		TotalHits: result.TotalCount,
+	}
+	for _, product := range result.Products {
+		entry.HitIDs = append(entry.HitIDs, product.ID.String())
+		entry.Scores = append(entry.Scores, product.Score)
+	}
+
+	r.recorder.Record(entry)
+}
+
+// extractFacets reads the value counts of every requested facet from the aggregations of a search response
+func extractFacets(response map[string]interface{}, facets []string) map[string][]models.FacetBucket {
+	aggregations, _ := response["aggregations"].(map[string]interface{})
+	result := make(map[string][]models.FacetBucket, len(facets))
+	for _, facet := range facets {
+		facetAgg, _ := aggregations[facet].(map[string]interface{})
+		values, _ := facetAgg[querybuilder.FacetAggregation].(map[string]interface{})
+		result[facet] = extractBuckets(values)
+	}
+	return result
+}
+
+// extractBuckets extracts the values and counts of a terms aggregation
+func extractBuckets(terms map[string]interface{}) []models.FacetBucket {
+	buckets := []models.FacetBucket{}
+	rawBuckets, _ := terms["buckets"].([]interface{})
+	for _, raw := range rawBuckets {
+		bucket, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key, _ := bucket["key"].(string)
+		count, _ := bucket["doc_count"].(float64)
+		buckets = append(buckets, models.FacetBucket{Value: key, Count: int64(count)})
+	}
+	return buckets
+}
+
+// extractPartialResultInfo extracts timed_out and _shards information and turns failures into warnings
+func (r *ElasticsearchProductRepository) extractPartialResultInfo(response map[string]interface{}, result *models.ProductSearchResult) {
+	if timedOut, ok := response["timed_out"].(bool); ok && timedOut {
+		result.TimedOut = true
+		result.Warnings = append(result.Warnings, "search timed out before all shards responded; results may be incomplete")
+	}
+
+	shards, ok := response["_shards"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	count := func(key string) int {
+		value, _ := shards[key].(float64)
+		return int(value)
+	}
+	result.Shards = models.ShardStats{
+		Total:      count("total"),
+		Successful: count("successful"),
+		Skipped:    count("skipped"),
+		Failed:     count("failed"),
+	}
+
+	if result.Shards.Failed == 0 {
+		return
+	}
+
+	result.Warnings = append(result.Warnings, fmt.Sprintf("%d of %d shards failed; results may be incomplete", result.Shards.Failed, result.Shards.Total))
+
+	failures, _ := shards["failures"].([]interface{})
+	for _, failure := range failures {
+		failureMap, ok := failure.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		reason, ok := failureMap["reason"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("shard %v failed: %v: %v", failureMap["shard"], reason["type"], reason["reason"]))
+	}
+	log.Printf("Partial search results: %v", result.Warnings)
+}
+
+// CreateProduct indexes a new product document. When the product has no ID, Elasticsearch assigns one.
+// Returns common.ErrConflict if a document with the same ID already exists.
+func (r *ElasticsearchProductRepository) CreateProduct(ctx context.Context, product models.Product) (models.Product, error) {
+	body, err := json.Marshal(product)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("failed to encode product: %w", err)
+	}
+
+	res, err := r.es.Index(
+		r.indexName,
+		bytes.NewReader(body),
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithDocumentID(product.ID.String()),
+		r.es.Index.WithOpType("create"),
+		r.es.Index.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.Product{}, fmt.Errorf("index request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusConflict {
+		return models.Product{}, fmt.Errorf("product %s: %w", product.ID, common.ErrConflict)
+	}
+
+	if res.IsError() {
+		return models.Product{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		ID string `json:"_id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.Product{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	product.ID = models.ProductID(response.ID)
+	return product, nil
+}
+
+// BulkCreateProducts creates products with the bulk API, reporting the outcome of every item
+func (r *ElasticsearchProductRepository) BulkCreateProducts(ctx context.Context, products []models.Product) (models.BulkResult, error) {
+	return BulkWriteProducts(ctx, r.es, r.indexName, BulkActionCreate, products)
+}
+
+// GetProductByID fetches a single product with the Get API. Returns common.ErrNotFound if it doesn't exist.
+func (r *ElasticsearchProductRepository) GetProductByID(ctx context.Context, id models.ProductID) (models.Product, error) {
+	res, err := r.es.Get(
+		r.indexName,
+		id.String(),
+		r.es.Get.WithContext(ctx),
+		r.es.Get.WithSourceIncludes(models.ProductSourceFields...),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.Product{}, fmt.Errorf("get request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return models.Product{}, fmt.Errorf("product %s: %w", id, common.ErrNotFound)
+	}
+
+	if res.IsError() {
+		return models.Product{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		ID     string         `json:"_id"`
+		Found  bool           `json:"found"`
+		Source models.Product `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.Product{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !response.Found {
+		return models.Product{}, fmt.Errorf("product %s: %w", id, common.ErrNotFound)
+	}
+
+	product := response.Source
+	product.ID = models.ProductID(response.ID)
+	return product, nil
+}
+
+// GetProductsByIDs fetches several products with the Multi Get API. Missing IDs are absent from the returned map.
+func (r *ElasticsearchProductRepository) GetProductsByIDs(ctx context.Context, ids []models.ProductID) (map[models.ProductID]models.Product, error) {
+	products := make(map[models.ProductID]models.Product, len(ids))
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"ids": productIDStrings(ids)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode mget request: %w", err)
+	}
+
+	res, err := r.es.Mget(
+		bytes.NewReader(body),
+		r.es.Mget.WithContext(ctx),
+		r.es.Mget.WithIndex(r.indexName),
+		r.es.Mget.WithSourceIncludes(models.ProductSourceFields...),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return nil, fmt.Errorf("mget request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Docs []struct {
+			ID     string         `json:"_id"`
+			Found  bool           `json:"found"`
+			Source models.Product `json:"_source"`
+		} `json:"docs"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	for _, doc := range response.Docs {
+		if !doc.Found {
+			continue
+		}
+		product := doc.Source
+		product.ID = models.ProductID(doc.ID)
+		products[product.ID] = product
+	}
+
+	return products, nil
+}
+
+// UpdateProduct merges the given fields into an existing product with the Update API and returns
+// the updated document. Returns common.ErrNotFound if it doesn't exist.
+func (r *ElasticsearchProductRepository) UpdateProduct(ctx context.Context, id models.ProductID, fields map[string]interface{}) (models.Product, error) {
+	body, err := json.Marshal(map[string]interface{}{"doc": fields})
+	if err != nil {
+		return models.Product{}, fmt.Errorf("failed to encode update: %w", err)
+	}
+
+	res, err := r.es.Update(
+		r.indexName,
+		id.String(),
+		bytes.NewReader(body),
+		r.es.Update.WithContext(ctx),
+		r.es.Update.WithSource("true"),
+		r.es.Update.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.Product{}, fmt.Errorf("update request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return models.Product{}, fmt.Errorf("product %s: %w", id, common.ErrNotFound)
+	}
+
+	if res.IsError() {
+		return models.Product{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		ID  string `json:"_id"`
+		Get struct {
+			Source models.Product `json:"_source"`
+		} `json:"get"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.Product{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	product := response.Get.Source
+	product.ID = models.ProductID(response.ID)
+	return product, nil
+}
+
+// UpsertProduct creates the product when it doesn't exist and otherwise updates its catalog fields,
+// preserving the original created_at. Reports whether the document was created.
+func (r *ElasticsearchProductRepository) UpsertProduct(ctx context.Context, product models.Product) (models.Product, bool, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"doc": map[string]interface{}{
+			"product_name": product.ProductName,
+			"drug_generic": product.DrugGeneric,
+			"company":      product.Company,
+			"updated_at":   product.UpdatedAt,
+		},
+		"upsert": product,
+	})
+	if err != nil {
+		return models.Product{}, false, fmt.Errorf("failed to encode upsert: %w", err)
+	}
+
+	res, err := r.es.Update(
+		r.indexName,
+		product.ID.String(),
+		bytes.NewReader(body),
+		r.es.Update.WithContext(ctx),
+		r.es.Update.WithSource("true"),
+		r.es.Update.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.Product{}, false, fmt.Errorf("update request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.Product{}, false, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		ID     string `json:"_id"`
+		Result string `json:"result"`
+		Get    struct {
+			Source models.Product `json:"_source"`
+		} `json:"get"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.Product{}, false, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	upserted := response.Get.Source
+	upserted.ID = models.ProductID(response.ID)
+	return upserted, response.Result == "created", nil
+}
+
+// DeleteProduct removes a product document. Returns common.ErrNotFound if it doesn't exist.
+func (r *ElasticsearchProductRepository) DeleteProduct(ctx context.Context, id models.ProductID) error {
+	res, err := r.es.Delete(
+		r.indexName,
+		id.String(),
+		r.es.Delete.WithContext(ctx),
+		r.es.Delete.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return fmt.Errorf("delete request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("product %s: %w", id, common.ErrNotFound)
+	}
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	return nil
+}
+
+// BulkDeleteProducts removes products with the bulk API, reporting the outcome of every item; item positions
+// refer to the ids slice and IDs that don't exist are reported with status 404
+func (r *ElasticsearchProductRepository) BulkDeleteProducts(ctx context.Context, ids []models.ProductID) (models.BulkResult, error) {
+	result := models.BulkResult{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var body bytes.Buffer
+	for _, id := range ids {
+		action, err := json.Marshal(map[string]interface{}{
+			"delete": map[string]string{"_index": r.indexName, "_id": id.String()},
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to encode bulk action: %w", err)
+		}
+		body.Write(action)
+		body.WriteString("\n")
+	}
+
+	res, err := r.es.Bulk(
+		&body,
+		r.es.Bulk.WithContext(ctx),
+		r.es.Bulk.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		return result, fmt.Errorf("bulk request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return result, decodeErrorResponse(res)
+	}
+
+	var response bulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return result, fmt.Errorf("failed to parse bulk response: %w", err)
+	}
+
+	for i, item := range response.Items {
+		for _, outcome := range item {
+			itemResult := models.BulkItemResult{Position: i, ID: models.ProductID(outcome.ID), Status: outcome.Status}
+			if outcome.Error != nil {
+				itemResult.Error = fmt.Sprintf("%s: %s", outcome.Error.Type, outcome.Error.Reason)
+			}
+			result.Add(itemResult)
+		}
+	}
+
+	return result, nil
+}
+
+// trackTotalHits returns the track_total_hits value for a search: true to count every hit, or the cap
+func trackTotalHits(params models.ProductSearchParams) interface{} {
+	if params.TrackTotalHits > 0 {
+		return params.TrackTotalHits
+	}
+	return true
+}
+
+// extractTotalCount extracts the total hit count from Elasticsearch response and whether
+// it is a lower bound because counting stopped at the track_total_hits cap
+func (r *ElasticsearchProductRepository) extractTotalCount(response map[string]interface{}) (int64, bool) {
 	hits, ok := response["hits"].(map[string]interface{})
 	if !ok {
-		return 0
+		return 0, false
 	}
 
 	total, ok := hits["total"].(map[string]interface{})
 	if !ok {
-		return 0
+		return 0, false
 	}
 
 	value, ok := total["value"].(float64)
 	if !ok {
-		return 0
-	}
-
-	return int64(value)
-}
-
-// buildProductQuery constructs the Elasticsearch query based on search parameters
-func (r *ElasticsearchProductRepository) buildProductQuery(params models.ProductSearchParams) map[string]interface{} {
-	query := map[string]interface{}{
-		"from": params.Offset,
-		"size": params.Limit,
-	}
-
-	// Add search conditions if keyword is provided
-	if params.Keyword != "" {
-		query = map[string]interface{}{
-			"query": map[string]interface{}{
-				"bool": map[string]interface{}{
-					"should": []map[string]interface{}{
-						{
-							"bool": map[string]interface{}{
-								"should": []map[string]interface{}{
-									{
-										"match": map[string]interface{}{
-											"product_name": map[string]interface{}{
-												"query":     params.Keyword,
-												"operator":  "and",
-												"fuzziness": "AUTO",
-											},
-										},
-									},
-									{
-										"match": map[string]interface{}{
-											"drug_generic": map[string]interface{}{
-												"query":     params.Keyword,
-												"operator":  "and",
-												"fuzziness": "AUTO",
-											},
-										},
-									},
-									{
-										"match": map[string]interface{}{
-											"company": map[string]interface{}{
-												"query":     params.Keyword,
-												"operator":  "and",
-												"fuzziness": "AUTO",
-											},
-										},
-									},
-								},
-							},
-						},
-						{
-							"bool": map[string]interface{}{
-								"should": []map[string]interface{}{
-									{
-										"wildcard": map[string]interface{}{
-											"product_name": map[string]interface{}{
-												"value": "*" + params.Keyword + "*",
-											},
-										},
-									},
-									{
-										"wildcard": map[string]interface{}{
-											"drug_generic": map[string]interface{}{
-												"value": "*" + params.Keyword + "*",
-											},
-										},
-									},
-									{
-										"wildcard": map[string]interface{}{
-											"company": map[string]interface{}{
-												"value": "*" + params.Keyword + "*",
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"sort": []map[string]interface{}{
-				{"_score": map[string]interface{}{"order": "desc"}},
-				{"product_name.keyword": map[string]interface{}{"order": "asc"}},
-			},
-			"from": params.Offset,
-			"size": params.Limit,
-		}
+		return 0, false
 	}
 
-	return query
+	return int64(value), total["relation"] == "gte"
 }
 
 func (r *ElasticsearchProductRepository) extractProductsFromResponse(response map[string]interface{}) ([]models.Product, error) {
@@ -245,17 +1179,37 @@ func (r *ElasticsearchProductRepository) extractProductsFromResponse(response ma
 
 		// Set ID and score
 		if idStr, ok := docId.(string); ok {
-			if id, err := strconv.Atoi(idStr); err == nil {
-				product.ID = uint64(id)
-			}
+			product.ID = models.ProductID(idStr)
 		}
 
 		if scoreFloat, ok := score.(float64); ok {
 			product.Score = scoreFloat
 		}
 
+		// Keep raw highlight fragments, still carrying the marker runes
+		if highlights, ok := hitMap["highlight"].(map[string]interface{}); ok {
+			product.Highlights = make(map[string][]string, len(highlights))
+			for field, fragments := range highlights {
+				list, _ := fragments.([]interface{})
+				for _, fragment := range list {
+					if text, ok := fragment.(string); ok {
+						product.Highlights[field] = append(product.Highlights[field], text)
+					}
+				}
+			}
+		}
+
 		products = append(products, product)
 	}
 
 	return products, nil
 }
+
+// productIDStrings converts product IDs to the strings used in Elasticsearch requests
+func productIDStrings(ids []models.ProductID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = string(id)
+	}
+	return values
+}
@@ -0,0 +1,89 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// SequenceIndex holds named counters, one document per sequence
+const SequenceIndex = "id_sequences"
+
+// sequenceRetries is how often a concurrent increment of the same counter is retried
+const sequenceRetries = 10
+
+// SequenceRepository defines the interface for named counter operations
+type SequenceRepository interface {
+	NextValue(ctx context.Context, name string) (int64, error)
+}
+
+// ElasticsearchSequenceRepository implements SequenceRepository using Elasticsearch
+type ElasticsearchSequenceRepository struct {
+	es        *elasticsearch.Client
+	indexName string
+}
+
+// NewElasticsearchSequenceRepository creates a new ElasticsearchSequenceRepository
+func NewElasticsearchSequenceRepository(es *elasticsearch.Client, indexName string) *ElasticsearchSequenceRepository {
+	return &ElasticsearchSequenceRepository{
+		es:        es,
+		indexName: indexName,
+	}
+}
+
+// NextValue atomically increments the named counter and returns its new value; the first value is 1
+func (r *ElasticsearchSequenceRepository) NextValue(ctx context.Context, name string) (int64, error) {
+	if err := createIndexWithMapping(r.es, r.indexName, `{
+		"mappings": {
+			"properties": {
+				"value": {"type": "long"}
+			}
+		}
+	}`); err != nil {
+		return 0, err
+	}
+
+	// A scripted upsert runs the increment on a missing counter too
+	body, err := json.Marshal(map[string]interface{}{
+		"scripted_upsert": true,
+		"script": map[string]interface{}{
+			"source": "ctx._source.value = (ctx._source.value == null ? 0 : ctx._source.value) + 1",
+		},
+		"upsert": map[string]interface{}{},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode update: %w", err)
+	}
+
+	res, err := r.es.Update(
+		r.indexName,
+		name,
+		bytes.NewReader(body),
+		r.es.Update.WithContext(ctx),
+		r.es.Update.WithRetryOnConflict(sequenceRetries),
+		r.es.Update.WithSource("true"),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("update request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Get struct {
+			Source struct {
+				Value int64 `json:"value"`
+			} `json:"_source"`
+		} `json:"get"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return response.Get.Source.Value, nil
+}
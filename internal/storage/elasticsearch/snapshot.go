@@ -0,0 +1,119 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// SnapshotIndex holds captured searches, one document per snapshot
+const SnapshotIndex = "search_snapshots"
+
+// SnapshotRepository defines the interface for search snapshot operations
+type SnapshotRepository interface {
+	SaveSnapshot(ctx context.Context, snapshot models.SearchSnapshot) (models.SearchSnapshot, error)
+	GetSnapshot(ctx context.Context, id string) (models.SearchSnapshot, error)
+}
+
+// ElasticsearchSnapshotRepository implements SnapshotRepository using Elasticsearch
+type ElasticsearchSnapshotRepository struct {
+	es        *elasticsearch.Client
+	indexName string
+}
+
+// NewElasticsearchSnapshotRepository creates a new ElasticsearchSnapshotRepository
+func NewElasticsearchSnapshotRepository(es *elasticsearch.Client, indexName string) *ElasticsearchSnapshotRepository {
+	return &ElasticsearchSnapshotRepository{
+		es:        es,
+		indexName: indexName,
+	}
+}
+
+// SaveSnapshot stores a snapshot, letting Elasticsearch assign its ID
+func (r *ElasticsearchSnapshotRepository) SaveSnapshot(ctx context.Context, snapshot models.SearchSnapshot) (models.SearchSnapshot, error) {
+	// The captured query and products are stored as is, without being indexed
+	if err := createIndexWithMapping(r.es, r.indexName, `{
+		"mappings": {
+			"properties": {
+				"created_at": {"type": "date"},
+				"note": {"type": "text"},
+				"request": {"type": "keyword"},
+				"query": {"type": "object", "enabled": false},
+				"total_count": {"type": "long"},
+				"took_ms": {"type": "long"},
+				"warnings": {"type": "keyword"},
+				"products": {"type": "object", "enabled": false}
+			}
+		}
+	}`); err != nil {
+		return models.SearchSnapshot{}, err
+	}
+
+	body, err := json.Marshal(snapshot)
+	if err != nil {
+		return models.SearchSnapshot{}, fmt.Errorf("failed to encode search snapshot: %w", err)
+	}
+
+	res, err := r.es.Index(
+		r.indexName,
+		bytes.NewReader(body),
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithRefresh("wait_for"),
+	)
+	if err != nil {
+		return models.SearchSnapshot{}, fmt.Errorf("index request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.SearchSnapshot{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		ID string `json:"_id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.SearchSnapshot{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	snapshot.ID = response.ID
+	return snapshot, nil
+}
+
+// GetSnapshot loads a snapshot by ID. Returns common.ErrNotFound if it doesn't exist.
+func (r *ElasticsearchSnapshotRepository) GetSnapshot(ctx context.Context, id string) (models.SearchSnapshot, error) {
+	res, err := r.es.Get(
+		r.indexName,
+		id,
+		r.es.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return models.SearchSnapshot{}, fmt.Errorf("get request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return models.SearchSnapshot{}, fmt.Errorf("search snapshot %s: %w", id, common.ErrNotFound)
+	}
+
+	if res.IsError() {
+		return models.SearchSnapshot{}, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		ID     string                `json:"_id"`
+		Source models.SearchSnapshot `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.SearchSnapshot{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	response.Source.ID = response.ID
+	return response.Source, nil
+}
@@ -0,0 +1,351 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/search/querybuilder"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// StatsRepository defines the interface for aggregated catalog statistics
+type StatsRepository interface {
+	GetCatalogStats(ctx context.Context) (models.CatalogStats, error)
+	GetIndexStats(ctx context.Context, size int) (models.IndexStats, error)
+	GetCreationHistogram(ctx context.Context, params models.CreationHistogramParams) (models.CreationHistogram, error)
+}
+
+// ElasticsearchStatsRepository implements StatsRepository using Elasticsearch aggregations
+type ElasticsearchStatsRepository struct {
+	es        *elasticsearch.Client
+	indexName string
+}
+
+// NewElasticsearchStatsRepository creates a new ElasticsearchStatsRepository
+func NewElasticsearchStatsRepository(es *elasticsearch.Client, indexName string) *ElasticsearchStatsRepository {
+	return &ElasticsearchStatsRepository{
+		es:        es,
+		indexName: indexName,
+	}
+}
+
+// valueAggregation is the response shape shared by single-value metric aggregations
+type valueAggregation struct {
+	Value *float64 `json:"value"`
+}
+
+// catalogStatsResponse mirrors the parts of the search response used by GetCatalogStats
+type catalogStatsResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+	} `json:"hits"`
+	Aggregations struct {
+		DistinctCompanies valueAggregation `json:"distinct_companies"`
+		DistinctGenerics  valueAggregation `json:"distinct_generics"`
+		NewestUpdatedAt   valueAggregation `json:"newest_updated_at"`
+		OldestUpdatedAt   valueAggregation `json:"oldest_updated_at"`
+		AddedLast30Days   struct {
+			DocCount int64 `json:"doc_count"`
+		} `json:"added_last_30_days"`
+	} `json:"aggregations"`
+}
+
+// GetCatalogStats computes catalog-wide statistics with a single multi-aggregation query
+func (r *ElasticsearchStatsRepository) GetCatalogStats(ctx context.Context) (models.CatalogStats, error) {
+	query := map[string]interface{}{
+		"size": 0,
+		"aggs": map[string]interface{}{
+			"distinct_companies": map[string]interface{}{
+				"cardinality": map[string]interface{}{"field": "company.keyword"},
+			},
+			"distinct_generics": map[string]interface{}{
+				"cardinality": map[string]interface{}{"field": "drug_generic.keyword"},
+			},
+			"newest_updated_at": map[string]interface{}{
+				"max": map[string]interface{}{"field": "updated_at"},
+			},
+			"oldest_updated_at": map[string]interface{}{
+				"min": map[string]interface{}{"field": "updated_at"},
+			},
+			"added_last_30_days": map[string]interface{}{
+				"filter": map[string]interface{}{
+					"range": map[string]interface{}{
+						"created_at": map[string]interface{}{"gte": "now-30d/d"},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return models.CatalogStats{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.indexName),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.CatalogStats{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.CatalogStats{}, decodeErrorResponse(res)
+	}
+
+	var response catalogStatsResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.CatalogStats{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	aggs := response.Aggregations
+	return models.CatalogStats{
+		TotalProducts:     response.Hits.Total.Value,
+		DistinctCompanies: int64(valueOrZero(aggs.DistinctCompanies.Value)),
+		DistinctGenerics:  int64(valueOrZero(aggs.DistinctGenerics.Value)),
+		NewestUpdatedAt:   epochMillisToTime(aggs.NewestUpdatedAt.Value),
+		OldestUpdatedAt:   epochMillisToTime(aggs.OldestUpdatedAt.Value),
+		AddedLast30Days:   aggs.AddedLast30Days.DocCount,
+	}, nil
+}
+
+// termsAggregation is the response shape of a terms aggregation
+type termsAggregation struct {
+	Buckets []struct {
+		Key      string `json:"key"`
+		DocCount int64  `json:"doc_count"`
+	} `json:"buckets"`
+}
+
+// facetBuckets converts the buckets of a terms aggregation
+func (a termsAggregation) facetBuckets() []models.FacetBucket {
+	buckets := make([]models.FacetBucket, 0, len(a.Buckets))
+	for _, bucket := range a.Buckets {
+		buckets = append(buckets, models.FacetBucket{Value: bucket.Key, Count: bucket.DocCount})
+	}
+	return buckets
+}
+
+// indexStatsResponse mirrors the parts of the search response used by GetIndexStats
+type indexStatsResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+	} `json:"hits"`
+	Aggregations struct {
+		Companies       termsAggregation `json:"companies"`
+		DrugGenerics    termsAggregation `json:"drug_generics"`
+		NewestCreatedAt valueAggregation `json:"newest_created_at"`
+		OldestCreatedAt valueAggregation `json:"oldest_created_at"`
+	} `json:"aggregations"`
+}
+
+// storeStatsResponse mirrors the parts of the index stats response used by GetIndexStats
+type storeStatsResponse struct {
+	All struct {
+		Primaries struct {
+			Store struct {
+				SizeInBytes int64 `json:"size_in_bytes"`
+			} `json:"store"`
+		} `json:"primaries"`
+		Total struct {
+			Store struct {
+				SizeInBytes int64 `json:"size_in_bytes"`
+			} `json:"store"`
+		} `json:"total"`
+	} `json:"_all"`
+}
+
+// GetIndexStats counts the documents of the index, per company and per drug generic (the size most frequent
+// values of each), finds the range of creation times with an aggregation query and reads the disk size of the
+// index from the Stats API
+func (r *ElasticsearchStatsRepository) GetIndexStats(ctx context.Context, size int) (models.IndexStats, error) {
+	query := map[string]interface{}{
+		"size": 0,
+		"aggs": map[string]interface{}{
+			"companies": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "company.keyword", "size": size},
+			},
+			"drug_generics": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "drug_generic.keyword", "size": size},
+			},
+			"newest_created_at": map[string]interface{}{
+				"max": map[string]interface{}{"field": "created_at"},
+			},
+			"oldest_created_at": map[string]interface{}{
+				"min": map[string]interface{}{"field": "created_at"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return models.IndexStats{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.indexName),
+		r.es.Search.WithBody(&buf),
+		r.es.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.IndexStats{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.IndexStats{}, decodeErrorResponse(res)
+	}
+
+	var response indexStatsResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.IndexStats{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	store, err := r.storeStats(ctx)
+	if err != nil {
+		return models.IndexStats{}, err
+	}
+
+	aggs := response.Aggregations
+	return models.IndexStats{
+		Index:            r.indexName,
+		TotalDocuments:   response.Hits.Total.Value,
+		Companies:        aggs.Companies.facetBuckets(),
+		DrugGenerics:     aggs.DrugGenerics.facetBuckets(),
+		NewestCreatedAt:  epochMillisToTime(aggs.NewestCreatedAt.Value),
+		OldestCreatedAt:  epochMillisToTime(aggs.OldestCreatedAt.Value),
+		PrimarySizeBytes: store.All.Primaries.Store.SizeInBytes,
+		SizeBytes:        store.All.Total.Store.SizeInBytes,
+	}, nil
+}
+
+// storeStats reads the store section of the Stats API, summed over the indices behind the index name
+func (r *ElasticsearchStatsRepository) storeStats(ctx context.Context) (storeStatsResponse, error) {
+	res, err := r.es.Indices.Stats(
+		r.es.Indices.Stats.WithContext(ctx),
+		r.es.Indices.Stats.WithIndex(r.indexName),
+		r.es.Indices.Stats.WithMetric("store"),
+	)
+	if err != nil {
+		return storeStatsResponse{}, fmt.Errorf("index stats request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return storeStatsResponse{}, decodeErrorResponse(res)
+	}
+
+	var response storeStatsResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return storeStatsResponse{}, fmt.Errorf("failed to parse index stats response: %w", err)
+	}
+	return response, nil
+}
+
+// creationHistogramResponse mirrors the parts of the search response used by GetCreationHistogram
+type creationHistogramResponse struct {
+	Aggregations struct {
+		Created struct {
+			Buckets []struct {
+				Key      int64 `json:"key"`
+				DocCount int64 `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"created"`
+	} `json:"aggregations"`
+}
+
+// GetCreationHistogram counts the products created per calendar interval with a date_histogram aggregation
+// over created_at. Empty intervals between the first and the last product are returned with a zero count.
+func (r *ElasticsearchStatsRepository) GetCreationHistogram(ctx context.Context, params models.CreationHistogramParams) (models.CreationHistogram, error) {
+	var filters querybuilder.Filters
+	filters.Range("created_at", params.CreatedAfter, params.CreatedBefore)
+
+	filter := make([]map[string]interface{}, 0, len(filters.Filter))
+	for _, clause := range filters.Filter {
+		filter = append(filter, clause.Map())
+	}
+
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{"filter": filter},
+		},
+		"aggs": map[string]interface{}{
+			"created": map[string]interface{}{
+				"date_histogram": map[string]interface{}{
+					"field":             "created_at",
+					"calendar_interval": params.Interval,
+					"min_doc_count":     0,
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return models.CreationHistogram{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.indexName),
+		r.es.Search.WithBody(&buf),
+	)
+	if err != nil {
+		log.Printf("Error getting response: %s", err)
+		return models.CreationHistogram{}, fmt.Errorf("search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.CreationHistogram{}, decodeErrorResponse(res)
+	}
+
+	var response creationHistogramResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.CreationHistogram{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	buckets := make([]models.DateBucket, 0, len(response.Aggregations.Created.Buckets))
+	for _, bucket := range response.Aggregations.Created.Buckets {
+		buckets = append(buckets, models.DateBucket{
+			Date:  time.UnixMilli(bucket.Key).UTC(),
+			Count: bucket.DocCount,
+		})
+	}
+
+	return models.CreationHistogram{Interval: params.Interval, Buckets: buckets}, nil
+}
+
+// valueOrZero dereferences an aggregation value, treating a missing value as zero
+func valueOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// epochMillisToTime converts a date aggregation value (epoch millis) into a time, or nil when empty
+func epochMillisToTime(v *float64) *time.Time {
+	if v == nil {
+		return nil
+	}
+	t := time.UnixMilli(int64(*v)).UTC()
+	return &t
+}
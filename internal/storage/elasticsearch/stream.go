@@ -0,0 +1,69 @@
+package elasticsearch
+
+import (
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// defaultStreamBatchSize is the number of products per batch when StreamAllProducts isn't given a limit
+const defaultStreamBatchSize = 1000
+
+// StreamAllProducts iterates over every product matching params, calling fn with one batch at a time.
+// Batches are read from a point in time with search_after, so the walk sees a consistent snapshot and
+// isn't bounded by the result window; params.Limit sets the batch size and the offset and pagination
+// tokens are ignored. Returning an error from fn stops the walk and the error is returned as is.
+func (r *ElasticsearchProductRepository) StreamAllProducts(ctx context.Context, params models.ProductSearchParams, fn func(products []models.Product) error) error {
+	if params.Limit <= 0 {
+		params.Limit = defaultStreamBatchSize
+	}
+	params.Offset = 0
+	params.PIT = ""
+	params.Cursor = &models.Cursor{}
+	// Only the batches matter, so don't pay for counting every hit
+	params.TrackTotalHits = 1
+
+	for {
+		result, err := r.FindProducts(ctx, params)
+		if err != nil {
+			// FindProducts only closes the point in time after the last batch
+			r.closeStream(ctx, params.Cursor)
+			return err
+		}
+
+		// The last batch leaves no cursor behind
+		var next *models.Cursor
+		if result.NextCursor != "" {
+			if next, err = models.ParseCursor(result.NextCursor); err != nil {
+				return fmt.Errorf("failed to continue stream: %w", err)
+			}
+		}
+
+		if len(result.Products) > 0 {
+			if err := fn(result.Products); err != nil {
+				r.closeStream(ctx, next)
+				return err
+			}
+		}
+
+		if next == nil {
+			return nil
+		}
+		params.Cursor = next
+	}
+}
+
+// closeStream releases the point in time of a stream that stopped before its last batch
+func (r *ElasticsearchProductRepository) closeStream(ctx context.Context, cursor *models.Cursor) {
+	if cursor == nil || cursor.PIT == "" {
+		return
+	}
+	// Release it even when the stream stopped because ctx was cancelled
+	err := r.ClosePointInTime(context.WithoutCancel(ctx), cursor.PIT)
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		log.Printf("Error closing point in time: %s", err)
+	}
+}
@@ -0,0 +1,316 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/models"
+	"elasticsearch/internal/search/querybuilder"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// SuggestIndex is the alias of the lightweight index holding the name, company and weight of every live
+// product, so autocomplete doesn't read the product documents
+const SuggestIndex = "product_suggest"
+
+// suggestRebuildScript turns a product into a suggest document while the suggest index is rebuilt; the weight
+// is computed like suggestWeight
+const suggestRebuildScript = `
+def company = ctx._source.company == null ? '' : ctx._source.company;
+double score = ctx._source.score == null ? 0 : ctx._source.score;
+ctx._source = [
+  'id': ctx._source.id,
+  'product_name': ctx._source.product_name,
+  'company': company,
+  'suggest': [
+    'input': [ctx._source.product_name],
+    'weight': (int) Math.min(Math.round(Math.max(score, 0) * 100), Integer.MAX_VALUE),
+    'contexts': ['company': [company]]
+  ]
+];`
+
+// SuggestIndexRepository defines the interface for the dedicated suggest index
+type SuggestIndexRepository interface {
+	IndexProducts(ctx context.Context, products []models.Product) error
+	DeleteProducts(ctx context.Context, ids []models.ProductID) error
+	Suggest(ctx context.Context, prefix, company string, size int) ([]models.Suggestion, error)
+	Rebuild(ctx context.Context, source string) (ReindexResult, error)
+}
+
+// ElasticsearchSuggestIndexRepository implements SuggestIndexRepository using Elasticsearch
+type ElasticsearchSuggestIndexRepository struct {
+	es    *elasticsearch.Client
+	alias string
+}
+
+// NewElasticsearchSuggestIndexRepository creates a new ElasticsearchSuggestIndexRepository. Every rebuild
+// creates a concrete index behind alias, so writes and suggestions always go through the alias.
+func NewElasticsearchSuggestIndexRepository(es *elasticsearch.Client, alias string) *ElasticsearchSuggestIndexRepository {
+	return &ElasticsearchSuggestIndexRepository{
+		es:    es,
+		alias: alias,
+	}
+}
+
+// suggestDocument is the suggest index document of a product
+type suggestDocument struct {
+	ID          models.ProductID `json:"id"`
+	ProductName string           `json:"product_name"`
+	Company     string           `json:"company"`
+	Suggest     suggestInput     `json:"suggest"`
+}
+
+// suggestInput is the value of a completion field with a weight and the company context
+type suggestInput struct {
+	Input    []string            `json:"input"`
+	Weight   int                 `json:"weight"`
+	Contexts map[string][]string `json:"contexts"`
+}
+
+// suggestWeight ranks the names of products by their score, in hundredths
+func suggestWeight(score float64) int {
+	return int(math.Min(math.Round(math.Max(score, 0)*100), math.MaxInt32))
+}
+
+// suggestIndexBody builds the settings and mappings of a new suggest index
+func suggestIndexBody() map[string]interface{} {
+	filters, analyzers := synonymAnalysis()
+	return map[string]interface{}{
+		"settings": map[string]interface{}{
+			"number_of_shards": 1,
+			"analysis": map[string]interface{}{
+				"filter":   filters,
+				"analyzer": analyzers,
+			},
+		},
+		"mappings": map[string]interface{}{
+			"dynamic": "strict",
+			"properties": map[string]interface{}{
+				"id":           map[string]interface{}{"type": "keyword"},
+				"product_name": map[string]interface{}{"type": "keyword", "index": false},
+				"company":      map[string]interface{}{"type": "keyword"},
+				"suggest": map[string]interface{}{
+					"type":            "completion",
+					"analyzer":        "simple",
+					"search_analyzer": SuggestSearchAnalyzer,
+					"contexts": []map[string]interface{}{
+						{"name": querybuilder.CompanyContext, "type": "category"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// IndexProducts writes the suggest documents of products with a single bulk request; soft-deleted products
+// and products without a name are removed instead. Writes require the alias, so nothing is written before the
+// first rebuild created the index.
+func (r *ElasticsearchSuggestIndexRepository) IndexProducts(ctx context.Context, products []models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, product := range products {
+		if product.DeletedAt != nil || product.ProductName == "" {
+			if err := encoder.Encode(map[string]interface{}{
+				"delete": map[string]interface{}{"_index": r.alias, "_id": product.ID.String()},
+			}); err != nil {
+				return fmt.Errorf("failed to encode bulk metadata: %w", err)
+			}
+			continue
+		}
+
+		meta := map[string]interface{}{
+			"index": map[string]interface{}{"_index": r.alias, "_id": product.ID.String()},
+		}
+		if err := encoder.Encode(meta); err != nil {
+			return fmt.Errorf("failed to encode bulk metadata: %w", err)
+		}
+		document := suggestDocument{
+			ID:          product.ID,
+			ProductName: product.ProductName,
+			Company:     product.Company,
+			Suggest: suggestInput{
+				Input:    []string{product.ProductName},
+				Weight:   suggestWeight(product.Score),
+				Contexts: map[string][]string{querybuilder.CompanyContext: {product.Company}},
+			},
+		}
+		if err := encoder.Encode(document); err != nil {
+			return fmt.Errorf("failed to encode suggest document: %w", err)
+		}
+	}
+
+	return r.bulk(ctx, &buf)
+}
+
+// DeleteProducts removes the suggest documents of products with a single bulk request; missing documents are
+// ignored
+func (r *ElasticsearchSuggestIndexRepository) DeleteProducts(ctx context.Context, ids []models.ProductID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, id := range ids {
+		if err := encoder.Encode(map[string]interface{}{
+			"delete": map[string]interface{}{"_index": r.alias, "_id": id.String()},
+		}); err != nil {
+			return fmt.Errorf("failed to encode bulk metadata: %w", err)
+		}
+	}
+
+	return r.bulk(ctx, &buf)
+}
+
+// bulk sends a bulk request to the alias and reports the first failed item; deleting a missing document
+// isn't a failure
+func (r *ElasticsearchSuggestIndexRepository) bulk(ctx context.Context, body *bytes.Buffer) error {
+	res, err := r.es.Bulk(
+		body,
+		r.es.Bulk.WithContext(ctx),
+		r.es.Bulk.WithRequireAlias(true),
+	)
+	if err != nil {
+		return fmt.Errorf("bulk request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  struct {
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if response.Errors {
+		for _, item := range response.Items {
+			for action, result := range item {
+				if result.Status >= 300 && !(action == "delete" && result.Status == http.StatusNotFound) {
+					return fmt.Errorf("failed to %s suggest document: %s", action, result.Error.Reason)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Suggest completes prefix to up to size distinct product names, highest weight first, only from products of
+// company when given
+func (r *ElasticsearchSuggestIndexRepository) Suggest(ctx context.Context, prefix, company string, size int) ([]models.Suggestion, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(querybuilder.SuggestIndexSuggest(prefix, company, size).Map()); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.alias),
+		r.es.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("suggest request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res)
+	}
+
+	var response suggestResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return response.completions(), nil
+}
+
+// Rebuild fills a new concrete suggest index from the live products of source, swaps the alias to it and
+// deletes the indices it replaced. On failure the new index is deleted and the alias left unchanged. Products
+// written during the rebuild may be missing until their next write.
+func (r *ElasticsearchSuggestIndexRepository) Rebuild(ctx context.Context, source string) (ReindexResult, error) {
+	previous, err := AliasTargets(ctx, r.es, r.alias)
+	if err != nil {
+		return ReindexResult{}, err
+	}
+
+	index := fmt.Sprintf("%s-%d", r.alias, time.Now().UnixMilli())
+	body, err := json.Marshal(suggestIndexBody())
+	if err != nil {
+		return ReindexResult{}, fmt.Errorf("failed to encode index body: %w", err)
+	}
+	res, err := r.es.Indices.Create(
+		index,
+		r.es.Indices.Create.WithContext(ctx),
+		r.es.Indices.Create.WithBody(bytes.NewReader(body)),
+	)
+	if err != nil {
+		return ReindexResult{}, fmt.Errorf("create index request failed: %w", err)
+	}
+	res.Body.Close()
+	if res.IsError() {
+		return ReindexResult{}, fmt.Errorf("failed to create index %s: %s", index, res.Status())
+	}
+
+	result, err := reindex(ctx, r.es, map[string]interface{}{
+		"source": map[string]interface{}{
+			"index": source,
+			"query": map[string]interface{}{
+				"bool": map[string]interface{}{
+					"filter":   []map[string]interface{}{{"exists": map[string]interface{}{"field": "product_name"}}},
+					"must_not": []map[string]interface{}{{"exists": map[string]interface{}{"field": "deleted_at"}}},
+				},
+			},
+		},
+		"dest":   map[string]interface{}{"index": index},
+		"script": map[string]interface{}{"lang": "painless", "source": suggestRebuildScript},
+	})
+	if err == nil && len(result.Failures) > 0 {
+		err = fmt.Errorf("%d of %d products failed to copy into %s", len(result.Failures), result.Total, index)
+	}
+	if err == nil {
+		err = SwapAlias(ctx, r.es, r.alias, index)
+	}
+	if err != nil {
+		r.deleteIndices(ctx, []string{index})
+		return result, err
+	}
+
+	r.deleteIndices(ctx, previous)
+	return result, nil
+}
+
+// deleteIndices deletes replaced or abandoned suggest indices; a leftover index only wastes space, so failures
+// are logged, not returned
+func (r *ElasticsearchSuggestIndexRepository) deleteIndices(ctx context.Context, indices []string) {
+	if len(indices) == 0 {
+		return
+	}
+	res, err := r.es.Indices.Delete(indices, r.es.Indices.Delete.WithContext(ctx))
+	if err != nil {
+		log.Printf("Failed to delete suggest indices %v: %v", indices, err)
+		return
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		log.Printf("Failed to delete suggest indices %v: %s", indices, res.Status())
+	}
+}
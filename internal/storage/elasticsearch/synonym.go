@@ -0,0 +1,203 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"elasticsearch/internal/common"
+	"elasticsearch/internal/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// ProductSynonymSet is the synonyms set applied when searching product indices. Elasticsearch reloads the search
+// analyzers using it whenever a rule changes, so edits take effect without reindexing.
+const ProductSynonymSet = "product-synonyms"
+
+// SynonymRepository defines the interface for synonym rule operations
+type SynonymRepository interface {
+	SaveRule(ctx context.Context, rule models.SynonymRule) (models.SynonymRule, error)
+	GetRule(ctx context.Context, id string) (models.SynonymRule, error)
+	DeleteRule(ctx context.Context, id string) error
+	FindRules(ctx context.Context, limit int, offset int) ([]models.SynonymRule, int64, error)
+}
+
+// ElasticsearchSynonymRepository implements SynonymRepository with the Elasticsearch synonyms API
+type ElasticsearchSynonymRepository struct {
+	es    *elasticsearch.Client
+	setID string
+}
+
+// NewElasticsearchSynonymRepository creates a new ElasticsearchSynonymRepository managing the rules of a synonyms set
+func NewElasticsearchSynonymRepository(es *elasticsearch.Client, setID string) *ElasticsearchSynonymRepository {
+	return &ElasticsearchSynonymRepository{
+		es:    es,
+		setID: setID,
+	}
+}
+
+// synonymRuleBody mirrors a rule of the synonyms API, whose synonyms are a single comma-separated string
+type synonymRuleBody struct {
+	ID       string `json:"id,omitempty"`
+	Synonyms string `json:"synonyms"`
+}
+
+// toModel splits the synonyms of a rule
+func (b synonymRuleBody) toModel() models.SynonymRule {
+	rule := models.SynonymRule{ID: b.ID, Synonyms: []string{}}
+	for _, synonym := range strings.Split(b.Synonyms, ",") {
+		if synonym = strings.TrimSpace(synonym); synonym != "" {
+			rule.Synonyms = append(rule.Synonyms, synonym)
+		}
+	}
+	return rule
+}
+
+// SaveRule creates or replaces a rule under its ID, creating the synonyms set on first use
+func (r *ElasticsearchSynonymRepository) SaveRule(ctx context.Context, rule models.SynonymRule) (models.SynonymRule, error) {
+	if err := EnsureSynonymSet(ctx, r.es, r.setID); err != nil {
+		return models.SynonymRule{}, err
+	}
+
+	body, err := json.Marshal(synonymRuleBody{Synonyms: strings.Join(rule.Synonyms, ", ")})
+	if err != nil {
+		return models.SynonymRule{}, fmt.Errorf("failed to encode synonym rule: %w", err)
+	}
+
+	res, err := r.es.SynonymsPutSynonymRule(
+		bytes.NewReader(body),
+		rule.ID,
+		r.setID,
+		r.es.SynonymsPutSynonymRule.WithContext(ctx),
+	)
+	if err != nil {
+		return models.SynonymRule{}, fmt.Errorf("put synonym rule request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return models.SynonymRule{}, decodeErrorResponse(res)
+	}
+	return rule, nil
+}
+
+// GetRule loads a rule by ID. Returns common.ErrNotFound if it doesn't exist.
+func (r *ElasticsearchSynonymRepository) GetRule(ctx context.Context, id string) (models.SynonymRule, error) {
+	res, err := r.es.SynonymsGetSynonymRule(
+		id,
+		r.setID,
+		r.es.SynonymsGetSynonymRule.WithContext(ctx),
+	)
+	if err != nil {
+		return models.SynonymRule{}, fmt.Errorf("get synonym rule request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return models.SynonymRule{}, fmt.Errorf("synonym rule %s: %w", id, common.ErrNotFound)
+	}
+	if res.IsError() {
+		return models.SynonymRule{}, decodeErrorResponse(res)
+	}
+
+	var response synonymRuleBody
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return models.SynonymRule{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return response.toModel(), nil
+}
+
+// DeleteRule removes a rule. Returns common.ErrNotFound if it doesn't exist.
+func (r *ElasticsearchSynonymRepository) DeleteRule(ctx context.Context, id string) error {
+	res, err := r.es.SynonymsDeleteSynonymRule(
+		id,
+		r.setID,
+		r.es.SynonymsDeleteSynonymRule.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("delete synonym rule request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("synonym rule %s: %w", id, common.ErrNotFound)
+	}
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+	return nil
+}
+
+// FindRules returns a page of rules in ID order and the total number of rules; a missing set has none
+func (r *ElasticsearchSynonymRepository) FindRules(ctx context.Context, limit int, offset int) ([]models.SynonymRule, int64, error) {
+	res, err := r.es.SynonymsGetSynonym(
+		r.setID,
+		r.es.SynonymsGetSynonym.WithContext(ctx),
+		r.es.SynonymsGetSynonym.WithFrom(offset),
+		r.es.SynonymsGetSynonym.WithSize(limit),
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get synonyms set request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return []models.SynonymRule{}, 0, nil
+	}
+	if res.IsError() {
+		return nil, 0, decodeErrorResponse(res)
+	}
+
+	var response struct {
+		Count       int64             `json:"count"`
+		SynonymsSet []synonymRuleBody `json:"synonyms_set"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	rules := make([]models.SynonymRule, 0, len(response.SynonymsSet))
+	for _, body := range response.SynonymsSet {
+		rules = append(rules, body.toModel())
+	}
+	return rules, response.Count, nil
+}
+
+// EnsureSynonymSet creates an empty synonyms set unless it already exists. Indices referencing a set can only be
+// created once it exists, and replacing an existing set would drop its rules.
+func EnsureSynonymSet(ctx context.Context, esClient *elasticsearch.Client, setID string) error {
+	res, err := esClient.SynonymsGetSynonym(
+		setID,
+		esClient.SynonymsGetSynonym.WithContext(ctx),
+		esClient.SynonymsGetSynonym.WithSize(0),
+	)
+	if err != nil {
+		return fmt.Errorf("get synonyms set request failed: %w", err)
+	}
+	if res.StatusCode != http.StatusNotFound {
+		defer res.Body.Close()
+		if res.IsError() {
+			return decodeErrorResponse(res)
+		}
+		return nil
+	}
+	res.Body.Close()
+
+	res, err = esClient.SynonymsPutSynonym(
+		setID,
+		strings.NewReader(`{"synonyms_set": []}`),
+		esClient.SynonymsPutSynonym.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("put synonyms set request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res)
+	}
+	return nil
+}